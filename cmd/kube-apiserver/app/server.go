@@ -21,6 +21,7 @@ package app
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -74,7 +75,8 @@ type APIServer struct {
 	TLSPrivateKeyFile          string
 	CertDirectory              string
 	APIPrefix                  string
-	ExpAPIPrefix               string
+	APIGroupPrefix             string
+	ExperimentalPrefix         string
 	StorageVersion             string
 	ExpStorageVersion          string
 	CloudProvider              string
@@ -102,6 +104,7 @@ type APIServer struct {
 	KubeletConfig              client.KubeletConfig
 	ClusterName                string
 	EnableProfiling            bool
+	EnableWatchCache           bool
 	MaxRequestsInFlight        int
 	MinRequestTimeout          int
 	LongRunningRequestRE       string
@@ -120,7 +123,7 @@ func NewAPIServer() *APIServer {
 		APIRate:                10.0,
 		APIBurst:               200,
 		APIPrefix:              "/api",
-		ExpAPIPrefix:           "/experimental",
+		APIGroupPrefix:         "/apis",
 		EventTTL:               1 * time.Hour,
 		AuthorizationMode:      "AlwaysAllow",
 		AdmissionControl:       "AlwaysAdmit",
@@ -178,7 +181,9 @@ func (s *APIServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CertDirectory, "cert-dir", s.CertDirectory, "The directory where the TLS certs are located (by default /var/run/kubernetes). "+
 		"If --tls-cert-file and --tls-private-key-file are provided, this flag will be ignored.")
 	fs.StringVar(&s.APIPrefix, "api-prefix", s.APIPrefix, "The prefix for API requests on the server. Default '/api'.")
-	fs.StringVar(&s.ExpAPIPrefix, "experimental-prefix", s.ExpAPIPrefix, "The prefix for experimental API requests on the server. Default '/experimental'.")
+	fs.StringVar(&s.APIGroupPrefix, "api-group-prefix", s.APIGroupPrefix, "The prefix for requests to API groups on the server. Default '/apis'.")
+	fs.StringVar(&s.ExperimentalPrefix, "experimental-prefix", s.ExperimentalPrefix, "Deprecated: see --api-group-prefix instead.")
+	fs.MarkDeprecated("experimental-prefix", "see --api-group-prefix instead.")
 	fs.StringVar(&s.StorageVersion, "storage-version", s.StorageVersion, "The version to store resources with. Defaults to server preferred")
 	fs.StringVar(&s.CloudProvider, "cloud-provider", s.CloudProvider, "The provider for cloud services.  Empty string for no provider.")
 	fs.StringVar(&s.CloudConfigFile, "cloud-config", s.CloudConfigFile, "The path to the cloud provider configuration file.  Empty string for no configuration file.")
@@ -208,6 +213,7 @@ func (s *APIServer) AddFlags(fs *pflag.FlagSet) {
 	client.BindKubeletClientConfigFlags(fs, &s.KubeletConfig)
 	fs.StringVar(&s.ClusterName, "cluster-name", s.ClusterName, "The instance prefix for the cluster")
 	fs.BoolVar(&s.EnableProfiling, "profiling", true, "Enable profiling via web interface host:port/debug/pprof/")
+	fs.BoolVar(&s.EnableWatchCache, "watch-cache", true, "Enable serving pod watches from an in memory cache, which is indexed by node")
 	fs.StringVar(&s.ExternalHost, "external-hostname", "", "The hostname to use when generating externalized URLs for this master (e.g. Swagger API Docs.)")
 	fs.IntVar(&s.MaxRequestsInFlight, "max-requests-inflight", 400, "The maximum number of requests in flight at a given time.  When the server exceeds this, it rejects requests.  Zero for no limit.")
 	fs.IntVar(&s.MinRequestTimeout, "min-request-timeout", 1800, "An optional field indicating the minimum number of seconds a handler must keep a request open before timing it out. Currently only honored by the watch request handler, which picks a randomized value above this number as the connection timeout, to spread out load.")
@@ -224,6 +230,20 @@ func (s *APIServer) verifyClusterIPFlags() {
 	}
 }
 
+// verifyAPIGroupPrefixFlags rejects --experimental-prefix. The experimental API used to be
+// served at <experimental-prefix>/v1, and is now served as an API group at
+// <api-group-prefix>/experimental/v1, so the old flag cannot be mapped onto the new one
+// without moving the API.
+func (s *APIServer) verifyAPIGroupPrefixFlags() error {
+	if len(s.ExperimentalPrefix) == 0 {
+		return nil
+	}
+	return fmt.Errorf("--experimental-prefix is no longer supported: the experimental API is now served at "+
+		"<api-group-prefix>/experimental/v1 (%s/experimental/v1 with the current flags) instead of %s/v1. "+
+		"Remove --experimental-prefix, set --api-group-prefix if the default of /apis is not wanted, and "+
+		"update clients that use %s/v1", s.APIGroupPrefix, s.ExperimentalPrefix, s.ExperimentalPrefix)
+}
+
 func newEtcd(etcdConfigFile string, etcdServerList util.StringList, interfacesFunc meta.VersionInterfacesFunc, defaultVersion, storageVersion, pathPrefix string) (etcdStorage storage.Interface, err error) {
 	var client tools.EtcdClient
 	if etcdConfigFile != "" {
@@ -253,6 +273,9 @@ func newEtcd(etcdConfigFile string, etcdServerList util.StringList, interfacesFu
 // Run runs the specified APIServer.  This should never exit.
 func (s *APIServer) Run(_ []string) error {
 	s.verifyClusterIPFlags()
+	if err := s.verifyAPIGroupPrefixFlags(); err != nil {
+		return err
+	}
 
 	// If advertise-address is not specified, use bind-address. If bind-address
 	// is also unset (or 0.0.0.0), setDefaults() in pkg/master/master.go will
@@ -389,9 +412,10 @@ func (s *APIServer) Run(_ []string) error {
 		EnableUISupport:        true,
 		EnableSwaggerSupport:   true,
 		EnableProfiling:        s.EnableProfiling,
+		EnableWatchCache:       s.EnableWatchCache,
 		EnableIndex:            true,
 		APIPrefix:              s.APIPrefix,
-		ExpAPIPrefix:           s.ExpAPIPrefix,
+		APIGroupPrefix:         s.APIGroupPrefix,
 		CorsAllowedOriginList:  s.CorsAllowedOriginList,
 		ReadWritePort:          s.SecurePort,
 		PublicAddress:          net.IP(s.AdvertiseAddress),
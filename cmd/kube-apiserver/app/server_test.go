@@ -18,7 +18,10 @@ package app
 
 import (
 	"regexp"
+	"strings"
 	"testing"
+
+	"github.com/spf13/pflag"
 )
 
 func TestLongRunningRequestRegexp(t *testing.T) {
@@ -57,3 +60,30 @@ func TestLongRunningRequestRegexp(t *testing.T) {
 		}
 	}
 }
+
+func TestExperimentalPrefixIsRejected(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expectErr bool
+	}{
+		{args: []string{"--api-group-prefix=/groups"}},
+		{args: []string{"--experimental-prefix=/experimental"}, expectErr: true},
+		{args: []string{"--api-group-prefix=/groups", "--experimental-prefix=/experimental"}, expectErr: true},
+	}
+	for _, test := range tests {
+		s := NewAPIServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(test.args); err != nil {
+			t.Errorf("%v: unexpected error: %v", test.args, err)
+			continue
+		}
+		err := s.verifyAPIGroupPrefixFlags()
+		if test.expectErr != (err != nil) {
+			t.Errorf("%v: expected error %v, got %v", test.args, test.expectErr, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "--api-group-prefix") {
+			t.Errorf("%v: expected the error to explain how to migrate, got %v", test.args, err)
+		}
+	}
+}
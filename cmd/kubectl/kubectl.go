@@ -26,8 +26,8 @@ import (
 
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
-	cmd := cmd.NewKubectlCommand(cmdutil.NewFactory(nil), os.Stdin, os.Stdout, os.Stderr)
-	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+	kubectl := cmd.NewKubectlCommand(cmdutil.NewFactory(nil), os.Stdin, os.Stdout, os.Stderr)
+	if err := kubectl.Execute(); err != nil {
+		os.Exit(cmd.UsageErrorExitCode(kubectl, os.Args[1:]))
 	}
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/apiserver"
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/auth/authenticator/bearertoken"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/plugin/pkg/auth/authenticator/request/union"
+	"k8s.io/kubernetes/plugin/pkg/auth/authenticator/request/x509"
+	"k8s.io/kubernetes/plugin/pkg/auth/authenticator/token/tokenreview"
+	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/subjectaccessreview"
+)
+
+const (
+	// AuthorizationModeAlwaysAllow allows every authenticated request.
+	AuthorizationModeAlwaysAllow = apiserver.ModeAlwaysAllow
+	// AuthorizationModeSubjectAccessReview asks the apiserver whether the
+	// user may perform the request on the node.
+	AuthorizationModeSubjectAccessReview = "SubjectAccessReview"
+)
+
+// AuthorizationModeChoices are the valid values of --authorization-mode.
+var AuthorizationModeChoices = []string{AuthorizationModeAlwaysAllow, AuthorizationModeSubjectAccessReview}
+
+// InitializeAuth returns the authenticator and authorizer for requests to the
+// kubelet server, as configured by the authentication and authorization flags.
+// A nil authenticator means requests are served without authentication or
+// authorization. clientConfig is used to delegate checks to the apiserver and
+// may be nil if no delegation is configured.
+func (s *KubeletServer) InitializeAuth(clientConfig *client.Config) (authenticator.Request, authorizer.Authorizer, error) {
+	var authenticators []authenticator.Request
+	var expClient *client.ExperimentalClient
+	newExpClient := func() (*client.ExperimentalClient, error) {
+		if expClient != nil {
+			return expClient, nil
+		}
+		if clientConfig == nil {
+			return nil, errors.New("delegating to the apiserver requires --api-servers")
+		}
+		c, err := client.NewExperimental(clientConfig)
+		if err != nil {
+			return nil, err
+		}
+		expClient = c
+		return c, nil
+	}
+
+	if len(s.ClientCAFile) > 0 {
+		roots, err := util.CertPoolFromFile(s.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to load client CA file %s: %v", s.ClientCAFile, err)
+		}
+		opts := x509.DefaultVerifyOptions()
+		opts.Roots = roots
+		authenticators = append(authenticators, x509.New(opts, x509.CommonNameUserConversion))
+	}
+
+	if s.AuthenticationTokenReview {
+		c, err := newExpClient()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to enable token reviews: %v", err)
+		}
+		authenticators = append(authenticators, bearertoken.New(tokenreview.New(c)))
+	}
+
+	var auth authenticator.Request
+	switch len(authenticators) {
+	case 0:
+	case 1:
+		auth = authenticators[0]
+	default:
+		auth = union.New(authenticators...)
+	}
+
+	switch s.AuthorizationMode {
+	case AuthorizationModeAlwaysAllow:
+		return auth, apiserver.NewAlwaysAllowAuthorizer(), nil
+	case AuthorizationModeSubjectAccessReview:
+		if auth == nil {
+			return nil, nil, fmt.Errorf("authorization mode %s requires --client-ca-file or --authentication-token-review", s.AuthorizationMode)
+		}
+		c, err := newExpClient()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to enable subject access reviews: %v", err)
+		}
+		return auth, subjectaccessreview.New(c), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown authorization mode %q (valid: %v)", s.AuthorizationMode, AuthorizationModeChoices)
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"time"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
 	"k8s.io/kubernetes/pkg/capabilities"
 	"k8s.io/kubernetes/pkg/client"
 	"k8s.io/kubernetes/pkg/client/chaosclient"
@@ -52,6 +54,7 @@ import (
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/mount"
 	nodeutil "k8s.io/kubernetes/pkg/util/node"
+	"k8s.io/kubernetes/pkg/util/oom"
 	"k8s.io/kubernetes/pkg/volume"
 
 	"github.com/golang/glog"
@@ -103,6 +106,12 @@ type KubeletServer struct {
 	ImageGCHighThresholdPercent    int
 	ImageGCLowThresholdPercent     int
 	LowDiskSpaceThresholdMB        int
+	EvictionHard                   string
+	EvictionSoft                   string
+	EvictionSoftGracePeriod        string
+	EvictionPressureTransition     time.Duration
+	KubeReserved                   util.ConfigurationMap
+	SystemReserved                 util.ConfigurationMap
 	NetworkPluginName              string
 	NetworkPluginDir               string
 	CloudProvider                  string
@@ -110,6 +119,9 @@ type KubeletServer struct {
 	TLSCertFile                    string
 	TLSPrivateKeyFile              string
 	CertDirectory                  string
+	ClientCAFile                   string
+	AuthenticationTokenReview      bool
+	AuthorizationMode              string
 	NodeStatusUpdateFrequency      time.Duration
 	ResourceContainer              string
 	CgroupRoot                     string
@@ -135,7 +147,7 @@ type KubeletServer struct {
 type KubeletBootstrap interface {
 	BirthCry()
 	StartGarbageCollection()
-	ListenAndServe(net.IP, uint, *kubelet.TLSOptions, bool)
+	ListenAndServe(net.IP, uint, *kubelet.TLSOptions, kubelet.AuthInterface, bool)
 	ListenAndServeReadOnly(net.IP, uint)
 	Run(<-chan kubelet.PodUpdate)
 	RunOnce(<-chan kubelet.PodUpdate) ([]kubelet.RunPodResult, error)
@@ -172,10 +184,14 @@ func NewKubeletServer() *KubeletServer {
 		ImageGCHighThresholdPercent: 90,
 		ImageGCLowThresholdPercent:  80,
 		LowDiskSpaceThresholdMB:     256,
+		EvictionPressureTransition:  5 * time.Minute,
+		KubeReserved:                make(util.ConfigurationMap),
+		SystemReserved:              make(util.ConfigurationMap),
 		NetworkPluginName:           "",
 		NetworkPluginDir:            "/usr/libexec/kubernetes/kubelet-plugins/net/exec/",
 		HostNetworkSources:          kubelet.FileSource,
 		CertDirectory:               "/var/run/kubernetes",
+		AuthorizationMode:           AuthorizationModeAlwaysAllow,
 		NodeStatusUpdateFrequency:   10 * time.Second,
 		ResourceContainer:           "/kubelet",
 		CgroupRoot:                  "",
@@ -206,6 +222,9 @@ func (s *KubeletServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.TLSPrivateKeyFile, "tls-private-key-file", s.TLSPrivateKeyFile, "File containing x509 private key matching --tls_cert_file.")
 	fs.StringVar(&s.CertDirectory, "cert-dir", s.CertDirectory, "The directory where the TLS certs are located (by default /var/run/kubernetes). "+
 		"If --tls_cert_file and --tls_private_key_file are provided, this flag will be ignored.")
+	fs.StringVar(&s.ClientCAFile, "client-ca-file", s.ClientCAFile, "If set, requests to the Kubelet server presenting a client certificate signed by one of the authorities in this file are authenticated with the CommonName of the certificate.")
+	fs.BoolVar(&s.AuthenticationTokenReview, "authentication-token-review", s.AuthenticationTokenReview, "If true, bearer tokens presented to the Kubelet server are authenticated by the apiserver with TokenReviews.")
+	fs.StringVar(&s.AuthorizationMode, "authorization-mode", s.AuthorizationMode, "Authorization mode for authenticated requests to the Kubelet server, one of: "+strings.Join(AuthorizationModeChoices, ",")+". SubjectAccessReview asks the apiserver whether the user may access the nodes/proxy, nodes/log or nodes/stats subresource of this node. Requests are not authenticated or authorized unless --client-ca-file or --authentication-token-review is set.")
 	fs.StringVar(&s.HostnameOverride, "hostname-override", s.HostnameOverride, "If non-empty, will use this string as identification instead of the actual hostname.")
 	fs.StringVar(&s.PodInfraContainerImage, "pod-infra-container-image", s.PodInfraContainerImage, "The image whose network/ipc namespaces containers in each pod will use.")
 	fs.StringVar(&s.DockerEndpoint, "docker-endpoint", s.DockerEndpoint, "If non-empty, use this for the docker endpoint to communicate with")
@@ -236,12 +255,18 @@ func (s *KubeletServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.ImageGCHighThresholdPercent, "image-gc-high-threshold", s.ImageGCHighThresholdPercent, "The percent of disk usage after which image garbage collection is always run. Default: 90%%")
 	fs.IntVar(&s.ImageGCLowThresholdPercent, "image-gc-low-threshold", s.ImageGCLowThresholdPercent, "The percent of disk usage before which image garbage collection is never run. Lowest disk usage to garbage collect to. Default: 80%%")
 	fs.IntVar(&s.LowDiskSpaceThresholdMB, "low-diskspace-threshold-mb", s.LowDiskSpaceThresholdMB, "The absolute free disk space, in MB, to maintain. When disk space falls below this threshold, new pods would be rejected. Default: 256")
+	fs.StringVar(&s.EvictionHard, "eviction-hard", s.EvictionHard, "Comma-separated list of thresholds (e.g. memory.available<100Mi,nodefs.available<1Gi) below which pods are evicted immediately. Valid signals are memory.available, nodefs.available and imagefs.available.")
+	fs.StringVar(&s.EvictionSoft, "eviction-soft", s.EvictionSoft, "Comma-separated list of thresholds (e.g. memory.available<300Mi) below which pods are evicted once the threshold has been met for its grace period.")
+	fs.StringVar(&s.EvictionSoftGracePeriod, "eviction-soft-grace-period", s.EvictionSoftGracePeriod, "Comma-separated list of grace periods (e.g. memory.available=1m30s) for the soft eviction thresholds.")
+	fs.DurationVar(&s.EvictionPressureTransition, "eviction-pressure-transition-period", s.EvictionPressureTransition, "Duration for which the kubelet keeps reporting a node pressure condition after its eviction thresholds stop being met. Default: 5m")
+	fs.Var(&s.KubeReserved, "kube-reserved", "A set of resource=quantity pairs (e.g. cpu=200m,memory=150Mi,storage=1Gi) reserved for the Kubernetes components, which are not allocatable to pods. Valid resources are cpu, memory and storage.")
+	fs.Var(&s.SystemReserved, "system-reserved", "A set of resource=quantity pairs (e.g. cpu=200m,memory=150Mi,storage=1Gi) reserved for the system daemons, which are not allocatable to pods. Valid resources are cpu, memory and storage.")
 	fs.StringVar(&s.NetworkPluginName, "network-plugin", s.NetworkPluginName, "<Warning: Alpha feature> The name of the network plugin to be invoked for various events in kubelet/pod lifecycle")
 	fs.StringVar(&s.NetworkPluginDir, "network-plugin-dir", s.NetworkPluginDir, "<Warning: Alpha feature> The full path of the directory in which to search for network plugins")
 	fs.StringVar(&s.CloudProvider, "cloud-provider", s.CloudProvider, "The provider for cloud services.  Empty string for no provider.")
 	fs.StringVar(&s.CloudConfigFile, "cloud-config", s.CloudConfigFile, "The path to the cloud provider configuration file.  Empty string for no configuration file.")
 	fs.StringVar(&s.ResourceContainer, "resource-container", s.ResourceContainer, "Absolute name of the resource-only container to create and run the Kubelet in (Default: /kubelet).")
-	fs.StringVar(&s.CgroupRoot, "cgroup_root", s.CgroupRoot, "Optional root cgroup to use for pods. This is handled by the container runtime on a best effort basis. Default: '', which means use the container runtime default.")
+	fs.StringVar(&s.CgroupRoot, "cgroup_root", s.CgroupRoot, "Optional root cgroup to use for pods. If set, pods are placed in a kubepods cgroup under it, with burstable and besteffort sub-cgroups per quality of service class. This is handled by the container runtime on a best effort basis. Default: '', which means use the container runtime default.")
 	fs.StringVar(&s.ContainerRuntime, "container_runtime", s.ContainerRuntime, "The container runtime to use. Possible values: 'docker', 'rkt'. Default: 'docker'.")
 	fs.StringVar(&s.SystemContainer, "system-container", s.SystemContainer, "Optional resource-only container in which to place all non-kernel processes that are not already in a container. Empty for no container. Rolling back the flag requires a reboot. (Default: \"\").")
 	fs.BoolVar(&s.ConfigureCBR0, "configure-cbr0", s.ConfigureCBR0, "If true, kubelet will configure cbr0 based on Node.Spec.PodCIDR.")
@@ -260,7 +285,7 @@ func (s *KubeletServer) Run(_ []string) error {
 	rand.Seed(time.Now().UTC().UnixNano())
 
 	// TODO(vmarmol): Do this through container config.
-	if err := util.ApplyOomScoreAdj(0, s.OOMScoreAdj); err != nil {
+	if err := oom.NewOomAdjuster().ApplyOomScoreAdj(0, s.OOMScoreAdj); err != nil {
 		glog.Warning(err)
 	}
 
@@ -291,6 +316,14 @@ func (s *KubeletServer) Run(_ []string) error {
 		DockerFreeDiskMB: s.LowDiskSpaceThresholdMB,
 		RootFreeDiskMB:   s.LowDiskSpaceThresholdMB,
 	}
+	evictionPolicy, err := s.EvictionPolicy()
+	if err != nil {
+		return err
+	}
+	reservation, err := kubelet.ParseReservation(s.KubeReserved, s.SystemReserved)
+	if err != nil {
+		return err
+	}
 	cloud, err := cloudprovider.InitCloudProvider(s.CloudProvider, s.CloudConfigFile)
 	if err != nil {
 		return err
@@ -316,6 +349,15 @@ func (s *KubeletServer) Run(_ []string) error {
 		return err
 	}
 
+	var authClientConfig *client.Config
+	if apiclient != nil {
+		authClientConfig = clientConfig
+	}
+	authn, authz, err := s.InitializeAuth(authClientConfig)
+	if err != nil {
+		return err
+	}
+
 	mounter := mount.New()
 	if s.Containerized {
 		glog.V(2).Info("Running kubelet in containerized mode (experimental)")
@@ -369,8 +411,12 @@ func (s *KubeletServer) Run(_ []string) error {
 		NetworkPluginName:              s.NetworkPluginName,
 		StreamingConnectionIdleTimeout: s.StreamingConnectionIdleTimeout,
 		TLSOptions:                     tlsOptions,
+		Authenticator:                  authn,
+		Authorizer:                     authz,
 		ImageGCPolicy:                  imageGCPolicy,
 		DiskSpacePolicy:                diskSpacePolicy,
+		EvictionPolicy:                 evictionPolicy,
+		Reservation:                    reservation,
 		Cloud:                          cloud,
 		NodeStatusUpdateFrequency: s.NodeStatusUpdateFrequency,
 		ResourceContainer:         s.ResourceContainer,
@@ -428,9 +474,29 @@ func (s *KubeletServer) InitializeTLS() (*kubelet.TLSOptions, error) {
 		CertFile: s.TLSCertFile,
 		KeyFile:  s.TLSPrivateKeyFile,
 	}
+	if len(s.ClientCAFile) > 0 {
+		// Advertise the accepted authorities so clients pick a matching certificate.
+		clientCAs, err := util.CertPoolFromFile(s.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client CA file %s: %v", s.ClientCAFile, err)
+		}
+		tlsOptions.Config.ClientCAs = clientCAs
+	}
 	return tlsOptions, nil
 }
 
+// EvictionPolicy parses the eviction thresholds configured by the --eviction-* flags.
+func (s *KubeletServer) EvictionPolicy() (kubelet.EvictionPolicy, error) {
+	thresholds, err := kubelet.ParseEvictionThresholds(s.EvictionHard, s.EvictionSoft, s.EvictionSoftGracePeriod)
+	if err != nil {
+		return kubelet.EvictionPolicy{}, err
+	}
+	return kubelet.EvictionPolicy{
+		Thresholds:               thresholds,
+		PressureTransitionPeriod: s.EvictionPressureTransition,
+	}, nil
+}
+
 func (s *KubeletServer) authPathClientConfig(useDefaults bool) (*client.Config, error) {
 	authInfo, err := clientauth.LoadFromFile(s.AuthPath.Value())
 	if err != nil && !useDefaults {
@@ -649,8 +715,12 @@ func startKubelet(k KubeletBootstrap, podCfg *config.PodConfig, kc *KubeletConfi
 
 	// start the kubelet server
 	if kc.EnableServer {
+		var auth kubelet.AuthInterface
+		if kc.Authenticator != nil {
+			auth = kubelet.NewKubeletAuth(kc.Authenticator, kc.Authorizer, kc.NodeName)
+		}
 		go util.Forever(func() {
-			k.ListenAndServe(net.IP(kc.Address), kc.Port, kc.TLSOptions, kc.EnableDebuggingHandlers)
+			k.ListenAndServe(net.IP(kc.Address), kc.Port, kc.TLSOptions, auth, kc.EnableDebuggingHandlers)
 		}, 0)
 	}
 	if kc.ReadOnlyPort > 0 {
@@ -723,8 +793,12 @@ type KubeletConfig struct {
 	StreamingConnectionIdleTimeout time.Duration
 	Recorder                       record.EventRecorder
 	TLSOptions                     *kubelet.TLSOptions
+	Authenticator                  authenticator.Request
+	Authorizer                     authorizer.Authorizer
 	ImageGCPolicy                  kubelet.ImageGCPolicy
 	DiskSpacePolicy                kubelet.DiskSpacePolicy
+	EvictionPolicy                 kubelet.EvictionPolicy
+	Reservation                    kubelet.Reservation
 	Cloud                          cloudprovider.Interface
 	NodeStatusUpdateFrequency      time.Duration
 	ResourceContainer              string
@@ -783,6 +857,8 @@ func createAndInitKubelet(kc *KubeletConfig) (k KubeletBootstrap, pc *config.Pod
 		kc.CadvisorInterface,
 		kc.ImageGCPolicy,
 		kc.DiskSpacePolicy,
+		kc.EvictionPolicy,
+		kc.Reservation,
 		kc.Cloud,
 		kc.NodeStatusUpdateFrequency,
 		kc.ResourceContainer,
@@ -234,7 +234,8 @@ func TestExampleObjectSchemas(t *testing.T) {
 			"namespace-prod":      &api.Namespace{},
 		},
 		"../docs/user-guide/downward-api": {
-			"dapi-pod": &api.Pod{},
+			"dapi-pod":    &api.Pod{},
+			"dapi-volume": &api.Pod{},
 		},
 		"../examples/elasticsearch": {
 			"apiserver-secret": nil,
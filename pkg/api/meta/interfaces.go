@@ -130,6 +130,8 @@ type RESTMapping struct {
 	// for convenience for passing around a consistent mapping.
 	APIVersion string
 	Kind       string
+	// Group is the API group that serves the resource, empty for the legacy API.
+	Group string
 
 	// Scope contains the information needed to deal with REST Resources that are in a resource hierarchy
 	Scope RESTScope
@@ -139,6 +141,11 @@ type RESTMapping struct {
 	MetadataAccessor
 }
 
+// GroupVersion returns the group qualified version of the mapping.
+func (m *RESTMapping) GroupVersion() string {
+	return JoinGroupVersion(m.Group, m.APIVersion)
+}
+
 // RESTMapper allows clients to map resources to kind, and map kind and version
 // to interfaces for manipulating those objects. It is primarily intended for
 // consumers of Kubernetes compatible REST APIs as defined in docs/api-conventions.md.
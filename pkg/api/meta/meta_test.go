@@ -603,3 +603,62 @@ func TestTypeMetaSelfLinker(t *testing.T) {
 		}
 	}
 }
+
+func TestUnstructuredAccessor(t *testing.T) {
+	obj := &runtime.Unstructured{
+		TypeMeta: runtime.TypeMeta{Kind: "Foo", APIVersion: "company.com/v1"},
+		Object: map[string]interface{}{
+			"kind":       "Foo",
+			"apiVersion": "company.com/v1",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "bar",
+				"labels":    map[string]interface{}{"a": "b"},
+			},
+		},
+	}
+	accessor, err := Accessor(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "foo", accessor.Name(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := "bar", accessor.Namespace(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := (map[string]string{"a": "b"}), accessor.Labels(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := "company.com/v1", accessor.APIVersion(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+
+	accessor.SetName("baz")
+	accessor.SetResourceVersion("1")
+	accessor.SetAnnotations(map[string]string{"x": "y"})
+	accessor.SetKind("Bar")
+	metadata := obj.Object["metadata"].(map[string]interface{})
+	if metadata["name"] != "baz" || metadata["resourceVersion"] != "1" {
+		t.Errorf("unexpected metadata: %#v", metadata)
+	}
+	if e, a := (map[string]interface{}{"x": "y"}), metadata["annotations"]; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if obj.Kind != "Bar" || obj.Object["kind"] != "Bar" {
+		t.Errorf("kind not updated: %#v", obj)
+	}
+
+	empty := &runtime.Unstructured{}
+	accessor, err = Accessor(empty)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accessor.Name() != "" || accessor.Labels() != nil {
+		t.Errorf("unexpected metadata on empty object: %#v", empty)
+	}
+	accessor.SetNamespace("other")
+	if e, a := "other", accessor.Namespace(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
@@ -111,7 +111,7 @@ func NewDefaultRESTMapper(versions []string, f VersionInterfacesFunc) *DefaultRE
 }
 
 func (m *DefaultRESTMapper) Add(scope RESTScope, kind string, version string, mixedCase bool) {
-	plural, singular := kindToResource(kind, mixedCase)
+	plural, singular := KindToResource(kind, mixedCase)
 	m.plurals[singular] = plural
 	m.singulars[plural] = singular
 	meta := typeMeta{APIVersion: version, Kind: kind}
@@ -129,8 +129,8 @@ func (m *DefaultRESTMapper) Add(scope RESTScope, kind string, version string, mi
 	m.scopes[meta] = scope
 }
 
-// kindToResource converts Kind to a resource name.
-func kindToResource(kind string, mixedCase bool) (plural, singular string) {
+// KindToResource converts Kind to a resource name.
+func KindToResource(kind string, mixedCase bool) (plural, singular string) {
 	if len(kind) == 0 {
 		return
 	}
@@ -314,3 +314,114 @@ func (m MultiRESTMapper) AliasesForResource(alias string) (aliases []string, ok
 	}
 	return nil, false
 }
+
+// JoinGroupVersion returns the group qualified version "<group>/<version>", or
+// only the version for the legacy API group.
+func JoinGroupVersion(group, version string) string {
+	if len(group) == 0 {
+		return version
+	}
+	return group + "/" + version
+}
+
+// SplitGroupVersion splits a group qualified version into its group and version.
+// Versions without a group belong to the legacy API group.
+func SplitGroupVersion(groupVersion string) (group, version string) {
+	i := strings.LastIndex(groupVersion, "/")
+	if i == -1 {
+		return "", groupVersion
+	}
+	return groupVersion[:i], groupVersion[i+1:]
+}
+
+// GroupMapper is the RESTMapper for the kinds of a single API group.
+type GroupMapper struct {
+	// Group is the name of the API group, empty for the legacy API.
+	Group string
+	RESTMapper
+}
+
+// GroupRESTMapper maps kinds across API groups. Kinds and versions are only unique
+// within a group, so each group has its own RESTMapper. Groups are searched in
+// order, and the mappings returned record the group that serves them.
+//
+// Versions passed to RESTMapping may be group qualified ("<group>/<version>"),
+// in which case they only select versions of that group. Versions without a
+// group apply to every group.
+type GroupRESTMapper []GroupMapper
+
+// ResourceSingularizer converts a REST resource name from plural to singular,
+// using the first group that serves the resource.
+func (m GroupRESTMapper) ResourceSingularizer(resource string) (singular string, err error) {
+	err = fmt.Errorf("no resource %q has been defined", resource)
+	for _, g := range m {
+		if singular, err = g.ResourceSingularizer(resource); err == nil {
+			return
+		}
+	}
+	return
+}
+
+// VersionAndKindForResource returns the group qualified default version and the
+// kind of a resource, using the first group that serves the resource.
+func (m GroupRESTMapper) VersionAndKindForResource(resource string) (defaultVersion, kind string, err error) {
+	err = fmt.Errorf("no resource %q has been defined", resource)
+	for _, g := range m {
+		if defaultVersion, kind, err = g.VersionAndKindForResource(resource); err == nil {
+			if group, _ := SplitGroupVersion(defaultVersion); len(group) == 0 {
+				defaultVersion = JoinGroupVersion(g.Group, defaultVersion)
+			}
+			return
+		}
+	}
+	return
+}
+
+// RESTMapping returns the REST mapping of kind from the first group that can map
+// it in one of the requested versions.
+func (m GroupRESTMapper) RESTMapping(kind string, versions ...string) (*RESTMapping, error) {
+	err := fmt.Errorf("no kind named %q is registered in versions %q", kind, versions)
+	for _, g := range m {
+		groupVersions, ok := versionsForGroup(g.Group, versions)
+		if !ok {
+			continue
+		}
+		mapping, mappingErr := g.RESTMapping(kind, groupVersions...)
+		if mappingErr != nil {
+			err = mappingErr
+			continue
+		}
+		groupMapping := *mapping
+		groupMapping.Group = g.Group
+		return &groupMapping, nil
+	}
+	return nil, err
+}
+
+// AliasesForResource returns the aliases of the first group that defines alias.
+func (m GroupRESTMapper) AliasesForResource(alias string) (aliases []string, ok bool) {
+	for _, g := range m {
+		if aliases, ok = g.AliasesForResource(alias); ok {
+			return
+		}
+	}
+	return nil, false
+}
+
+// versionsForGroup returns the versions that apply to group, without their group
+// qualifier. It returns false if versions were requested but none of them apply.
+func versionsForGroup(group string, versions []string) ([]string, bool) {
+	requested := false
+	out := []string{}
+	for _, groupVersion := range versions {
+		if len(groupVersion) == 0 {
+			continue
+		}
+		requested = true
+		g, version := SplitGroupVersion(groupVersion)
+		if len(g) == 0 || g == group {
+			out = append(out, version)
+		}
+	}
+	return out, !requested || len(out) > 0
+}
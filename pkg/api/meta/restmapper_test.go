@@ -124,7 +124,7 @@ func TestKindToResource(t *testing.T) {
 		{Kind: "lowercases", MixedCase: false, Plural: "lowercases", Singular: "lowercases"},
 	}
 	for i, testCase := range testCases {
-		plural, singular := kindToResource(testCase.Kind, testCase.MixedCase)
+		plural, singular := KindToResource(testCase.Kind, testCase.MixedCase)
 		if singular != testCase.Singular || plural != testCase.Plural {
 			t.Errorf("%d: unexpected plural and singular: %s %s", i, plural, singular)
 		}
@@ -281,3 +281,74 @@ func TestRESTMapperReportsErrorOnBadVersion(t *testing.T) {
 		t.Errorf("unexpected non-error")
 	}
 }
+
+func TestGroupRESTMapper(t *testing.T) {
+	legacy := NewDefaultRESTMapper([]string{"v1"}, fakeInterfaces)
+	legacy.Add(RESTScopeNamespace, "Pod", "v1", false)
+	experimental := NewDefaultRESTMapper([]string{"v1"}, fakeInterfaces)
+	experimental.Add(RESTScopeRoot, "ThirdPartyResource", "v1", false)
+	experimental.Add(RESTScopeNamespace, "Pod", "v1", false)
+	mapper := GroupRESTMapper{{"", legacy}, {"experimental", experimental}}
+
+	mapping, err := mapper.RESTMapping("Pod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Group != "" || mapping.GroupVersion() != "v1" {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+
+	mapping, err = mapper.RESTMapping("Pod", "experimental/v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Group != "experimental" || mapping.APIVersion != "v1" || mapping.GroupVersion() != "experimental/v1" {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+
+	mapping, err = mapper.RESTMapping("ThirdPartyResource", "v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Group != "experimental" || mapping.Resource != "thirdpartyresources" {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+
+	if _, err := mapper.RESTMapping("Pod", "other/v1"); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+	if _, err := mapper.RESTMapping("Unknown"); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+
+	version, kind, err := mapper.VersionAndKindForResource("thirdpartyresources")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "experimental/v1" || kind != "ThirdPartyResource" {
+		t.Errorf("unexpected version and kind: %s %s", version, kind)
+	}
+	if _, _, err := mapper.VersionAndKindForResource("unknowns"); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+}
+
+func TestSplitGroupVersion(t *testing.T) {
+	testCases := []struct {
+		groupVersion   string
+		group, version string
+	}{
+		{"v1", "", "v1"},
+		{"experimental/v1", "experimental", "v1"},
+		{"company.com/v1beta1", "company.com", "v1beta1"},
+	}
+	for _, testCase := range testCases {
+		group, version := SplitGroupVersion(testCase.groupVersion)
+		if group != testCase.group || version != testCase.version {
+			t.Errorf("%s: unexpected group and version: %q %q", testCase.groupVersion, group, version)
+		}
+		if e, a := testCase.groupVersion, JoinGroupVersion(group, version); e != a {
+			t.Errorf("expected %q, got %q", e, a)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// unstructuredAccessor implements Interface for a runtime.Unstructured, whose
+// metadata is only available as the "metadata" entry of its JSON compatible map.
+type unstructuredAccessor struct {
+	obj *runtime.Unstructured
+}
+
+func (a unstructuredAccessor) object() map[string]interface{} {
+	if a.obj.Object == nil {
+		a.obj.Object = make(map[string]interface{})
+	}
+	return a.obj.Object
+}
+
+func (a unstructuredAccessor) metadata() map[string]interface{} {
+	if m, ok := a.obj.Object["metadata"].(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+func (a unstructuredAccessor) setMetadata(field string, value interface{}) {
+	m := a.metadata()
+	if m == nil {
+		m = make(map[string]interface{})
+		a.object()["metadata"] = m
+	}
+	m[field] = value
+}
+
+func (a unstructuredAccessor) getString(field string) string {
+	s, _ := a.metadata()[field].(string)
+	return s
+}
+
+func (a unstructuredAccessor) getStringMap(field string) map[string]string {
+	m, ok := a.metadata()[field].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
+
+func (a unstructuredAccessor) setStringMap(field string, value map[string]string) {
+	m := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		m[k] = v
+	}
+	a.setMetadata(field, m)
+}
+
+func (a unstructuredAccessor) APIVersion() string {
+	return a.obj.APIVersion
+}
+
+func (a unstructuredAccessor) SetAPIVersion(version string) {
+	a.obj.APIVersion = version
+	a.object()["apiVersion"] = version
+}
+
+func (a unstructuredAccessor) Kind() string {
+	return a.obj.Kind
+}
+
+func (a unstructuredAccessor) SetKind(kind string) {
+	a.obj.Kind = kind
+	a.object()["kind"] = kind
+}
+
+func (a unstructuredAccessor) Namespace() string {
+	return a.getString("namespace")
+}
+
+func (a unstructuredAccessor) SetNamespace(namespace string) {
+	a.setMetadata("namespace", namespace)
+}
+
+func (a unstructuredAccessor) Name() string {
+	return a.getString("name")
+}
+
+func (a unstructuredAccessor) SetName(name string) {
+	a.setMetadata("name", name)
+}
+
+func (a unstructuredAccessor) GenerateName() string {
+	return a.getString("generateName")
+}
+
+func (a unstructuredAccessor) SetGenerateName(name string) {
+	a.setMetadata("generateName", name)
+}
+
+func (a unstructuredAccessor) UID() types.UID {
+	return types.UID(a.getString("uid"))
+}
+
+func (a unstructuredAccessor) SetUID(uid types.UID) {
+	a.setMetadata("uid", string(uid))
+}
+
+func (a unstructuredAccessor) ResourceVersion() string {
+	return a.getString("resourceVersion")
+}
+
+func (a unstructuredAccessor) SetResourceVersion(version string) {
+	a.setMetadata("resourceVersion", version)
+}
+
+func (a unstructuredAccessor) SelfLink() string {
+	return a.getString("selfLink")
+}
+
+func (a unstructuredAccessor) SetSelfLink(selfLink string) {
+	a.setMetadata("selfLink", selfLink)
+}
+
+func (a unstructuredAccessor) Labels() map[string]string {
+	return a.getStringMap("labels")
+}
+
+func (a unstructuredAccessor) SetLabels(labels map[string]string) {
+	a.setStringMap("labels", labels)
+}
+
+func (a unstructuredAccessor) Annotations() map[string]string {
+	return a.getStringMap("annotations")
+}
+
+func (a unstructuredAccessor) SetAnnotations(annotations map[string]string) {
+	a.setStringMap("annotations", annotations)
+}
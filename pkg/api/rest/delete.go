@@ -17,8 +17,11 @@ limitations under the License.
 package rest
 
 import (
+	"time"
+
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 // RESTDeleteStrategy defines deletion behavior on an object that follows Kubernetes
@@ -35,17 +38,65 @@ type RESTDeleteStrategy interface {
 // should be gracefully deleted, if gracefulPending is set the object has already been gracefully deleted
 // (and the provided grace period is longer than the time to deletion), and an error is returned if the
 // condition cannot be checked or the gracePeriodSeconds is invalid. The options argument may be updated with
-// default values if graceful is true.
+// default values if graceful is true. If graceful is true the DeletionTimestamp and
+// DeletionGracePeriodSeconds of the object are updated to reflect the requested grace period.
 func BeforeDelete(strategy RESTDeleteStrategy, ctx api.Context, obj runtime.Object, options *api.DeleteOptions) (graceful, gracefulPending bool, err error) {
 	if strategy == nil {
 		return false, false, nil
 	}
-	_, _, kerr := objectMetaAndKind(strategy, obj)
+	objectMeta, _, kerr := objectMetaAndKind(strategy, obj)
 	if kerr != nil {
 		return false, false, kerr
 	}
+
+	// if the object is already being deleted, the grace period may only be shortened
+	if objectMeta.DeletionTimestamp != nil {
+		// the object was marked for deletion without a grace period, so delete it now
+		if objectMeta.DeletionGracePeriodSeconds == nil || *objectMeta.DeletionGracePeriodSeconds == 0 {
+			return false, false, nil
+		}
+		if options.GracePeriodSeconds == nil {
+			options.GracePeriodSeconds = objectMeta.DeletionGracePeriodSeconds
+			return false, true, nil
+		}
+		period := *options.GracePeriodSeconds
+		if period >= *objectMeta.DeletionGracePeriodSeconds {
+			return false, true, nil
+		}
+		setDeletionGracePeriod(objectMeta, period)
+		return true, false, nil
+	}
+
 	if !strategy.CheckGracefulDelete(obj, options) {
 		return false, false, nil
 	}
+	setDeletionGracePeriod(objectMeta, *options.GracePeriodSeconds)
 	return true, false, nil
 }
+
+// setDeletionGracePeriod records on the object that it will be deleted after period seconds.
+func setDeletionGracePeriod(objectMeta *api.ObjectMeta, period int64) {
+	now := util.NewTime(util.Now().Add(time.Duration(period) * time.Second))
+	objectMeta.DeletionTimestamp = &now
+	objectMeta.DeletionGracePeriodSeconds = &period
+}
+
+// MarkForDeletion sets the DeletionTimestamp of an object that is not yet being deleted so that
+// the components named in its Finalizers can observe the deletion and perform their cleanup. It
+// returns false if the object was already marked.
+func MarkForDeletion(objectMeta *api.ObjectMeta) bool {
+	if objectMeta.DeletionTimestamp != nil {
+		return false
+	}
+	setDeletionGracePeriod(objectMeta, 0)
+	return true
+}
+
+// ReadyForDeletion returns true if an object that has been marked for deletion has no remaining
+// finalizers and its grace period has been reduced to zero, so it may be removed from storage.
+func ReadyForDeletion(objectMeta *api.ObjectMeta) bool {
+	if objectMeta.DeletionTimestamp == nil || len(objectMeta.Finalizers) != 0 {
+		return false
+	}
+	return objectMeta.DeletionGracePeriodSeconds == nil || *objectMeta.DeletionGracePeriodSeconds == 0
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// FieldFunc returns the value field selectors see for a field of an object.
+type FieldFunc func(obj runtime.Object) string
+
+// SelectableFields declares the fields of a kind that field selectors can select
+// on, keyed by their field label. The name and namespace of an object can be
+// selected on for every kind, as metadata.name and metadata.namespace, and are
+// not declared.
+type SelectableFields map[string]FieldFunc
+
+// Labels returns the sorted labels of the fields that can be selected on.
+func (f SelectableFields) Labels() []string {
+	labels := []string{"metadata.name", "metadata.namespace"}
+	for label := range f {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Has returns true if the field with the given label can be selected on.
+func (f SelectableFields) Has(label string) bool {
+	if label == "metadata.name" || label == "metadata.namespace" {
+		return true
+	}
+	_, ok := f[label]
+	return ok
+}
+
+// Set returns the values of the selectable fields of obj.
+func (f SelectableFields) Set(obj runtime.Object) fields.Set {
+	set := fields.Set{}
+	if meta, err := ObjectMetaFor(obj); err == nil {
+		set["metadata.name"] = meta.Name
+		set["metadata.namespace"] = meta.Namespace
+	}
+	for label, fn := range f {
+		set[label] = fn(obj)
+	}
+	return set
+}
+
+// FieldLabelConversionFunc returns a field label conversion function for kind
+// that keeps the labels of the selectable fields, and rejects every other label
+// with an error listing the supported ones.
+func (f SelectableFields) FieldLabelConversionFunc(kind string) func(label, value string) (string, string, error) {
+	return func(label, value string) (string, string, error) {
+		if !f.Has(label) {
+			return "", "", fmt.Errorf("field label %q is not supported for %s, supported field labels are: %s", label, kind, strings.Join(f.Labels(), ", "))
+		}
+		return label, value, nil
+	}
+}
+
+// PodSelectableFields are the fields of pods that field selectors can select on.
+var PodSelectableFields = SelectableFields{
+	"spec.nodeName": func(obj runtime.Object) string { return obj.(*Pod).Spec.NodeName },
+	"status.phase":  func(obj runtime.Object) string { return string(obj.(*Pod).Status.Phase) },
+}
+
+// NodeSelectableFields are the fields of nodes that field selectors can select on.
+var NodeSelectableFields = SelectableFields{
+	"spec.unschedulable": func(obj runtime.Object) string { return strconv.FormatBool(obj.(*Node).Spec.Unschedulable) },
+}
+
+// ReplicationControllerSelectableFields are the fields of replication controllers
+// that field selectors can select on.
+var ReplicationControllerSelectableFields = SelectableFields{
+	"status.replicas": func(obj runtime.Object) string { return strconv.Itoa(obj.(*ReplicationController).Status.Replicas) },
+}
+
+// EventSelectableFields are the fields of events that field selectors can select on.
+var EventSelectableFields = SelectableFields{
+	"involvedObject.kind":            func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.Kind },
+	"involvedObject.namespace":       func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.Namespace },
+	"involvedObject.name":            func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.Name },
+	"involvedObject.uid":             func(obj runtime.Object) string { return string(obj.(*Event).InvolvedObject.UID) },
+	"involvedObject.apiVersion":      func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.APIVersion },
+	"involvedObject.resourceVersion": func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.ResourceVersion },
+	"involvedObject.fieldPath":       func(obj runtime.Object) string { return obj.(*Event).InvolvedObject.FieldPath },
+	"reason":                         func(obj runtime.Object) string { return obj.(*Event).Reason },
+	"source":                         func(obj runtime.Object) string { return obj.(*Event).Source.Component },
+}
+
+// NamespaceSelectableFields are the fields of namespaces that field selectors can
+// select on.
+var NamespaceSelectableFields = SelectableFields{
+	"status.phase": func(obj runtime.Object) string { return string(obj.(*Namespace).Status.Phase) },
+}
+
+// SecretSelectableFields are the fields of secrets that field selectors can select on.
+var SecretSelectableFields = SelectableFields{
+	"type": func(obj runtime.Object) string { return string(obj.(*Secret).Type) },
+}
+
+// PersistentVolumeSelectableFields are the fields of persistent volumes that field
+// selectors can select on.
+var PersistentVolumeSelectableFields = SelectableFields{
+	"status.phase": func(obj runtime.Object) string { return string(obj.(*PersistentVolume).Status.Phase) },
+}
+
+// PersistentVolumeClaimSelectableFields are the fields of persistent volume claims
+// that field selectors can select on.
+var PersistentVolumeClaimSelectableFields = SelectableFields{
+	"status.phase": func(obj runtime.Object) string { return string(obj.(*PersistentVolumeClaim).Status.Phase) },
+}
+
+// NameSelectableFields are the fields of the kinds that can only be selected on
+// by name and namespace.
+var NameSelectableFields = SelectableFields{}
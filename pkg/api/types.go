@@ -143,6 +143,10 @@ type ObjectMeta struct {
 	// will send a hard termination signal to the container.
 	DeletionTimestamp *util.Time `json:"deletionTimestamp,omitempty"`
 
+	// DeletionGracePeriodSeconds records the graceful deletion value set when graceful deletion
+	// was requested. Represents the most recent grace period, and may only be shortened once set.
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+
 	// Labels are key value pairs that may be used to scope and select individual resources.
 	// Label keys are of the form:
 	//     label-key ::= prefixed-name | name
@@ -160,6 +164,12 @@ type ObjectMeta struct {
 	// objects.  Annotation keys have the same formatting restrictions as Label keys. See the
 	// comments on Labels for details.
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Finalizers are opaque identifiers of components that must perform cleanup before the
+	// object is removed from storage. While any finalizer remains, a delete request only sets
+	// DeletionTimestamp; each component removes its own entry once its cleanup is complete and
+	// the object is deleted once the list is empty.
+	Finalizers []string `json:"finalizers,omitempty"`
 }
 
 const (
@@ -219,6 +229,8 @@ type VolumeSource struct {
 	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
 	// RBD represents a Rados Block Device mount on the host that shares a pod's lifetime
 	RBD *RBDVolumeSource `json:"rbd,omitempty"`
+	// DownwardAPI represents metadata about the pod that should populate this volume
+	DownwardAPI *DownwardAPIVolumeSource `json:"downwardAPI,omitempty"`
 }
 
 // Similar to VolumeSource but meant for the administrator who creates PVs.
@@ -501,6 +513,26 @@ type SecretVolumeSource struct {
 	SecretName string `json:"secretName"`
 }
 
+// DownwardAPIVolumeSource represents a volume containing downward API info.
+//
+// Each item is presented in the volume as a file, and the files are updated
+// when the metadata of the pod changes.
+type DownwardAPIVolumeSource struct {
+	// Items is a list of DownwardAPIVolume file
+	Items []DownwardAPIVolumeFile `json:"items,omitempty"`
+}
+
+// DownwardAPIVolumeFile represents a single file containing information from
+// the downward API. Exactly one of FieldRef and ResourceFieldRef must be set.
+type DownwardAPIVolumeFile struct {
+	// Required: Path is the relative path name of the file to be created.
+	Path string `json:"path"`
+	// Selects a field of the pod: only annotations, labels, name and namespace are supported.
+	FieldRef *ObjectFieldSelector `json:"fieldRef,omitempty"`
+	// Selects a resource of a container: only resource limits and requests are supported.
+	ResourceFieldRef *ResourceFieldSelector `json:"resourceFieldRef,omitempty"`
+}
+
 // NFSVolumeSource represents an NFS Mount that lasts the lifetime of a pod
 type NFSVolumeSource struct {
 	// Server is the hostname or IP address of the NFS server
@@ -610,6 +642,15 @@ type ObjectFieldSelector struct {
 	FieldPath string `json:"fieldPath"`
 }
 
+// ResourceFieldSelector selects a resource of a container: limits.cpu,
+// limits.memory, requests.cpu or requests.memory.
+type ResourceFieldSelector struct {
+	// Required: Name of the container in the pod whose resource is selected
+	ContainerName string `json:"containerName"`
+	// Required: Resource to select
+	Resource string `json:"resource"`
+}
+
 // HTTPGetAction describes an action based on HTTP Get requests.
 type HTTPGetAction struct {
 	// Optional: Path to access on the HTTP server.
@@ -775,6 +816,8 @@ const (
 type ContainerStateWaiting struct {
 	// Reason could be pulling image,
 	Reason string `json:"reason,omitempty"`
+	// Message regarding why the container is not yet running.
+	Message string `json:"message,omitempty"`
 }
 
 type ContainerStateRunning struct {
@@ -840,6 +883,21 @@ const (
 	PodUnknown PodPhase = "Unknown"
 )
 
+// PodQOSClass is the quality of service class of a pod, which determines the
+// order in which its containers are killed when a node runs out of resources.
+type PodQOSClass string
+
+// These are the valid quality of service classes of pods.
+const (
+	// PodQOSGuaranteed means every container sets requests equal to its limits for both cpu and memory.
+	PodQOSGuaranteed PodQOSClass = "Guaranteed"
+	// PodQOSBurstable means at least one container sets a cpu or memory request or limit,
+	// but the pod does not qualify as Guaranteed.
+	PodQOSBurstable PodQOSClass = "Burstable"
+	// PodQOSBestEffort means no container sets a cpu or memory request or limit.
+	PodQOSBestEffort PodQOSClass = "BestEffort"
+)
+
 type PodConditionType string
 
 // These are valid conditions of pod.
@@ -892,6 +950,11 @@ const (
 // PodSpec is a description of a pod
 type PodSpec struct {
 	Volumes []Volume `json:"volumes"`
+	// List of initialization containers belonging to the pod. They are run
+	// in order before the containers of the pod are started, and each must
+	// exit successfully before the next one is started. A failing init
+	// container is retried according to the restart policy of the pod.
+	InitContainers []Container `json:"initContainers,omitempty"`
 	// Required: there must be at least one container in a pod.
 	Containers    []Container   `json:"containers"`
 	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
@@ -951,6 +1014,14 @@ type PodStatus struct {
 	// TODO: Make real decisions about what our info should look like. Re-enable fuzz test
 	// when we have done this.
 	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty"`
+
+	// The list has one entry per init container in the manifest, in the
+	// same format as ContainerStatuses.
+	InitContainerStatuses []ContainerStatus `json:"initContainerStatuses,omitempty"`
+
+	// The quality of service class of the pod, computed from the resource
+	// requirements of its containers.
+	QOSClass PodQOSClass `json:"qosClass,omitempty"`
 }
 
 // PodStatusResult is a wrapper for PodStatus returned by kubelet that can be encode/decoded
@@ -1322,6 +1393,10 @@ type NodeSystemInfo struct {
 type NodeStatus struct {
 	// Capacity represents the available resources of a node.
 	Capacity ResourceList `json:"capacity,omitempty"`
+	// Allocatable represents the resources of a node that are available for
+	// scheduling: its capacity minus the resources reserved for the system
+	// daemons and the Kubernetes components.
+	Allocatable ResourceList `json:"allocatable,omitempty"`
 	// NodePhase is the current lifecycle phase of the node.
 	Phase NodePhase `json:"phase,omitempty"`
 	// Conditions is an array of current node conditions.
@@ -1352,6 +1427,12 @@ type NodeConditionType string
 const (
 	// NodeReady means kubelet is healthy and ready to accept pods.
 	NodeReady NodeConditionType = "Ready"
+	// NodeMemoryPressure means the kubelet is evicting pods because the node
+	// is running low on memory.
+	NodeMemoryPressure NodeConditionType = "MemoryPressure"
+	// NodeDiskPressure means the kubelet is evicting pods because the node
+	// is running low on disk space.
+	NodeDiskPressure NodeConditionType = "DiskPressure"
 )
 
 type NodeCondition struct {
@@ -1520,6 +1601,26 @@ type PodLogOptions struct {
 
 	// If true, return previous terminated container logs
 	Previous bool
+
+	// A relative time in seconds before the current time from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceSeconds *int64
+
+	// An RFC3339 timestamp from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceTime *util.Time
+
+	// If true, add an RFC3339 or RFC3339Nano timestamp at the beginning of every line
+	// of log output.
+	Timestamps bool
+
+	// If set, the number of lines from the end of the logs to show. If not specified,
+	// logs are shown from the creation of the container or sinceSeconds or sinceTime
+	TailLines *int64
 }
 
 // PodAttachOptions is the query options to a Pod's remote attach call
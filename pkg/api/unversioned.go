@@ -29,6 +29,52 @@ type APIVersions struct {
 	Versions []string `json:"versions"`
 }
 
+// APIGroupList is a list of the API groups served under /apis.
+type APIGroupList struct {
+	Groups []APIGroup `json:"groups"`
+}
+
+// APIGroup contains the name, the supported versions, and the preferred version
+// of an API group.
+type APIGroup struct {
+	// Name is the name of the group, empty for the legacy API served under /api.
+	Name string `json:"name"`
+	// Versions are the versions supported in this group.
+	Versions []GroupVersion `json:"versions"`
+	// PreferredVersion is the version clients should use when they have no
+	// preference of their own.
+	PreferredVersion GroupVersion `json:"preferredVersion,omitempty"`
+}
+
+// GroupVersion identifies a version of an API group.
+type GroupVersion struct {
+	// GroupVersion is the group qualified version, "<group>/<version>", or only
+	// "<version>" for the legacy API.
+	GroupVersion string `json:"groupVersion"`
+	// Version is the version without the group, e.g. "v1".
+	Version string `json:"version"`
+}
+
+// APIResourceList is the list of resources served by a version of an API group.
+type APIResourceList struct {
+	// GroupVersion is the group qualified version the resources are served in.
+	GroupVersion string `json:"groupVersion"`
+	// APIResources are the resources served in the group version.
+	APIResources []APIResource `json:"resources"`
+}
+
+// APIResource describes a resource served by the API.
+type APIResource struct {
+	// Name is the plural name of the resource, or "<resource>/<subresource>".
+	Name string `json:"name"`
+	// Namespaced is true if the resource is scoped to namespaces.
+	Namespaced bool `json:"namespaced"`
+	// Kind is the kind of the objects served by the resource.
+	Kind string `json:"kind"`
+	// Verbs are the actions the resource supports, e.g. "get", "list" or "watch".
+	Verbs []string `json:"verbs"`
+}
+
 // RootPaths lists the paths available at root.
 // For example: "/healthz", "/api".
 type RootPaths struct {
@@ -17,7 +17,6 @@ limitations under the License.
 package v1
 
 import (
-	"fmt"
 	"reflect"
 
 	"k8s.io/kubernetes/pkg/api"
@@ -37,123 +36,42 @@ func addConversionFuncs() {
 		panic(err)
 	}
 
-	// Add field conversion funcs.
+	// Add field conversion funcs. Field labels are the same in v1 as in the internal
+	// API, apart from the pod spec.host label that old v1 clients send.
+	for kind, selectable := range map[string]api.SelectableFields{
+		"Node":                  api.NodeSelectableFields,
+		"ReplicationController": api.ReplicationControllerSelectableFields,
+		"Event":                 api.EventSelectableFields,
+		"Namespace":             api.NamespaceSelectableFields,
+		"Secret":                api.SecretSelectableFields,
+		"PersistentVolume":      api.PersistentVolumeSelectableFields,
+		"PersistentVolumeClaim": api.PersistentVolumeClaimSelectableFields,
+		"ServiceAccount":        api.NameSelectableFields,
+		"Endpoints":             api.NameSelectableFields,
+		"Service":               api.NameSelectableFields,
+		"PodTemplate":           api.NameSelectableFields,
+		"LimitRange":            api.NameSelectableFields,
+		"ResourceQuota":         api.NameSelectableFields,
+	} {
+		err = api.Scheme.AddFieldLabelConversionFunc("v1", kind, selectable.FieldLabelConversionFunc(kind))
+		if err != nil {
+			// If one of the conversion functions is malformed, detect it immediately.
+			panic(err)
+		}
+	}
+	convertPodFieldLabel := api.PodSelectableFields.FieldLabelConversionFunc("Pod")
 	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Pod",
 		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name",
-				"metadata.namespace",
-				"status.phase",
-				"spec.nodeName":
-				return label, value, nil
-				// This is for backwards compatability with old v1 clients which send spec.host
-			case "spec.host":
+			// This is for backwards compatability with old v1 clients which send spec.host
+			if label == "spec.host" {
 				return "spec.nodeName", value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Node",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
-				return label, value, nil
-			case "spec.unschedulable":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "ReplicationController",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name",
-				"status.replicas":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Event",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "involvedObject.kind",
-				"involvedObject.namespace",
-				"involvedObject.name",
-				"involvedObject.uid",
-				"involvedObject.apiVersion",
-				"involvedObject.resourceVersion",
-				"involvedObject.fieldPath",
-				"reason",
-				"source":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Namespace",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "status.phase":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Secret",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "type":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "ServiceAccount",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
 			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Endpoints",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
+			// The labels and annotations of a pod can be selected by the
+			// downward API, but are not selectable fields.
+			if label == "metadata.labels" || label == "metadata.annotations" {
 				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
 			}
+			return convertPodFieldLabel(label, value)
 		})
 	if err != nil {
 		// If one of the conversion functions is malformed, detect it immediately.
@@ -242,6 +160,16 @@ func convert_api_PodSpec_To_v1_PodSpec(in *api.PodSpec, out *PodSpec, s conversi
 	} else {
 		out.Volumes = nil
 	}
+	if in.InitContainers != nil {
+		out.InitContainers = make([]Container, len(in.InitContainers))
+		for i := range in.InitContainers {
+			if err := convert_api_Container_To_v1_Container(&in.InitContainers[i], &out.InitContainers[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.InitContainers = nil
+	}
 	if in.Containers != nil {
 		out.Containers = make([]Container, len(in.Containers))
 		for i := range in.Containers {
@@ -306,6 +234,16 @@ func convert_v1_PodSpec_To_api_PodSpec(in *PodSpec, out *api.PodSpec, s conversi
 	} else {
 		out.Volumes = nil
 	}
+	if in.InitContainers != nil {
+		out.InitContainers = make([]api.Container, len(in.InitContainers))
+		for i := range in.InitContainers {
+			if err := convert_v1_Container_To_api_Container(&in.InitContainers[i], &out.InitContainers[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.InitContainers = nil
+	}
 	if in.Containers != nil {
 		out.Containers = make([]api.Container, len(in.Containers))
 		for i := range in.Containers {
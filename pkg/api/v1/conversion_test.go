@@ -17,6 +17,7 @@ limitations under the License.
 package v1_test
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api"
@@ -91,3 +92,24 @@ func TestPodSpecConversion(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldLabelConversion(t *testing.T) {
+	label, value, err := api.Scheme.ConvertFieldLabel("v1", "Node", "spec.unschedulable", "true")
+	if err != nil || label != "spec.unschedulable" || value != "true" {
+		t.Errorf("unexpected conversion: %s=%s, %v", label, value, err)
+	}
+	label, value, err = api.Scheme.ConvertFieldLabel("v1", "Service", "metadata.name", "foo")
+	if err != nil || label != "metadata.name" || value != "foo" {
+		t.Errorf("unexpected conversion: %s=%s, %v", label, value, err)
+	}
+
+	_, _, err = api.Scheme.ConvertFieldLabel("v1", "Node", "spec.podCIDR", "")
+	if err == nil {
+		t.Fatalf("expected an error converting an unsupported field label")
+	}
+	for _, supported := range []string{"metadata.name", "metadata.namespace", "spec.unschedulable"} {
+		if !strings.Contains(err.Error(), supported) {
+			t.Errorf("expected %q to list the supported field label %s", err, supported)
+		}
+	}
+}
@@ -96,6 +96,7 @@ func addDefaultingFuncs() {
 				obj.RestartPolicy = RestartPolicyAlways
 			}
 			if obj.HostNetwork {
+				defaultHostNetworkPorts(&obj.InitContainers)
 				defaultHostNetworkPorts(&obj.Containers)
 			}
 		},
@@ -141,6 +141,10 @@ type ObjectMeta struct {
 	// will send a hard termination signal to the container.
 	DeletionTimestamp *util.Time `json:"deletionTimestamp,omitempty" description:"RFC 3339 date and time at which the object will be deleted; populated by the system when a graceful deletion is requested, read-only; if not set, graceful deletion of the object has not been requested; see http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata"`
 
+	// DeletionGracePeriodSeconds records the graceful deletion value set when graceful deletion
+	// was requested. Represents the most recent grace period, and may only be shortened once set.
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty" description:"number of seconds allowed for this object to gracefully terminate before it will be removed from the system; only set when deletionTimestamp is also set, read-only; may only be shortened"`
+
 	// Labels are key value pairs that may be used to scope and select individual resources.
 	// TODO: replace map[string]string with labels.LabelSet type
 	Labels map[string]string `json:"labels,omitempty" description:"map of string keys and values that can be used to organize and categorize objects; may match selectors of replication controllers and services; see http://releases.k8s.io/HEAD/docs/user-guide/labels.md"`
@@ -149,6 +153,12 @@ type ObjectMeta struct {
 	// external tooling. They are not queryable and should be preserved when modifying
 	// objects.
 	Annotations map[string]string `json:"annotations,omitempty" description:"map of string keys and values that can be used by external tooling to store and retrieve arbitrary metadata about objects; see http://releases.k8s.io/HEAD/docs/user-guide/annotations.md"`
+
+	// Finalizers are opaque identifiers of components that must perform cleanup before the
+	// object is removed from storage. While any finalizer remains, a delete request only sets
+	// DeletionTimestamp; each component removes its own entry once its cleanup is complete and
+	// the object is deleted once the list is empty.
+	Finalizers []string `json:"finalizers,omitempty" description:"list of identifiers of components that must finish cleanup before the object is removed from storage; a deletion request sets deletionTimestamp and the object is removed once the list is empty"`
 }
 
 const (
@@ -202,6 +212,8 @@ type VolumeSource struct {
 	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty" description:"a reference to a PersistentVolumeClaim in the same namespace; see http://releases.k8s.io/HEAD/docs/user-guide/persistent-volumes.md#persistentvolumeclaims"`
 	// RBD represents a Rados Block Device mount on the host that shares a pod's lifetime
 	RBD *RBDVolumeSource `json:"rbd,omitempty" description:"rados block volume that will be mounted on the host machine; see http://releases.k8s.io/HEAD/examples/rbd/README.md"`
+	// DownwardAPI represents metadata about the pod that should populate this volume
+	DownwardAPI *DownwardAPIVolumeSource `json:"downwardAPI,omitempty" description:"downward API about the pod that should populate this volume"`
 }
 
 type PersistentVolumeClaimVolumeSource struct {
@@ -495,6 +507,23 @@ type SecretVolumeSource struct {
 	SecretName string `json:"secretName" description:"secretName is the name of a secret in the pod's namespace; see http://releases.k8s.io/HEAD/docs/user-guide/volumes.md#secrets"`
 }
 
+// DownwardAPIVolumeSource represents a volume containing downward API info.
+type DownwardAPIVolumeSource struct {
+	// Items is a list of downward API volume file
+	Items []DownwardAPIVolumeFile `json:"items,omitempty" description:"list of downward API volume file"`
+}
+
+// DownwardAPIVolumeFile represents a single file containing information from
+// the downward API.
+type DownwardAPIVolumeFile struct {
+	// Required: Path is the relative path name of the file to be created.
+	Path string `json:"path" description:"the relative path name of the file to be created; must not be absolute or contain the '..' path; must be utf-8 encoded; the first item of the relative path must not start with '..'"`
+	// Selects a field of the pod: only annotations, labels, name and namespace are supported.
+	FieldRef *ObjectFieldSelector `json:"fieldRef,omitempty" description:"selects a field of the pod: only annotations, labels, name and namespace are supported"`
+	// Selects a resource of a container: only resource limits and requests are supported.
+	ResourceFieldRef *ResourceFieldSelector `json:"resourceFieldRef,omitempty" description:"selects a resource of a container: only resource limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are supported"`
+}
+
 // NFSVolumeSource represents an NFS mount that lasts the lifetime of a pod
 type NFSVolumeSource struct {
 	// Server is the hostname or IP address of the NFS server
@@ -584,6 +613,14 @@ type ObjectFieldSelector struct {
 	FieldPath string `json:"fieldPath" description:"path of the field to select in the specified API version"`
 }
 
+// ResourceFieldSelector selects a resource of a container.
+type ResourceFieldSelector struct {
+	// Required: Name of the container in the pod whose resource is selected
+	ContainerName string `json:"containerName" description:"name of the container in the pod whose resource is selected"`
+	// Required: Resource to select
+	Resource string `json:"resource" description:"resource to select: limits.cpu, limits.memory, requests.cpu or requests.memory"`
+}
+
 // HTTPGetAction describes an action based on HTTP Get requests.
 type HTTPGetAction struct {
 	// Optional: Path to access on the HTTP server.
@@ -751,6 +788,8 @@ const (
 type ContainerStateWaiting struct {
 	// Reason could be pulling image,
 	Reason string `json:"reason,omitempty" description:"(brief) reason the container is not yet running, such as pulling its image"`
+	// Message regarding why the container is not yet running.
+	Message string `json:"message,omitempty" description:"message regarding why the container is not yet running"`
 }
 
 type ContainerStateRunning struct {
@@ -817,6 +856,21 @@ const (
 	PodUnknown PodPhase = "Unknown"
 )
 
+// PodQOSClass is the quality of service class of a pod, which determines the
+// order in which its containers are killed when a node runs out of resources.
+type PodQOSClass string
+
+// These are the valid quality of service classes of pods.
+const (
+	// PodQOSGuaranteed means every container sets requests equal to its limits for both cpu and memory.
+	PodQOSGuaranteed PodQOSClass = "Guaranteed"
+	// PodQOSBurstable means at least one container sets a cpu or memory request or limit,
+	// but the pod does not qualify as Guaranteed.
+	PodQOSBurstable PodQOSClass = "Burstable"
+	// PodQOSBestEffort means no container sets a cpu or memory request or limit.
+	PodQOSBestEffort PodQOSClass = "BestEffort"
+)
+
 // PodConditionType is a valid value for PodCondition.Type
 type PodConditionType string
 
@@ -864,6 +918,8 @@ const (
 // PodSpec is a description of a pod
 type PodSpec struct {
 	Volumes []Volume `json:"volumes,omitempty" description:"list of volumes that can be mounted by containers belonging to the pod; see http://releases.k8s.io/HEAD/docs/user-guide/volumes.md" patchStrategy:"merge" patchMergeKey:"name"`
+	// List of initialization containers belonging to the pod, run in order before the containers are started.
+	InitContainers []Container `json:"initContainers,omitempty" description:"list of initialization containers belonging to the pod; run in order before the containers are started, each must exit successfully before the next one is started; failures are retried according to the restart policy of the pod" patchStrategy:"merge" patchMergeKey:"name"`
 	// Required: there must be at least one container in a pod.
 	Containers    []Container   `json:"containers" description:"list of containers belonging to the pod; cannot be updated; containers cannot currently be added or removed; there must be at least one container in a Pod; see http://releases.k8s.io/HEAD/docs/user-guide/containers.md" patchStrategy:"merge" patchMergeKey:"name"`
 	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty" description:"restart policy for all containers within the pod; one of Always, OnFailure, Never; defaults to Always; see http://releases.k8s.io/HEAD/docs/user-guide/pod-states.md#restartpolicy"`
@@ -917,6 +973,10 @@ type PodStatus struct {
 	// The list has one entry per container in the manifest. Each entry is currently the output
 	// of `docker inspect`.
 	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty" description:"list of container statuses; see http://releases.k8s.io/HEAD/docs/user-guide/pod-states.md#container-statuses"`
+	// The list has one entry per init container in the manifest.
+	InitContainerStatuses []ContainerStatus `json:"initContainerStatuses,omitempty" description:"list of init container statuses, one per init container in the manifest"`
+
+	QOSClass PodQOSClass `json:"qosClass,omitempty" description:"quality of service class of the pod, computed from the resource requirements of its containers: Guaranteed, Burstable or BestEffort"`
 }
 
 // PodStatusResult is a wrapper for PodStatus returned by kubelet that can be encode/decoded
@@ -1290,6 +1350,10 @@ type NodeStatus struct {
 	// Capacity represents the available resources of a node.
 	// see http://releases.k8s.io/HEAD/docs/user-guide/compute-resources.md for more details.
 	Capacity ResourceList `json:"capacity,omitempty" description:"compute resource capacity of the node; see http://releases.k8s.io/HEAD/docs/user-guide/compute-resources.md"`
+	// Allocatable represents the resources of a node that are available for
+	// scheduling: its capacity minus the resources reserved for the system
+	// daemons and the Kubernetes components.
+	Allocatable ResourceList `json:"allocatable,omitempty" description:"compute resources of the node available for scheduling, which is its capacity minus the resources reserved for system daemons and Kubernetes components"`
 	// NodePhase is the current lifecycle phase of the node.
 	Phase NodePhase `json:"phase,omitempty" description:"most recently observed lifecycle phase of the node; see http://releases.k8s.io/HEAD/docs/admin/node.md#node-phase"`
 	// Conditions is an array of current node conditions.
@@ -1320,10 +1384,16 @@ type NodeConditionType string
 const (
 	// NodeReady means kubelet is healthy and ready to accept pods.
 	NodeReady NodeConditionType = "Ready"
+	// NodeMemoryPressure means the kubelet is evicting pods because the node
+	// is running low on memory.
+	NodeMemoryPressure NodeConditionType = "MemoryPressure"
+	// NodeDiskPressure means the kubelet is evicting pods because the node
+	// is running low on disk space.
+	NodeDiskPressure NodeConditionType = "DiskPressure"
 )
 
 type NodeCondition struct {
-	Type               NodeConditionType `json:"type" description:"type of node condition, currently only Ready"`
+	Type               NodeConditionType `json:"type" description:"type of node condition: Ready, MemoryPressure or DiskPressure"`
 	Status             ConditionStatus   `json:"status" description:"status of the condition, one of True, False, Unknown"`
 	LastHeartbeatTime  util.Time         `json:"lastHeartbeatTime,omitempty" description:"last time we got an update on a given condition"`
 	LastTransitionTime util.Time         `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
@@ -1478,6 +1548,18 @@ type PodLogOptions struct {
 
 	//  If true, return previous terminated container logs
 	Previous bool `json:"previous,omitempty" description:"return previous terminated container logs; defaults to false"`
+
+	// A relative time in seconds before the current time from which to show logs.
+	SinceSeconds *int64 `json:"sinceSeconds,omitempty" description:"relative time in seconds before the current time from which to show logs; only one of sinceSeconds or sinceTime may be specified"`
+
+	// An RFC3339 timestamp from which to show logs.
+	SinceTime *util.Time `json:"sinceTime,omitempty" description:"an RFC3339 timestamp from which to show logs; only one of sinceSeconds or sinceTime may be specified"`
+
+	// If true, add an RFC3339 or RFC3339Nano timestamp at the beginning of every line of log output.
+	Timestamps bool `json:"timestamps,omitempty" description:"add an RFC3339 or RFC3339Nano timestamp at the beginning of every line of log output; defaults to false"`
+
+	// If set, the number of lines from the end of the logs to show.
+	TailLines *int64 `json:"tailLines,omitempty" description:"if set, the number of lines from the end of the logs to show; defaults to showing all logs"`
 }
 
 // PodAttachOptions is the query options to a Pod's remote attach call
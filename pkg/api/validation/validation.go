@@ -83,6 +83,21 @@ func ValidateAnnotations(annotations map[string]string, field string) errs.Valid
 	return allErrs
 }
 
+// ValidateFinalizers validates that a list of finalizers contains only unique, qualified names.
+func ValidateFinalizers(finalizers []string, field string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	seen := util.StringSet{}
+	for _, finalizer := range finalizers {
+		if !util.IsQualifiedName(finalizer) {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, finalizer, qualifiedNameErrorMsg))
+		} else if seen.Has(finalizer) {
+			allErrs = append(allErrs, errs.NewFieldDuplicate(field, finalizer))
+		}
+		seen.Insert(finalizer)
+	}
+	return allErrs
+}
+
 // ValidateNameFunc validates that the provided name is valid for a given resource type.
 // Not all resources have the same validation rules for names. Prefix is true if the
 // name will have a value appended to it.
@@ -238,6 +253,7 @@ func ValidateObjectMeta(meta *api.ObjectMeta, requiresNamespace bool, nameFn Val
 	}
 	allErrs = append(allErrs, ValidateLabels(meta.Labels, "labels")...)
 	allErrs = append(allErrs, ValidateAnnotations(meta.Annotations, "annotations")...)
+	allErrs = append(allErrs, ValidateFinalizers(meta.Finalizers, "finalizers")...)
 
 	return allErrs
 }
@@ -256,6 +272,10 @@ func ValidateObjectMetaUpdate(new, old *api.ObjectMeta) errs.ValidationErrorList
 	} else {
 		new.CreationTimestamp = old.CreationTimestamp
 	}
+	// ignore changes to the deletion timestamp and grace period: they are only set by a
+	// delete, and can never be set, removed or changed by an update
+	new.DeletionTimestamp = old.DeletionTimestamp
+	new.DeletionGracePeriodSeconds = old.DeletionGracePeriodSeconds
 
 	// Reject updates that don't specify a resource version
 	if new.ResourceVersion == "" {
@@ -277,6 +297,16 @@ func ValidateObjectMetaUpdate(new, old *api.ObjectMeta) errs.ValidationErrorList
 
 	allErrs = append(allErrs, ValidateLabels(new.Labels, "labels")...)
 	allErrs = append(allErrs, ValidateAnnotations(new.Annotations, "annotations")...)
+	allErrs = append(allErrs, ValidateFinalizers(new.Finalizers, "finalizers")...)
+	// finalizers may only be removed once deletion has been requested
+	if !old.DeletionTimestamp.IsZero() {
+		oldFinalizers := util.NewStringSet(old.Finalizers...)
+		for _, finalizer := range new.Finalizers {
+			if !oldFinalizers.Has(finalizer) {
+				allErrs = append(allErrs, errs.NewFieldForbidden("finalizers", finalizer))
+			}
+		}
+	}
 
 	return allErrs
 }
@@ -350,6 +380,10 @@ func validateSource(source *api.VolumeSource) errs.ValidationErrorList {
 		numVolumes++
 		allErrs = append(allErrs, validateRBD(source.RBD).Prefix("rbd")...)
 	}
+	if source.DownwardAPI != nil {
+		numVolumes++
+		allErrs = append(allErrs, validateDownwardAPIVolumeSource(source.DownwardAPI).Prefix("downwardAPI")...)
+	}
 	if numVolumes != 1 {
 		allErrs = append(allErrs, errs.NewFieldInvalid("", source, "exactly 1 volume type is required"))
 	}
@@ -473,6 +507,57 @@ func validateRBD(rbd *api.RBDVolumeSource) errs.ValidationErrorList {
 	return allErrs
 }
 
+var validDownwardAPIFieldPathExpressions = util.NewStringSet("metadata.name", "metadata.namespace", "metadata.labels", "metadata.annotations")
+
+var validDownwardAPIResources = util.NewStringSet("limits.cpu", "limits.memory", "requests.cpu", "requests.memory")
+
+func validateDownwardAPIVolumeSource(downwardAPIVolume *api.DownwardAPIVolumeSource) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	paths := util.StringSet{}
+	for i, file := range downwardAPIVolume.Items {
+		fErrs := errs.ValidationErrorList{}
+		if len(file.Path) == 0 {
+			fErrs = append(fErrs, errs.NewFieldRequired("path"))
+		} else if path.IsAbs(file.Path) {
+			fErrs = append(fErrs, errs.NewFieldInvalid("path", file.Path, "must not be an absolute path"))
+		} else if items := strings.Split(file.Path, "/"); strings.HasPrefix(items[0], "..") {
+			// Names starting with ".." are reserved for the data directories of the volume.
+			fErrs = append(fErrs, errs.NewFieldInvalid("path", file.Path, "must not start with '..'"))
+		} else if util.NewStringSet(items...).Has("..") {
+			fErrs = append(fErrs, errs.NewFieldInvalid("path", file.Path, "must not contain '..'"))
+		} else if paths.Has(path.Clean(file.Path)) {
+			fErrs = append(fErrs, errs.NewFieldDuplicate("path", file.Path))
+		} else {
+			paths.Insert(path.Clean(file.Path))
+		}
+		switch {
+		case file.FieldRef != nil && file.ResourceFieldRef != nil:
+			fErrs = append(fErrs, errs.NewFieldInvalid("", "", "fieldRef and resourceFieldRef cannot both be specified"))
+		case file.FieldRef != nil:
+			fErrs = append(fErrs, validateObjectFieldSelector(file.FieldRef, validDownwardAPIFieldPathExpressions).Prefix("fieldRef")...)
+		case file.ResourceFieldRef != nil:
+			fErrs = append(fErrs, validateResourceFieldSelector(file.ResourceFieldRef).Prefix("resourceFieldRef")...)
+		default:
+			fErrs = append(fErrs, errs.NewFieldRequired("fieldRef"))
+		}
+		allErrs = append(allErrs, fErrs.PrefixIndex(i).Prefix("items")...)
+	}
+	return allErrs
+}
+
+func validateResourceFieldSelector(rs *api.ResourceFieldSelector) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if rs.ContainerName == "" {
+		allErrs = append(allErrs, errs.NewFieldRequired("containerName"))
+	}
+	if rs.Resource == "" {
+		allErrs = append(allErrs, errs.NewFieldRequired("resource"))
+	} else if !validDownwardAPIResources.Has(rs.Resource) {
+		allErrs = append(allErrs, errs.NewFieldValueNotSupported("resource", rs.Resource, validDownwardAPIResources.List()))
+	}
+	return allErrs
+}
+
 func ValidatePersistentVolumeName(name string, prefix bool) (bool, string) {
 	return nameIsDNSSubdomain(name, prefix)
 }
@@ -661,7 +746,7 @@ func validateEnvVarValueFrom(ev api.EnvVar) errs.ValidationErrorList {
 	switch {
 	case ev.ValueFrom.FieldRef != nil:
 		numSources++
-		allErrs = append(allErrs, validateObjectFieldSelector(ev.ValueFrom.FieldRef).Prefix("fieldRef")...)
+		allErrs = append(allErrs, validateObjectFieldSelector(ev.ValueFrom.FieldRef, validFieldPathExpressions).Prefix("fieldRef")...)
 	}
 
 	if ev.Value != "" && numSources != 0 {
@@ -673,7 +758,7 @@ func validateEnvVarValueFrom(ev api.EnvVar) errs.ValidationErrorList {
 
 var validFieldPathExpressions = util.NewStringSet("metadata.name", "metadata.namespace")
 
-func validateObjectFieldSelector(fs *api.ObjectFieldSelector) errs.ValidationErrorList {
+func validateObjectFieldSelector(fs *api.ObjectFieldSelector, expressions util.StringSet) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 
 	if fs.APIVersion == "" {
@@ -684,8 +769,8 @@ func validateObjectFieldSelector(fs *api.ObjectFieldSelector) errs.ValidationErr
 		internalFieldPath, _, err := api.Scheme.ConvertFieldLabel(fs.APIVersion, "Pod", fs.FieldPath, "")
 		if err != nil {
 			allErrs = append(allErrs, errs.NewFieldInvalid("fieldPath", fs.FieldPath, "error converting fieldPath"))
-		} else if !validFieldPathExpressions.Has(internalFieldPath) {
-			allErrs = append(allErrs, errs.NewFieldValueNotSupported("fieldPath", internalFieldPath, validFieldPathExpressions.List()))
+		} else if !expressions.Has(internalFieldPath) {
+			allErrs = append(allErrs, errs.NewFieldValueNotSupported("fieldPath", internalFieldPath, expressions.List()))
 		}
 	}
 
@@ -881,6 +966,40 @@ func validateContainers(containers []api.Container, volumes util.StringSet) errs
 	return allErrs
 }
 
+// validateInitContainers validates the init containers of a pod. They are
+// validated like regular containers, may not share a name with any
+// container of the pod, and may not set fields that only make sense for
+// long running containers.
+func validateInitContainers(containers, otherContainers []api.Container, volumes util.StringSet) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if len(containers) == 0 {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateContainers(containers, volumes)...)
+
+	otherNames := util.StringSet{}
+	for _, ctr := range otherContainers {
+		otherNames.Insert(ctr.Name)
+	}
+	for i, ctr := range containers {
+		cErrs := errs.ValidationErrorList{}
+		if otherNames.Has(ctr.Name) {
+			cErrs = append(cErrs, errs.NewFieldDuplicate("name", ctr.Name))
+		}
+		if ctr.Lifecycle != nil {
+			cErrs = append(cErrs, errs.NewFieldForbidden("lifecycle", ctr.Lifecycle))
+		}
+		if ctr.LivenessProbe != nil {
+			cErrs = append(cErrs, errs.NewFieldForbidden("livenessProbe", ctr.LivenessProbe))
+		}
+		if ctr.ReadinessProbe != nil {
+			cErrs = append(cErrs, errs.NewFieldForbidden("readinessProbe", ctr.ReadinessProbe))
+		}
+		allErrs = append(allErrs, cErrs.PrefixIndex(i)...)
+	}
+	return allErrs
+}
+
 func validateRestartPolicy(restartPolicy *api.RestartPolicy) errs.ValidationErrorList {
 	allErrors := errs.ValidationErrorList{}
 	switch *restartPolicy {
@@ -956,10 +1075,12 @@ func ValidatePodSpec(spec *api.PodSpec) errs.ValidationErrorList {
 
 	allVolumes, vErrs := validateVolumes(spec.Volumes)
 	allErrs = append(allErrs, vErrs.Prefix("volumes")...)
+	allErrs = append(allErrs, validateInitContainers(spec.InitContainers, spec.Containers, allVolumes).Prefix("initContainers")...)
 	allErrs = append(allErrs, validateContainers(spec.Containers, allVolumes).Prefix("containers")...)
 	allErrs = append(allErrs, validateRestartPolicy(&spec.RestartPolicy).Prefix("restartPolicy")...)
 	allErrs = append(allErrs, validateDNSPolicy(&spec.DNSPolicy).Prefix("dnsPolicy")...)
 	allErrs = append(allErrs, ValidateLabels(spec.NodeSelector, "nodeSelector")...)
+	allErrs = append(allErrs, validateHostNetwork(spec.HostNetwork, spec.InitContainers).Prefix("hostNetwork")...)
 	allErrs = append(allErrs, validateHostNetwork(spec.HostNetwork, spec.Containers).Prefix("hostNetwork")...)
 	allErrs = append(allErrs, validateImagePullSecrets(spec.ImagePullSecrets).Prefix("imagePullSecrets")...)
 	if len(spec.ServiceAccountName) > 0 {
@@ -988,6 +1109,10 @@ func ValidatePodUpdate(newPod, oldPod *api.Pod) errs.ValidationErrorList {
 		allErrs = append(allErrs, errs.NewFieldInvalid("spec.containers", "content of spec.containers is not printed out, please refer to the \"details\"", "may not add or remove containers"))
 		return allErrs
 	}
+	if len(newPod.Spec.InitContainers) != len(oldPod.Spec.InitContainers) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("spec.initContainers", "content of spec.initContainers is not printed out, please refer to the \"details\"", "may not add or remove init containers"))
+		return allErrs
+	}
 	pod := *newPod
 	// Tricky, we need to copy the container list so that we don't overwrite the update
 	var newContainers []api.Container
@@ -996,6 +1121,12 @@ func ValidatePodUpdate(newPod, oldPod *api.Pod) errs.ValidationErrorList {
 		newContainers = append(newContainers, container)
 	}
 	pod.Spec.Containers = newContainers
+	var newInitContainers []api.Container
+	for ix, container := range pod.Spec.InitContainers {
+		container.Image = oldPod.Spec.InitContainers[ix].Image
+		newInitContainers = append(newInitContainers, container)
+	}
+	pod.Spec.InitContainers = newInitContainers
 	if !api.Semantic.DeepEqual(pod.Spec, oldPod.Spec) {
 		//TODO: Pinpoint the specific field that causes the invalid error after we have strategic merge diff
 		allErrs = append(allErrs, errs.NewFieldInvalid("spec", "content of spec is not printed out, please refer to the \"details\"", "may not update fields other than container.image"))
@@ -1023,6 +1154,23 @@ func ValidatePodStatusUpdate(newPod, oldPod *api.Pod) errs.ValidationErrorList {
 	return allErrs
 }
 
+// ValidatePodLogOptions tests that the options for a pod log request are consistent.
+func ValidatePodLogOptions(opts *api.PodLogOptions) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if opts.TailLines != nil && *opts.TailLines < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("tailLines", *opts.TailLines, isNegativeErrorMsg))
+	}
+	if opts.SinceSeconds != nil {
+		if *opts.SinceSeconds < 1 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("sinceSeconds", *opts.SinceSeconds, "must be greater than 0"))
+		}
+		if opts.SinceTime != nil {
+			allErrs = append(allErrs, errs.NewFieldInvalid("sinceSeconds", *opts.SinceSeconds, "only one of sinceSeconds or sinceTime may be specified"))
+		}
+	}
+	return allErrs
+}
+
 // ValidatePodTemplate tests if required fields in the pod template are set.
 func ValidatePodTemplate(pod *api.PodTemplate) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
@@ -105,6 +105,58 @@ func TestValidateObjectMetaUpdateIgnoresCreationTimestamp(t *testing.T) {
 	}
 }
 
+func TestValidateObjectMetaUpdatePreservesDeletion(t *testing.T) {
+	now := util.NewTime(time.Unix(10, 0))
+	grace := int64(30)
+	newMeta := &api.ObjectMeta{Name: "test", ResourceVersion: "1"}
+	if errs := ValidateObjectMetaUpdate(
+		newMeta,
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1", DeletionTimestamp: &now, DeletionGracePeriodSeconds: &grace},
+	); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if newMeta.DeletionTimestamp == nil || newMeta.DeletionGracePeriodSeconds == nil {
+		t.Errorf("expected deletion fields to be preserved: %#v", newMeta)
+	}
+
+	// an update cannot mark an object for deletion
+	newMeta = &api.ObjectMeta{Name: "test", ResourceVersion: "1", DeletionTimestamp: &now, DeletionGracePeriodSeconds: &grace}
+	if errs := ValidateObjectMetaUpdate(
+		newMeta,
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1"},
+	); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if newMeta.DeletionTimestamp != nil || newMeta.DeletionGracePeriodSeconds != nil {
+		t.Errorf("expected deletion fields to be ignored: %#v", newMeta)
+	}
+}
+
+func TestValidateObjectMetaUpdateFinalizers(t *testing.T) {
+	now := util.NewTime(time.Unix(10, 0))
+	if errs := ValidateObjectMetaUpdate(
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1", Finalizers: []string{"example.com/a"}},
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1"},
+	); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := ValidateObjectMetaUpdate(
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1"},
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1", DeletionTimestamp: &now, Finalizers: []string{"example.com/a"}},
+	); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := ValidateObjectMetaUpdate(
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1", Finalizers: []string{"example.com/a", "example.com/b"}},
+		&api.ObjectMeta{Name: "test", ResourceVersion: "1", DeletionTimestamp: &now, Finalizers: []string{"example.com/a"}},
+	); len(errs) != 1 {
+		t.Errorf("expected finalizers to be immutable once deleting: %v", errs)
+	}
+	if errs := ValidateObjectMeta(&api.ObjectMeta{Name: "test", Finalizers: []string{"a", "a", "/b"}}, false, nameIsDNSSubdomain); len(errs) != 2 {
+		t.Errorf("expected duplicate and invalid finalizer errors: %v", errs)
+	}
+}
+
 // Ensure trailing slash is allowed in generate name
 func TestValidateObjectMetaTrimsTrailingSlash(t *testing.T) {
 	errs := ValidateObjectMeta(&api.ObjectMeta{Name: "test", GenerateName: "foo-"}, false, nameIsDNSSubdomain)
@@ -459,6 +511,12 @@ func TestValidateVolumes(t *testing.T) {
 		{Name: "secret", VolumeSource: api.VolumeSource{Secret: &api.SecretVolumeSource{"my-secret"}}},
 		{Name: "glusterfs", VolumeSource: api.VolumeSource{Glusterfs: &api.GlusterfsVolumeSource{"host1", "path", false}}},
 		{Name: "rbd", VolumeSource: api.VolumeSource{RBD: &api.RBDVolumeSource{CephMonitors: []string{"foo"}, RBDImage: "bar", FSType: "ext4"}}},
+		{Name: "downwardapi", VolumeSource: api.VolumeSource{DownwardAPI: &api.DownwardAPIVolumeSource{Items: []api.DownwardAPIVolumeFile{
+			{Path: "labels", FieldRef: &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.labels"}},
+			{Path: "annotations", FieldRef: &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.annotations"}},
+			{Path: "name/pod", FieldRef: &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}},
+			{Path: "cpu_limit", ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "ctr", Resource: "limits.cpu"}},
+		}}}},
 	}
 	names, errs := validateVolumes(successCase)
 	if len(errs) != 0 {
@@ -474,6 +532,9 @@ func TestValidateVolumes(t *testing.T) {
 	emptyPath := api.VolumeSource{Glusterfs: &api.GlusterfsVolumeSource{"host", "", false}}
 	emptyMon := api.VolumeSource{RBD: &api.RBDVolumeSource{CephMonitors: []string{}, RBDImage: "bar", FSType: "ext4"}}
 	emptyImage := api.VolumeSource{RBD: &api.RBDVolumeSource{CephMonitors: []string{"foo"}, RBDImage: "", FSType: "ext4"}}
+	labelsRef := &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.labels"}
+	duplicateItemPath := api.VolumeSource{DownwardAPI: &api.DownwardAPIVolumeSource{Items: []api.DownwardAPIVolumeFile{{Path: "labels", FieldRef: labelsRef}, {Path: "./labels", FieldRef: labelsRef}}}}
+	emptyItemSource := api.VolumeSource{DownwardAPI: &api.DownwardAPIVolumeSource{Items: []api.DownwardAPIVolumeFile{{Path: "labels"}}}}
 	errorCases := map[string]struct {
 		V []api.Volume
 		T errors.ValidationErrorType
@@ -489,6 +550,8 @@ func TestValidateVolumes(t *testing.T) {
 		"empty path":           {[]api.Volume{{Name: "badpath", VolumeSource: emptyPath}}, errors.ValidationErrorTypeRequired, "[0].source.glusterfs.path"},
 		"empty mon":            {[]api.Volume{{Name: "badmon", VolumeSource: emptyMon}}, errors.ValidationErrorTypeRequired, "[0].source.rbd.monitors"},
 		"empty image":          {[]api.Volume{{Name: "badimage", VolumeSource: emptyImage}}, errors.ValidationErrorTypeRequired, "[0].source.rbd.image"},
+		"duplicate item path":  {[]api.Volume{{Name: "dupitem", VolumeSource: duplicateItemPath}}, errors.ValidationErrorTypeDuplicate, "[0].source.downwardAPI.items[1].path"},
+		"empty item source":    {[]api.Volume{{Name: "emptyitem", VolumeSource: emptyItemSource}}, errors.ValidationErrorTypeRequired, "[0].source.downwardAPI.items[0].fieldRef"},
 	}
 	for k, v := range errorCases {
 		_, errs := validateVolumes(v.V)
@@ -511,6 +574,37 @@ func TestValidateVolumes(t *testing.T) {
 	}
 }
 
+func TestValidateDownwardAPIVolumeSource(t *testing.T) {
+	labelsRef := &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.labels"}
+	errorCases := map[string]struct {
+		F api.DownwardAPIVolumeFile
+		T errors.ValidationErrorType
+		P string
+	}{
+		"empty path":           {api.DownwardAPIVolumeFile{FieldRef: labelsRef}, errors.ValidationErrorTypeRequired, "items[0].path"},
+		"absolute path":        {api.DownwardAPIVolumeFile{Path: "/labels", FieldRef: labelsRef}, errors.ValidationErrorTypeInvalid, "items[0].path"},
+		"path with ..":         {api.DownwardAPIVolumeFile{Path: "a/../../labels", FieldRef: labelsRef}, errors.ValidationErrorTypeInvalid, "items[0].path"},
+		"path starting ..":     {api.DownwardAPIVolumeFile{Path: "..data", FieldRef: labelsRef}, errors.ValidationErrorTypeInvalid, "items[0].path"},
+		"unsupported field":    {api.DownwardAPIVolumeFile{Path: "node", FieldRef: &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: "spec.nodeName"}}, errors.ValidationErrorTypeNotSupported, "items[0].fieldRef.fieldPath"},
+		"unsupported resource": {api.DownwardAPIVolumeFile{Path: "cpu", ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "ctr", Resource: "cpu"}}, errors.ValidationErrorTypeNotSupported, "items[0].resourceFieldRef.resource"},
+		"empty container":      {api.DownwardAPIVolumeFile{Path: "cpu", ResourceFieldRef: &api.ResourceFieldSelector{Resource: "limits.cpu"}}, errors.ValidationErrorTypeRequired, "items[0].resourceFieldRef.containerName"},
+		"both sources":         {api.DownwardAPIVolumeFile{Path: "cpu", FieldRef: labelsRef, ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "ctr", Resource: "limits.cpu"}}, errors.ValidationErrorTypeInvalid, "items[0]"},
+	}
+	for k, v := range errorCases {
+		errs := validateDownwardAPIVolumeSource(&api.DownwardAPIVolumeSource{Items: []api.DownwardAPIVolumeFile{v.F}})
+		if len(errs) != 1 {
+			t.Errorf("%s: expected one error, got %v", k, errs)
+			continue
+		}
+		if errs[0].(*errors.ValidationError).Type != v.T {
+			t.Errorf("%s: expected error to have type %s: %v", k, v.T, errs[0])
+		}
+		if errs[0].(*errors.ValidationError).Field != v.P {
+			t.Errorf("%s: expected error to have field %s: %v", k, v.P, errs[0])
+		}
+	}
+}
+
 func TestValidatePorts(t *testing.T) {
 	successCase := []api.ContainerPort{
 		{Name: "abc", ContainerPort: 80, HostPort: 80, Protocol: "TCP"},
@@ -1089,6 +1183,18 @@ func TestValidatePodSpec(t *testing.T) {
 			RestartPolicy: api.RestartPolicyAlways,
 			DNSPolicy:     api.DNSClusterFirst,
 		},
+		{ // Populate InitContainers.
+			Volumes: []api.Volume{
+				{Name: "vol", VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}},
+			},
+			InitContainers: []api.Container{
+				{Name: "init1", Image: "image", ImagePullPolicy: "IfNotPresent", VolumeMounts: []api.VolumeMount{{Name: "vol", MountPath: "/data"}}},
+				{Name: "init2", Image: "image", ImagePullPolicy: "IfNotPresent"},
+			},
+			Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy: api.RestartPolicyOnFailure,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
 	}
 	for i := range successCases {
 		if errs := ValidatePodSpec(&successCases[i]); len(errs) != 0 {
@@ -1139,6 +1245,45 @@ func TestValidatePodSpec(t *testing.T) {
 			RestartPolicy: api.RestartPolicyAlways,
 			DNSPolicy:     api.DNSClusterFirst,
 		},
+		"bad init container": {
+			InitContainers: []api.Container{{}},
+			Containers:     []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy:  api.RestartPolicyAlways,
+			DNSPolicy:      api.DNSClusterFirst,
+		},
+		"init container with the name of a container": {
+			InitContainers: []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			Containers:     []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy:  api.RestartPolicyAlways,
+			DNSPolicy:      api.DNSClusterFirst,
+		},
+		"duplicate init container names": {
+			InitContainers: []api.Container{
+				{Name: "init", Image: "image", ImagePullPolicy: "IfNotPresent"},
+				{Name: "init", Image: "image", ImagePullPolicy: "IfNotPresent"},
+			},
+			Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
+		"init container with a readiness probe": {
+			InitContainers: []api.Container{
+				{Name: "init", Image: "image", ImagePullPolicy: "IfNotPresent", ReadinessProbe: &api.Probe{
+					Handler: api.Handler{Exec: &api.ExecAction{Command: []string{"true"}}},
+				}},
+			},
+			Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
+		"init container mounting an unknown volume": {
+			InitContainers: []api.Container{
+				{Name: "init", Image: "image", ImagePullPolicy: "IfNotPresent", VolumeMounts: []api.VolumeMount{{Name: "missing", MountPath: "/data"}}},
+			},
+			Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
 		"bad-active-deadline-seconds": {
 			Volumes: []api.Volume{
 				{Name: "vol", VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}},
@@ -1349,6 +1494,42 @@ func TestValidatePodUpdate(t *testing.T) {
 			true,
 			"image change",
 		},
+		{
+			api.Pod{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec: api.PodSpec{
+					InitContainers: []api.Container{{Image: "init:V1"}},
+					Containers:     []api.Container{{Image: "foo:V1"}},
+				},
+			},
+			api.Pod{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec: api.PodSpec{
+					InitContainers: []api.Container{{Image: "init:V2"}},
+					Containers:     []api.Container{{Image: "foo:V1"}},
+				},
+			},
+			true,
+			"init container image change",
+		},
+		{
+			api.Pod{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec: api.PodSpec{
+					InitContainers: []api.Container{{Image: "init:V1"}, {Image: "init:V1"}},
+					Containers:     []api.Container{{Image: "foo:V1"}},
+				},
+			},
+			api.Pod{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec: api.PodSpec{
+					InitContainers: []api.Container{{Image: "init:V1"}},
+					Containers:     []api.Container{{Image: "foo:V1"}},
+				},
+			},
+			false,
+			"more init containers",
+		},
 		{
 			api.Pod{
 				ObjectMeta: api.ObjectMeta{Name: "foo"},
@@ -2042,6 +2223,36 @@ func TestValidateReplicationControllerUpdate(t *testing.T) {
 
 }
 
+func TestValidatePodLogOptions(t *testing.T) {
+	negative, zero, positive := int64(-1), int64(0), int64(10)
+	now := util.Now()
+
+	successCases := []api.PodLogOptions{
+		{},
+		{Follow: true, Timestamps: true},
+		{TailLines: &zero},
+		{TailLines: &positive, SinceSeconds: &positive},
+		{SinceTime: &now},
+	}
+	for i := range successCases {
+		if errs := ValidatePodLogOptions(&successCases[i]); len(errs) != 0 {
+			t.Errorf("%d: expected success: %v", i, errs)
+		}
+	}
+
+	errorCases := map[string]api.PodLogOptions{
+		"negative tailLines":         {TailLines: &negative},
+		"zero sinceSeconds":          {SinceSeconds: &zero},
+		"negative sinceSeconds":      {SinceSeconds: &negative},
+		"sinceSeconds and sinceTime": {SinceSeconds: &positive, SinceTime: &now},
+	}
+	for k, v := range errorCases {
+		if errs := ValidatePodLogOptions(&v); len(errs) == 0 {
+			t.Errorf("%s: expected failure", k)
+		}
+	}
+}
+
 func TestValidateReplicationController(t *testing.T) {
 	validSelector := map[string]string{"a": "b"}
 	validPodTemplate := api.PodTemplate{
@@ -3031,7 +3242,7 @@ func TestValidateNamespaceStatusUpdate(t *testing.T) {
 				Status: api.NamespaceStatus{
 					Phase: api.NamespaceTerminating,
 				},
-			}, true},
+			}, false},
 		{api.Namespace{
 			ObjectMeta: api.ObjectMeta{
 				Name: "foo"}},
@@ -31,6 +31,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/conversion"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
 	watchjson "k8s.io/kubernetes/pkg/watch/json"
 
 	"github.com/emicklei/go-restful"
@@ -52,11 +53,27 @@ type action struct {
 	Namer  ScopeNamer
 }
 
+// discoveryVerbs maps the actions installed for a resource to the verbs reported
+// for it by discovery.
+var discoveryVerbs = map[string]string{
+	"GET":       "get",
+	"LIST":      "list",
+	"POST":      "create",
+	"PUT":       "update",
+	"PATCH":     "patch",
+	"DELETE":    "delete",
+	"WATCH":     "watch",
+	"WATCHLIST": "watch",
+	"PROXY":     "proxy",
+	"CONNECT":   "connect",
+}
+
 // errEmptyName is returned when API requests do not fill the name section of the path.
 var errEmptyName = errors.NewBadRequest("name must be provided")
 
-// Installs handlers for API resources.
-func (a *APIInstaller) Install() (ws *restful.WebService, errors []error) {
+// Installs handlers for API resources, and returns the resources that were
+// installed for discovery.
+func (a *APIInstaller) Install() (ws *restful.WebService, apiResources []api.APIResource, errors []error) {
 	errors = make([]error, 0)
 
 	// Create the WebService.
@@ -73,11 +90,15 @@ func (a *APIInstaller) Install() (ws *restful.WebService, errors []error) {
 	}
 	sort.Strings(paths)
 	for _, path := range paths {
-		if err := a.registerResourceHandlers(path, a.group.Storage[path], ws, proxyHandler); err != nil {
+		apiResource, err := a.registerResourceHandlers(path, a.group.Storage[path], ws, proxyHandler)
+		if err != nil {
 			errors = append(errors, err)
 		}
+		if apiResource != nil {
+			apiResources = append(apiResources, *apiResource)
+		}
 	}
-	return ws, errors
+	return ws, apiResources, errors
 }
 
 func (a *APIInstaller) newWebService() *restful.WebService {
@@ -92,7 +113,7 @@ func (a *APIInstaller) newWebService() *restful.WebService {
 	return ws
 }
 
-func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storage, ws *restful.WebService, proxyHandler http.Handler) error {
+func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storage, ws *restful.WebService, proxyHandler http.Handler) (*api.APIResource, error) {
 	admit := a.group.Admit
 	context := a.group.Context
 
@@ -109,40 +130,40 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		resource = parts[0]
 	default:
 		// TODO: support deeper paths
-		return fmt.Errorf("api_installer allows only one or two segment paths (resource or resource/subresource)")
+		return nil, fmt.Errorf("api_installer allows only one or two segment paths (resource or resource/subresource)")
 	}
 	hasSubresource := len(subresource) > 0
 
 	object := storage.New()
 	_, kind, err := a.group.Typer.ObjectVersionAndKind(object)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	versionedPtr, err := a.group.Creater.New(a.group.Version, kind)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	versionedObject := indirectArbitraryPointer(versionedPtr)
 
 	mapping, err := a.group.Mapper.RESTMapping(kind, a.group.Version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// subresources must have parent resources, and follow the namespacing rules of their parent
 	if hasSubresource {
 		parentStorage, ok := a.group.Storage[resource]
 		if !ok {
-			return fmt.Errorf("subresources can only be declared when the parent is also registered: %s needs %s", path, resource)
+			return nil, fmt.Errorf("subresources can only be declared when the parent is also registered: %s needs %s", path, resource)
 		}
 		parentObject := parentStorage.New()
 		_, parentKind, err := a.group.Typer.ObjectVersionAndKind(parentObject)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		parentMapping, err := a.group.Mapper.RESTMapping(parentKind, a.group.Version)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		mapping.Scope = parentMapping.Scope
 	}
@@ -175,14 +196,14 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		_, listKind, err := a.group.Typer.ObjectVersionAndKind(list)
 		versionedListPtr, err := a.group.Creater.New(a.group.Version, listKind)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		versionedList = indirectArbitraryPointer(versionedListPtr)
 	}
 
 	versionedListOptions, err := a.group.Creater.New(serverVersion, "ListOptions")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var versionedDeleterObject interface{}
@@ -190,7 +211,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 	case isGracefulDeleter:
 		objectPtr, err := a.group.Creater.New(serverVersion, "DeleteOptions")
 		if err != nil {
-			return err
+			return nil, err
 		}
 		versionedDeleterObject = indirectArbitraryPointer(objectPtr)
 		isDeleter = true
@@ -200,7 +221,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 
 	versionedStatusPtr, err := a.group.Creater.New(serverVersion, "Status")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	versionedStatus := indirectArbitraryPointer(versionedStatusPtr)
 	var (
@@ -214,11 +235,11 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		getOptions, getSubpath, getSubpathKey = getterWithOptions.NewGetOptions()
 		_, getOptionsKind, err = a.group.Typer.ObjectVersionAndKind(getOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		versionedGetOptions, err = a.group.Creater.New(serverVersion, getOptionsKind)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		isGetter = true
 	}
@@ -235,7 +256,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		if connectOptions != nil {
 			_, connectOptionsKind, err = a.group.Typer.ObjectVersionAndKind(connectOptions)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			versionedConnectOptions, err = a.group.Creater.New(serverVersion, connectOptionsKind)
 		}
@@ -338,7 +359,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 		}
 		break
 	default:
-		return fmt.Errorf("unsupported restscope: %s", scope.Name())
+		return nil, fmt.Errorf("unsupported restscope: %s", scope.Name())
 	}
 
 	// Create Routes for the actions.
@@ -398,7 +419,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 				Writes(versionedObject)
 			if isGetterWithOptions {
 				if err := addObjectParams(ws, route, versionedGetOptions); err != nil {
-					return err
+					return nil, err
 				}
 			}
 			addParams(route, action.Params)
@@ -417,7 +438,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 				Returns(http.StatusOK, "OK", versionedList).
 				Writes(versionedList)
 			if err := addObjectParams(ws, route, versionedListOptions); err != nil {
-				return err
+				return nil, err
 			}
 			switch {
 			case isLister && isWatcher:
@@ -523,7 +544,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 				Returns(http.StatusOK, "OK", watchjson.WatchEvent{}).
 				Writes(watchjson.WatchEvent{})
 			if err := addObjectParams(ws, route, versionedListOptions); err != nil {
-				return err
+				return nil, err
 			}
 			addParams(route, action.Params)
 			ws.Route(route)
@@ -542,7 +563,7 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 				Returns(http.StatusOK, "OK", watchjson.WatchEvent{}).
 				Writes(watchjson.WatchEvent{})
 			if err := addObjectParams(ws, route, versionedListOptions); err != nil {
-				return err
+				return nil, err
 			}
 			addParams(route, action.Params)
 			ws.Route(route)
@@ -570,18 +591,28 @@ func (a *APIInstaller) registerResourceHandlers(path string, storage rest.Storag
 					Writes("string")
 				if versionedConnectOptions != nil {
 					if err := addObjectParams(ws, route, versionedConnectOptions); err != nil {
-						return err
+						return nil, err
 					}
 				}
 				addParams(route, action.Params)
 				ws.Route(route)
 			}
 		default:
-			return fmt.Errorf("unrecognized action verb: %s", action.Verb)
+			return nil, fmt.Errorf("unrecognized action verb: %s", action.Verb)
 		}
 		// Note: update GetAttribs() when adding a custom handler.
 	}
-	return nil
+
+	verbs := util.NewStringSet()
+	for _, action := range actions {
+		verbs.Insert(discoveryVerbs[action.Verb])
+	}
+	return &api.APIResource{
+		Name:       path,
+		Namespaced: scope.Name() == meta.RESTScopeNameNamespace,
+		Kind:       mapping.Kind,
+		Verbs:      verbs.List(),
+	}, nil
 }
 
 // rootScopeNaming reads only names from a request and ignores namespaces. It implements ScopeNamer
@@ -797,7 +828,12 @@ func addObjectParams(ws *restful.WebService, route *restful.RouteBuilder, obj in
 					continue
 				}
 				desc := sf.Tag.Get("description")
-				route.Param(ws.QueryParameter(jsonName, desc).DataType(typeToJSON(sf.Type.Name())))
+				typeName := sf.Type.Name()
+				if sf.Type.Kind() == reflect.Ptr {
+					// Optional parameters are pointers, document the type they point to.
+					typeName = sf.Type.Elem().String()
+				}
+				route.Param(ws.QueryParameter(jsonName, desc).DataType(typeToJSON(typeName)))
 			}
 		}
 	}
@@ -78,6 +78,8 @@ type APIGroupVersion struct {
 
 	Root    string
 	Version string
+	// Group is the name of the API group served, empty for the legacy API.
+	Group string
 
 	// ServerVersion controls the Kubernetes APIVersion used for common objects in the apiserver
 	// schema like api.Status, api.DeleteOptions, and api.ListOptions. Other implementors may
@@ -124,7 +126,8 @@ func (g *APIGroupVersion) InstallREST(container *restful.Container) error {
 		minRequestTimeout: g.MinRequestTimeout,
 		proxyDialerFn:     g.ProxyDialerFn,
 	}
-	ws, registrationErrors := installer.Install()
+	ws, apiResources, registrationErrors := installer.Install()
+	addSupportedResourcesRoute(ws, meta.JoinGroupVersion(g.Group, g.Version), apiResources)
 	container.Add(ws)
 	return errors.NewAggregate(registrationErrors)
 }
@@ -196,6 +199,37 @@ func AddApiWebService(container *restful.Container, apiPrefix string, versions [
 	container.Add(ws)
 }
 
+// AddApisWebService adds a service to return the API groups served under apiPrefix,
+// and the versions of each group. groups is called on every request, so that
+// groups registered at runtime are listed.
+func AddApisWebService(container *restful.Container, apiPrefix string, groups func() []api.APIGroup) {
+	ws := new(restful.WebService)
+	ws.Path(apiPrefix)
+	ws.Doc("get available API groups")
+	ws.Route(ws.GET("/").To(APIGroupListHandler(groups)).
+		Doc("get available API groups").
+		Operation("getAPIGroups").
+		Produces(restful.MIME_JSON).
+		Consumes(restful.MIME_JSON))
+	ws.Route(ws.GET("/{group}").To(APIGroupHandler(groups)).
+		Doc("get the versions of an API group").
+		Operation("getAPIGroup").
+		Param(ws.PathParameter("group", "name of the API group").DataType("string")).
+		Produces(restful.MIME_JSON).
+		Consumes(restful.MIME_JSON))
+	container.Add(ws)
+}
+
+// addSupportedResourcesRoute adds a route to ws that lists the resources served
+// in a group version.
+func addSupportedResourcesRoute(ws *restful.WebService, groupVersion string, apiResources []api.APIResource) {
+	ws.Route(ws.GET("/").To(SupportedResourcesHandler(groupVersion, apiResources)).
+		Doc("get available resources").
+		Operation("getAPIResources").
+		Produces(restful.MIME_JSON).
+		Consumes(restful.MIME_JSON))
+}
+
 // handleVersion writes the server's version information.
 func handleVersion(req *restful.Request, resp *restful.Response) {
 	// TODO: use restful's Response methods
@@ -210,6 +244,36 @@ func APIVersionHandler(versions ...string) restful.RouteFunction {
 	}
 }
 
+// APIGroupListHandler returns a handler which will list the provided API groups.
+func APIGroupListHandler(groups func() []api.APIGroup) restful.RouteFunction {
+	return func(req *restful.Request, resp *restful.Response) {
+		writeRawJSON(http.StatusOK, api.APIGroupList{Groups: groups()}, resp.ResponseWriter)
+	}
+}
+
+// APIGroupHandler returns a handler which will describe the API group named in
+// the request, if it is one of the provided groups.
+func APIGroupHandler(groups func() []api.APIGroup) restful.RouteFunction {
+	return func(req *restful.Request, resp *restful.Response) {
+		name := req.PathParameter("group")
+		for _, group := range groups() {
+			if group.Name == name {
+				writeRawJSON(http.StatusOK, group, resp.ResponseWriter)
+				return
+			}
+		}
+		notFound(resp.ResponseWriter, req.Request)
+	}
+}
+
+// SupportedResourcesHandler returns a handler which will list the provided resources
+// as available in groupVersion.
+func SupportedResourcesHandler(groupVersion string, apiResources []api.APIResource) restful.RouteFunction {
+	return func(req *restful.Request, resp *restful.Response) {
+		writeRawJSON(http.StatusOK, api.APIResourceList{GroupVersion: groupVersion, APIResources: apiResources}, resp.ResponseWriter)
+	}
+}
+
 // write renders a returned runtime.Object to the response as a stream or an encoded object. If the object
 // returned by the response implements rest.ResourceStreamer that interface will be used to render the
 // response. The Accept header and current API version will be passed in, and the output will be copied
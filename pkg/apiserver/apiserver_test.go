@@ -738,6 +738,88 @@ func TestVersion(t *testing.T) {
 	}
 }
 
+func TestSupportedResources(t *testing.T) {
+	handler := handle(map[string]rest.Storage{"simple": &SimpleRESTStorage{}})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/api/" + testVersion)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", response.StatusCode)
+	}
+
+	var list api.APIResourceList
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.GroupVersion != testVersion || len(list.APIResources) != 1 {
+		t.Fatalf("unexpected resource list: %#v", list)
+	}
+	resource := list.APIResources[0]
+	if resource.Name != "simple" || resource.Kind != "Simple" || !resource.Namespaced {
+		t.Errorf("unexpected resource: %#v", resource)
+	}
+	if e, a := []string{"create", "delete", "get", "list", "patch", "proxy", "update", "watch"}, resource.Verbs; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+func TestAPIGroups(t *testing.T) {
+	groups := []api.APIGroup{
+		{
+			Name:             "experimental",
+			Versions:         []api.GroupVersion{{GroupVersion: "experimental/v1", Version: "v1"}},
+			PreferredVersion: api.GroupVersion{GroupVersion: "experimental/v1", Version: "v1"},
+		},
+	}
+	container := restful.NewContainer()
+	container.Router(restful.CurlyRouter{})
+	AddApisWebService(container, "/apis", func() []api.APIGroup { return groups })
+	server := httptest.NewServer(container)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/apis")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var list api.APIGroupList
+	err = json.NewDecoder(response.Body).Decode(&list)
+	response.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(groups, list.Groups) {
+		t.Errorf("expected %#v, got %#v", groups, list.Groups)
+	}
+
+	response, err = http.Get(server.URL + "/apis/experimental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var group api.APIGroup
+	err = json.NewDecoder(response.Body).Decode(&group)
+	response.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(groups[0], group) {
+		t.Errorf("expected %#v, got %#v", groups[0], group)
+	}
+
+	response, err = http.Get(server.URL + "/apis/unknown")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected status: %d", response.StatusCode)
+	}
+}
+
 func TestList(t *testing.T) {
 	testCases := []struct {
 		url       string
@@ -378,6 +378,10 @@ func (r *requestAttributeGetter) GetAttribs(req *http.Request) authorizer.Attrib
 	// in empty (does not understand defaulting rules.)
 	attribs.Namespace = apiRequestInfo.Namespace
 
+	attribs.Verb = apiRequestInfo.Verb
+	attribs.Subresource = apiRequestInfo.Subresource
+	attribs.Name = apiRequestInfo.Name
+
 	return &attribs
 }
 
@@ -442,7 +446,7 @@ type APIRequestInfoResolver struct {
 //
 // Fully qualified paths for above:
 // /api/{version}/*
-// /api/{version}/*
+// /apis/{group}/{version}/*
 func (r *APIRequestInfoResolver) GetAPIRequestInfo(req *http.Request) (APIRequestInfo, error) {
 	requestInfo := APIRequestInfo{
 		Raw: splitPath(req.URL.Path),
@@ -454,17 +458,19 @@ func (r *APIRequestInfoResolver) GetAPIRequestInfo(req *http.Request) (APIReques
 	}
 
 	for _, currPrefix := range r.APIPrefixes.List() {
-		// handle input of form /api/{version}/* by adjusting special paths
-		if currentParts[0] == currPrefix {
-			if len(currentParts) > 1 {
-				requestInfo.APIVersion = currentParts[1]
+		// handle input of form /api/{version}/* or /apis/{group}/{version}/* by adjusting special paths
+		prefixParts := splitPath(currPrefix)
+		if hasPathPrefix(currentParts, prefixParts) {
+			if len(currentParts) > len(prefixParts) {
+				requestInfo.APIVersion = currentParts[len(prefixParts)]
 			}
 
-			if len(currentParts) > 2 {
-				currentParts = currentParts[2:]
+			if len(currentParts) > len(prefixParts)+1 {
+				currentParts = currentParts[len(prefixParts)+1:]
 			} else {
 				return requestInfo, fmt.Errorf("Unable to determine kind and namespace from url, %v", req.URL)
 			}
+			break
 		}
 	}
 
@@ -535,3 +541,16 @@ func (r *APIRequestInfoResolver) GetAPIRequestInfo(req *http.Request) (APIReques
 
 	return requestInfo, nil
 }
+
+// hasPathPrefix returns true if the path parts start with all of the prefix parts.
+func hasPathPrefix(parts, prefix []string) bool {
+	if len(prefix) == 0 || len(parts) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if parts[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
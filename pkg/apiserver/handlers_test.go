@@ -244,9 +244,13 @@ func TestGetAPIRequestInfo(t *testing.T) {
 		// subresource identification
 		{"GET", "/namespaces/other/pods/foo/status", "get", "", "other", "pods", "status", "Pod", "foo", []string{"pods", "foo", "status"}},
 		{"PUT", "/namespaces/other/finalize", "update", "", "other", "finalize", "", "", "", []string{"finalize"}},
+
+		// API group paths
+		{"GET", "/apis/experimental/v1/namespaces/other/pods/foo", "get", "v1", "other", "pods", "", "Pod", "foo", []string{"pods", "foo"}},
+		{"GET", "/apis/experimental/v1/watch/pods", "watch", "v1", api.NamespaceAll, "pods", "", "Pod", "", []string{"pods"}},
 	}
 
-	apiRequestInfoResolver := &APIRequestInfoResolver{util.NewStringSet("api"), latest.RESTMapper}
+	apiRequestInfoResolver := &APIRequestInfoResolver{util.NewStringSet("api", "apis/experimental"), latest.RESTMapper}
 
 	for _, successCase := range successCases {
 		req, _ := http.NewRequest(successCase.method, successCase.url, nil)
@@ -41,6 +41,16 @@ type Attributes interface {
 
 	// The kind of object, if a request is for a REST object.
 	GetResource() string
+
+	// The kube verb associated with the request (get, list, create, ...),
+	// or empty if the request does not map to one.
+	GetVerb() string
+
+	// The subresource being requested, if present.
+	GetSubresource() string
+
+	// The name of the object being requested, if present.
+	GetName() string
 }
 
 // Authorizer makes an authorization decision based on information gained by making
@@ -58,10 +68,13 @@ func (f AuthorizerFunc) Authorize(a Attributes) error {
 
 // AttributesRecord implements Attributes interface.
 type AttributesRecord struct {
-	User      user.Info
-	ReadOnly  bool
-	Namespace string
-	Resource  string
+	User        user.Info
+	ReadOnly    bool
+	Namespace   string
+	Resource    string
+	Verb        string
+	Subresource string
+	Name        string
 }
 
 func (a AttributesRecord) GetUserName() string {
@@ -83,3 +96,15 @@ func (a AttributesRecord) GetNamespace() string {
 func (a AttributesRecord) GetResource() string {
 	return a.Resource
 }
+
+func (a AttributesRecord) GetVerb() string {
+	return a.Verb
+}
+
+func (a AttributesRecord) GetSubresource() string {
+	return a.Subresource
+}
+
+func (a AttributesRecord) GetName() string {
+	return a.Name
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package client
 
 import (
+	"fmt"
+
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/watch"
 )
 
 // ControllerHasDesiredReplicas returns a condition that will be true iff the desired replica count
@@ -38,6 +42,104 @@ func ControllerHasDesiredReplicas(c Interface, controller *api.ReplicationContro
 		// or, after this check has passed, a modification causes the rc manager to create more pods.
 		// This will not be an issue once we've implemented graceful delete for rcs, but till then
 		// concurrent stop operations on the same rc might have unintended side effects.
-		return ctrl.Status.ObservedGeneration >= desiredGeneration && ctrl.Status.Replicas == ctrl.Spec.Replicas, nil
+		return controllerHasDesiredReplicas(ctrl, desiredGeneration), nil
+	}
+}
+
+func controllerHasDesiredReplicas(ctrl *api.ReplicationController, desiredGeneration int64) bool {
+	return ctrl.Status.ObservedGeneration >= desiredGeneration && ctrl.Status.Replicas == ctrl.Spec.Replicas
+}
+
+// ErrPodCompleted is returned by PodHasCondition to indicate that the pod has
+// finished running and its conditions will no longer change.
+var ErrPodCompleted = fmt.Errorf("pod ran to completion")
+
+// ControllerHasDesiredReplicasEvent is a watch condition that is true once a
+// controller has observed its latest spec and reports the desired number of
+// replicas, the same check ControllerHasDesiredReplicas polls for.
+func ControllerHasDesiredReplicasEvent(event watch.Event) (bool, error) {
+	switch event.Type {
+	case watch.Error:
+		return false, errors.FromObject(event.Object)
+	case watch.Deleted:
+		return false, deletedError("replicationControllers", event)
+	}
+	switch t := event.Object.(type) {
+	case *api.ReplicationController:
+		return controllerHasDesiredReplicas(t, t.Generation), nil
+	}
+	return false, nil
+}
+
+// PodHasCondition returns a watch condition that is true once a pod reports
+// a condition of conditionType with the given status.
+func PodHasCondition(conditionType api.PodConditionType, status api.ConditionStatus) watch.ConditionFunc {
+	return func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Error:
+			return false, errors.FromObject(event.Object)
+		case watch.Deleted:
+			return false, deletedError("pods", event)
+		}
+		switch t := event.Object.(type) {
+		case *api.Pod:
+			for _, condition := range t.Status.Conditions {
+				if condition.Type == conditionType {
+					if condition.Status == status {
+						return true, nil
+					}
+					break
+				}
+			}
+			switch t.Status.Phase {
+			case api.PodSucceeded, api.PodFailed:
+				return false, ErrPodCompleted
+			}
+		}
+		return false, nil
+	}
+}
+
+// NodeHasCondition returns a watch condition that is true once a node reports
+// a condition of conditionType with the given status.
+func NodeHasCondition(conditionType api.NodeConditionType, status api.ConditionStatus) watch.ConditionFunc {
+	return func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Error:
+			return false, errors.FromObject(event.Object)
+		case watch.Deleted:
+			return false, deletedError("nodes", event)
+		}
+		switch t := event.Object.(type) {
+		case *api.Node:
+			for _, condition := range t.Status.Conditions {
+				if condition.Type == conditionType {
+					return condition.Status == status, nil
+				}
+			}
+		}
+		return false, nil
+	}
+}
+
+// ObjectDeleted is a watch condition that is true once the watched object has
+// been deleted.
+func ObjectDeleted(event watch.Event) (bool, error) {
+	switch event.Type {
+	case watch.Error:
+		return false, errors.FromObject(event.Object)
+	case watch.Deleted:
+		return true, nil
+	}
+	return false, nil
+}
+
+// deletedError reports that the object of a deleted event will never meet the
+// condition being waited for.
+func deletedError(kind string, event watch.Event) error {
+	name := ""
+	if meta, err := api.ObjectMetaFor(event.Object); err == nil {
+		name = meta.Name
 	}
+	return errors.NewNotFound(kind, name)
 }
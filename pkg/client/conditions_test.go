@@ -0,0 +1,102 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+func TestPodHasCondition(t *testing.T) {
+	pod := func(phase api.PodPhase, ready api.ConditionStatus) *api.Pod {
+		return &api.Pod{
+			ObjectMeta: api.ObjectMeta{Name: "foo"},
+			Status: api.PodStatus{
+				Phase:      phase,
+				Conditions: []api.PodCondition{{Type: api.PodReady, Status: ready}},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		event     watch.Event
+		status    api.ConditionStatus
+		done      bool
+		expectErr bool
+	}{
+		{"not ready", watch.Event{Type: watch.Modified, Object: pod(api.PodRunning, api.ConditionFalse)}, api.ConditionTrue, false, false},
+		{"ready", watch.Event{Type: watch.Modified, Object: pod(api.PodRunning, api.ConditionTrue)}, api.ConditionTrue, true, false},
+		{"not ready wanted", watch.Event{Type: watch.Added, Object: pod(api.PodRunning, api.ConditionFalse)}, api.ConditionFalse, true, false},
+		{"completed", watch.Event{Type: watch.Modified, Object: pod(api.PodSucceeded, api.ConditionFalse)}, api.ConditionTrue, false, true},
+		{"deleted", watch.Event{Type: watch.Deleted, Object: pod(api.PodRunning, api.ConditionFalse)}, api.ConditionTrue, false, true},
+	}
+	for _, test := range tests {
+		done, err := PodHasCondition(api.PodReady, test.status)(test.event)
+		if done != test.done || (err != nil) != test.expectErr {
+			t.Errorf("%s: expected done=%t error=%t, got done=%t error=%v", test.name, test.done, test.expectErr, done, err)
+		}
+	}
+}
+
+func TestNodeHasCondition(t *testing.T) {
+	node := &api.Node{
+		Status: api.NodeStatus{
+			Conditions: []api.NodeCondition{{Type: api.NodeReady, Status: api.ConditionTrue}},
+		},
+	}
+	if done, err := NodeHasCondition(api.NodeReady, api.ConditionTrue)(watch.Event{Type: watch.Modified, Object: node}); !done || err != nil {
+		t.Errorf("expected the node to be ready, got %t %v", done, err)
+	}
+	if done, err := NodeHasCondition(api.NodeReady, api.ConditionFalse)(watch.Event{Type: watch.Modified, Object: node}); done || err != nil {
+		t.Errorf("expected the node not to be unready, got %t %v", done, err)
+	}
+}
+
+func TestControllerHasDesiredReplicasEvent(t *testing.T) {
+	rc := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Generation: 2},
+		Spec:       api.ReplicationControllerSpec{Replicas: 3},
+		Status:     api.ReplicationControllerStatus{Replicas: 3, ObservedGeneration: 1},
+	}
+	if done, _ := ControllerHasDesiredReplicasEvent(watch.Event{Type: watch.Modified, Object: rc}); done {
+		t.Errorf("expected a controller that has not observed its spec not to be done")
+	}
+	rc.Status.ObservedGeneration = 2
+	if done, _ := ControllerHasDesiredReplicasEvent(watch.Event{Type: watch.Modified, Object: rc}); !done {
+		t.Errorf("expected the controller to be done")
+	}
+	if _, err := ControllerHasDesiredReplicasEvent(watch.Event{Type: watch.Deleted, Object: rc}); !errors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestObjectDeleted(t *testing.T) {
+	pod := &api.Pod{}
+	if done, _ := ObjectDeleted(watch.Event{Type: watch.Modified, Object: pod}); done {
+		t.Errorf("expected a modified object not to be deleted")
+	}
+	if done, _ := ObjectDeleted(watch.Event{Type: watch.Deleted, Object: pod}); !done {
+		t.Errorf("expected a deleted object to be deleted")
+	}
+	status := &api.Status{Status: api.StatusFailure, Code: 500, Message: "boom"}
+	if _, err := ObjectDeleted(watch.Event{Type: watch.Error, Object: status}); err == nil {
+		t.Errorf("expected an error event to return an error")
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/meta"
+)
+
+// DiscoveryInterface holds the methods that discover the API groups, versions
+// and resources a server supports.
+type DiscoveryInterface interface {
+	ServerGroupsInterface
+	ServerResourcesInterface
+}
+
+// ServerGroupsInterface has methods for obtaining the API groups a server supports.
+type ServerGroupsInterface interface {
+	// ServerGroups returns the API groups the server supports. The legacy API
+	// is returned as the group with an empty name.
+	ServerGroups() (*api.APIGroupList, error)
+}
+
+// ServerResourcesInterface has methods for obtaining the resources a server supports.
+type ServerResourcesInterface interface {
+	// ServerResourcesForGroupVersion returns the resources served for the given
+	// group version, "v1" for the legacy API or "<group>/<version>" otherwise.
+	ServerResourcesForGroupVersion(groupVersion string) (*api.APIResourceList, error)
+	// ServerResources returns the resources of every group version the server
+	// supports, keyed by group version.
+	ServerResources() (map[string]*api.APIResourceList, error)
+}
+
+// DiscoveryClient discovers the API groups, versions and resources a server supports.
+type DiscoveryClient struct {
+	*RESTClient
+	// legacyPrefix and groupPrefix are the paths the legacy API and the API
+	// groups are served at.
+	legacyPrefix string
+	groupPrefix  string
+}
+
+// ServerGroups returns the API groups the server supports, starting with the
+// legacy API.
+func (d *DiscoveryClient) ServerGroups() (*api.APIGroupList, error) {
+	v := &api.APIVersions{}
+	if err := d.getJSON(d.legacyPrefix, v); err != nil {
+		return nil, err
+	}
+	legacy := api.APIGroup{}
+	for _, version := range v.Versions {
+		legacy.Versions = append(legacy.Versions, api.GroupVersion{GroupVersion: version, Version: version})
+	}
+	if len(legacy.Versions) > 0 {
+		legacy.PreferredVersion = legacy.Versions[0]
+	}
+
+	groups := &api.APIGroupList{}
+	err := d.getJSON(d.groupPrefix, groups)
+	// servers that predate API groups don't serve the group prefix
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	groups.Groups = append([]api.APIGroup{legacy}, groups.Groups...)
+	return groups, nil
+}
+
+// ServerResourcesForGroupVersion returns the resources served for the given group version.
+func (d *DiscoveryClient) ServerResourcesForGroupVersion(groupVersion string) (*api.APIResourceList, error) {
+	group, version := meta.SplitGroupVersion(groupVersion)
+	resources := &api.APIResourceList{}
+	if err := d.getJSON(d.GroupPath(group)+"/"+version, resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// ServerResources returns the resources of every group version the server supports.
+func (d *DiscoveryClient) ServerResources() (map[string]*api.APIResourceList, error) {
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]*api.APIResourceList{}
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			resources, err := d.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return nil, err
+			}
+			result[version.GroupVersion] = resources
+		}
+	}
+	return result, nil
+}
+
+// GroupPath returns the path the versions of the given API group were discovered at,
+// the legacy API prefix for the group with an empty name.
+func (d *DiscoveryClient) GroupPath(group string) string {
+	if len(group) == 0 {
+		return d.legacyPrefix
+	}
+	return d.groupPrefix + "/" + group
+}
+
+// getJSON decodes the JSON served at the given server relative path into obj.
+func (d *DiscoveryClient) getJSON(path string, obj interface{}) error {
+	body, err := d.Get().AbsPath(path).Do().Raw()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, obj); err != nil {
+		return fmt.Errorf("got '%s': %v", string(body), err)
+	}
+	return nil
+}
+
+// NewDiscoveryClient creates a new DiscoveryClient for the given config.
+func NewDiscoveryClient(c *Config) (*DiscoveryClient, error) {
+	config := *c
+	if err := SetKubernetesDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &DiscoveryClient{RESTClient: client, legacyPrefix: config.Prefix, groupPrefix: config.GroupPrefix}, nil
+}
+
+// NewDiscoveryClientOrDie creates a new DiscoveryClient for the given config and
+// panics if there is an error in the config.
+func NewDiscoveryClientOrDie(c *Config) *DiscoveryClient {
+	client, err := NewDiscoveryClient(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestServerGroups(t *testing.T) {
+	apiGroups := api.APIGroupList{
+		Groups: []api.APIGroup{
+			{
+				Name:             "experimental",
+				Versions:         []api.GroupVersion{{GroupVersion: "experimental/v1", Version: "v1"}},
+				PreferredVersion: api.GroupVersion{GroupVersion: "experimental/v1", Version: "v1"},
+			},
+		},
+	}
+	testCases := []struct {
+		apis     interface{}
+		expected []api.APIGroup
+	}{
+		{
+			apis: apiGroups,
+			expected: []api.APIGroup{
+				{
+					Versions:         []api.GroupVersion{{GroupVersion: "v1", Version: "v1"}},
+					PreferredVersion: api.GroupVersion{GroupVersion: "v1", Version: "v1"},
+				},
+				apiGroups.Groups[0],
+			},
+		},
+		// servers without API groups
+		{
+			apis: nil,
+			expected: []api.APIGroup{
+				{
+					Versions:         []api.GroupVersion{{GroupVersion: "v1", Version: "v1"}},
+					PreferredVersion: api.GroupVersion{GroupVersion: "v1", Version: "v1"},
+				},
+			},
+		},
+	}
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var obj interface{}
+			switch req.URL.Path {
+			case "/api":
+				obj = api.APIVersions{Versions: []string{"v1"}}
+			case "/apis":
+				obj = testCase.apis
+			}
+			if obj == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			output, err := json.Marshal(obj)
+			if err != nil {
+				t.Errorf("unexpected encoding error: %v", err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write(output)
+		}))
+		client := NewDiscoveryClientOrDie(&Config{Host: server.URL})
+		groups, err := client.ServerGroups()
+		server.Close()
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(testCase.expected, groups.Groups) {
+			t.Errorf("%d: expected %#v, got %#v", i, testCase.expected, groups.Groups)
+		}
+	}
+}
+
+func TestServerResources(t *testing.T) {
+	legacyResources := api.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []api.APIResource{
+			{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+			{Name: "nodes", Namespaced: false, Kind: "Node", Verbs: []string{"get"}},
+		},
+	}
+	expResources := api.APIResourceList{
+		GroupVersion: "experimental/v1",
+		APIResources: []api.APIResource{
+			{Name: "thirdpartyresources", Namespaced: false, Kind: "ThirdPartyResource", Verbs: []string{"create"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var obj interface{}
+		switch req.URL.Path {
+		case "/api":
+			obj = api.APIVersions{Versions: []string{"v1"}}
+		case "/api/v1":
+			obj = legacyResources
+		case "/apis":
+			obj = api.APIGroupList{
+				Groups: []api.APIGroup{
+					{
+						Name:     "experimental",
+						Versions: []api.GroupVersion{{GroupVersion: "experimental/v1", Version: "v1"}},
+					},
+				},
+			}
+		case "/apis/experimental/v1":
+			obj = expResources
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		output, err := json.Marshal(obj)
+		if err != nil {
+			t.Errorf("unexpected encoding error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(output)
+	}))
+	defer server.Close()
+	client := NewDiscoveryClientOrDie(&Config{Host: server.URL})
+	got, err := client.ServerResources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]*api.APIResourceList{
+		"v1":              &legacyResources,
+		"experimental/v1": &expResources,
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %#v, got %#v", expected, got)
+	}
+
+	if _, err := client.ServerResourcesForGroupVersion("unknown/v1"); err == nil {
+		t.Errorf("expected an error for an unknown group version")
+	}
+}
+
+func TestGroupPrefix(t *testing.T) {
+	resources := api.APIResourceList{
+		GroupVersion: "experimental/v1",
+		APIResources: []api.APIResource{
+			{Name: "jobs", Namespaced: true, Kind: "Job", Verbs: []string{"get"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/groups/experimental/v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		output, err := json.Marshal(resources)
+		if err != nil {
+			t.Errorf("unexpected encoding error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(output)
+	}))
+	defer server.Close()
+	client := NewDiscoveryClientOrDie(&Config{Host: server.URL, GroupPrefix: "/groups"})
+	if path := client.GroupPath("experimental"); path != "/groups/experimental" {
+		t.Errorf("expected /groups/experimental, got %s", path)
+	}
+	if path := client.GroupPath(""); path != "/api" {
+		t.Errorf("expected /api for the legacy API, got %s", path)
+	}
+	got, err := client.ServerResourcesForGroupVersion("experimental/v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(&resources, got) {
+		t.Errorf("expected %#v, got %#v", resources, got)
+	}
+}
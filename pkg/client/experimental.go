@@ -32,6 +32,9 @@ import (
 // incompatible ways at any time.
 type ExperimentalInterface interface {
 	VersionInterface
+	ThirdPartyResourcesInterface
+	TokenReviewsInterface
+	SubjectAccessReviewsInterface
 }
 
 // ExperimentalClient is used to interact with experimental Kubernetes features.
@@ -41,6 +44,21 @@ type ExperimentalClient struct {
 	*RESTClient
 }
 
+// ThirdPartyResources returns an interface for ThirdPartyResources in the cluster.
+func (c *ExperimentalClient) ThirdPartyResources() ThirdPartyResourceInterface {
+	return newThirdPartyResources(c)
+}
+
+// TokenReviews returns an interface for reviewing bearer tokens.
+func (c *ExperimentalClient) TokenReviews() TokenReviewInterface {
+	return newTokenReviews(c)
+}
+
+// SubjectAccessReviews returns an interface for reviewing access requests.
+func (c *ExperimentalClient) SubjectAccessReviews() SubjectAccessReviewInterface {
+	return newSubjectAccessReviews(c)
+}
+
 // ServerVersion retrieves and parses the server's version.
 func (c *ExperimentalClient) ServerVersion() (*version.Info, error) {
 	body, err := c.Get().AbsPath("/version").Do().Raw()
@@ -62,12 +80,16 @@ func (c *ExperimentalClient) ServerAPIVersions() (*api.APIVersions, error) {
 	if err != nil {
 		return nil, err
 	}
-	var v api.APIVersions
-	err = json.Unmarshal(body, &v)
+	var group api.APIGroup
+	err = json.Unmarshal(body, &group)
 	if err != nil {
 		return nil, fmt.Errorf("got '%s': %v", string(body), err)
 	}
-	return &v, nil
+	v := &api.APIVersions{}
+	for _, version := range group.Versions {
+		v.Versions = append(v.Versions, version.Version)
+	}
+	return v, nil
 }
 
 // NewExperimental creates a new ExperimentalClient for the given config. This client
@@ -100,7 +122,7 @@ func NewExperimentalOrDie(c *Config) *ExperimentalClient {
 
 func setExperimentalDefaults(config *Config) error {
 	if config.Prefix == "" {
-		config.Prefix = "/experimental"
+		config.Prefix = "/apis/" + explatest.Group
 	}
 	if config.UserAgent == "" {
 		config.UserAgent = DefaultKubernetesUserAgent()
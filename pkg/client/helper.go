@@ -45,6 +45,10 @@ type Config struct {
 	// Prefix is the sub path of the server. If not specified, the client will set
 	// a default value.  Use "/" to indicate the server root should be used
 	Prefix string
+	// GroupPrefix is the sub path of the server that API groups are served under, as
+	// <GroupPrefix>/<group>/<version>. If not specified, the client will set a default
+	// value.
+	GroupPrefix string
 	// Version is the API version to talk to. Must be provided when initializing
 	// a RESTClient directly. When initializing a Client, will be set with the default
 	// code version.
@@ -276,6 +280,9 @@ func SetKubernetesDefaults(config *Config) error {
 	if config.Prefix == "" {
 		config.Prefix = "/api"
 	}
+	if config.GroupPrefix == "" {
+		config.GroupPrefix = "/apis"
+	}
 	if len(config.UserAgent) == 0 {
 		config.UserAgent = DefaultKubernetesUserAgent()
 	}
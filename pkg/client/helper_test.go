@@ -275,11 +275,12 @@ func TestSetKubernetesDefaults(t *testing.T) {
 		{
 			Config{},
 			Config{
-				Prefix:  "/api",
-				Version: latest.Version,
-				Codec:   latest.Codec,
-				QPS:     5,
-				Burst:   10,
+				Prefix:      "/api",
+				GroupPrefix: "/apis",
+				Version:     latest.Version,
+				Codec:       latest.Codec,
+				QPS:         5,
+				Burst:       10,
 			},
 			false,
 		},
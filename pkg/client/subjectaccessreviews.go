@@ -0,0 +1,46 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+// SubjectAccessReviewsInterface has methods to work with SubjectAccessReview resources in a cluster.
+type SubjectAccessReviewsInterface interface {
+	SubjectAccessReviews() SubjectAccessReviewInterface
+}
+
+// SubjectAccessReviewInterface has methods to work with SubjectAccessReview resources.
+type SubjectAccessReviewInterface interface {
+	Create(review *expapi.SubjectAccessReview) (*expapi.SubjectAccessReview, error)
+}
+
+// subjectAccessReviews implements SubjectAccessReviewInterface
+type subjectAccessReviews struct {
+	client *ExperimentalClient
+}
+
+func newSubjectAccessReviews(c *ExperimentalClient) *subjectAccessReviews {
+	return &subjectAccessReviews{c}
+}
+
+func (c *subjectAccessReviews) Create(review *expapi.SubjectAccessReview) (result *expapi.SubjectAccessReview, err error) {
+	result = &expapi.SubjectAccessReview{}
+	err = c.client.Post().Resource("subjectaccessreviews").Body(review).Do().Into(result)
+	return
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testclient
+
+import (
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+// FakeSubjectAccessReviews implements SubjectAccessReviewInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the method you want to test easier.
+type FakeSubjectAccessReviews struct {
+	Fake *FakeExperimental
+}
+
+func (c *FakeSubjectAccessReviews) Create(review *expapi.SubjectAccessReview) (*expapi.SubjectAccessReview, error) {
+	obj, err := c.Fake.Invokes(NewRootCreateAction("subjectaccessreviews", review), review)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*expapi.SubjectAccessReview), err
+}
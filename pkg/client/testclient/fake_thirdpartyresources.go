@@ -0,0 +1,76 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testclient
+
+import (
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// FakeThirdPartyResources implements ThirdPartyResourceInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the method you want to test easier.
+type FakeThirdPartyResources struct {
+	Fake *FakeExperimental
+}
+
+func (c *FakeThirdPartyResources) Get(name string) (*expapi.ThirdPartyResource, error) {
+	obj, err := c.Fake.Invokes(NewRootGetAction("thirdpartyresources", name), &expapi.ThirdPartyResource{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*expapi.ThirdPartyResource), err
+}
+
+func (c *FakeThirdPartyResources) List(label labels.Selector, field fields.Selector) (*expapi.ThirdPartyResourceList, error) {
+	obj, err := c.Fake.Invokes(NewRootListAction("thirdpartyresources", label, field), &expapi.ThirdPartyResourceList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*expapi.ThirdPartyResourceList), err
+}
+
+func (c *FakeThirdPartyResources) Create(rsrc *expapi.ThirdPartyResource) (*expapi.ThirdPartyResource, error) {
+	obj, err := c.Fake.Invokes(NewRootCreateAction("thirdpartyresources", rsrc), rsrc)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*expapi.ThirdPartyResource), err
+}
+
+func (c *FakeThirdPartyResources) Update(rsrc *expapi.ThirdPartyResource) (*expapi.ThirdPartyResource, error) {
+	obj, err := c.Fake.Invokes(NewRootUpdateAction("thirdpartyresources", rsrc), rsrc)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*expapi.ThirdPartyResource), err
+}
+
+func (c *FakeThirdPartyResources) Delete(name string) error {
+	_, err := c.Fake.Invokes(NewRootDeleteAction("thirdpartyresources", name), &expapi.ThirdPartyResource{})
+	return err
+}
+
+func (c *FakeThirdPartyResources) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	c.Fake.Invokes(NewRootWatchAction("thirdpartyresources", label, field, resourceVersion), nil)
+	return c.Fake.Watch, c.Fake.Err()
+}
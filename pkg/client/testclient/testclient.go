@@ -180,3 +180,26 @@ func (c *Fake) ServerAPIVersions() (*api.APIVersions, error) {
 func (c *Fake) ComponentStatuses() client.ComponentStatusInterface {
 	return &FakeComponentStatuses{Fake: c}
 }
+
+// NewSimpleFakeExperimental returns an experimental client that will respond with the provided objects
+func NewSimpleFakeExperimental(objects ...runtime.Object) *FakeExperimental {
+	return &FakeExperimental{NewSimpleFake(objects...)}
+}
+
+// FakeExperimental implements client.ExperimentalInterface. Meant to be embedded into a struct to get a
+// default implementation.
+type FakeExperimental struct {
+	*Fake
+}
+
+func (c *FakeExperimental) ThirdPartyResources() client.ThirdPartyResourceInterface {
+	return &FakeThirdPartyResources{Fake: c}
+}
+
+func (c *FakeExperimental) TokenReviews() client.TokenReviewInterface {
+	return &FakeTokenReviews{Fake: c}
+}
+
+func (c *FakeExperimental) SubjectAccessReviews() client.SubjectAccessReviewInterface {
+	return &FakeSubjectAccessReviews{Fake: c}
+}
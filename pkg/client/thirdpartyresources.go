@@ -0,0 +1,97 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// ThirdPartyResourcesInterface has methods to work with ThirdPartyResource resources in a cluster.
+type ThirdPartyResourcesInterface interface {
+	ThirdPartyResources() ThirdPartyResourceInterface
+}
+
+// ThirdPartyResourceInterface has methods to work with ThirdPartyResource resources.
+type ThirdPartyResourceInterface interface {
+	List(label labels.Selector, field fields.Selector) (*expapi.ThirdPartyResourceList, error)
+	Get(name string) (*expapi.ThirdPartyResource, error)
+	Create(resource *expapi.ThirdPartyResource) (*expapi.ThirdPartyResource, error)
+	Update(resource *expapi.ThirdPartyResource) (*expapi.ThirdPartyResource, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// thirdPartyResources implements ThirdPartyResourceInterface
+type thirdPartyResources struct {
+	client *ExperimentalClient
+}
+
+func newThirdPartyResources(c *ExperimentalClient) *thirdPartyResources {
+	return &thirdPartyResources{c}
+}
+
+func (c *thirdPartyResources) List(label labels.Selector, field fields.Selector) (result *expapi.ThirdPartyResourceList, err error) {
+	result = &expapi.ThirdPartyResourceList{}
+	err = c.client.Get().
+		Resource("thirdpartyresources").
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Do().
+		Into(result)
+	return
+}
+
+func (c *thirdPartyResources) Get(name string) (result *expapi.ThirdPartyResource, err error) {
+	result = &expapi.ThirdPartyResource{}
+	err = c.client.Get().Resource("thirdpartyresources").Name(name).Do().Into(result)
+	return
+}
+
+func (c *thirdPartyResources) Create(resource *expapi.ThirdPartyResource) (result *expapi.ThirdPartyResource, err error) {
+	result = &expapi.ThirdPartyResource{}
+	err = c.client.Post().Resource("thirdpartyresources").Body(resource).Do().Into(result)
+	return
+}
+
+func (c *thirdPartyResources) Update(resource *expapi.ThirdPartyResource) (result *expapi.ThirdPartyResource, err error) {
+	result = &expapi.ThirdPartyResource{}
+	if len(resource.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", resource)
+		return
+	}
+	err = c.client.Put().Resource("thirdpartyresources").Name(resource.Name).Body(resource).Do().Into(result)
+	return
+}
+
+func (c *thirdPartyResources) Delete(name string) error {
+	return c.client.Delete().Resource("thirdpartyresources").Name(name).Do().Error()
+}
+
+func (c *thirdPartyResources) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Resource("thirdpartyresources").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Watch()
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+// TokenReviewsInterface has methods to work with TokenReview resources in a cluster.
+type TokenReviewsInterface interface {
+	TokenReviews() TokenReviewInterface
+}
+
+// TokenReviewInterface has methods to work with TokenReview resources.
+type TokenReviewInterface interface {
+	Create(review *expapi.TokenReview) (*expapi.TokenReview, error)
+}
+
+// tokenReviews implements TokenReviewInterface
+type tokenReviews struct {
+	client *ExperimentalClient
+}
+
+func newTokenReviews(c *ExperimentalClient) *tokenReviews {
+	return &tokenReviews{c}
+}
+
+func (c *tokenReviews) Create(review *expapi.TokenReview) (result *expapi.TokenReview, err error) {
+	result = &expapi.TokenReview{}
+	err = c.client.Post().Resource("tokenreviews").Body(review).Do().Into(result)
+	return
+}
@@ -163,7 +163,9 @@ func (nc *NodeController) deletePods(nodeID string) error {
 		}
 		glog.V(2).Infof("Delete pod %v", pod.Name)
 		nc.recorder.Eventf(&pod, "NodeControllerEviction", "Deleting Pod %s from Node %s", pod.Name, nodeID)
-		if err := nc.kubeClient.Pods(pod.Namespace).Delete(pod.Name, nil); err != nil {
+		// The kubelet of the node is not reporting, so it would never complete a graceful
+		// deletion. Delete the pod immediately so that it can be replaced.
+		if err := nc.kubeClient.Pods(pod.Namespace).Delete(pod.Name, api.NewDeleteOptions(0)); err != nil {
 			glog.Errorf("Error deleting pod %v: %v", pod.Name, err)
 		}
 	}
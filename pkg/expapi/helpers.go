@@ -0,0 +1,82 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// ExtractApiGroupAndKind returns the kind and API group a ThirdPartyResource
+// defines. A resource named "cron-tab.stable.example.com" defines the kind
+// CronTab in the group "stable.example.com".
+func ExtractApiGroupAndKind(rsrc *ThirdPartyResource) (kind string, group string, err error) {
+	parts := strings.SplitN(rsrc.Name, ".", 2)
+	if len(parts) < 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("unexpectedly short resource name: %s, expected at least <kind>.<domain>", rsrc.Name)
+	}
+	for _, word := range strings.Split(parts[0], "-") {
+		if len(word) == 0 {
+			continue
+		}
+		kind += strings.ToUpper(word[:1]) + word[1:]
+	}
+	return kind, parts[1], nil
+}
+
+// LabelSelectorAsSelector converts a LabelSelector into a labels.Selector. A
+// nil or empty LabelSelector selects everything.
+func LabelSelectorAsSelector(ps *LabelSelector) (labels.Selector, error) {
+	if ps == nil {
+		return labels.Everything(), nil
+	}
+	requirements := []labels.Requirement{}
+	for key, value := range ps.MatchLabels {
+		r, err := labels.NewRequirement(key, labels.EqualsOperator, util.NewStringSet(value))
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *r)
+	}
+	for _, expr := range ps.MatchExpressions {
+		var op labels.Operator
+		switch expr.Operator {
+		case LabelSelectorOpIn:
+			op = labels.InOperator
+		case LabelSelectorOpNotIn:
+			op = labels.NotInOperator
+		case LabelSelectorOpExists:
+			if len(expr.Values) > 0 {
+				return nil, fmt.Errorf("values must be empty for the %s operator", expr.Operator)
+			}
+			op = labels.ExistsOperator
+		default:
+			return nil, fmt.Errorf("%q is not a valid label selector operator", expr.Operator)
+		}
+		r, err := labels.NewRequirement(expr.Key, op, util.NewStringSet(expr.Values...))
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *r)
+	}
+	sort.Sort(labels.ByKey(requirements))
+	return labels.LabelSelector(requirements), nil
+}
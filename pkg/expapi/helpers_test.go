@@ -0,0 +1,123 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expapi
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+func TestExtractApiGroupAndKind(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedKind  string
+		expectedGroup string
+		expectErr     bool
+	}{
+		{name: "foo.company.com", expectedKind: "Foo", expectedGroup: "company.com"},
+		{name: "cron-tab.stable.example.com", expectedKind: "CronTab", expectedGroup: "stable.example.com"},
+		{name: "foo", expectErr: true},
+	}
+	for _, test := range tests {
+		kind, group, err := ExtractApiGroupAndKind(&ThirdPartyResource{ObjectMeta: api.ObjectMeta{Name: test.name}})
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("expected error for %s", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if kind != test.expectedKind || group != test.expectedGroup {
+			t.Errorf("expected %s, %s, got %s, %s", test.expectedKind, test.expectedGroup, kind, group)
+		}
+	}
+}
+
+func TestLabelSelectorAsSelector(t *testing.T) {
+	tests := []struct {
+		in        *LabelSelector
+		out       string
+		expectErr bool
+	}{
+		{in: nil, out: ""},
+		{in: &LabelSelector{}, out: ""},
+		{
+			in:  &LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			out: "app=web",
+		},
+		{
+			in: &LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+				MatchExpressions: []LabelSelectorRequirement{
+					{Key: "tier", Operator: LabelSelectorOpIn, Values: []string{"frontend", "canary"}},
+					{Key: "track", Operator: LabelSelectorOpNotIn, Values: []string{"stable"}},
+					{Key: "environment", Operator: LabelSelectorOpExists},
+				},
+			},
+			out: "app=web,environment,tier in (canary,frontend),track notin (stable)",
+		},
+		{
+			in: &LabelSelector{MatchExpressions: []LabelSelectorRequirement{
+				{Key: "tier", Operator: LabelSelectorOpIn},
+			}},
+			expectErr: true,
+		},
+		{
+			in: &LabelSelector{MatchExpressions: []LabelSelectorRequirement{
+				{Key: "tier", Operator: LabelSelectorOpExists, Values: []string{"frontend"}},
+			}},
+			expectErr: true,
+		},
+		{
+			in: &LabelSelector{MatchExpressions: []LabelSelectorRequirement{
+				{Key: "tier", Operator: "Equals", Values: []string{"frontend"}},
+			}},
+			expectErr: true,
+		},
+	}
+	for i, test := range tests {
+		selector, err := LabelSelectorAsSelector(test.in)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if selector.String() != test.out {
+			t.Errorf("%d: expected %q, got %q", i, test.out, selector.String())
+		}
+	}
+
+	selector, err := LabelSelectorAsSelector(&LabelSelector{MatchExpressions: []LabelSelectorRequirement{
+		{Key: "tier", Operator: LabelSelectorOpIn, Values: []string{"frontend", "canary"}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !selector.Matches(labels.Set{"tier": "canary"}) || selector.Matches(labels.Set{"tier": "backend"}) {
+		t.Errorf("unexpected matching of %s", selector)
+	}
+}
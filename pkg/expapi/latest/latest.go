@@ -41,6 +41,9 @@ var (
 
 const importPrefix = "k8s.io/kubernetes/pkg/expapi"
 
+// Group is the name of the API group the experimental types are served in.
+const Group = "experimental"
+
 func init() {
 	Version = registered.RegisteredVersions[0]
 	Codec = runtime.CodecFor(api.Scheme, Version)
@@ -51,9 +54,18 @@ func init() {
 
 	// the list of kinds that are scoped at the root of the api hierarchy
 	// if a kind is not enumerated here, it is assumed to have a namespace scope
-	rootScoped := util.NewStringSet()
+	rootScoped := util.NewStringSet(
+		"ThirdPartyResource",
+		"TokenReview",
+		"SubjectAccessReview",
+	)
 
-	ignoredKinds := util.NewStringSet()
+	// these kinds are never served under their own names; objects of a third
+	// party kind are mapped onto them by the apiserver
+	ignoredKinds := util.NewStringSet(
+		"ThirdPartyResourceData",
+		"ThirdPartyResourceDataList",
+	)
 
 	RESTMapper = api.NewDefaultRESTMapper(Versions, InterfacesFor, importPrefix, ignoredKinds, rootScoped)
 	api.RegisterRESTMapper(RESTMapper)
@@ -16,4 +16,24 @@ limitations under the License.
 
 package expapi
 
-func init() {}
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func init() {
+	api.Scheme.AddKnownTypes("",
+		&ThirdPartyResource{},
+		&ThirdPartyResourceList{},
+		&ThirdPartyResourceData{},
+		&ThirdPartyResourceDataList{},
+		&TokenReview{},
+		&SubjectAccessReview{},
+	)
+}
+
+func (*ThirdPartyResource) IsAnAPIObject()         {}
+func (*ThirdPartyResourceList) IsAnAPIObject()     {}
+func (*ThirdPartyResourceData) IsAnAPIObject()     {}
+func (*ThirdPartyResourceDataList) IsAnAPIObject() {}
+func (*TokenReview) IsAnAPIObject()                {}
+func (*SubjectAccessReview) IsAnAPIObject()        {}
@@ -27,3 +27,190 @@ support is experimental.
 */
 
 package expapi
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// ThirdPartyResource describes a new API type that is served by the apiserver
+// alongside the built-in types. The name of a ThirdPartyResource has the form
+// <kind>.<group>, where <kind> is the lower-case, dash separated name of the new
+// kind (e.g. "cron-tab" for CronTab) and <group> is a DNS domain the resource
+// belongs to (e.g. "stable.example.com"). Objects of the new kind are served at
+// /apis/<group>/<version>.
+type ThirdPartyResource struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	// Description is a human readable description of the resource.
+	Description string `json:"description,omitempty"`
+
+	// Versions are the versions of the resource that are served.
+	Versions []APIVersion `json:"versions,omitempty"`
+}
+
+// ThirdPartyResourceList is a list of ThirdPartyResources.
+type ThirdPartyResourceList struct {
+	api.TypeMeta `json:",inline"`
+	api.ListMeta `json:"metadata,omitempty"`
+
+	Items []ThirdPartyResource `json:"items"`
+}
+
+// APIVersion is a version of a ThirdPartyResource that is served.
+type APIVersion struct {
+	// Name of this version (e.g. "v1").
+	Name string `json:"name,omitempty"`
+}
+
+// ThirdPartyResourceData is the storage representation of an object whose kind
+// is defined by a ThirdPartyResource. Data holds the JSON serialization of the
+// object as it was provided by the user.
+type ThirdPartyResourceData struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	// Data is the raw JSON of the object.
+	Data []byte `json:"data,omitempty"`
+}
+
+// ThirdPartyResourceDataList is a list of ThirdPartyResourceData.
+type ThirdPartyResourceDataList struct {
+	api.TypeMeta `json:",inline"`
+	api.ListMeta `json:"metadata,omitempty"`
+
+	Items []ThirdPartyResourceData `json:"items"`
+}
+
+// LabelSelector is a label query over a set of resources. Unlike the plain
+// map[string]string selectors of the v1 API, it can express set based
+// requirements, such as "tier in (frontend,canary)". The requirements of
+// MatchLabels and MatchExpressions are ANDed, and an empty LabelSelector
+// matches all objects.
+// TODO: no served resource uses LabelSelector yet. It is meant for the
+// selectors of the workload types of the experimental API.
+type LabelSelector struct {
+	// MatchLabels is a map of {key,value} pairs. A single {key,value} in the
+	// map is equivalent to an element of MatchExpressions, whose key field is
+	// "key", the operator is "In", and the values array contains only "value".
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions is a list of label selector requirements.
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement is a selector that contains values, a key and an
+// operator that relates the key and values.
+type LabelSelectorRequirement struct {
+	// Key is the label key that the selector applies to.
+	Key string `json:"key"`
+
+	// Operator represents a key's relationship to a set of values.
+	Operator LabelSelectorOperator `json:"operator"`
+
+	// Values is a set of values. It must be non-empty if the operator is In
+	// or NotIn, and empty if the operator is Exists.
+	Values []string `json:"values,omitempty"`
+}
+
+// LabelSelectorOperator is the set of operators that can be used in a
+// LabelSelectorRequirement.
+type LabelSelectorOperator string
+
+const (
+	LabelSelectorOpIn     LabelSelectorOperator = "In"
+	LabelSelectorOpNotIn  LabelSelectorOperator = "NotIn"
+	LabelSelectorOpExists LabelSelectorOperator = "Exists"
+)
+
+// TokenReview attempts to authenticate a bearer token against the apiserver.
+// It is used by components such as the kubelet to delegate authentication of
+// the requests they serve. TokenReviews are not persisted.
+type TokenReview struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds information about the request being evaluated.
+	Spec TokenReviewSpec `json:"spec"`
+
+	// Status is filled in by the server and indicates whether the token
+	// can be authenticated.
+	Status TokenReviewStatus `json:"status,omitempty"`
+}
+
+// TokenReviewSpec is a description of the token authentication request.
+type TokenReviewSpec struct {
+	// Token is the opaque bearer token.
+	Token string `json:"token,omitempty"`
+}
+
+// TokenReviewStatus is the result of the token authentication request.
+type TokenReviewStatus struct {
+	// Authenticated indicates that the token was associated with a known user.
+	Authenticated bool `json:"authenticated,omitempty"`
+
+	// User is the user associated with the token.
+	User UserInfo `json:"user,omitempty"`
+}
+
+// UserInfo holds the information about the user needed to implement the
+// user.Info interface.
+type UserInfo struct {
+	// Username is the name that uniquely identifies this user among all active users.
+	Username string `json:"username,omitempty"`
+
+	// UID is a unique value that identifies this user across time.
+	UID string `json:"uid,omitempty"`
+
+	// Groups are the names of the groups this user is a part of.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// SubjectAccessReview checks whether a user or group can perform an action.
+// It is used by components such as the kubelet to delegate authorization of
+// the requests they serve. SubjectAccessReviews are not persisted.
+type SubjectAccessReview struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds information about the request being evaluated.
+	Spec SubjectAccessReviewSpec `json:"spec"`
+
+	// Status is filled in by the server and indicates whether the request
+	// is allowed or not.
+	Status SubjectAccessReviewStatus `json:"status,omitempty"`
+}
+
+// SubjectAccessReviewSpec is a description of the access request.
+type SubjectAccessReviewSpec struct {
+	// User is the user you're testing for.
+	User string `json:"user,omitempty"`
+
+	// Groups are the groups you're testing for.
+	Groups []string `json:"groups,omitempty"`
+
+	// Verb is a kube verb, like get, list, watch, create, update, delete or proxy.
+	Verb string `json:"verb,omitempty"`
+
+	// Namespace is the namespace of the action being requested. Empty for
+	// cluster scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Resource is one of the existing resource types.
+	Resource string `json:"resource,omitempty"`
+
+	// Subresource is one of the existing subresource types.
+	Subresource string `json:"subresource,omitempty"`
+
+	// Name is the name of the resource being requested, if any.
+	Name string `json:"name,omitempty"`
+}
+
+// SubjectAccessReviewStatus is the result of the access request.
+type SubjectAccessReviewStatus struct {
+	// Allowed is true if the action would be allowed, false otherwise.
+	Allowed bool `json:"allowed"`
+
+	// Reason is optional. It indicates why a request was allowed or denied.
+	Reason string `json:"reason,omitempty"`
+}
@@ -16,4 +16,15 @@ limitations under the License.
 
 package v1
 
-func addConversionFuncs() {}
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func addConversionFuncs() {
+	// Add field conversion funcs.
+	err := api.Scheme.AddFieldLabelConversionFunc("v1", "ThirdPartyResource", api.NameSelectableFields.FieldLabelConversionFunc("ThirdPartyResource"))
+	if err != nil {
+		// If one of the conversion functions is malformed, detect it immediately.
+		panic(err)
+	}
+}
@@ -27,4 +27,24 @@ func init() {
 	addDeepCopyFuncs()
 	addConversionFuncs()
 	addDefaultingFuncs()
+	addKnownTypes()
 }
+
+// Adds the list of known types to api.Scheme.
+func addKnownTypes() {
+	api.Scheme.AddKnownTypes("v1",
+		&ThirdPartyResource{},
+		&ThirdPartyResourceList{},
+		&ThirdPartyResourceData{},
+		&ThirdPartyResourceDataList{},
+		&TokenReview{},
+		&SubjectAccessReview{},
+	)
+}
+
+func (*ThirdPartyResource) IsAnAPIObject()         {}
+func (*ThirdPartyResourceList) IsAnAPIObject()     {}
+func (*ThirdPartyResourceData) IsAnAPIObject()     {}
+func (*ThirdPartyResourceDataList) IsAnAPIObject() {}
+func (*TokenReview) IsAnAPIObject()                {}
+func (*SubjectAccessReview) IsAnAPIObject()        {}
@@ -15,3 +15,184 @@ limitations under the License.
 */
 
 package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// ThirdPartyResource describes a new API type that is served by the apiserver
+// alongside the built-in types. The name of a ThirdPartyResource has the form
+// <kind>.<group>, where <kind> is the lower-case, dash separated name of the new
+// kind (e.g. "cron-tab" for CronTab) and <group> is a DNS domain the resource
+// belongs to (e.g. "stable.example.com").
+type ThirdPartyResource struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty" description:"standard object metadata"`
+
+	// Description is a human readable description of the resource.
+	Description string `json:"description,omitempty" description:"description of the function of the third party resource"`
+
+	// Versions are the versions of the resource that are served.
+	Versions []APIVersion `json:"versions,omitempty" description:"versions for this third party object"`
+}
+
+// ThirdPartyResourceList is a list of ThirdPartyResources.
+type ThirdPartyResourceList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty" description:"standard list metadata; see http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata"`
+
+	Items []ThirdPartyResource `json:"items" description:"items is a list of schema objects"`
+}
+
+// APIVersion is a version of a ThirdPartyResource that is served.
+type APIVersion struct {
+	// Name of this version (e.g. "v1").
+	Name string `json:"name,omitempty" description:"name of this version (e.g. 'v1')"`
+}
+
+// ThirdPartyResourceData is the storage representation of an object whose kind
+// is defined by a ThirdPartyResource.
+type ThirdPartyResourceData struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty" description:"standard object metadata"`
+
+	// Data is the raw JSON of the object.
+	Data []byte `json:"data,omitempty" description:"the raw JSON data for this data"`
+}
+
+// ThirdPartyResourceDataList is a list of ThirdPartyResourceData.
+type ThirdPartyResourceDataList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty" description:"standard list metadata; see http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata"`
+
+	Items []ThirdPartyResourceData `json:"items" description:"items is a list of third party objects"`
+}
+
+// LabelSelector is a label query over a set of resources. The requirements of
+// matchLabels and matchExpressions are ANDed, and an empty LabelSelector
+// matches all objects.
+type LabelSelector struct {
+	// MatchLabels is a map of {key,value} pairs. A single {key,value} in the
+	// map is equivalent to an element of MatchExpressions, whose key field is
+	// "key", the operator is "In", and the values array contains only "value".
+	MatchLabels map[string]string `json:"matchLabels,omitempty" description:"map of {key,value} pairs; a single {key,value} is equivalent to a requirement whose key is key, operator is In and values contain only value"`
+
+	// MatchExpressions is a list of label selector requirements.
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty" description:"list of label selector requirements; the requirements are ANDed"`
+}
+
+// LabelSelectorRequirement is a selector that contains values, a key and an
+// operator that relates the key and values.
+type LabelSelectorRequirement struct {
+	// Key is the label key that the selector applies to.
+	Key string `json:"key" description:"the label key that the selector applies to"`
+
+	// Operator represents a key's relationship to a set of values.
+	Operator LabelSelectorOperator `json:"operator" description:"the key's relationship to the set of values; one of In, NotIn or Exists"`
+
+	// Values is a set of values. It must be non-empty if the operator is In
+	// or NotIn, and empty if the operator is Exists.
+	Values []string `json:"values,omitempty" description:"set of values; must be non-empty for In and NotIn, and empty for Exists"`
+}
+
+// LabelSelectorOperator is the set of operators that can be used in a
+// LabelSelectorRequirement.
+type LabelSelectorOperator string
+
+const (
+	LabelSelectorOpIn     LabelSelectorOperator = "In"
+	LabelSelectorOpNotIn  LabelSelectorOperator = "NotIn"
+	LabelSelectorOpExists LabelSelectorOperator = "Exists"
+)
+
+// TokenReview attempts to authenticate a bearer token against the apiserver.
+// It is used by components such as the kubelet to delegate authentication of
+// the requests they serve. TokenReviews are not persisted.
+type TokenReview struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty" description:"standard object metadata"`
+
+	// Spec holds information about the request being evaluated.
+	Spec TokenReviewSpec `json:"spec" description:"information about the token being reviewed"`
+
+	// Status is filled in by the server and indicates whether the token
+	// can be authenticated.
+	Status TokenReviewStatus `json:"status,omitempty" description:"result of the review; populated by the server"`
+}
+
+// TokenReviewSpec is a description of the token authentication request.
+type TokenReviewSpec struct {
+	// Token is the opaque bearer token.
+	Token string `json:"token,omitempty" description:"opaque bearer token"`
+}
+
+// TokenReviewStatus is the result of the token authentication request.
+type TokenReviewStatus struct {
+	// Authenticated indicates that the token was associated with a known user.
+	Authenticated bool `json:"authenticated,omitempty" description:"true if the token is associated with a known user"`
+
+	// User is the user associated with the token.
+	User UserInfo `json:"user,omitempty" description:"user associated with the token"`
+}
+
+// UserInfo holds the information about the user needed to implement the
+// user.Info interface.
+type UserInfo struct {
+	// Username is the name that uniquely identifies this user among all active users.
+	Username string `json:"username,omitempty" description:"name that uniquely identifies this user among all active users"`
+
+	// UID is a unique value that identifies this user across time.
+	UID string `json:"uid,omitempty" description:"unique value that identifies this user across time"`
+
+	// Groups are the names of the groups this user is a part of.
+	Groups []string `json:"groups,omitempty" description:"groups this user is a part of"`
+}
+
+// SubjectAccessReview checks whether a user or group can perform an action.
+// It is used by components such as the kubelet to delegate authorization of
+// the requests they serve. SubjectAccessReviews are not persisted.
+type SubjectAccessReview struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty" description:"standard object metadata"`
+
+	// Spec holds information about the request being evaluated.
+	Spec SubjectAccessReviewSpec `json:"spec" description:"description of the access request being evaluated"`
+
+	// Status is filled in by the server and indicates whether the request
+	// is allowed or not.
+	Status SubjectAccessReviewStatus `json:"status,omitempty" description:"result of the review; populated by the server"`
+}
+
+// SubjectAccessReviewSpec is a description of the access request.
+type SubjectAccessReviewSpec struct {
+	// User is the user you're testing for.
+	User string `json:"user,omitempty" description:"user to check access for"`
+
+	// Groups are the groups you're testing for.
+	Groups []string `json:"groups,omitempty" description:"groups to check access for"`
+
+	// Verb is a kube verb, like get, list, watch, create, update, delete or proxy.
+	Verb string `json:"verb,omitempty" description:"kube verb, like get, list, watch, create, update, delete or proxy"`
+
+	// Namespace is the namespace of the action being requested. Empty for
+	// cluster scoped resources.
+	Namespace string `json:"namespace,omitempty" description:"namespace of the requested action; empty for cluster scoped resources"`
+
+	// Resource is one of the existing resource types.
+	Resource string `json:"resource,omitempty" description:"resource type of the requested action"`
+
+	// Subresource is one of the existing subresource types.
+	Subresource string `json:"subresource,omitempty" description:"subresource of the requested action"`
+
+	// Name is the name of the resource being requested, if any.
+	Name string `json:"name,omitempty" description:"name of the requested object, if any"`
+}
+
+// SubjectAccessReviewStatus is the result of the access request.
+type SubjectAccessReviewStatus struct {
+	// Allowed is true if the action would be allowed, false otherwise.
+	Allowed bool `json:"allowed" description:"true if the action would be allowed"`
+
+	// Reason is optional. It indicates why a request was allowed or denied.
+	Reason string `json:"reason,omitempty" description:"reason the request was allowed or denied"`
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	apivalidation "k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/util"
+	errs "k8s.io/kubernetes/pkg/util/fielderrors"
+)
+
+// ValidateThirdPartyResourceName can be used to check whether the given third
+// party resource name is valid. The name must be of the form <kind>.<group>,
+// where <kind> is a DNS label and <group> is a DNS subdomain with at least
+// two segments. Prefix indicates this name will be used as part of generation,
+// in which case trailing dashes are allowed.
+func ValidateThirdPartyResourceName(name string, prefix bool) (bool, string) {
+	if prefix {
+		name = strings.TrimSuffix(name, "-") + "a"
+	}
+	if !util.IsDNS1123Subdomain(name) {
+		return false, fmt.Sprintf("must be a DNS subdomain (at most %d characters, matching regex %s): e.g. \"cron-tab.stable.example.com\"", util.DNS1123SubdomainMaxLength, util.DNS1123SubdomainFmt)
+	}
+	if strings.Count(name, ".") < 2 {
+		return false, "must be of the form <kind>.<domain>.<tld>: e.g. \"cron-tab.stable.example.com\""
+	}
+	return true, ""
+}
+
+// ValidateThirdPartyResource tests if required fields in the ThirdPartyResource are set.
+func ValidateThirdPartyResource(obj *expapi.ThirdPartyResource) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&obj.ObjectMeta, false, ValidateThirdPartyResourceName).Prefix("metadata")...)
+	allErrs = append(allErrs, validateAPIVersions(obj.Versions).Prefix("versions")...)
+	return allErrs
+}
+
+// ValidateThirdPartyResourceUpdate tests to see if the update is legal for an end user to make.
+func ValidateThirdPartyResourceUpdate(update, old *expapi.ThirdPartyResource) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&update.ObjectMeta, &old.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, validateAPIVersions(update.Versions).Prefix("versions")...)
+	return allErrs
+}
+
+func validateAPIVersions(versions []expapi.APIVersion) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if len(versions) == 0 {
+		return append(allErrs, errs.NewFieldRequired(""))
+	}
+	found := util.NewStringSet()
+	for i := range versions {
+		name := versions[i].Name
+		switch {
+		case len(name) == 0:
+			allErrs = append(allErrs, errs.NewFieldRequired(fmt.Sprintf("[%d].name", i)))
+		case !util.IsDNS1123Label(name):
+			allErrs = append(allErrs, errs.NewFieldInvalid(fmt.Sprintf("[%d].name", i), name, fmt.Sprintf("must be a DNS label (at most %d characters, matching regex %s)", util.DNS1123LabelMaxLength, util.DNS1123LabelFmt)))
+		case found.Has(name):
+			allErrs = append(allErrs, errs.NewFieldDuplicate(fmt.Sprintf("[%d].name", i), name))
+		}
+		found.Insert(name)
+	}
+	return allErrs
+}
+
+// ValidateThirdPartyResourceData tests if required fields in the ThirdPartyResourceData are set.
+func ValidateThirdPartyResourceData(obj *expapi.ThirdPartyResourceData) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&obj.ObjectMeta, true, apivalidation.ValidatePodName).Prefix("metadata")...)
+	return allErrs
+}
+
+// ValidateThirdPartyResourceDataUpdate tests to see if the update is legal for an end user to make.
+func ValidateThirdPartyResourceDataUpdate(update, old *expapi.ThirdPartyResourceData) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&update.ObjectMeta, &old.ObjectMeta).Prefix("metadata")...)
+	return allErrs
+}
+
+// ValidateLabelSelector tests that the requirements of a LabelSelector are valid.
+func ValidateLabelSelector(ps *expapi.LabelSelector) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if ps == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, apivalidation.ValidateLabels(ps.MatchLabels, "matchLabels")...)
+	for i := range ps.MatchExpressions {
+		allErrs = append(allErrs, validateLabelSelectorRequirement(&ps.MatchExpressions[i]).Prefix(fmt.Sprintf("matchExpressions[%d]", i))...)
+	}
+	return allErrs
+}
+
+func validateLabelSelectorRequirement(sr *expapi.LabelSelectorRequirement) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	switch sr.Operator {
+	case expapi.LabelSelectorOpIn, expapi.LabelSelectorOpNotIn:
+		if len(sr.Values) == 0 {
+			allErrs = append(allErrs, errs.NewFieldRequired("values"))
+		}
+	case expapi.LabelSelectorOpExists:
+		if len(sr.Values) > 0 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("values", sr.Values, "must be empty when operator is Exists"))
+		}
+	default:
+		allErrs = append(allErrs, errs.NewFieldValueNotSupported("operator", sr.Operator, []string{string(expapi.LabelSelectorOpIn), string(expapi.LabelSelectorOpNotIn), string(expapi.LabelSelectorOpExists)}))
+	}
+	if !util.IsQualifiedName(sr.Key) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("key", sr.Key, "must be a qualified name"))
+	}
+	for i, value := range sr.Values {
+		if !util.IsValidLabelValue(value) {
+			allErrs = append(allErrs, errs.NewFieldInvalid(fmt.Sprintf("values[%d]", i), value, "must be a valid label value"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+func TestValidateThirdPartyResource(t *testing.T) {
+	successCases := []expapi.ThirdPartyResource{
+		{
+			ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "cron-tab.stable.example.com"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}, {Name: "v2beta1"}},
+		},
+	}
+	for i := range successCases {
+		if errs := ValidateThirdPartyResource(&successCases[i]); len(errs) != 0 {
+			t.Errorf("expected success for %s: %v", successCases[i].Name, errs)
+		}
+	}
+
+	errorCases := map[string]expapi.ThirdPartyResource{
+		"missing domain": {
+			ObjectMeta: api.ObjectMeta{Name: "foo"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}},
+		},
+		"missing top level domain": {
+			ObjectMeta: api.ObjectMeta{Name: "foo.company"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}},
+		},
+		"invalid name": {
+			ObjectMeta: api.ObjectMeta{Name: "Foo.company.com"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}},
+		},
+		"missing versions": {
+			ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+		},
+		"empty version": {
+			ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+			Versions:   []expapi.APIVersion{{Name: ""}},
+		},
+		"invalid version": {
+			ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+			Versions:   []expapi.APIVersion{{Name: "V_1"}},
+		},
+		"duplicate version": {
+			ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+			Versions:   []expapi.APIVersion{{Name: "v1"}, {Name: "v1"}},
+		},
+	}
+	for k, v := range errorCases {
+		if errs := ValidateThirdPartyResource(&v); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+}
+
+func TestValidateLabelSelector(t *testing.T) {
+	successCases := []*expapi.LabelSelector{
+		nil,
+		{},
+		{MatchLabels: map[string]string{"app": "web"}},
+		{
+			MatchLabels: map[string]string{"app": "web"},
+			MatchExpressions: []expapi.LabelSelectorRequirement{
+				{Key: "tier", Operator: expapi.LabelSelectorOpIn, Values: []string{"frontend", "canary"}},
+				{Key: "example.com/track", Operator: expapi.LabelSelectorOpNotIn, Values: []string{"stable"}},
+				{Key: "environment", Operator: expapi.LabelSelectorOpExists},
+			},
+		},
+	}
+	for i := range successCases {
+		if errs := ValidateLabelSelector(successCases[i]); len(errs) != 0 {
+			t.Errorf("expected success for %#v: %v", successCases[i], errs)
+		}
+	}
+
+	errorCases := map[string]expapi.LabelSelectorRequirement{
+		"no values for In":     {Key: "tier", Operator: expapi.LabelSelectorOpIn},
+		"no values for NotIn":  {Key: "tier", Operator: expapi.LabelSelectorOpNotIn},
+		"values for Exists":    {Key: "tier", Operator: expapi.LabelSelectorOpExists, Values: []string{"frontend"}},
+		"unsupported operator": {Key: "tier", Operator: "Equals", Values: []string{"frontend"}},
+		"invalid key":          {Key: "-tier", Operator: expapi.LabelSelectorOpExists},
+		"invalid value":        {Key: "tier", Operator: expapi.LabelSelectorOpIn, Values: []string{"front end"}},
+	}
+	for k, v := range errorCases {
+		ps := &expapi.LabelSelector{MatchExpressions: []expapi.LabelSelectorRequirement{v}}
+		if errs := ValidateLabelSelector(ps); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+	if errs := ValidateLabelSelector(&expapi.LabelSelector{MatchLabels: map[string]string{"app": "web server"}}); len(errs) == 0 {
+		t.Errorf("expected failure for an invalid matchLabels value")
+	}
+}
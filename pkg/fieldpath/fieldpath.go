@@ -18,10 +18,24 @@ package fieldpath
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/meta"
 )
 
+// FormatMap formats map[string]string to a string of key="value" lines,
+// sorted by key.
+func FormatMap(m map[string]string) string {
+	lines := make([]string, 0, len(m))
+	for key, value := range m {
+		lines = append(lines, fmt.Sprintf("%s=%q", key, value))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
 // ExtractFieldPathAsString extracts the field from the given object
 // and returns it as a string.  The object must be a pointer to an
 // API type.
@@ -30,6 +44,8 @@ import (
 //
 // 1.  metadata.name - The name of an API object
 // 2.  metadata.namespace - The namespace of an API object
+// 3.  metadata.labels - The labels of an API object, formatted by FormatMap
+// 4.  metadata.annotations - The annotations of an API object, formatted by FormatMap
 func ExtractFieldPathAsString(obj interface{}, fieldPath string) (string, error) {
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
@@ -41,7 +57,47 @@ func ExtractFieldPathAsString(obj interface{}, fieldPath string) (string, error)
 		return accessor.Name(), nil
 	case "metadata.namespace":
 		return accessor.Namespace(), nil
+	case "metadata.labels":
+		return FormatMap(accessor.Labels()), nil
+	case "metadata.annotations":
+		return FormatMap(accessor.Annotations()), nil
 	}
 
 	return "", fmt.Errorf("Unsupported fieldPath: %v", fieldPath)
 }
+
+// ExtractResourceValueByContainerName extracts the value of a resource of
+// the named container of the pod, and returns it as a string. A resource
+// that is not set is returned as "0".
+//
+// The supported resources are limits.cpu, limits.memory, requests.cpu and
+// requests.memory.
+func ExtractResourceValueByContainerName(pod *api.Pod, containerName, resource string) (string, error) {
+	var container *api.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == containerName {
+			container = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return "", fmt.Errorf("container %q not found in pod %q", containerName, pod.Name)
+	}
+
+	var list api.ResourceList
+	switch {
+	case strings.HasPrefix(resource, "limits."):
+		list = container.Resources.Limits
+	case strings.HasPrefix(resource, "requests."):
+		list = container.Resources.Requests
+	default:
+		return "", fmt.Errorf("Unsupported container resource: %v", resource)
+	}
+	switch name := api.ResourceName(resource[strings.Index(resource, ".")+1:]); name {
+	case api.ResourceCPU, api.ResourceMemory:
+		quantity := list[name]
+		return quantity.String(), nil
+	}
+
+	return "", fmt.Errorf("Unsupported container resource: %v", resource)
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
 )
 
 func TestExtractFieldPathAsString(t *testing.T) {
@@ -57,6 +58,26 @@ func TestExtractFieldPathAsString(t *testing.T) {
 			},
 			expectedValue: "object-name",
 		},
+		{
+			name:      "ok - labels",
+			fieldPath: "metadata.labels",
+			obj: &api.Pod{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"key": "value", "builder": "john-doe"},
+				},
+			},
+			expectedValue: "builder=\"john-doe\"\nkey=\"value\"",
+		},
+		{
+			name:      "ok - annotations",
+			fieldPath: "metadata.annotations",
+			obj: &api.Pod{
+				ObjectMeta: api.ObjectMeta{
+					Annotations: map[string]string{"description": "a \"quoted\" value"},
+				},
+			},
+			expectedValue: `description="a \"quoted\" value"`,
+		},
 		{
 			name:      "invalid expression",
 			fieldPath: "metadata.whoops",
@@ -84,3 +105,52 @@ func TestExtractFieldPathAsString(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractResourceValueByContainerName(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{
+					Name: "bar",
+					Resources: api.ResourceRequirements{
+						Limits: api.ResourceList{
+							api.ResourceCPU:    resource.MustParse("500m"),
+							api.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Requests: api.ResourceList{
+							api.ResourceCPU: resource.MustParse("250m"),
+						},
+					},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		containerName           string
+		resource                string
+		expectedValue           string
+		expectedMessageFragment string
+	}{
+		{containerName: "bar", resource: "limits.cpu", expectedValue: "500m"},
+		{containerName: "bar", resource: "limits.memory", expectedValue: "64Mi"},
+		{containerName: "bar", resource: "requests.cpu", expectedValue: "250m"},
+		{containerName: "bar", resource: "requests.memory", expectedValue: "0"},
+		{containerName: "bar", resource: "limits.storage", expectedMessageFragment: "Unsupported container resource"},
+		{containerName: "bar", resource: "cpu", expectedMessageFragment: "Unsupported container resource"},
+		{containerName: "baz", resource: "limits.cpu", expectedMessageFragment: "not found"},
+	}
+
+	for _, tc := range cases {
+		actual, err := ExtractResourceValueByContainerName(pod, tc.containerName, tc.resource)
+		if err != nil {
+			if tc.expectedMessageFragment == "" || !strings.Contains(err.Error(), tc.expectedMessageFragment) {
+				t.Errorf("%s/%s: unexpected error: %v", tc.containerName, tc.resource, err)
+			}
+		} else if tc.expectedMessageFragment != "" {
+			t.Errorf("%s/%s: expected an error containing %q", tc.containerName, tc.resource, tc.expectedMessageFragment)
+		} else if actual != tc.expectedValue {
+			t.Errorf("%s/%s: unexpected result; got %q, expected %q", tc.containerName, tc.resource, actual, tc.expectedValue)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// LastAppliedConfigAnnotation is the annotation of an object which holds the
+// configuration that was last applied to it with kubectl apply.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// GetOriginalConfiguration returns the configuration that was last applied to
+// the object of info, or nil if none was.
+func GetOriginalConfiguration(info *resource.Info) ([]byte, error) {
+	annotations, err := info.Mapping.MetadataAccessor.Annotations(info.Object)
+	if err != nil {
+		return nil, err
+	}
+	original, ok := annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(original), nil
+}
+
+// GetModifiedConfiguration returns the configuration of the object of info as
+// it was given by the user, in the version it was given in. The configuration
+// is annotated with itself, so it is recorded as the configuration last
+// applied to the object when applied. The status and the null fields of the
+// object are left out, since they are not part of the user's intent.
+func GetModifiedConfiguration(info *resource.Info) ([]byte, error) {
+	var data []byte
+	var err error
+	if obj, ok := info.VersionedObject.(runtime.Object); ok {
+		data, err = json.Marshal(obj)
+	} else {
+		data, err = info.Mapping.Codec.Encode(info.Object)
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	delete(config, "status")
+	removeNullFields(config)
+
+	metadata, ok := config["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		config["metadata"] = metadata
+	}
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		annotations = map[string]interface{}{}
+	}
+	delete(annotations, LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		delete(metadata, "annotations")
+	}
+	applied, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	annotations[LastAppliedConfigAnnotation] = string(applied)
+	metadata["annotations"] = annotations
+	return json.Marshal(config)
+}
+
+// removeNullFields removes the fields which are null from a JSON object, and
+// from the objects nested in it.
+func removeNullFields(obj map[string]interface{}) {
+	for k, v := range obj {
+		switch typed := v.(type) {
+		case nil:
+			delete(obj, k)
+		case map[string]interface{}:
+			removeNullFields(typed)
+		case []interface{}:
+			for _, elem := range typed {
+				if elemObj, ok := elem.(map[string]interface{}); ok {
+					removeNullFields(elemObj)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/strategicpatch"
+)
+
+const (
+	apply_long = `Apply a configuration to a resource by filename or stdin.
+
+The resource will be created if it doesn't exist yet. The configuration that is
+applied is recorded in the kubectl.kubernetes.io/last-applied-configuration
+annotation of the resource, so the fields removed from the configuration since
+it was last applied are deleted, while the fields set by others are preserved.
+
+JSON and YAML formats are accepted.`
+	apply_example = `// Apply the configuration in pod.json to a pod.
+$ kubectl apply -f ./pod.json
+
+// Apply the configurations of all the resources in a directory.
+$ kubectl apply -f ./manifests
+
+// Apply the JSON passed into stdin to a pod.
+$ cat pod.json | kubectl apply -f -`
+)
+
+func NewCmdApply(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	var filenames util.StringList
+	cmd := &cobra.Command{
+		Use:     "apply -f FILENAME",
+		Short:   "Apply a configuration to a resource by filename or stdin",
+		Long:    apply_long,
+		Example: apply_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(ValidateArgs(cmd, args))
+			cmdutil.CheckErr(cmdutil.ValidateOutputArgs(cmd))
+			shortOutput := cmdutil.GetFlagString(cmd, "output") == "name"
+			cmdutil.CheckErr(RunApply(f, out, filenames, shortOutput))
+		},
+	}
+
+	usage := "Filename, directory, or URL to file that contains the configuration to apply"
+	kubectl.AddJsonFilenameFlag(cmd, &filenames, usage)
+	cmd.MarkFlagRequired("filename")
+
+	cmdutil.AddOutputFlagsForMutation(cmd)
+	return cmd
+}
+
+func RunApply(f *cmdutil.Factory, out io.Writer, filenames util.StringList, shortOutput bool) error {
+	schema, err := f.Validator()
+	if err != nil {
+		return err
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		Schema(schema).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = r.Visit(func(info *resource.Info) error {
+		// the versioned struct the patch is computed against
+		dataStruct := info.VersionedObject
+		modified, err := kubectl.GetModifiedConfiguration(info)
+		if err != nil {
+			return cmdutil.AddSourceToErr("retrieving modified configuration from", info.Source, err)
+		}
+
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		if err := info.Get(); err != nil {
+			if !errors.IsNotFound(err) {
+				return cmdutil.AddSourceToErr("retrieving current configuration from", info.Source, err)
+			}
+			obj, err := helper.Create(info.Namespace, true, modified)
+			if err != nil {
+				return cmdutil.AddSourceToErr("creating", info.Source, err)
+			}
+			count++
+			info.Refresh(obj, true)
+			cmdutil.PrintSuccess(mapper, shortOutput, out, info.Mapping.Resource, info.Name, "created")
+			return nil
+		}
+
+		if dataStruct == nil {
+			return cmdutil.AddSourceToErr("applying", info.Source, fmt.Errorf("%s cannot be patched, only created", info.Mapping.Kind))
+		}
+		original, err := kubectl.GetOriginalConfiguration(info)
+		if err != nil {
+			return cmdutil.AddSourceToErr("retrieving original configuration from", info.Source, err)
+		}
+		current, err := info.Mapping.Codec.Encode(info.Object)
+		if err != nil {
+			return cmdutil.AddSourceToErr("serializing current configuration from", info.Source, err)
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, dataStruct)
+		if err != nil {
+			return cmdutil.AddSourceToErr("creating patch for", info.Source, err)
+		}
+		obj, err := helper.Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
+		if err != nil {
+			return cmdutil.AddSourceToErr("applying patch to", info.Source, err)
+		}
+		count++
+		info.Refresh(obj, true)
+		cmdutil.PrintSuccess(mapper, shortOutput, out, info.Mapping.Resource, info.Name, "configured")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no objects passed to apply")
+	}
+	return nil
+}
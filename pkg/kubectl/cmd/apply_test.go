@@ -0,0 +1,141 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/kubectl"
+)
+
+const applyFilename = "../../../examples/guestbook/redis-master-controller.yaml"
+
+// readAppliedConfig returns the metadata of the object in a request body and
+// the configuration recorded in its last applied annotation.
+func readAppliedConfig(t *testing.T, req *http.Request) (metadata map[string]interface{}, applied map[string]interface{}) {
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metadata, _ = obj["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	config, ok := annotations[kubectl.LastAppliedConfigAnnotation].(string)
+	if !ok {
+		t.Fatalf("expected the configuration to be recorded in %s", data)
+	}
+	if err := json.Unmarshal([]byte(config), &applied); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return metadata, applied
+}
+
+func TestApplyObjectWithoutAnnotation(t *testing.T) {
+	_, _, rc := testData()
+	rc.Items[0].Name = "redis-master"
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+				return &http.Response{StatusCode: 404, Body: stringBody("")}, nil
+			case p == "/namespaces/test/replicationcontrollers" && m == "POST":
+				_, applied := readAppliedConfig(t, req)
+				if applied["kind"] != "ReplicationController" {
+					t.Errorf("unexpected applied configuration: %#v", applied)
+				}
+				if _, ok := applied["status"]; ok {
+					t.Errorf("unexpected status in the applied configuration: %#v", applied)
+				}
+				return &http.Response{StatusCode: 201, Body: objBody(codec, &rc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdApply(f, buf)
+	cmd.Flags().Set("filename", applyFilename)
+	cmd.Flags().Set("output", "name")
+	cmd.Run(cmd, []string{})
+
+	if buf.String() != "replicationcontroller/redis-master\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestApplyObject(t *testing.T) {
+	_, _, rc := testData()
+	rc.Items[0].Name = "redis-master"
+	rc.Items[0].Labels = map[string]string{"name": "redis-master", "tier": "backend", "owner": "ops"}
+	rc.Items[0].Annotations = map[string]string{
+		kubectl.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"ReplicationController","metadata":{"labels":{"name":"redis-master","tier":"backend"},"name":"redis-master"}}`,
+	}
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &rc.Items[0])}, nil
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "PATCH":
+				metadata, applied := readAppliedConfig(t, req)
+				// the label removed from the configuration is deleted, and the
+				// label set by others is preserved
+				expected := map[string]interface{}{"tier": nil}
+				if !reflect.DeepEqual(metadata["labels"], expected) {
+					t.Errorf("expected the patch of the labels to be %#v, got %#v", expected, metadata["labels"])
+				}
+				if labels := applied["metadata"].(map[string]interface{})["labels"]; !reflect.DeepEqual(labels, map[string]interface{}{"name": "redis-master"}) {
+					t.Errorf("unexpected applied labels: %#v", labels)
+				}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &rc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdApply(f, buf)
+	cmd.Flags().Set("filename", applyFilename)
+	cmd.Flags().Set("output", "name")
+	cmd.Run(cmd, []string{})
+
+	if buf.String() != "replicationcontroller/redis-master\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
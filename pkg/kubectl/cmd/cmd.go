@@ -126,18 +126,28 @@ Find more information at https://github.com/GoogleCloudPlatform/kubernetes.`,
 
 	cmds.AddCommand(NewCmdGet(f, out))
 	cmds.AddCommand(NewCmdDescribe(f, out))
+	cmds.AddCommand(NewCmdExplain(f, out))
 	cmds.AddCommand(NewCmdCreate(f, out))
 	cmds.AddCommand(NewCmdReplace(f, out))
+	cmds.AddCommand(NewCmdApply(f, out))
+	cmds.AddCommand(NewCmdDiff(f, out))
 	cmds.AddCommand(NewCmdPatch(f, out))
+	cmds.AddCommand(NewCmdEdit(f, out))
 	cmds.AddCommand(NewCmdDelete(f, out))
 
 	cmds.AddCommand(NewCmdNamespace(out))
 	cmds.AddCommand(NewCmdLog(f, out))
 	cmds.AddCommand(NewCmdRollingUpdate(f, out))
+	cmds.AddCommand(NewCmdRollout(f, out))
+	cmds.AddCommand(NewCmdWait(f, out))
 	cmds.AddCommand(NewCmdScale(f, out))
+	cmds.AddCommand(NewCmdCordon(f, out))
+	cmds.AddCommand(NewCmdUncordon(f, out))
+	cmds.AddCommand(NewCmdDrain(f, out))
 
 	cmds.AddCommand(NewCmdAttach(f, in, out, err))
 	cmds.AddCommand(NewCmdExec(f, in, out, err))
+	cmds.AddCommand(NewCmdCp(f, out, err))
 	cmds.AddCommand(NewCmdPortForward(f))
 	cmds.AddCommand(NewCmdProxy(f, out))
 
@@ -156,6 +166,17 @@ Find more information at https://github.com/GoogleCloudPlatform/kubernetes.`,
 	return cmds
 }
 
+// UsageErrorExitCode returns the code kubectl should exit with when the
+// command line in args, parsed by the root command, is rejected before a
+// command runs, e.g. because a flag is unknown. It is 1, except for commands
+// that give exit code 1 a meaning of its own.
+func UsageErrorExitCode(root *cobra.Command, args []string) int {
+	if c, _, err := root.Find(args); err == nil && c.Name() == "diff" {
+		return diffExitError
+	}
+	return cmdutil.DefaultErrorExitCode
+}
+
 func runHelp(cmd *cobra.Command, args []string) {
 	cmd.Help()
 }
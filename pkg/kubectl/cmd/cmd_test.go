@@ -27,6 +27,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/emicklei/go-restful/swagger"
+
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/latest"
 	"k8s.io/kubernetes/pkg/api/meta"
@@ -130,6 +132,7 @@ type testFactory struct {
 	Describer    kubectl.Describer
 	Printer      kubectl.ResourcePrinter
 	Validator    validation.Schema
+	Schema       *swagger.ApiDeclaration
 	Namespace    string
 	ClientConfig *client.Config
 	Err          error
@@ -213,6 +216,9 @@ func NewAPIFactory() (*cmdutil.Factory, *testFactory, runtime.Codec) {
 		Validator: func() (validation.Schema, error) {
 			return t.Validator, t.Err
 		},
+		SwaggerSchema: func(groupVersion string) (*swagger.ApiDeclaration, error) {
+			return t.Schema, t.Err
+		},
 		DefaultNamespace: func() (string, bool, error) {
 			return t.Namespace, false, t.Err
 		},
@@ -0,0 +1,427 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/client"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	cp_example = `// Copy /tmp/foo_dir local directory to /tmp/bar_dir in a remote pod in the default namespace
+$ kubectl cp /tmp/foo_dir 123456-7890:/tmp/bar_dir
+
+// Copy /tmp/foo local file to /tmp/bar in a remote pod in a specific container
+$ kubectl cp /tmp/foo 123456-7890:/tmp/bar -c ruby-container
+
+// Copy /tmp/foo local file into the /tmp directory of a remote pod in namespace other
+$ kubectl cp /tmp/foo other/123456-7890:/tmp/
+
+// Copy /tmp/heap.hprof from a remote pod to the current local directory
+$ kubectl cp 123456-7890:/tmp/heap.hprof .`
+)
+
+// NewCmdCp creates a command that copies files and directories to and from containers.
+func NewCmdCp(f *cmdutil.Factory, cmdOut, cmdErr io.Writer) *cobra.Command {
+	options := &CopyOptions{
+		Out: cmdOut,
+		Err: cmdErr,
+
+		Executor: &DefaultRemoteExecutor{},
+	}
+	cmd := &cobra.Command{
+		Use:   "cp [NAMESPACE/]POD:SRC DEST | SRC [NAMESPACE/]POD:DEST [-c CONTAINER]",
+		Short: "Copy files and directories to and from containers.",
+		Long: `Copy files and directories to and from containers.
+
+The copy runs tar in the container through exec, so the container image must include a tar binary.
+If DEST ends with '/', or is an existing local directory, SRC is copied into it under its own name.
+File permissions are preserved.`,
+		Example: cp_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(f, cmd, args))
+			cmdutil.CheckErr(options.Validate())
+			cmdutil.CheckErr(options.Run())
+		},
+	}
+	cmd.Flags().StringVarP(&options.ContainerName, "container", "c", "", "Container name. If omitted, the first container in the pod will be chosen")
+	return cmd
+}
+
+// fileSpec is either a local path or a path in a pod.
+type fileSpec struct {
+	PodNamespace string
+	PodName      string
+	File         string
+}
+
+// parseFileSpec parses [NAMESPACE/]POD:PATH as a path in a pod, and anything
+// else as a local path. Local paths that contain ':' can be given as ./PATH.
+func parseFileSpec(arg, namespace string) (fileSpec, error) {
+	i := strings.Index(arg, ":")
+	if i == -1 || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") {
+		return fileSpec{File: arg}, nil
+	}
+	pod, file := arg[:i], arg[i+1:]
+	if len(file) == 0 {
+		return fileSpec{}, fmt.Errorf("a path is required in %q", arg)
+	}
+	parts := strings.Split(pod, "/")
+	switch {
+	case len(parts) == 1 && len(parts[0]) > 0:
+		return fileSpec{PodNamespace: namespace, PodName: parts[0], File: file}, nil
+	case len(parts) == 2 && len(parts[0]) > 0 && len(parts[1]) > 0:
+		return fileSpec{PodNamespace: parts[0], PodName: parts[1], File: file}, nil
+	}
+	return fileSpec{}, fmt.Errorf("unexpected pod in %q, expected [NAMESPACE/]POD:PATH", arg)
+}
+
+// CopyOptions declare the arguments accepted by the Cp command
+type CopyOptions struct {
+	ContainerName string
+	Src           fileSpec
+	Dest          fileSpec
+
+	Out io.Writer
+	Err io.Writer
+
+	Executor RemoteExecutor
+	Client   *client.Client
+	Config   *client.Config
+}
+
+// Complete verifies command line arguments and loads data from the command environment
+func (o *CopyOptions) Complete(f *cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return cmdutil.UsageError(cmd, "SRC and DEST are required for cp")
+	}
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Src, err = parseFileSpec(args[0], namespace); err != nil {
+		return err
+	}
+	if o.Dest, err = parseFileSpec(args[1], namespace); err != nil {
+		return err
+	}
+
+	if o.Config, err = f.ClientConfig(); err != nil {
+		return err
+	}
+	if o.Client, err = f.Client(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks that the provided copy options are specified.
+func (o *CopyOptions) Validate() error {
+	if len(o.Src.PodName) == 0 && len(o.Dest.PodName) == 0 {
+		return fmt.Errorf("one of SRC or DEST must be a path in a pod, given as [NAMESPACE/]POD:PATH")
+	}
+	if len(o.Src.PodName) > 0 && len(o.Dest.PodName) > 0 {
+		return fmt.Errorf("copying between two pods is not supported")
+	}
+	if len(o.Src.File) == 0 || len(o.Dest.File) == 0 {
+		return fmt.Errorf("SRC and DEST must not be empty")
+	}
+	if o.Out == nil || o.Err == nil {
+		return fmt.Errorf("both output and error output must be provided")
+	}
+	if o.Executor == nil || o.Client == nil || o.Config == nil {
+		return fmt.Errorf("client, client config, and executor must be provided")
+	}
+	return nil
+}
+
+// Run copies SRC to DEST.
+func (o *CopyOptions) Run() error {
+	if len(o.Src.PodName) > 0 {
+		return o.copyFromPod()
+	}
+	return o.copyToPod()
+}
+
+func (o *CopyOptions) execOptions(spec fileSpec, command []string) *ExecOptions {
+	return &ExecOptions{
+		Namespace:     spec.PodNamespace,
+		PodName:       spec.PodName,
+		ContainerName: o.ContainerName,
+		Command:       command,
+		Out:           o.Out,
+		Err:           o.Err,
+
+		Executor: o.Executor,
+		Client:   o.Client,
+		Config:   o.Config,
+	}
+}
+
+// copyToPod streams a tar archive of the local source into tar running in the
+// container.
+func (o *CopyOptions) copyToPod() error {
+	src := filepath.Clean(o.Src.File)
+	if _, err := os.Lstat(src); err != nil {
+		return err
+	}
+	dest := o.Dest.File
+	destDir, name := path.Dir(path.Clean(dest)), path.Base(path.Clean(dest))
+	if strings.HasSuffix(dest, "/") {
+		destDir, name = path.Clean(dest), filepath.Base(src)
+	}
+
+	reader, writer := io.Pipe()
+	tarErr := make(chan error, 1)
+	go func() {
+		err := makeTar(src, name, writer)
+		writer.CloseWithError(err)
+		tarErr <- err
+	}()
+
+	options := o.execOptions(o.Dest, []string{"tar", "xf", "-", "-C", destDir})
+	options.Stdin = true
+	options.In = reader
+	err := options.Run()
+	// Unblock the archive writer if the command ended before reading all of it.
+	reader.Close()
+	if tErr := <-tarErr; tErr != nil && tErr != io.ErrClosedPipe {
+		return tErr
+	}
+	return err
+}
+
+// copyFromPod extracts a tar archive of the source written by tar running in
+// the container.
+func (o *CopyOptions) copyFromPod() error {
+	src := path.Clean(o.Src.File)
+	srcDir, name := path.Dir(src), path.Base(src)
+	if name == "/" || name == "." {
+		return fmt.Errorf("cannot copy %q, give the path of a file or directory in it instead", o.Src.File)
+	}
+	dest := o.Dest.File
+	if info, err := os.Stat(dest); (err == nil && info.IsDir()) || strings.HasSuffix(dest, string(os.PathSeparator)) {
+		dest = filepath.Join(dest, name)
+	}
+
+	reader, writer := io.Pipe()
+	execErr := make(chan error, 1)
+	go func() {
+		options := o.execOptions(o.Src, []string{"tar", "cf", "-", "-C", srcDir, name})
+		options.Out = writer
+		err := options.Run()
+		writer.CloseWithError(err)
+		execErr <- err
+	}()
+
+	err := untar(reader, name, filepath.Clean(dest), o.Err)
+	// Unblock the command if the archive was not read to its end.
+	reader.Close()
+	if eErr := <-execErr; eErr != nil {
+		return eErr
+	}
+	return err
+}
+
+// makeTar writes a tar archive of src to w, naming its root entry name.
+func makeTar(src, name string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// untar extracts the tar archive read from r, whose entries are rooted at
+// name, to dest. Entries that would be written outside of dest, and links
+// that point outside of it, are refused. Symlinks already on disk, including
+// those extracted earlier from the same archive, are resolved before anything
+// is written, so an entry cannot be written outside of dest through a link.
+func untar(r io.Reader, name, dest string, errOut io.Writer) error {
+	realDest, err := evalSymlinks(dest)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := path.Clean(header.Name)
+		if entry != name && !strings.HasPrefix(entry, name+"/") {
+			return fmt.Errorf("unexpected entry %q in archive of %q", header.Name, name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(entry, name)))
+		if !isWithin(dest, target) {
+			return fmt.Errorf("refusing to extract %q outside of %q", header.Name, dest)
+		}
+		// Work on the resolved path from here on, so that the checks below
+		// and the writes see the same file.
+		if target == dest {
+			target = realDest
+		} else {
+			parent, err := evalSymlinks(filepath.Dir(target))
+			if err != nil {
+				return err
+			}
+			if !isWithin(realDest, parent) {
+				return fmt.Errorf("refusing to extract %q outside of %q", header.Name, dest)
+			}
+			target = filepath.Join(parent, filepath.Base(target))
+			// Never write through a symlink that is already there; replace it.
+			if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+		}
+
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode.Perm()); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, mode.Perm()); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, mode.Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := header.Linkname
+			if !filepath.IsAbs(link) {
+				link = filepath.Join(filepath.Dir(target), link)
+			}
+			if !isWithin(realDest, link) {
+				fmt.Fprintf(errOut, "warning: skipping symlink %q to %q, it points outside of %q\n", header.Name, header.Linkname, dest)
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			fmt.Fprintf(errOut, "warning: skipping %q, unsupported file type\n", header.Name)
+		}
+	}
+}
+
+// evalSymlinks is like filepath.EvalSymlinks, but p and any of its parents
+// need not exist yet; the missing part of p is appended to the resolved part
+// as it is. A dangling symlink is an error, since creating a file through it
+// could write anywhere.
+func evalSymlinks(p string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(p)
+	if err == nil || !os.IsNotExist(err) {
+		return resolved, err
+	}
+	if _, err := os.Lstat(p); err == nil {
+		return "", fmt.Errorf("%q is a symlink to a missing file", p)
+	}
+	parent := filepath.Dir(p)
+	if parent == p {
+		return p, nil
+	}
+	resolvedParent, err := evalSymlinks(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(p)), nil
+}
+
+func writeFile(target string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// The mode given to OpenFile is subject to the umask, and an existing file keeps its mode.
+	return os.Chmod(target, perm)
+}
+
+// isWithin returns true if target is dir or is below it.
+func isWithin(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/client"
+)
+
+func TestParseFileSpec(t *testing.T) {
+	tests := []struct {
+		arg       string
+		expected  fileSpec
+		expectErr bool
+	}{
+		{arg: "/tmp/foo", expected: fileSpec{File: "/tmp/foo"}},
+		{arg: "foo", expected: fileSpec{File: "foo"}},
+		{arg: "./foo:bar", expected: fileSpec{File: "./foo:bar"}},
+		{arg: "pod:/tmp/foo", expected: fileSpec{PodNamespace: "test", PodName: "pod", File: "/tmp/foo"}},
+		{arg: "other/pod:/tmp/foo", expected: fileSpec{PodNamespace: "other", PodName: "pod", File: "/tmp/foo"}},
+		{arg: "pod:", expectErr: true},
+		{arg: "a/b/pod:/tmp", expectErr: true},
+		{arg: "/pod:/tmp", expected: fileSpec{File: "/pod:/tmp"}},
+		{arg: "other/:/tmp", expectErr: true},
+	}
+	for _, test := range tests {
+		spec, err := parseFileSpec(test.arg, "test")
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.arg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.arg, err)
+			continue
+		}
+		if spec != test.expected {
+			t.Errorf("%s: expected %#v, got %#v", test.arg, test.expected, spec)
+		}
+	}
+}
+
+// fakeTarExecutor records the command run in the container and its input,
+// and answers with a canned output.
+type fakeTarExecutor struct {
+	command []string
+	stdin   []byte
+	stdout  []byte
+}
+
+func (f *fakeTarExecutor) Execute(req *client.Request, config *client.Config, command []string, stdin io.Reader, stdout, stderr io.Writer, tty bool) error {
+	f.command = command
+	if stdin != nil {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		f.stdin = data
+	}
+	_, err := stdout.Write(f.stdout)
+	return err
+}
+
+func runCopy(t *testing.T, ex RemoteExecutor, args []string) error {
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/v1/namespaces/test/pods/foo" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, execPod())}, nil
+			default:
+				t.Errorf("unexpected request: %s %#v\n%#v", req.Method, req.URL, req)
+				return nil, fmt.Errorf("unexpected request")
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: "v1"}
+	options := &CopyOptions{
+		Out:      ioutil.Discard,
+		Err:      ioutil.Discard,
+		Executor: ex,
+	}
+	if err := options.Complete(f, &cobra.Command{}, args); err != nil {
+		t.Fatal(err)
+	}
+	if err := options.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return options.Run()
+}
+
+func writeTestTree(t *testing.T, dir string) {
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "a"), []byte("file a"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "sub", "b"), []byte("file b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("a", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkTestTree(t *testing.T, dir string) {
+	expected := map[string]struct {
+		mode    os.FileMode
+		content string
+	}{
+		"a":     {0600, "file a"},
+		"sub":   {os.ModeDir | 0750, ""},
+		"sub/b": {0755, "file b"},
+	}
+	for name, e := range expected {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if info.Mode() != e.mode {
+			t.Errorf("%s: expected mode %v, got %v", name, e.mode, info.Mode())
+		}
+		if !info.IsDir() {
+			data, _ := ioutil.ReadFile(file)
+			if string(data) != e.content {
+				t.Errorf("%s: expected %q, got %q", name, e.content, string(data))
+			}
+		}
+	}
+	if link, err := os.Readlink(filepath.Join(dir, "link")); err != nil || link != "a" {
+		t.Errorf("expected link to a, got %q: %v", link, err)
+	}
+}
+
+func TestCopyToPod(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "src")
+	writeTestTree(t, src)
+
+	tests := []struct {
+		dest    string
+		command []string
+		root    string
+	}{
+		{dest: "foo:/tmp/copy", command: []string{"tar", "xf", "-", "-C", "/tmp"}, root: "copy"},
+		{dest: "foo:/tmp/", command: []string{"tar", "xf", "-", "-C", "/tmp"}, root: "src"},
+		{dest: "test/foo:copy", command: []string{"tar", "xf", "-", "-C", "."}, root: "copy"},
+	}
+	for i, test := range tests {
+		ex := &fakeTarExecutor{}
+		if err := runCopy(t, ex, []string{src, test.dest}); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(ex.command, test.command) {
+			t.Errorf("%d: expected command %v, got %v", i, test.command, ex.command)
+		}
+		// Extract what the container would have received to check the archive.
+		out := filepath.Join(tmp, fmt.Sprintf("out%d", i))
+		if err := untar(bytes.NewReader(ex.stdin), test.root, out, ioutil.Discard); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		checkTestTree(t, out)
+	}
+}
+
+func TestCopyFromPod(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	writeTestTree(t, filepath.Join(tmp, "data"))
+	archive := &bytes.Buffer{}
+	if err := makeTar(filepath.Join(tmp, "data"), "data", archive); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(tmp, "existing"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dest     string
+		expected string
+	}{
+		{dest: filepath.Join(tmp, "copy"), expected: filepath.Join(tmp, "copy")},
+		{dest: filepath.Join(tmp, "existing"), expected: filepath.Join(tmp, "existing", "data")},
+		{dest: filepath.Join(tmp, "new") + "/", expected: filepath.Join(tmp, "new", "data")},
+	}
+	for i, test := range tests {
+		ex := &fakeTarExecutor{stdout: archive.Bytes()}
+		if err := runCopy(t, ex, []string{"foo:/var/data", test.dest}); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if expected := []string{"tar", "cf", "-", "-C", "/var", "data"}; !reflect.DeepEqual(ex.command, expected) {
+			t.Errorf("%d: expected command %v, got %v", i, expected, ex.command)
+		}
+		checkTestTree(t, test.expected)
+	}
+}
+
+func TestUntarRefusesTraversal(t *testing.T) {
+	tests := map[string][]tar.Header{
+		"parent directory": {
+			{Name: "data/../../evil", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		"absolute path": {
+			{Name: "/etc/evil", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		"other root": {
+			{Name: "other/evil", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+	}
+	for name, headers := range tests {
+		tmp, err := ioutil.TempDir("", "cp-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmp)
+		archive := &bytes.Buffer{}
+		tw := tar.NewWriter(archive)
+		for i := range headers {
+			if err := tw.WriteHeader(&headers[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tw.Close()
+
+		dest := filepath.Join(tmp, "dest", "data")
+		if err := untar(archive, "data", dest, ioutil.Discard); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if _, err := os.Stat(filepath.Join(tmp, "evil")); err == nil {
+			t.Errorf("%s: file written outside of the destination", name)
+		}
+	}
+}
+
+func TestUntarSkipsEscapingSymlinks(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	archive := &bytes.Buffer{}
+	tw := tar.NewWriter(archive)
+	headers := []tar.Header{
+		{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "data/abs", Typeflag: tar.TypeSymlink, Linkname: "/etc"},
+		{Name: "data/rel", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+		{Name: "data/ok", Typeflag: tar.TypeSymlink, Linkname: "sub/file"},
+	}
+	for i := range headers {
+		if err := tw.WriteHeader(&headers[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+
+	dest := filepath.Join(tmp, "data")
+	errOut := &bytes.Buffer{}
+	if err := untar(archive, "data", dest, errOut); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"abs", "rel"} {
+		if _, err := os.Lstat(filepath.Join(dest, name)); err == nil {
+			t.Errorf("%s: expected the symlink to be skipped", name)
+		}
+	}
+	if link, err := os.Readlink(filepath.Join(dest, "ok")); err != nil || link != "sub/file" {
+		t.Errorf("expected link to sub/file, got %q: %v", link, err)
+	}
+	if errOut.Len() == 0 {
+		t.Errorf("expected warnings for the skipped symlinks")
+	}
+}
+
+func TestUntarRefusesWritingThroughSymlinks(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	archive := &bytes.Buffer{}
+	tw := tar.NewWriter(archive)
+	// n/s1 points at n itself, so n/s1/x is n/x on disk and its link
+	// target ../escaped is outside of the destination.
+	headers := []tar.Header{
+		{Name: "n/s1", Typeflag: tar.TypeSymlink, Linkname: "."},
+		{Name: "n/s1/x", Typeflag: tar.TypeSymlink, Linkname: "../escaped"},
+		{Name: "n/x/pwned", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+	}
+	for i := range headers {
+		if err := tw.WriteHeader(&headers[i]); err != nil {
+			t.Fatal(err)
+		}
+		if headers[i].Size > 0 {
+			tw.Write([]byte("evil"))
+		}
+	}
+	tw.Close()
+
+	escaped := filepath.Join(tmp, "escaped")
+	if err := os.Mkdir(escaped, 0755); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(tmp, "n")
+	if err := untar(archive, "n", dest, ioutil.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(escaped, "pwned")); err == nil {
+		t.Errorf("file written outside of the destination")
+	}
+	if info, err := os.Lstat(filepath.Join(dest, "x")); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be a directory: %v", filepath.Join(dest, "x"), err)
+	}
+}
+
+func TestUntarDoesNotWriteThroughExistingSymlinks(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	outside := filepath.Join(tmp, "outside")
+	if err := os.Mkdir(outside, 0755); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(tmp, "n")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Symlinks left in the destination by an earlier copy.
+	if err := os.Symlink(outside, filepath.Join(dest, "dir")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "file"), filepath.Join(dest, "file")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]bool{
+		// The parent of the entry resolves to outside of the destination.
+		"n/dir/pwned": true,
+		// The link is replaced by the extracted file.
+		"n/file": false,
+	}
+	for entry, expectErr := range tests {
+		archive := &bytes.Buffer{}
+		tw := tar.NewWriter(archive)
+		if err := tw.WriteHeader(&tar.Header{Name: entry, Typeflag: tar.TypeReg, Mode: 0644, Size: 4}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte("evil"))
+		tw.Close()
+		if err := untar(archive, "n", dest, ioutil.Discard); (err != nil) != expectErr {
+			t.Errorf("%s: expected error %v, got %v", entry, expectErr, err)
+		}
+	}
+	if files, _ := ioutil.ReadDir(outside); len(files) != 0 {
+		t.Errorf("files written outside of the destination: %v", files)
+	}
+}
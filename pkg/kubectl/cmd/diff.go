@@ -0,0 +1,143 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+const (
+	diff_long = `Diff a configuration in a file or stdin against the live objects.
+
+The live objects and the configuration are shown as YAML in the unified diff
+format. The status and the metadata populated by the server, such as the
+resourceVersion, uid and creationTimestamp, are left out, and so are the fields
+defaulted by the server that the configuration does not set. Objects that do
+not exist yet are shown as added.
+
+The exit status is 0 when there are no differences, 1 when there are
+differences, and greater than 1 when kubectl diff fails, so it can be used to
+check configurations before they are applied.
+
+JSON and YAML formats are accepted.`
+	diff_example = `// Diff the configuration in pod.json against the live pod.
+$ kubectl diff -f ./pod.json
+
+// Diff the configurations of all the resources in a directory.
+$ kubectl diff -f ./manifests`
+)
+
+// diffContext is the number of unchanged lines shown around every change.
+const diffContext = 3
+
+const (
+	// diffExitDiffers is the exit code of kubectl diff when objects differ.
+	diffExitDiffers = 1
+	// diffExitError is the exit code of kubectl diff when it fails. It must
+	// be distinct from diffExitDiffers, so that scripts can tell the two apart.
+	diffExitError = 2
+)
+
+func NewCmdDiff(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	var filenames util.StringList
+	cmd := &cobra.Command{
+		Use:     "diff -f FILENAME",
+		Short:   "Diff a configuration against the live objects",
+		Long:    diff_long,
+		Example: diff_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErrWithCode(ValidateArgs(cmd, args), diffExitError)
+			differs, err := RunDiff(f, out, filenames)
+			cmdutil.CheckErrWithCode(err, diffExitError)
+			if differs {
+				cmdutil.ExitWithCode(diffExitDiffers)
+			}
+		},
+	}
+
+	usage := "Filename, directory, or URL to file that contains the configuration to diff"
+	kubectl.AddJsonFilenameFlag(cmd, &filenames, usage)
+	cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+// RunDiff prints the differences between the configurations in filenames and
+// the live objects, and returns true if there are any.
+func RunDiff(f *cmdutil.Factory, out io.Writer, filenames util.StringList) (bool, error) {
+	schema, err := f.Validator()
+	if err != nil {
+		return false, err
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return false, err
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		Schema(schema).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return false, err
+	}
+
+	count := 0
+	differs := false
+	err = r.Visit(func(info *resource.Info) error {
+		count++
+		live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return cmdutil.AddSourceToErr("retrieving current configuration from", info.Source, err)
+			}
+			live = nil
+		}
+		current, configured, err := kubectl.GetDiffConfigurations(info, live)
+		if err != nil {
+			return cmdutil.AddSourceToErr("comparing configuration from", info.Source, err)
+		}
+		name := path.Join(info.Namespace, info.Mapping.Resource, info.Name)
+		if diff := util.UnifiedDiff("live/"+name, "configured/"+name, string(current), string(configured), diffContext); len(diff) > 0 {
+			differs = true
+			fmt.Fprint(out, diff)
+		}
+		return nil
+	})
+	if err != nil {
+		return differs, err
+	}
+	if count == 0 {
+		return false, fmt.Errorf("no objects passed to diff")
+	}
+	return differs, nil
+}
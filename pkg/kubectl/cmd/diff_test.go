@@ -0,0 +1,364 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// liveController returns the controller of applyFilename as the server would
+// return it.
+func liveController(t *testing.T, codec runtime.Codec) *api.ReplicationController {
+	data, err := ioutil.ReadFile(applyFilename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := codec.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rc := obj.(*api.ReplicationController)
+	rc.Namespace = "test"
+	rc.ResourceVersion = "10"
+	rc.UID = "4c4a6e5d-4f5a-11e5-9ef1-42010af00002"
+	rc.CreationTimestamp = util.Now()
+	rc.Status.Replicas = 1
+	rc.Spec.Template.Spec.ServiceAccountName = "default"
+	return rc
+}
+
+func TestDiff(t *testing.T) {
+	_, _, codec := NewAPIFactory()
+	unchanged := liveController(t, codec)
+	scaled := liveController(t, codec)
+	scaled.Spec.Replicas = 3
+	labeled := liveController(t, codec)
+	labeled.Labels["owner"] = "ops"
+	labeled.Labels["tier"] = "backend"
+	labeled.Annotations = map[string]string{
+		kubectl.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"ReplicationController","metadata":{"labels":{"name":"redis-master","owner":"ops"},"name":"redis-master"}}`,
+	}
+
+	tests := []struct {
+		name     string
+		live     *api.ReplicationController
+		differs  bool
+		expected []string
+	}{
+		{
+			name: "unchanged",
+			live: unchanged,
+		},
+		{
+			name:    "changed field",
+			live:    scaled,
+			differs: true,
+			expected: []string{
+				"--- live/test/replicationcontrollers/redis-master\n+++ configured/test/replicationcontrollers/redis-master\n",
+				"-  replicas: 3\n+  replicas: 1\n",
+			},
+		},
+		{
+			// the label removed from the applied configuration is shown, the
+			// label set by others is not
+			name:     "removed field",
+			live:     labeled,
+			differs:  true,
+			expected: []string{"-    owner: ops\n"},
+		},
+		{
+			name:    "missing object",
+			differs: true,
+			expected: []string{
+				"@@ -0,0 +1,",
+				"+kind: ReplicationController\n",
+			},
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+					if test.live == nil {
+						return &http.Response{StatusCode: 404, Body: stringBody("")}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, test.live)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", test.name, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		differs, err := RunDiff(f, buf, util.StringList{applyFilename})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if differs != test.differs {
+			t.Errorf("%s: expected differs to be %t, got output:\n%s", test.name, test.differs, buf.String())
+		}
+		if !test.differs && buf.Len() != 0 {
+			t.Errorf("%s: unexpected output:\n%s", test.name, buf.String())
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("%s: expected output to contain %q, got:\n%s", test.name, expected, buf.String())
+			}
+		}
+		if strings.Contains(buf.String(), "tier") {
+			t.Errorf("%s: unexpected defaulted field in the output:\n%s", test.name, buf.String())
+		}
+	}
+}
+
+const volumeControllerConfig = `apiVersion: v1
+kind: ReplicationController
+metadata:
+  name: redis-master
+spec:
+  replicas: 1
+  selector:
+    name: redis-master
+  template:
+    metadata:
+      labels:
+        name: redis-master
+    spec:
+      containers:
+      - name: master
+        image: redis
+        volumeMounts:
+        - name: data
+          mountPath: /data
+      volumes:
+      - name: data
+        emptyDir: {}
+`
+
+// TestDiffAdmissionAddedVolume checks that the service account token volume
+// and volume mount added to pods by admission control are not shown as
+// differences, while changes to the items of the same lists are.
+func TestDiffAdmissionAddedVolume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubectl-diff")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "rc.yaml")
+	if err := ioutil.WriteFile(filename, []byte(volumeControllerConfig), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, _, codec := NewAPIFactory()
+	admitted := func(image string) *api.ReplicationController {
+		data, err := yaml.YAMLToJSON([]byte(volumeControllerConfig))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		obj, err := codec.Decode(data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rc := obj.(*api.ReplicationController)
+		rc.Namespace = "test"
+		rc.ResourceVersion = "10"
+		spec := &rc.Spec.Template.Spec
+		spec.ServiceAccountName = "default"
+		spec.Volumes = append(spec.Volumes, api.Volume{
+			Name:         "default-token-abcde",
+			VolumeSource: api.VolumeSource{Secret: &api.SecretVolumeSource{SecretName: "default-token-abcde"}},
+		})
+		spec.Containers[0].VolumeMounts = append(spec.Containers[0].VolumeMounts, api.VolumeMount{
+			Name:      "default-token-abcde",
+			ReadOnly:  true,
+			MountPath: "/var/run/secrets/kubernetes.io/serviceaccount",
+		})
+		spec.Containers[0].Image = image
+		return rc
+	}
+
+	tests := []struct {
+		name     string
+		live     *api.ReplicationController
+		differs  bool
+		expected []string
+	}{
+		{
+			name: "unchanged",
+			live: admitted("redis"),
+		},
+		{
+			name:     "changed container",
+			live:     admitted("redis:2.8"),
+			differs:  true,
+			expected: []string{"-      - image: redis:2.8\n+      - image: redis\n"},
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, test.live)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", test.name, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		differs, err := RunDiff(f, buf, util.StringList{filename})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if differs != test.differs {
+			t.Errorf("%s: expected differs to be %t, got output:\n%s", test.name, test.differs, buf.String())
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("%s: expected output to contain %q, got:\n%s", test.name, expected, buf.String())
+			}
+		}
+		if strings.Contains(buf.String(), "default-token") {
+			t.Errorf("%s: unexpected admission added volume in the output:\n%s", test.name, buf.String())
+		}
+	}
+}
+
+// exitCode is panicked with by the fatal error handler in TestDiffExitCodes.
+type exitCode int
+
+func TestDiffExitCodes(t *testing.T) {
+	_, _, codec := NewAPIFactory()
+	unchanged := liveController(t, codec)
+	scaled := liveController(t, codec)
+	scaled.Spec.Replicas = 3
+
+	tests := []struct {
+		name     string
+		live     *api.ReplicationController
+		status   int
+		args     []string
+		expected int
+	}{
+		{
+			name:     "unchanged",
+			live:     unchanged,
+			status:   200,
+			expected: 0,
+		},
+		{
+			name:     "differs",
+			live:     scaled,
+			status:   200,
+			expected: diffExitDiffers,
+		},
+		{
+			name:     "server error",
+			status:   500,
+			expected: diffExitError,
+		},
+		{
+			name:     "unexpected args",
+			live:     unchanged,
+			status:   200,
+			args:     []string{"foo"},
+			expected: diffExitError,
+		},
+	}
+
+	cmdutil.BehaviorOnFatal(func(msg string, code int) {
+		panic(exitCode(code))
+	})
+	defer cmdutil.DefaultBehaviorOnFatal()
+
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				if test.live == nil {
+					return &http.Response{StatusCode: test.status, Body: stringBody("")}, nil
+				}
+				return &http.Response{StatusCode: test.status, Body: objBody(codec, test.live)}, nil
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+		cmd := NewCmdDiff(f, buf)
+		cmd.Flags().Set("filename", applyFilename)
+
+		code := func() (code exitCode) {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(exitCode)
+				}
+			}()
+			cmd.Run(cmd, test.args)
+			return 0
+		}()
+		if int(code) != test.expected {
+			t.Errorf("%s: expected exit code %d, got %d", test.name, test.expected, code)
+		}
+	}
+}
+
+func TestUsageErrorExitCode(t *testing.T) {
+	root := NewKubectlCommand(cmdutil.NewFactory(nil), nil, ioutil.Discard, ioutil.Discard)
+	tests := map[string]struct {
+		args     []string
+		expected int
+	}{
+		"diff":  {[]string{"diff", "--unknown"}, diffExitError},
+		"get":   {[]string{"get", "--unknown"}, cmdutil.DefaultErrorExitCode},
+		"bogus": {[]string{"bogus"}, cmdutil.DefaultErrorExitCode},
+	}
+	for name, test := range tests {
+		if code := UsageErrorExitCode(root, test.args); code != test.expected {
+			t.Errorf("%s: expected exit code %d, got %d", name, test.expected, code)
+		}
+	}
+}
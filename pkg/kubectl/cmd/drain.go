@@ -0,0 +1,312 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/fields"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+const (
+	cordon_long = `Mark node as unschedulable.
+
+The scheduler will not place new pods on the node, pods already running on it
+are left alone.`
+	cordon_example = `// Mark node "foo" as unschedulable.
+$ kubectl cordon foo`
+
+	uncordon_long    = `Mark node as schedulable.`
+	uncordon_example = `// Mark node "foo" as schedulable.
+$ kubectl uncordon foo`
+
+	drain_long = `Drain node in preparation for maintenance.
+
+The given node will be marked unschedulable to prevent new pods from arriving.
+Then drain deletes every pod on the node that is managed by a replication
+controller, honoring the pod's termination grace period unless --grace-period
+is given, and waits until those pods are gone or --timeout expires.
+
+Drain refuses to continue if the node runs pods that are not managed by a
+replication controller, mirror pods of static pods, or pods with emptyDir
+volumes. With --force, unmanaged pods and pods with emptyDir volumes are
+deleted as well, losing their data, while mirror pods are left in place since
+the kubelet would recreate them anyway.
+
+When you are ready to put the node back into service, use kubectl uncordon.`
+	drain_example = `// Drain node "foo", even if there are pods not managed by a replication controller on it.
+$ kubectl drain foo --force
+
+// As above, but give the pods one minute to terminate and wait at most five minutes.
+$ kubectl drain foo --force --grace-period=60 --timeout=5m`
+)
+
+// mirrorPodAnnotation marks the API copy of a static pod created by the
+// kubelet, it mirrors kubelet.ConfigMirrorAnnotationKey.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// drainPollInterval is how often drain checks whether deleted pods are gone.
+var drainPollInterval = time.Second
+
+// DrainOptions declare the arguments accepted by the cordon, uncordon and
+// drain commands
+type DrainOptions struct {
+	NodeName           string
+	Force              bool
+	GracePeriodSeconds int
+	Timeout            time.Duration
+
+	client *client.Client
+	mapper meta.RESTMapper
+	out    io.Writer
+}
+
+// NewCmdCordon returns a command that marks a node unschedulable.
+func NewCmdCordon(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{out: out}
+	cmd := &cobra.Command{
+		Use:     "cordon NODE",
+		Short:   "Mark node as unschedulable",
+		Long:    cordon_long,
+		Example: cordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(f, cmd, args))
+			cmdutil.CheckErr(options.RunCordonOrUncordon(true))
+		},
+	}
+	return cmd
+}
+
+// NewCmdUncordon returns a command that marks a node schedulable again.
+func NewCmdUncordon(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{out: out}
+	cmd := &cobra.Command{
+		Use:     "uncordon NODE",
+		Short:   "Mark node as schedulable",
+		Long:    uncordon_long,
+		Example: uncordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(f, cmd, args))
+			cmdutil.CheckErr(options.RunCordonOrUncordon(false))
+		},
+	}
+	return cmd
+}
+
+// NewCmdDrain returns a command that cordons a node and deletes the pods on it.
+func NewCmdDrain(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{out: out}
+	cmd := &cobra.Command{
+		Use:     "drain NODE [--force] [--grace-period=<seconds>] [--timeout=<duration>]",
+		Short:   "Drain node in preparation for maintenance",
+		Long:    drain_long,
+		Example: drain_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(f, cmd, args))
+			cmdutil.CheckErr(options.RunDrain())
+		},
+	}
+	cmd.Flags().BoolVar(&options.Force, "force", false, "Continue even if there are pods not managed by a replication controller, mirror pods or pods with emptyDir volumes on the node.")
+	cmd.Flags().IntVar(&options.GracePeriodSeconds, "grace-period", -1, "Period of time in seconds given to each pod to terminate gracefully. If negative, the default value specified in the pod will be used.")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", 0, "The length of time to wait for the deleted pods to disappear, zero means wait forever.")
+	return cmd
+}
+
+// Complete verifies command line arguments and loads data from the command environment
+func (o *DrainOptions) Complete(f *cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageError(cmd, "USAGE: %s NODE", cmd.Name())
+	}
+	o.NodeName = args[0]
+
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	o.client = client
+	o.mapper, _ = f.Object()
+	return nil
+}
+
+// RunCordonOrUncordon marks the node unschedulable when desired is true and
+// schedulable otherwise.
+func (o *DrainOptions) RunCordonOrUncordon(desired bool) error {
+	verb := "cordoned"
+	if !desired {
+		verb = "uncordoned"
+	}
+
+	node, err := o.client.Nodes().Get(o.NodeName)
+	if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable == desired {
+		cmdutil.PrintSuccess(o.mapper, false, o.out, "nodes", o.NodeName, "already "+verb)
+		return nil
+	}
+	node.Spec.Unschedulable = desired
+	if _, err := o.client.Nodes().Update(node); err != nil {
+		return err
+	}
+	cmdutil.PrintSuccess(o.mapper, false, o.out, "nodes", o.NodeName, verb)
+	return nil
+}
+
+// RunDrain cordons the node, deletes the pods on it and waits for them to go away.
+func (o *DrainOptions) RunDrain() error {
+	if err := o.RunCordonOrUncordon(true); err != nil {
+		return err
+	}
+	pods, err := o.getPodsForDeletion()
+	if err != nil {
+		return err
+	}
+	return o.deletePods(pods)
+}
+
+// getPodsForDeletion returns the pods on the node that drain should delete,
+// or an error naming the pods that prevent it from doing so without --force.
+func (o *DrainOptions) getPodsForDeletion() ([]api.Pod, error) {
+	podList, err := o.client.Pods(api.NamespaceAll).List(labels.Everything(), fields.OneTermEqualSelector(client.PodHost, o.NodeName))
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []api.Pod{}
+	unmanaged, mirror, localData := []string{}, []string{}, []string{}
+	for _, pod := range podList.Items {
+		name := pod.Namespace + "/" + pod.Name
+		if _, found := pod.Annotations[mirrorPodAnnotation]; found {
+			mirror = append(mirror, name)
+			continue
+		}
+		managed, err := o.isManaged(&pod)
+		if err != nil {
+			return nil, err
+		}
+		if !managed {
+			unmanaged = append(unmanaged, name)
+		}
+		if hasLocalData(&pod) {
+			localData = append(localData, name)
+		}
+		pods = append(pods, pod)
+	}
+
+	if o.Force {
+		return pods, nil
+	}
+	problems := []string{}
+	if len(unmanaged) > 0 {
+		problems = append(problems, fmt.Sprintf("pods not managed by a replication controller: %s", strings.Join(unmanaged, ", ")))
+	}
+	if len(mirror) > 0 {
+		problems = append(problems, fmt.Sprintf("mirror pods: %s", strings.Join(mirror, ", ")))
+	}
+	if len(localData) > 0 {
+		problems = append(problems, fmt.Sprintf("pods with emptyDir volumes: %s", strings.Join(localData, ", ")))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("refusing to drain node %q, use --force to override; %s", o.NodeName, strings.Join(problems, "; "))
+	}
+	return pods, nil
+}
+
+// isManaged returns true if the pod was created by a controller that still
+// exists and will replace it elsewhere.
+func (o *DrainOptions) isManaged(pod *api.Pod) (bool, error) {
+	createdBy, found := pod.Annotations[controller.CreatedByAnnotation]
+	if !found {
+		return false, nil
+	}
+	ref := &api.SerializedReference{}
+	if err := latest.Codec.DecodeInto([]byte(createdBy), ref); err != nil {
+		return false, err
+	}
+	switch ref.Reference.Kind {
+	case "ReplicationController":
+		_, err := o.client.ReplicationControllers(ref.Reference.Namespace).Get(ref.Reference.Name)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return false, nil
+}
+
+// hasLocalData returns true if the pod keeps data on the node in an emptyDir volume.
+func hasLocalData(pod *api.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// deletePods deletes the given pods and waits until they are gone or the
+// timeout expires.
+func (o *DrainOptions) deletePods(pods []api.Pod) error {
+	var options *api.DeleteOptions
+	if o.GracePeriodSeconds >= 0 {
+		options = api.NewDeleteOptions(int64(o.GracePeriodSeconds))
+	}
+	for _, pod := range pods {
+		if err := o.client.Pods(pod.Namespace).Delete(pod.Name, options); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		cmdutil.PrintSuccess(o.mapper, false, o.out, "pods", pod.Name, "deleted")
+	}
+
+	err := wait.Poll(drainPollInterval, o.Timeout, func() (bool, error) {
+		pending := []api.Pod{}
+		for _, pod := range pods {
+			current, err := o.client.Pods(pod.Namespace).Get(pod.Name)
+			if errors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			pending = append(pending, pod)
+		}
+		pods = pending
+		return len(pods) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		names := []string{}
+		for _, pod := range pods {
+			names = append(names, pod.Namespace+"/"+pod.Name)
+		}
+		return fmt.Errorf("timed out waiting for pods to be deleted from node %q: %s", o.NodeName, strings.Join(names, ", "))
+	}
+	return err
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+func drainNode(unschedulable bool) *api.Node {
+	return &api.Node{
+		ObjectMeta: api.ObjectMeta{Name: "node1", ResourceVersion: "10"},
+		Spec:       api.NodeSpec{Unschedulable: unschedulable},
+	}
+}
+
+func drainPod(name string, annotations map[string]string, volumes ...api.Volume) api.Pod {
+	return api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name), Annotations: annotations},
+		Spec: api.PodSpec{
+			NodeName:      "node1",
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+			Volumes:       volumes,
+		},
+	}
+}
+
+func createdByRC(t *testing.T, name string) map[string]string {
+	data, err := latest.Codec.Encode(&api.SerializedReference{
+		Reference: api.ObjectReference{Kind: "ReplicationController", Namespace: "default", Name: name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return map[string]string{controller.CreatedByAnnotation: string(data)}
+}
+
+func TestCordon(t *testing.T) {
+	tests := []struct {
+		name          string
+		node          *api.Node
+		cordon        bool
+		expectUpdate  bool
+		expectedOut   string
+		expectedState bool
+	}{
+		{
+			name:          "cordon",
+			node:          drainNode(false),
+			cordon:        true,
+			expectUpdate:  true,
+			expectedOut:   "node \"node1\" cordoned\n",
+			expectedState: true,
+		},
+		{
+			name:        "cordon already cordoned",
+			node:        drainNode(true),
+			cordon:      true,
+			expectedOut: "node \"node1\" already cordoned\n",
+		},
+		{
+			name:          "uncordon",
+			node:          drainNode(true),
+			cordon:        false,
+			expectUpdate:  true,
+			expectedOut:   "node \"node1\" uncordoned\n",
+			expectedState: false,
+		},
+	}
+	nodePath := "/api/" + testapi.Version() + "/nodes/node1"
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		var updated *api.Node
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == nodePath && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, test.node)}, nil
+				case p == nodePath && m == "PUT":
+					data, _ := ioutil.ReadAll(req.Body)
+					obj, err := codec.Decode(data)
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", test.name, err)
+					}
+					updated = obj.(*api.Node)
+					return &http.Response{StatusCode: 200, Body: objBody(codec, updated)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %s %#v", test.name, req.Method, req.URL)
+					return nil, nil
+				}
+			}),
+		}
+		tf.ClientConfig = &client.Config{Version: testapi.Version()}
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdCordon(f, buf)
+		if !test.cordon {
+			cmd = NewCmdUncordon(f, buf)
+		}
+		cmd.Run(cmd, []string{"node1"})
+
+		if test.expectUpdate {
+			if updated == nil {
+				t.Errorf("%s: expected the node to be updated", test.name)
+			} else if updated.Spec.Unschedulable != test.expectedState {
+				t.Errorf("%s: expected unschedulable to be %v", test.name, test.expectedState)
+			}
+		} else if updated != nil {
+			t.Errorf("%s: unexpected update: %#v", test.name, updated)
+		}
+		if buf.String() != test.expectedOut {
+			t.Errorf("%s: expected output %q, got %q", test.name, test.expectedOut, buf.String())
+		}
+	}
+}
+
+func TestDrain(t *testing.T) {
+	drainPollInterval = time.Millisecond
+	defer func() { drainPollInterval = time.Second }()
+
+	rc := &api.ReplicationController{ObjectMeta: api.ObjectMeta{Name: "rc", Namespace: "default"}}
+	emptyDir := api.Volume{Name: "scratch", VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}}
+
+	tests := []struct {
+		name           string
+		pods           []api.Pod
+		force          bool
+		expectErr      string
+		expectDeleted  []string
+		expectUnsched  bool
+		deleteNotFound bool
+	}{
+		{
+			name:          "managed pods",
+			pods:          []api.Pod{drainPod("bar", createdByRC(t, "rc"))},
+			expectDeleted: []string{"bar"},
+		},
+		{
+			name:      "unmanaged pod",
+			pods:      []api.Pod{drainPod("bar", createdByRC(t, "rc")), drainPod("baz", nil)},
+			expectErr: "pods not managed by a replication controller: default/baz",
+		},
+		{
+			name:      "orphaned pod",
+			pods:      []api.Pod{drainPod("bar", createdByRC(t, "missing"))},
+			expectErr: "pods not managed by a replication controller: default/bar",
+		},
+		{
+			name:          "unmanaged pod forced",
+			pods:          []api.Pod{drainPod("bar", createdByRC(t, "rc")), drainPod("baz", nil)},
+			force:         true,
+			expectDeleted: []string{"bar", "baz"},
+		},
+		{
+			name:      "mirror pod",
+			pods:      []api.Pod{drainPod("static", map[string]string{mirrorPodAnnotation: "hash"})},
+			expectErr: "mirror pods: default/static",
+		},
+		{
+			name:          "mirror pod forced",
+			pods:          []api.Pod{drainPod("static", map[string]string{mirrorPodAnnotation: "hash"}), drainPod("bar", createdByRC(t, "rc"))},
+			force:         true,
+			expectDeleted: []string{"bar"},
+		},
+		{
+			name:      "emptyDir pod",
+			pods:      []api.Pod{drainPod("bar", createdByRC(t, "rc"), emptyDir)},
+			expectErr: "pods with emptyDir volumes: default/bar",
+		},
+		{
+			name:          "emptyDir pod forced",
+			pods:          []api.Pod{drainPod("bar", createdByRC(t, "rc"), emptyDir)},
+			force:         true,
+			expectDeleted: []string{"bar"},
+		},
+	}
+
+	version := testapi.Version()
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		deleted := []string{}
+		var updatedNode *api.Node
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/api/"+version+"/nodes/node1" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, drainNode(false))}, nil
+				case p == "/api/"+version+"/nodes/node1" && m == "PUT":
+					data, _ := ioutil.ReadAll(req.Body)
+					obj, err := codec.Decode(data)
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", test.name, err)
+					}
+					updatedNode = obj.(*api.Node)
+					return &http.Response{StatusCode: 200, Body: objBody(codec, updatedNode)}, nil
+				case p == "/api/"+version+"/pods" && m == "GET":
+					if selector := req.URL.Query().Get("fieldSelector"); selector != "spec.nodeName=node1" {
+						t.Errorf("%s: unexpected field selector %q", test.name, selector)
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: test.pods})}, nil
+				case p == "/api/"+version+"/namespaces/default/replicationcontrollers/rc" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+				case p == "/api/"+version+"/namespaces/default/replicationcontrollers/missing" && m == "GET":
+					return &http.Response{StatusCode: 404, Body: stringBody("")}, nil
+				case strings.HasPrefix(p, "/api/"+version+"/namespaces/default/pods/") && m == "DELETE":
+					deleted = append(deleted, strings.TrimPrefix(p, "/api/"+version+"/namespaces/default/pods/"))
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &api.Status{Status: api.StatusSuccess})}, nil
+				case strings.HasPrefix(p, "/api/"+version+"/namespaces/default/pods/") && m == "GET":
+					return &http.Response{StatusCode: 404, Body: stringBody("")}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %s %#v", test.name, req.Method, req.URL)
+					return nil, nil
+				}
+			}),
+		}
+		tf.ClientConfig = &client.Config{Version: version}
+		buf := bytes.NewBuffer([]byte{})
+
+		options := &DrainOptions{Force: test.force, GracePeriodSeconds: -1, out: buf}
+		if err := options.Complete(f, &cobra.Command{}, []string{"node1"}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		err := options.RunDrain()
+
+		if updatedNode == nil || !updatedNode.Spec.Unschedulable {
+			t.Errorf("%s: expected the node to be cordoned", test.name)
+		}
+		if len(test.expectErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.expectErr, err)
+			}
+			if len(deleted) > 0 {
+				t.Errorf("%s: unexpected deletions: %v", test.name, deleted)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expectDeleted, deleted) {
+			t.Errorf("%s: expected %v to be deleted, got %v", test.name, test.expectDeleted, deleted)
+		}
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	drainPollInterval = time.Millisecond
+	defer func() { drainPollInterval = time.Second }()
+
+	version := testapi.Version()
+	pod := drainPod("bar", createdByRC(t, "rc"))
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/"+version+"/nodes/node1":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, drainNode(true))}, nil
+			case p == "/api/"+version+"/pods" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: []api.Pod{pod}})}, nil
+			case p == "/api/"+version+"/namespaces/default/replicationcontrollers/rc":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &api.ReplicationController{ObjectMeta: api.ObjectMeta{Name: "rc"}})}, nil
+			case p == "/api/"+version+"/namespaces/default/pods/bar" && m == "DELETE":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &api.Status{Status: api.StatusSuccess})}, nil
+			case p == "/api/"+version+"/namespaces/default/pods/bar" && m == "GET":
+				// the pod is still terminating
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &pod)}, nil
+			default:
+				t.Fatalf("unexpected request: %s %#v", req.Method, req.URL)
+				return nil, nil
+			}
+		}),
+	}
+	tf.ClientConfig = &client.Config{Version: version}
+	buf := bytes.NewBuffer([]byte{})
+
+	options := &DrainOptions{GracePeriodSeconds: 30, Timeout: 20 * time.Millisecond, out: buf}
+	if err := options.Complete(f, &cobra.Command{}, []string{"node1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := options.RunDrain()
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for pods to be deleted from node \"node1\": default/bar") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := "node \"node1\" already cordoned\npod \"bar\" deleted\n"
+	if buf.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, buf.String())
+	}
+}
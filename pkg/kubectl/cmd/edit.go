@@ -0,0 +1,342 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/util/editor"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/strategicpatch"
+	utilyaml "k8s.io/kubernetes/pkg/util/yaml"
+)
+
+const (
+	edit_long = `Edit a resource from the default editor.
+
+The edit command allows you to directly edit any API resource you can retrieve via the
+command line tools. It will open the editor defined by your KUBE_EDITOR, or EDITOR
+environment variables, or fall back to 'vi'. You can edit multiple objects, although
+changes are applied one at a time. The command accepts filenames as well as command
+line arguments, although the files you point to must be previously saved versions of
+resources.
+
+The files to edit will be output in the default API version. The default format is
+YAML - if you would like to edit in JSON pass -o json.
+
+The changes are applied to the resources as strategic merge patches, so they don't
+overwrite the changes others made to the resources in the meantime. In the event an
+error occurs while updating, the editor is reopened with the errors as comments at
+the top of the file. If the edit is then abandoned, a temporary file containing your
+unapplied changes is kept.`
+
+	edit_example = `// Edit the service named 'docker-registry':
+$ kubectl edit svc/docker-registry
+
+// Edit the service 'docker-registry' in JSON:
+$ kubectl edit svc/docker-registry -o json
+
+// Edit all the replication controllers labeled app=web at once:
+$ kubectl edit rc -l app=web
+
+// Use an alternative editor
+$ KUBE_EDITOR="nano" kubectl edit svc/docker-registry`
+
+	edit_header = `# Please edit the object below. Lines beginning with a '#' will be ignored,
+# and an empty file will abort the edit. If an error occurs while saving this file will be
+# reopened with the relevant failures.
+#
+`
+)
+
+func NewCmdEdit(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	var filenames util.StringList
+	cmd := &cobra.Command{
+		Use:     "edit (RESOURCE/NAME | -f FILENAME)",
+		Short:   "Edit a resource on the server",
+		Long:    edit_long,
+		Example: edit_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunEdit(f, out, cmd, args, filenames)
+			cmdutil.CheckErr(err)
+		},
+	}
+	usage := "Filename, directory, or URL to file to use to edit the resource"
+	kubectl.AddJsonFilenameFlag(cmd, &filenames, usage)
+	cmd.Flags().StringP("output", "o", "yaml", "Output format. One of: yaml|json.")
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	return cmd
+}
+
+func RunEdit(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string, filenames util.StringList) error {
+	var ext string
+	switch format := cmdutil.GetFlagString(cmd, "output"); format {
+	case "yaml":
+		ext = ".yaml"
+	case "json":
+		ext = ".json"
+	default:
+		return cmdutil.UsageError(cmd, "The flag 'output' must be one of yaml|json")
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	schema, err := f.Validator()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	rmap := &resource.Mapper{
+		ObjectTyper:  typer,
+		RESTMapper:   mapper,
+		ClientMapper: f.ClientMapperForCommand(),
+	}
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		SelectorParam(cmdutil.GetFlagString(cmd, "selector")).
+		ResourceTypeOrNameArgs(true, args...).
+		Latest().
+		Flatten().
+		Do()
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("no objects passed to edit")
+	}
+
+	originals := map[string][]byte{}
+	items := []json.RawMessage{}
+	for _, info := range infos {
+		data, err := info.Mapping.Codec.Encode(info.Object)
+		if err != nil {
+			return err
+		}
+		originals[editKey(info)] = data
+		items = append(items, json.RawMessage(data))
+	}
+	original, err := editContents(items, ext)
+	if err != nil {
+		return err
+	}
+
+	edit := editor.NewDefaultEditor()
+	contents := original
+	// the errors of the last attempt to apply the edits, which are shown in the
+	// header of the file when it is reopened
+	var editErrs []string
+	for {
+		buf := &bytes.Buffer{}
+		writeEditHeader(buf, editErrs)
+		buf.Write(contents)
+
+		edited, file, err := edit.LaunchTempFile("kubectl-edit-", ext, buf)
+		if len(file) > 0 {
+			os.Remove(file)
+		}
+		if err != nil {
+			if len(editErrs) > 0 {
+				preserveEdits(contents, ext, out)
+			}
+			return err
+		}
+		edited = stripComments(edited)
+
+		switch {
+		case len(bytes.TrimSpace(edited)) == 0:
+			fmt.Fprintln(out, "Edit cancelled, saved file was empty.")
+			if len(editErrs) > 0 {
+				return preserveEdits(contents, ext, out)
+			}
+			return nil
+		case bytes.Equal(edited, stripComments(contents)):
+			if len(editErrs) == 0 {
+				fmt.Fprintln(out, "Edit cancelled, no changes made.")
+				return nil
+			}
+			fmt.Fprintln(out, "Edit cancelled, no valid changes were saved.")
+			return preserveEdits(contents, ext, out)
+		}
+
+		contents = edited
+		editErrs = applyEdits(edited, originals, rmap, schema, out)
+		if len(editErrs) == 0 {
+			return nil
+		}
+	}
+}
+
+// editKey identifies an edited object.
+func editKey(info *resource.Info) string {
+	return fmt.Sprintf("%s/%s/%s", info.Mapping.Resource, info.Namespace, info.Name)
+}
+
+// editContents returns the objects to edit in the given format, as a List when
+// there are several.
+func editContents(items []json.RawMessage, ext string) ([]byte, error) {
+	data := []byte(items[0])
+	if len(items) > 1 {
+		list := map[string]interface{}{
+			"kind":       "List",
+			"apiVersion": latest.Version,
+			"items":      items,
+		}
+		var err error
+		if data, err = json.Marshal(list); err != nil {
+			return nil, err
+		}
+	}
+	if ext == ".json" {
+		buf := &bytes.Buffer{}
+		if err := json.Indent(buf, data, "", "    "); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\n")
+		return buf.Bytes(), nil
+	}
+	return yaml.JSONToYAML(data)
+}
+
+// applyEdits patches the objects whose edited configuration differs from their
+// original one, and returns the errors to show to the user. The original
+// configurations of the objects which are patched are updated.
+func applyEdits(edited []byte, originals map[string][]byte, rmap *resource.Mapper, schema validation.Schema, out io.Writer) []string {
+	data, err := utilyaml.ToJSON(edited)
+	if err != nil {
+		return []string{fmt.Sprintf("The edited file had a syntax error: %v", err)}
+	}
+	items := []json.RawMessage{data}
+	list := struct {
+		Kind  string            `json:"kind"`
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return []string{fmt.Sprintf("The edited file had a syntax error: %v", err)}
+	}
+	if list.Kind == "List" {
+		items = list.Items
+	}
+
+	infos := []*resource.Info{}
+	for _, item := range items {
+		if err := resource.ValidateSchema(item, schema); err != nil {
+			return []string{fmt.Sprintf("The edited file failed validation: %v", err)}
+		}
+		info, err := rmap.InfoForData(item, "edited-file")
+		if err != nil {
+			return []string{fmt.Sprintf("The edited file had an error: %v", err)}
+		}
+		if _, ok := originals[editKey(info)]; !ok {
+			return []string{fmt.Sprintf("The edited file contains %s %q, which is not one of the objects being edited: the kind, name and namespace of objects cannot be changed", info.Mapping.Kind, info.Name)}
+		}
+		infos = append(infos, info)
+	}
+
+	editErrs := []string{}
+	for i, info := range infos {
+		key := editKey(info)
+		if info.VersionedObject == nil {
+			editErrs = append(editErrs, fmt.Sprintf("%s %q cannot be patched", info.Mapping.Kind, info.Name))
+			continue
+		}
+		patch, err := strategicpatch.CreateTwoWayMergePatch(originals[key], items[i], info.VersionedObject)
+		if err != nil {
+			editErrs = append(editErrs, fmt.Sprintf("%s %q could not be patched: %v", info.Mapping.Kind, info.Name, err))
+			continue
+		}
+		if string(patch) == "{}" {
+			continue
+		}
+		obj, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
+		if err != nil {
+			editErrs = append(editErrs, fmt.Sprintf("%s %q could not be patched: %v", info.Mapping.Kind, info.Name, err))
+			continue
+		}
+		originals[key] = items[i]
+		info.Refresh(obj, true)
+		cmdutil.PrintSuccess(rmap.RESTMapper, false, out, info.Mapping.Resource, info.Name, "edited")
+	}
+	return editErrs
+}
+
+// writeEditHeader writes the comment header of a file being edited, with the
+// errors of the last attempt to apply the edits.
+func writeEditHeader(w io.Writer, editErrs []string) {
+	fmt.Fprint(w, edit_header)
+	for _, editErr := range editErrs {
+		for _, line := range strings.Split(editErr, "\n") {
+			fmt.Fprintf(w, "# %s\n", line)
+		}
+		fmt.Fprintln(w, "#")
+	}
+}
+
+// stripComments removes the lines beginning with a '#' from the contents of an
+// edited file.
+func stripComments(data []byte) []byte {
+	buf := &bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// preserveEdits writes the edits of an abandoned edit, which could not be
+// applied, to a file the user can recover them from.
+func preserveEdits(contents []byte, ext string, out io.Writer) error {
+	f, err := ioutil.TempFile("", "kubectl-edit-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	path := f.Name() + ext
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	if _, err := f.Write(contents); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "A copy of your changes has been stored to %q\n", path)
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/client"
+)
+
+// setTestEditor sets KUBE_EDITOR to a script running the given shell commands
+// on the file to edit, which is $1, and returns a func restoring it.
+func setTestEditor(t *testing.T, commands string) func() {
+	dir, err := ioutil.TempDir("", "kubectl-edit-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	script := filepath.Join(dir, "editor")
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\n"+commands+"\n"), 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	original := os.Getenv("KUBE_EDITOR")
+	os.Setenv("KUBE_EDITOR", script)
+	return func() {
+		os.Setenv("KUBE_EDITOR", original)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestEditObject(t *testing.T) {
+	defer setTestEditor(t, `sed -i 's/sessionAffinity: None/sessionAffinity: ClientIP/' "$1"`)()
+	_, svc, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			case p == "/namespaces/test/services/baz" && m == "PATCH":
+				patch, _ := ioutil.ReadAll(req.Body)
+				if string(patch) != `{"spec":{"sessionAffinity":"ClientIP"}}` {
+					t.Errorf("unexpected patch: %s", patch)
+				}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "service \"baz\" edited\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestEditMultipleObjects(t *testing.T) {
+	defer setTestEditor(t, `grep -q "kind: List" "$1" && sed -i 's/sessionAffinity: None/sessionAffinity: ClientIP/' "$1"`)()
+	pods, svc, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			case p == "/namespaces/test/pods/foo" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &pods.Items[0])}, nil
+			case p == "/namespaces/test/services/baz" && m == "PATCH":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			default:
+				// the unchanged pod is not patched
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz", "pods/foo"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "service \"baz\" edited\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestEditObjectNoChanges(t *testing.T) {
+	defer setTestEditor(t, `true`)()
+	_, svc, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "Edit cancelled, no changes made.\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestEditObjectErrorPreservesChanges(t *testing.T) {
+	// the edit fails, and the reopened file is saved without changes
+	defer setTestEditor(t, `grep -q "could not be patched" "$1" || sed -i 's/sessionAffinity: None/sessionAffinity: ClientIP/' "$1"`)()
+	_, svc, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			case p == "/namespaces/test/services/baz" && m == "PATCH":
+				return &http.Response{StatusCode: 409, Body: stringBody("")}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	match := regexp.MustCompile(`A copy of your changes has been stored to "(.*)"`).FindStringSubmatch(buf.String())
+	if !strings.HasPrefix(buf.String(), "Edit cancelled, no valid changes were saved.\n") || match == nil {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	defer os.Remove(match[1])
+	preserved, err := ioutil.ReadFile(match[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(preserved), "sessionAffinity: ClientIP") {
+		t.Errorf("expected the changes to be preserved, got %s", preserved)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	in := "# header\n#\nkind: Service\n  # indented\nmetadata:\n  name: foo\n"
+	if out := string(stripComments([]byte(in))); out != "kind: Service\nmetadata:\n  name: foo\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	explain_long = `Documentation of resources.
+
+Fetches the schema the server publishes for the resource and prints the type and
+description of the requested field, followed by the fields it contains. Nested
+fields are addressed with a dotted path, e.g. pods.spec.containers.
+
+Possible resource types include (case insensitive): pods (po), services (svc),
+replicationcontrollers (rc), nodes (no), events (ev), componentstatuses (cs),
+limitranges (limits), persistentvolumes (pv), persistentvolumeclaims (pvc),
+resourcequotas (quota), namespaces (ns), endpoints (ep) or secrets.`
+	explain_example = `// Get the documentation of the resource and its fields
+$ kubectl explain pods
+
+// Get the documentation of a specific field of a resource
+$ kubectl explain pods.spec.containers.livenessProbe
+
+// Print every field below a resource
+$ kubectl explain services.spec --recursive`
+)
+
+// NewCmdExplain returns a cobra command for showing the documentation of a
+// resource and its fields.
+func NewCmdExplain(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "explain RESOURCE[.FIELD...]",
+		Short:   "Documentation of resources",
+		Long:    explain_long,
+		Example: explain_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunExplain(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Bool("recursive", false, "If true, print the names and types of every field below the requested one instead of just its direct fields.")
+	return cmd
+}
+
+// RunExplain prints the documentation of the resource or field named in args.
+func RunExplain(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageError(cmd, "We require a resource, optionally followed by a dotted field path, e.g. pods.spec.containers")
+	}
+	recursive := cmdutil.GetFlagBool(cmd, "recursive")
+
+	inResource, fieldsPath, err := kubectl.SplitAndParseResourceRequest(args[0])
+	if err != nil {
+		return err
+	}
+
+	mapper, _ := f.Object()
+	version, kind, err := mapper.VersionAndKindForResource(inResource)
+	if err != nil {
+		return err
+	}
+	mapping, err := mapper.RESTMapping(kind, version)
+	if err != nil {
+		return err
+	}
+
+	schema, err := f.SwaggerSchema(mapping.GroupVersion())
+	if err != nil {
+		return err
+	}
+	return kubectl.PrintModelDescription(mapping, fieldsPath, out, schema, recursive)
+}
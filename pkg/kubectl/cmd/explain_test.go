@@ -0,0 +1,62 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful/swagger"
+
+	"k8s.io/kubernetes/pkg/api/testapi"
+)
+
+func TestExplain(t *testing.T) {
+	data, err := ioutil.ReadFile("../../../api/swagger-spec/" + testapi.Version() + ".json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := &swagger.ApiDeclaration{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, tf, _ := NewAPIFactory()
+	tf.Schema = schema
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdExplain(f, buf)
+	if err := RunExplain(f, buf, cmd, []string{"pods.spec.containers.livenessProbe"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{"KIND:     Pod", "RESOURCE: livenessProbe <Object>", "   httpGet\t<Object>"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q:\n%s", expected, out)
+		}
+	}
+
+	if err := RunExplain(f, buf, cmd, []string{"pods.spec.foo"}); err == nil {
+		t.Errorf("expected an error for a missing field")
+	}
+	if err := RunExplain(f, buf, cmd, []string{}); err == nil {
+		t.Errorf("expected a usage error")
+	}
+}
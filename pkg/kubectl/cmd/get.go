@@ -23,6 +23,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubectl"
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/watch"
 
@@ -38,7 +39,9 @@ limitranges (limits), persistentvolumes (pv), persistentvolumeclaims (pvc),
 resourcequotas (quota), namespaces (ns), endpoints (ep) or secrets.
 
 By specifying the output as 'template' and providing a Go template as the value
-of the --template flag, you can filter the attributes of the fetched resource(s).`
+of the --template flag, you can filter the attributes of the fetched resource(s).
+The 'jsonpath' and 'custom-columns' output formats select fields with jsonpath
+expressions instead.`
 	get_example = `// List all pods in ps output format.
 $ kubectl get pods
 
@@ -54,6 +57,12 @@ $ kubectl get -o json pod web-pod-13je7
 // Return only the phase value of the specified pod.
 $ kubectl get -o template web-pod-13je7 --template={{.status.phase}} --api-version=v1
 
+// List the name and node of every pod, sorted by node.
+$ kubectl get pods -o custom-columns=NAME:.metadata.name,NODE:.spec.nodeName --sort-by=.spec.nodeName
+
+// List all pods sorted by their creation time.
+$ kubectl get pods --sort-by=.metadata.creationTimestamp
+
 // List all replication controllers and services together in ps output format.
 $ kubectl get rc,services
 
@@ -83,6 +92,7 @@ func NewCmdGet(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolP("watch", "w", false, "After listing/getting the requested object, watch for changes.")
 	cmd.Flags().Bool("watch-only", false, "Watch for changes to the requested object(s), without listing/getting first.")
 	cmd.Flags().Bool("all-namespaces", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().String("sort-by", "", "If non-empty, sort list types using this field specification.  The field specification is expressed as a JSONPath expression (e.g. '.metadata.name'). Strings holding quantities or timestamps are compared by their value.")
 	kubectl.AddLabelsToColumnsFlag(cmd, &util.StringList{}, "Accepts a comma separated list of labels that are going to be presented as columns. Names are case-sensitive. You can also use multiple flag statements like -L label1 -L label2...")
 	return cmd
 }
@@ -92,6 +102,7 @@ func NewCmdGet(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 func RunGet(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
 	selector := cmdutil.GetFlagString(cmd, "selector")
 	allNamespaces := cmdutil.GetFlagBool(cmd, "all-namespaces")
+	sorting := cmdutil.GetFlagString(cmd, "sort-by")
 	mapper, typer := f.Object()
 
 	cmdNamespace, _, err := f.DefaultNamespace()
@@ -183,6 +194,22 @@ func RunGet(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 			return err
 		}
 
+		if len(sorting) > 0 {
+			objs := make([]runtime.Object, len(infos))
+			for ix := range infos {
+				objs[ix] = infos[ix].Object
+			}
+			sorter, err := kubectl.SortObjects(objs, sorting)
+			if err != nil {
+				return err
+			}
+			sorted := make([]*resource.Info, len(infos))
+			for ix := range infos {
+				sorted[ix] = infos[sorter.OriginalPosition(ix)]
+			}
+			infos = sorted
+		}
+
 		// the outermost object will be converted to the output-version, but inner
 		// objects can use their mappings
 		version := cmdutil.OutputVersion(cmd, defaultVersion)
@@ -200,6 +227,9 @@ func RunGet(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		if err != nil {
 			return err
 		}
+		if len(sorting) > 0 {
+			printer = &kubectl.SortingPrinter{Delegate: printer, SortField: sorting}
+		}
 		return printer.PrintObj(r.Object, out)
 	})
 }
@@ -301,6 +301,62 @@ func TestGetListObjects(t *testing.T) {
 	}
 }
 
+func TestGetSortedObjects(t *testing.T) {
+	pods, _, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Resp:  &http.Response{StatusCode: 200, Body: objBody(codec, pods)},
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdGet(f, buf)
+	cmd.SetOutput(buf)
+	cmd.Flags().Set("sort-by", ".metadata.name")
+	cmd.Run(cmd, []string{"pods"})
+
+	actual := tf.Printer.(*testPrinter).Objects
+	if len(actual) != 1 {
+		t.Fatalf("unexpected objects: %#v", actual)
+	}
+	sorted := actual[0].(*api.PodList)
+	if len(sorted.Items) != 2 || sorted.Items[0].Name != "bar" || sorted.Items[1].Name != "foo" {
+		t.Errorf("unexpected order: %#v", sorted.Items)
+	}
+}
+
+func TestGetSortedCustomColumns(t *testing.T) {
+	pods, _, _ := testData()
+	pods.Items[0].Spec.NodeName = "node1"
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Resp:  &http.Response{StatusCode: 200, Body: objBody(codec, pods)},
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdGet(f, buf)
+	cmd.SetOutput(buf)
+	cmd.Flags().Set("output", "custom-columns=NAME:.metadata.name,NODE:.spec.nodeName")
+	cmd.Flags().Set("sort-by", ".metadata.name")
+	cmd.Run(cmd, []string{"pods"})
+
+	if tf.Printer.(*testPrinter).Objects != nil {
+		t.Errorf("unexpected print to default printer")
+	}
+	expected := "NAME      NODE\nbar       <none>\nfoo       node1\n"
+	if buf.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
+
 func TestGetListComponentStatus(t *testing.T) {
 	statuses := testComponentStatusData()
 
@@ -17,15 +17,25 @@ limitations under the License.
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/fields"
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
 	libutil "k8s.io/kubernetes/pkg/util"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/watch"
 )
 
 const (
@@ -36,7 +46,13 @@ $ kubectl logs 123456-7890 ruby-container
 $ kubectl logs -p 123456-7890 ruby-container
 
 // Starts streaming of ruby-container logs from pod 123456-7890.
-$ kubectl logs -f 123456-7890 ruby-container`
+$ kubectl logs -f 123456-7890 ruby-container
+
+// Returns the last 20 lines of every container in pod 123456-7890, written in the last hour.
+$ kubectl logs --all-containers --tail=20 --since=1h 123456-7890
+
+// Starts streaming the logs of all pods labeled app=nginx, including pods that match later.
+$ kubectl logs -f -l app=nginx`
 )
 
 func selectContainer(pod *api.Pod, in io.Reader, out io.Writer) string {
@@ -69,9 +85,13 @@ type logParams struct {
 func NewCmdLog(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	params := &logParams{}
 	cmd := &cobra.Command{
-		Use:     "logs [-f] [-p] POD [-c CONTAINER]",
-		Short:   "Print the logs for a container in a pod.",
-		Long:    "Print the logs for a container in a pod. If the pod has only one container, the container name is optional.",
+		Use:   "logs [-f] [-p] (POD | -l SELECTOR) [-c CONTAINER | --all-containers]",
+		Short: "Print the logs for a container in a pod.",
+		Long: `Print the logs for a container in a pod. If the pod has only one container, the container name is optional.
+
+When pods are selected by label with -l, the logs of all of them are interleaved line by line and every
+line is prefixed with [POD/CONTAINER]. With -f, pods that start matching the selector while streaming are
+followed as well.`,
 		Example: log_example,
 		Run: func(cmd *cobra.Command, args []string) {
 			err := RunLog(f, out, cmd, args, params)
@@ -83,6 +103,12 @@ func NewCmdLog(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().Bool("interactive", true, "If true, prompt the user for input when required. Default true.")
 	cmd.Flags().BoolP("previous", "p", false, "If true, print the logs for the previous instance of the container in a pod if it exists.")
 	cmd.Flags().StringVarP(&params.containerName, "container", "c", "", "Container name")
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().Bool("all-containers", false, "If true, print the logs of all containers in the selected pods.")
+	cmd.Flags().Duration("since", 0, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs. Only one of since-time / since may be used.")
+	cmd.Flags().String("since-time", "", "Only return logs after a specific date (RFC3339). Defaults to all logs. Only one of since-time / since may be used.")
+	cmd.Flags().Int("tail", -1, "Lines of recent log file to display. Defaults to -1, showing all log lines.")
+	cmd.Flags().Bool("timestamps", false, "Include timestamps on each line in the log output.")
 	return cmd
 }
 
@@ -92,12 +118,27 @@ func RunLog(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		printDeprecationWarning("logs", "log")
 	}
 
-	if len(args) == 0 {
-		return cmdutil.UsageError(cmd, "POD is required for log")
+	selector := cmdutil.GetFlagString(cmd, "selector")
+	if len(selector) > 0 {
+		if len(args) > 0 {
+			return cmdutil.UsageError(cmd, "POD may not be specified together with a selector")
+		}
+	} else {
+		if len(args) == 0 {
+			return cmdutil.UsageError(cmd, "POD is required for log")
+		}
+		if len(args) > 2 {
+			return cmdutil.UsageError(cmd, "log POD [CONTAINER]")
+		}
+	}
+	allContainers := cmdutil.GetFlagBool(cmd, "all-containers")
+	if allContainers && (len(p.containerName) > 0 || len(args) == 2) {
+		return cmdutil.UsageError(cmd, "a container may not be specified together with --all-containers")
 	}
 
-	if len(args) > 2 {
-		return cmdutil.UsageError(cmd, "log POD [CONTAINER]")
+	logOptions, err := podLogOptions(cmd)
+	if err != nil {
+		return err
 	}
 
 	namespace, _, err := f.DefaultNamespace()
@@ -109,6 +150,24 @@ func RunLog(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		return err
 	}
 
+	streamer := &logStreamer{
+		client:        client,
+		namespace:     namespace,
+		options:       *logOptions,
+		container:     p.containerName,
+		allContainers: allContainers,
+		out:           out,
+		started:       libutil.StringSet{},
+	}
+
+	if len(selector) > 0 {
+		labelSelector, err := labels.Parse(selector)
+		if err != nil {
+			return err
+		}
+		return streamer.streamSelector(labelSelector)
+	}
+
 	podID := args[0]
 
 	pod, err := client.Pods(namespace).Get(podID)
@@ -116,6 +175,11 @@ func RunLog(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		return err
 	}
 
+	if allContainers {
+		streamer.streamPod(pod)
+		return streamer.wait()
+	}
+
 	var container string
 	if cmdutil.GetFlagString(cmd, "container") != "" {
 		// [-c CONTAINER]
@@ -132,30 +196,219 @@ func RunLog(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		}
 	}
 
-	follow := false
-	if cmdutil.GetFlagBool(cmd, "follow") {
-		follow = true
+	logOptions.Container = container
+	readCloser, err := logRequest(client, namespace, podID, logOptions).Stream()
+	if err != nil {
+		return err
 	}
 
-	previous := false
-	if cmdutil.GetFlagBool(cmd, "previous") {
-		previous = true
+	defer readCloser.Close()
+	_, err = io.Copy(out, readCloser)
+	return err
+}
+
+// podLogOptions builds the log request options from the command flags.
+func podLogOptions(cmd *cobra.Command) (*api.PodLogOptions, error) {
+	logOptions := &api.PodLogOptions{
+		Follow:     cmdutil.GetFlagBool(cmd, "follow"),
+		Previous:   cmdutil.GetFlagBool(cmd, "previous"),
+		Timestamps: cmdutil.GetFlagBool(cmd, "timestamps"),
+	}
+	if since := cmdutil.GetFlagDuration(cmd, "since"); since != 0 {
+		if since < 0 {
+			return nil, fmt.Errorf("--since must be a positive duration")
+		}
+		seconds := int64(math.Ceil(since.Seconds()))
+		logOptions.SinceSeconds = &seconds
+	}
+	if sinceTime := cmdutil.GetFlagString(cmd, "since-time"); len(sinceTime) > 0 {
+		if logOptions.SinceSeconds != nil {
+			return nil, fmt.Errorf("only one of --since or --since-time may be specified")
+		}
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return nil, fmt.Errorf("--since-time must be an RFC3339 timestamp: %v", err)
+		}
+		since := libutil.NewTime(t)
+		logOptions.SinceTime = &since
+	}
+	if tail := cmdutil.GetFlagInt(cmd, "tail"); tail >= 0 {
+		lines := int64(tail)
+		logOptions.TailLines = &lines
 	}
+	return logOptions, nil
+}
 
-	readCloser, err := client.RESTClient.Get().
+// logRequest returns the request that streams the log of a pod container.
+func logRequest(c *client.Client, namespace, podID string, logOptions *api.PodLogOptions) *client.Request {
+	req := c.RESTClient.Get().
 		Namespace(namespace).
 		Name(podID).
 		Resource("pods").
 		SubResource("log").
-		Param("follow", strconv.FormatBool(follow)).
-		Param("container", container).
-		Param("previous", strconv.FormatBool(previous)).
-		Stream()
+		Param("follow", strconv.FormatBool(logOptions.Follow)).
+		Param("container", logOptions.Container).
+		Param("previous", strconv.FormatBool(logOptions.Previous))
+	if logOptions.Timestamps {
+		req.Param("timestamps", "true")
+	}
+	if logOptions.SinceSeconds != nil {
+		req.Param("sinceSeconds", strconv.FormatInt(*logOptions.SinceSeconds, 10))
+	}
+	if logOptions.SinceTime != nil {
+		req.Param("sinceTime", logOptions.SinceTime.Format(time.RFC3339))
+	}
+	if logOptions.TailLines != nil {
+		req.Param("tailLines", strconv.FormatInt(*logOptions.TailLines, 10))
+	}
+	return req
+}
+
+// logStreamer interleaves the logs of several pod containers line by line,
+// prefixing every line with the pod and container it came from.
+type logStreamer struct {
+	client        *client.Client
+	namespace     string
+	options       api.PodLogOptions
+	container     string
+	allContainers bool
+	out           io.Writer
+
+	wg sync.WaitGroup
+	// lock guards out, started and errs.
+	lock    sync.Mutex
+	started libutil.StringSet
+	errs    []error
+}
+
+// streamSelector streams the logs of every pod matching selector. When
+// following, it keeps watching for pods that start matching the selector
+// until the watch is closed by the server.
+func (s *logStreamer) streamSelector(selector labels.Selector) error {
+	pods, err := s.client.Pods(s.namespace).List(selector, fields.Everything())
 	if err != nil {
 		return err
 	}
+	for i := range pods.Items {
+		s.streamPod(&pods.Items[i])
+	}
+	if s.options.Follow {
+		w, err := s.client.Pods(s.namespace).Watch(selector, fields.Everything(), pods.ResourceVersion)
+		if err != nil {
+			s.addError(err)
+			return s.wait()
+		}
+		for event := range w.ResultChan() {
+			pod, ok := event.Object.(*api.Pod)
+			if !ok || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+			s.streamPod(pod)
+		}
+	}
+	return s.wait()
+}
+
+// streamPod starts streaming the selected containers of pod that are not
+// streamed yet. When following, containers that have not started are skipped;
+// they are picked up from a later update of the pod.
+func (s *logStreamer) streamPod(pod *api.Pod) {
+	containers, err := s.podContainers(pod)
+	if err != nil {
+		s.addError(err)
+		return
+	}
+	for _, container := range containers {
+		if s.options.Follow && !containerStarted(pod, container) {
+			continue
+		}
+		key := pod.Name + "/" + container
+		s.lock.Lock()
+		started := s.started.Has(key)
+		s.started.Insert(key)
+		s.lock.Unlock()
+		if started {
+			continue
+		}
+		s.wg.Add(1)
+		go s.stream(pod.Name, container)
+	}
+}
+
+// podContainers returns the names of the containers of pod to print logs for.
+func (s *logStreamer) podContainers(pod *api.Pod) ([]string, error) {
+	if s.allContainers {
+		names := []string{}
+		for _, container := range pod.Spec.Containers {
+			names = append(names, container.Name)
+		}
+		return names, nil
+	}
+	if len(s.container) > 0 {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == s.container {
+				return []string{s.container}, nil
+			}
+		}
+		return nil, fmt.Errorf("container %s is not valid for pod %s", s.container, pod.Name)
+	}
+	if len(pod.Spec.Containers) != 1 {
+		return nil, fmt.Errorf("pod %s has more than one container; please specify a container with -c or use --all-containers", pod.Name)
+	}
+	return []string{pod.Spec.Containers[0].Name}, nil
+}
+
+// containerStarted returns true if the named container of pod has started
+// running, and therefore has logs to stream.
+func containerStarted(pod *api.Pod, name string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.State.Running != nil || status.State.Terminated != nil
+		}
+	}
+	return false
+}
 
+func (s *logStreamer) stream(podID, container string) {
+	defer s.wg.Done()
+	logOptions := s.options
+	logOptions.Container = container
+	readCloser, err := logRequest(s.client, s.namespace, podID, &logOptions).Stream()
+	if err != nil {
+		s.addError(fmt.Errorf("unable to stream logs for %s/%s: %v", podID, container, err))
+		return
+	}
 	defer readCloser.Close()
-	_, err = io.Copy(out, readCloser)
-	return err
+
+	prefix := fmt.Sprintf("[%s/%s] ", podID, container)
+	reader := bufio.NewReader(readCloser)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			s.lock.Lock()
+			fmt.Fprint(s.out, prefix+line)
+			s.lock.Unlock()
+		}
+		if err != nil {
+			if err != io.EOF {
+				s.addError(fmt.Errorf("error reading logs for %s/%s: %v", podID, container, err))
+			}
+			return
+		}
+	}
+}
+
+func (s *logStreamer) addError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+// wait blocks until all streams have finished and returns their errors.
+func (s *logStreamer) wait() error {
+	s.wg.Wait()
+	return utilerrors.NewAggregate(s.errs)
 }
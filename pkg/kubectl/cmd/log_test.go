@@ -20,10 +20,15 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/watch"
 )
 
 func TestSelectContainer(t *testing.T) {
@@ -196,6 +201,180 @@ func TestLog(t *testing.T) {
 	}
 }
 
+func TestLogOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		query url.Values
+	}{
+		{
+			name:  "defaults",
+			query: url.Values{"container": {"bar"}, "follow": {"false"}, "previous": {"false"}},
+		},
+		{
+			name:  "tail and timestamps",
+			flags: map[string]string{"tail": "20", "timestamps": "true"},
+			query: url.Values{"container": {"bar"}, "follow": {"false"}, "previous": {"false"}, "tailLines": {"20"}, "timestamps": {"true"}},
+		},
+		{
+			name:  "since",
+			flags: map[string]string{"since": "1h", "follow": "true"},
+			query: url.Values{"container": {"bar"}, "follow": {"true"}, "previous": {"false"}, "sinceSeconds": {"3600"}},
+		},
+		{
+			name:  "since time",
+			flags: map[string]string{"since-time": "2015-08-01T10:00:00Z"},
+			query: url.Values{"container": {"bar"}, "follow": {"false"}, "previous": {"false"}, "sinceTime": {"2015-08-01T10:00:00Z"}},
+		},
+	}
+	for _, test := range tests {
+		var query url.Values
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/api/v1/namespaces/test/pods/foo" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, testPod())}, nil
+				case p == "/api/v1/namespaces/test/pods/foo/log" && m == "GET":
+					query = req.URL.Query()
+					return &http.Response{StatusCode: 200, Body: stringBody("test log content")}, nil
+				default:
+					t.Errorf("%s: unexpected request: %#v\n%#v", test.name, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		tf.ClientConfig = &client.Config{Version: "v1"}
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdLog(f, buf)
+		for k, v := range test.flags {
+			cmd.Flags().Set(k, v)
+		}
+		cmd.Run(cmd, []string{"foo"})
+
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("%s: expected query %v, got %v", test.name, test.query, query)
+		}
+	}
+}
+
+func TestLogSelector(t *testing.T) {
+	pod1, pod2 := testPod(), testPod()
+	pod2.Name = "baz"
+	pod2.Spec.Containers = append(pod2.Spec.Containers, api.Container{Name: "qux"})
+
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/v1/namespaces/test/pods" && m == "GET":
+				if req.URL.Query().Get("labelSelector") != "app=foo" {
+					t.Errorf("unexpected selector: %v", req.URL)
+				}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: []api.Pod{*pod1, *pod2}})}, nil
+			case strings.HasSuffix(p, "/log") && m == "GET":
+				name := strings.Split(p, "/")[6]
+				container := req.URL.Query().Get("container")
+				return &http.Response{StatusCode: 200, Body: stringBody("first " + name + "/" + container + "\nsecond")}, nil
+			default:
+				t.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: "v1"}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdLog(f, buf)
+	cmd.Flags().Set("selector", "app=foo")
+	cmd.Flags().Set("all-containers", "true")
+	cmd.Run(cmd, []string{})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	sort.Strings(lines)
+	expected := []string{
+		"[baz/bar] first baz/bar",
+		"[baz/bar] second",
+		"[baz/qux] first baz/qux",
+		"[baz/qux] second",
+		"[foo/bar] first foo/bar",
+		"[foo/bar] second",
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestLogSelectorFollow(t *testing.T) {
+	running := api.PodStatus{
+		ContainerStatuses: []api.ContainerStatus{
+			{Name: "bar", State: api.ContainerState{Running: &api.ContainerStateRunning{}}},
+		},
+	}
+	pending, started := testPod(), testPod()
+	pending.Name, started.Name = "pending", "pending"
+	pending.Status = api.PodStatus{
+		ContainerStatuses: []api.ContainerStatus{
+			{Name: "bar", State: api.ContainerState{Waiting: &api.ContainerStateWaiting{}}},
+		},
+	}
+	started.Status = running
+	existing, added := testPod(), testPod()
+	existing.Status = running
+	added.Name = "added"
+	added.Status = running
+	events := []watch.Event{
+		{Type: watch.Modified, Object: started},
+		{Type: watch.Added, Object: added},
+		{Type: watch.Modified, Object: added},
+	}
+
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/v1/namespaces/test/pods" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: []api.Pod{*pending, *existing}})}, nil
+			case p == "/api/v1/watch/namespaces/test/pods" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: watchBody(codec, events)}, nil
+			case strings.HasSuffix(p, "/log") && m == "GET":
+				if req.URL.Query().Get("follow") != "true" {
+					t.Errorf("expected a follow request: %v", req.URL)
+				}
+				return &http.Response{StatusCode: 200, Body: stringBody("hello from " + strings.Split(p, "/")[6])}, nil
+			default:
+				t.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: "v1"}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdLog(f, buf)
+	cmd.Flags().Set("selector", "app=foo")
+	cmd.Flags().Set("follow", "true")
+	cmd.Run(cmd, []string{})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	sort.Strings(lines)
+	expected := []string{
+		"[added/bar] hello from added",
+		"[foo/bar] hello from foo",
+		"[pending/bar] hello from pending",
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
 func testPod() *api.Pod {
 	return &api.Pod{
 		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10"},
@@ -265,6 +265,7 @@ func RunRollingUpdate(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, arg
 		Timeout:        timeout,
 		CleanupPolicy:  updateCleanupPolicy,
 		UpdateAcceptor: kubectl.DefaultUpdateAcceptor,
+		ChangeCause:    commandLine(),
 	}
 	if cmdutil.GetFlagBool(cmd, "rollback") {
 		kubectl.AbortRollingUpdate(config)
@@ -0,0 +1,322 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+const (
+	rollout_long = `Manage the rollouts of a replication controller.
+
+Every rolling update of a replication controller records a new revision on the
+controller it creates, along with the command that caused it.`
+	rollout_example = `// View the rollout history of the frontend replication controller.
+$ kubectl rollout history rc/frontend
+
+// Roll frontend back to the revision it ran before its last rolling update.
+$ kubectl rollout undo rc/frontend`
+
+	rolloutHistory_long    = `View the rollout history of a replication controller.`
+	rolloutHistory_example = `// View the revisions of the frontend replication controller.
+$ kubectl rollout history rc/frontend
+
+// View the details of revision 3 of frontend.
+$ kubectl rollout history rc/frontend --revision=3`
+
+	rolloutUndo_long = `Roll a replication controller back to an earlier revision.
+
+Performs a rolling update from the current controller to one running the pod
+template recorded for the requested revision.`
+	rolloutUndo_example = `// Roll frontend back to the revision it ran before its last rolling update.
+$ kubectl rollout undo rc/frontend
+
+// Roll frontend back to revision 3.
+$ kubectl rollout undo rc/frontend --to-revision=3`
+
+	rolloutStatus_long = `Watch the status of the latest rollout of a replication controller.
+
+Waits until the rolling update in progress has finished and the controller
+reports all of its desired replicas.`
+	rolloutStatus_example = `// Watch the rollout of frontend until it finishes.
+$ kubectl rollout status rc/frontend`
+)
+
+func NewCmdRollout(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rollout SUBCOMMAND",
+		Short:   "Manage the rollouts of a replication controller.",
+		Long:    rollout_long,
+		Example: rollout_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(NewCmdRolloutHistory(f, out))
+	cmd.AddCommand(NewCmdRolloutUndo(f, out))
+	cmd.AddCommand(NewCmdRolloutStatus(f, out))
+	return cmd
+}
+
+func NewCmdRolloutHistory(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "history (TYPE NAME | TYPE/NAME) [--revision=REVISION]",
+		Short:   "View the rollout history of a replication controller.",
+		Long:    rolloutHistory_long,
+		Example: rolloutHistory_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutHistory(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Int("revision", 0, "If non-zero, show the details of this revision.")
+	return cmd
+}
+
+func NewCmdRolloutUndo(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undo (TYPE NAME | TYPE/NAME) [--to-revision=REVISION]",
+		Short:   "Roll a replication controller back to an earlier revision.",
+		Long:    rolloutUndo_long,
+		Example: rolloutUndo_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutUndo(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Int("to-revision", 0, "The revision to roll back to. Zero means the revision before the current one.")
+	cmd.Flags().String("update-period", updatePeriod, `Time to wait between updating pods. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().String("poll-interval", pollInterval, `Time delay between polling for replication controller status after the update. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().String("timeout", timeout, `Max time to wait for a replication controller to update before giving up. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().String("deployment-label-key", "deployment", "The key to use to differentiate between two different controllers, default 'deployment'.")
+	return cmd
+}
+
+func NewCmdRolloutStatus(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status (TYPE NAME | TYPE/NAME)",
+		Short:   "Watch the status of the latest rollout of a replication controller.",
+		Long:    rolloutStatus_long,
+		Example: rolloutStatus_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutStatus(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolP("watch", "w", true, "If true, wait until the rollout finishes. Otherwise print the current status and exit.")
+	cmd.Flags().String("poll-interval", pollInterval, `Time delay between polling for replication controller status. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().Duration("timeout", 0, "The length of time to wait for the rollout to finish, zero means wait forever.")
+	return cmd
+}
+
+func RunRolloutHistory(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	info, err := rolloutController(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	rc, err := client.ReplicationControllers(info.Namespace).Get(info.Name)
+	if err != nil {
+		return err
+	}
+	history, err := kubectl.RolloutHistory(rc)
+	if err != nil {
+		return err
+	}
+
+	var description string
+	if revision := cmdutil.GetFlagInt(cmd, "revision"); revision != 0 {
+		entry, err := kubectl.FindRolloutRevision(history, int64(revision))
+		if err != nil {
+			return err
+		}
+		description, err = kubectl.DescribeRolloutRevision(rc.Name, entry)
+	} else {
+		description, err = kubectl.DescribeRolloutHistory(rc.Name, history)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, description)
+	return nil
+}
+
+func RunRolloutUndo(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	deploymentKey := cmdutil.GetFlagString(cmd, "deployment-label-key")
+	if len(deploymentKey) == 0 {
+		return cmdutil.UsageError(cmd, "--deployment-label-key can not be empty")
+	}
+	toRevision := cmdutil.GetFlagInt(cmd, "to-revision")
+	if toRevision < 0 {
+		return cmdutil.UsageError(cmd, "--to-revision must be a non-negative revision")
+	}
+	info, err := rolloutController(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	oldRc, err := client.ReplicationControllers(info.Namespace).Get(info.Name)
+	if err != nil {
+		return err
+	}
+	if next, found := kubectl.GetNextControllerAnnotation(oldRc); found {
+		if nextRc, err := kubectl.LoadExistingNextReplicationController(client, info.Namespace, next); err != nil {
+			return err
+		} else if nextRc != nil {
+			return fmt.Errorf("a rolling update of %s to %s is in progress, use 'kubectl rolling-update %s --rollback' to abort it", oldRc.Name, next, oldRc.Name)
+		}
+	}
+
+	history, err := kubectl.RolloutHistory(oldRc)
+	if err != nil {
+		return err
+	}
+	revision, err := kubectl.FindRolloutRevision(history, int64(toRevision))
+	if err != nil {
+		return err
+	}
+	if revision.Revision == history[len(history)-1].Revision {
+		return fmt.Errorf("%s is already running revision %d", oldRc.Name, revision.Revision)
+	}
+	newRc, err := kubectl.CreateControllerForRevision(oldRc, revision, client.Codec, deploymentKey)
+	if err != nil {
+		return err
+	}
+	oldHash, err := api.HashObject(oldRc, client.Codec)
+	if err != nil {
+		return err
+	}
+	oldRc, err = kubectl.UpdateExistingReplicationController(client, oldRc, info.Namespace, newRc.Name, deploymentKey, oldHash, out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Rolling %s back to revision %d\n", oldRc.Name, revision.Revision)
+	updater := kubectl.NewRollingUpdater(info.Namespace, kubectl.NewRollingUpdaterClient(client))
+	config := &kubectl.RollingUpdaterConfig{
+		Out:            out,
+		OldRc:          oldRc,
+		NewRc:          newRc,
+		UpdatePeriod:   cmdutil.GetFlagDuration(cmd, "update-period"),
+		Interval:       cmdutil.GetFlagDuration(cmd, "poll-interval"),
+		Timeout:        cmdutil.GetFlagDuration(cmd, "timeout"),
+		CleanupPolicy:  kubectl.RenameRollingUpdateCleanupPolicy,
+		UpdateAcceptor: kubectl.DefaultUpdateAcceptor,
+		ChangeCause:    revision.ChangeCause,
+	}
+	if err := updater.Update(config); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s\n", oldRc.Name)
+	return nil
+}
+
+func RunRolloutStatus(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	info, err := rolloutController(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	status, done, err := kubectl.RolloutStatus(client, info.Namespace, info.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, status)
+	if done || !cmdutil.GetFlagBool(cmd, "watch") {
+		return nil
+	}
+
+	last := status
+	err = wait.Poll(cmdutil.GetFlagDuration(cmd, "poll-interval"), cmdutil.GetFlagDuration(cmd, "timeout"), func() (bool, error) {
+		status, done, err := kubectl.RolloutStatus(client, info.Namespace, info.Name)
+		if errors.IsNotFound(err) {
+			// The controller is briefly absent while a rolling update renames
+			// the new controller to the old name.
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if status != last {
+			fmt.Fprint(out, status)
+			last = status
+		}
+		return done, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out waiting for the rollout of %s to finish", info.Name)
+	}
+	return err
+}
+
+// rolloutController resolves the replication controller named by args.
+func rolloutController(f *cmdutil.Factory, cmd *cobra.Command, args []string) (*resource.Info, error) {
+	if len(args) == 0 {
+		return nil, cmdutil.UsageError(cmd, "Must specify the replication controller to manage")
+	}
+	cmdNamespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return nil, err
+	}
+	mapper, typer := f.Object()
+	infos, err := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(true, args...).
+		SingleResourceType().
+		Do().
+		Infos()
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) != 1 {
+		return nil, cmdutil.UsageError(cmd, "Must specify exactly one replication controller")
+	}
+	if kind := infos[0].Mapping.Kind; kind != "ReplicationController" {
+		return nil, fmt.Errorf("rollouts of a %s are not supported, only replication controllers", kind)
+	}
+	return infos[0], nil
+}
+
+// commandLine returns the kubectl invocation, which is recorded as the change
+// cause of the revisions it rolls out.
+func commandLine() string {
+	args := append([]string{filepath.Base(os.Args[0])}, os.Args[1:]...)
+	return strings.Join(args, " ")
+}
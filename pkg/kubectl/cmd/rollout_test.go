@@ -0,0 +1,128 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/client"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+func rolloutRc(t *testing.T) *api.ReplicationController {
+	template := func(image string) api.PodTemplateSpec {
+		return api.PodTemplateSpec{
+			ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+			Spec: api.PodSpec{
+				Containers:    []api.Container{{Name: "foo", Image: image}},
+				RestartPolicy: api.RestartPolicyAlways,
+				DNSPolicy:     api.DNSClusterFirst,
+			},
+		}
+	}
+	history, err := latest.Codec.Encode(&api.PodTemplateList{
+		Items: []api.PodTemplate{{
+			ObjectMeta: api.ObjectMeta{Annotations: map[string]string{"kubectl.kubernetes.io/revision": "1"}},
+			Template:   template("foo:v1"),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	current := template("foo:v2")
+	return &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:      "foo",
+			Namespace: "test",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/revision":        "2",
+				"kubectl.kubernetes.io/change-cause":    "kubectl rolling-update foo --image=foo:v2",
+				"kubectl.kubernetes.io/rollout-history": string(history),
+			},
+		},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 2,
+			Selector: map[string]string{"app": "foo"},
+			Template: &current,
+		},
+		Status: api.ReplicationControllerStatus{Replicas: 2},
+	}
+}
+
+func rolloutFactory(t *testing.T, rc *api.ReplicationController) *cmdutil.Factory {
+	f, tf, codec := NewAPIFactory()
+	rcPath := "/namespaces/test/replicationcontrollers/foo"
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			// The builder requests the controller without the API version
+			// prefix and the client with it.
+			switch p, m := strings.TrimPrefix(req.URL.Path, "/api/"+testapi.Version()), req.Method; {
+			case p == rcPath && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+			default:
+				t.Fatalf("unexpected request: %s %#v", req.Method, req.URL)
+				return nil, nil
+			}
+		}),
+	}
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	tf.Namespace = "test"
+	return f
+}
+
+func TestRolloutHistory(t *testing.T) {
+	rc := rolloutRc(t)
+	buf := bytes.NewBuffer([]byte{})
+	cmd := NewCmdRolloutHistory(rolloutFactory(t, rc), buf)
+	cmd.Run(cmd, []string{"replicationcontrollers/foo"})
+
+	expected := `replicationcontrollers "foo":
+REVISION	CHANGE-CAUSE
+1		<none>
+2		kubectl rolling-update foo --image=foo:v2
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	cmd = NewCmdRolloutHistory(rolloutFactory(t, rc), buf)
+	cmd.Flags().Set("revision", "1")
+	cmd.Run(cmd, []string{"replicationcontrollers", "foo"})
+	if out := buf.String(); !strings.Contains(out, "revision #1") || !strings.Contains(out, "foo:v1") {
+		t.Errorf("unexpected revision description:\n%s", out)
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	rc := rolloutRc(t)
+	buf := bytes.NewBuffer([]byte{})
+	cmd := NewCmdRolloutStatus(rolloutFactory(t, rc), buf)
+	cmd.Run(cmd, []string{"replicationcontrollers/foo"})
+
+	expected := "replication controller \"foo\" successfully rolled out\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// the editor used when neither KUBE_EDITOR nor EDITOR are set
+	defaultEditor = "vi"
+	// the shell editors containing spaces are run with
+	defaultShell = "/bin/bash"
+)
+
+// Editor launches a text editor on files.
+type Editor struct {
+	Args  []string
+	Shell bool
+}
+
+// NewDefaultEditor returns an Editor for the editor chosen by the user in the
+// KUBE_EDITOR or EDITOR environment variables. Editors containing spaces are
+// run with a shell, which allows arguments to be passed to them.
+func NewDefaultEditor() Editor {
+	editor := os.Getenv("KUBE_EDITOR")
+	if len(editor) == 0 {
+		editor = os.Getenv("EDITOR")
+	}
+	if len(editor) == 0 {
+		editor = defaultEditor
+	}
+	if !strings.Contains(editor, " ") {
+		return Editor{Args: []string{editor}}
+	}
+	shell := os.Getenv("SHELL")
+	if len(shell) == 0 {
+		shell = defaultShell
+	}
+	return Editor{Args: []string{shell, "-c", editor}, Shell: true}
+}
+
+// args returns the arguments of the command which edits path.
+func (e Editor) args(path string) []string {
+	args := make([]string, len(e.Args))
+	copy(args, e.Args)
+	if e.Shell {
+		last := args[len(args)-1]
+		args[len(args)-1] = fmt.Sprintf("%s %q", last, path)
+	} else {
+		args = append(args, path)
+	}
+	return args
+}
+
+// Launch opens the file at path in the editor, and waits for the editor to exit.
+func (e Editor) Launch(path string) error {
+	if len(e.Args) == 0 {
+		return fmt.Errorf("no editor defined, can't open %s", path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	args := e.args(abs)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	glog.V(5).Infof("Opening file with editor %v", args)
+	if err := cmd.Run(); err != nil {
+		if err, ok := err.(*exec.Error); ok && err.Err == exec.ErrNotFound {
+			return fmt.Errorf("unable to launch the editor %q", strings.Join(e.Args, " "))
+		}
+		return fmt.Errorf("there was a problem with the editor %q: %v", strings.Join(e.Args, " "), err)
+	}
+	return nil
+}
+
+// LaunchTempFile writes the contents of r to a temporary file, whose name
+// starts with prefix and ends with suffix, and opens it in the editor. It
+// returns the contents of the file once the editor exits, and the path of
+// the file, which the caller is responsible for removing.
+func (e Editor) LaunchTempFile(prefix, suffix string, r io.Reader) ([]byte, string, error) {
+	f, err := ioutil.TempFile("", prefix)
+	if err != nil {
+		return nil, "", err
+	}
+	// the suffix lets editors pick the syntax highlighting of the file
+	path := f.Name() + suffix
+	f.Close()
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return nil, "", err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, path, err
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return nil, path, err
+	}
+	if err := e.Launch(path); err != nil {
+		return nil, path, err
+	}
+	edited, err := ioutil.ReadFile(path)
+	return edited, path, err
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	if e, a := []string{"/bin/bash", "-c", "test \"/tmp/a\""}, (Editor{Args: []string{"/bin/bash", "-c", "test"}, Shell: true}).args("/tmp/a"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+	if e, a := []string{"/bin/bash", "-c", "test", "/tmp/a"}, (Editor{Args: []string{"/bin/bash", "-c", "test"}, Shell: false}).args("/tmp/a"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+}
+
+func TestNewDefaultEditor(t *testing.T) {
+	defer os.Setenv("KUBE_EDITOR", os.Getenv("KUBE_EDITOR"))
+	os.Setenv("KUBE_EDITOR", "vim -n")
+	e := NewDefaultEditor()
+	if !e.Shell || e.Args[len(e.Args)-1] != "vim -n" {
+		t.Errorf("unexpected editor: %#v", e)
+	}
+	os.Setenv("KUBE_EDITOR", "nano")
+	if e := NewDefaultEditor(); e.Shell || !reflect.DeepEqual(e.Args, []string{"nano"}) {
+		t.Errorf("unexpected editor: %#v", e)
+	}
+}
+
+func TestEditor(t *testing.T) {
+	edit := Editor{Args: []string{"cat"}}
+	contents, path, err := edit.LaunchTempFile("test", ".yaml", bytes.NewBufferString("test something"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(path)
+	if !strings.HasSuffix(path, ".yaml") {
+		t.Errorf("expected the path to end with .yaml, got %s", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("no temp file: %s", path)
+	}
+	if disk, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(contents, disk) {
+		t.Errorf("unexpected file on disk: %v %s", err, string(disk))
+	}
+	if !bytes.Equal(contents, []byte("test something")) {
+		t.Errorf("unexpected contents: %s", string(contents))
+	}
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package util
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"sync"
 
+	"github.com/emicklei/go-restful/swagger"
+	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -33,6 +37,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/client"
 	"k8s.io/kubernetes/pkg/client/clientcmd"
+	explatest "k8s.io/kubernetes/pkg/expapi/latest"
 	"k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/kubectl/resource"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -78,6 +83,8 @@ type Factory struct {
 	LabelsForObject func(object runtime.Object) (map[string]string, error)
 	// Returns a schema that can validate objects stored on disk.
 	Validator func() (validation.Schema, error)
+	// SwaggerSchema returns the schema declaration served for the provided group version.
+	SwaggerSchema func(groupVersion string) (*swagger.ApiDeclaration, error)
 	// Returns the default namespace to use in cases where no
 	// other namespace is specified and whether the namespace was
 	// overriden.
@@ -107,6 +114,7 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 	}
 
 	clients := NewClientCache(clientConfig)
+	groups := &apiGroupCache{mapper: mapper}
 
 	return &Factory{
 		clients:    clients,
@@ -118,7 +126,7 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 			CheckErr(err)
 			cmdApiVersion := cfg.Version
 
-			return kubectl.OutputVersionMapper{mapper, cmdApiVersion}, api.Scheme
+			return kubectl.OutputVersionMapper{groups.RESTMapper(cfg), cmdApiVersion}, resource.UnstructuredObjectTyper{ObjectTyper: api.Scheme}
 		},
 		Client: func() (*client.Client, error) {
 			return clients.ClientForVersion("")
@@ -127,6 +135,13 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 			return clients.ClientConfigForVersion("")
 		},
 		RESTClient: func(mapping *meta.RESTMapping) (resource.RESTClient, error) {
+			if len(mapping.Group) > 0 {
+				cfg, err := clientConfig.ClientConfig()
+				if err != nil {
+					return nil, err
+				}
+				return groups.RESTClient(cfg, mapping)
+			}
 			client, err := clients.ClientForVersion(mapping.APIVersion)
 			if err != nil {
 				return nil, err
@@ -198,6 +213,9 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 			return kubectl.ScalerFor(mapping.Kind, kubectl.NewScalerClient(client))
 		},
 		Reaper: func(mapping *meta.RESTMapping) (kubectl.Reaper, error) {
+			if len(mapping.Group) > 0 {
+				return kubectl.ReaperFor(mapping.Kind, nil)
+			}
 			client, err := clients.ClientForVersion(mapping.APIVersion)
 			if err != nil {
 				return nil, err
@@ -214,6 +232,22 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 			}
 			return validation.NullSchema{}, nil
 		},
+		SwaggerSchema: func(groupVersion string) (*swagger.ApiDeclaration, error) {
+			client, err := clients.ClientForVersion("")
+			if err != nil {
+				return nil, err
+			}
+			cfg, err := clientConfig.ClientConfig()
+			if err != nil {
+				return nil, err
+			}
+			group, version := meta.SplitGroupVersion(groupVersion)
+			path, err := groups.Path(cfg, group)
+			if err != nil {
+				return nil, err
+			}
+			return getSchema(client, path, version)
+		},
 		DefaultNamespace: func() (string, bool, error) {
 			return clientConfig.Namespace()
 		},
@@ -224,6 +258,78 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 	}
 }
 
+// apiGroupCache discovers the API groups of the server the first time they are
+// needed, and reuses the result for the lifetime of the factory.
+type apiGroupCache struct {
+	// mapper maps the kinds of the legacy API.
+	mapper meta.RESTMapper
+
+	once       sync.Once
+	discovery  *client.DiscoveryClient
+	discovered meta.RESTMapper
+}
+
+// discover maps the kinds of every API group the server supports, using the
+// legacy mapper for the legacy API. Discovery is best effort, since older servers
+// do not support it, and the legacy mapper is used alone when it fails.
+func (c *apiGroupCache) discover(cfg *client.Config) {
+	c.once.Do(func() {
+		c.discovered = c.mapper
+		discovery, err := client.NewDiscoveryClient(cfg)
+		if err != nil {
+			glog.V(4).Infof("Unable to create a discovery client: %v", err)
+			return
+		}
+		known := map[string]meta.RESTMapper{
+			"":              c.mapper,
+			explatest.Group: explatest.RESTMapper,
+		}
+		groupMapper, err := resource.NewDiscoveryRESTMapper(discovery, known, latest.Codec)
+		if err != nil {
+			glog.V(4).Infof("Unable to discover the API groups of the server: %v", err)
+			return
+		}
+		c.discovery = discovery
+		c.discovered = groupMapper
+	})
+}
+
+// RESTMapper returns the mapper for the kinds of every API group the server supports.
+func (c *apiGroupCache) RESTMapper(cfg *client.Config) meta.RESTMapper {
+	c.discover(cfg)
+	return c.discovered
+}
+
+// Path returns the path the versions of an API group were discovered at. The legacy
+// API, the group with an empty name, is served at the prefix of cfg.
+func (c *apiGroupCache) Path(cfg *client.Config, group string) (string, error) {
+	if len(group) == 0 {
+		if len(cfg.Prefix) == 0 {
+			return "/api", nil
+		}
+		return cfg.Prefix, nil
+	}
+	c.discover(cfg)
+	if c.discovery == nil {
+		return "", fmt.Errorf("the API group %q was not discovered on the server", group)
+	}
+	return c.discovery.GroupPath(group), nil
+}
+
+// RESTClient returns a RESTClient for resources of an API group, which are served
+// at the path the group was discovered at.
+func (c *apiGroupCache) RESTClient(cfg *client.Config, mapping *meta.RESTMapping) (*client.RESTClient, error) {
+	path, err := c.Path(cfg, mapping.Group)
+	if err != nil {
+		return nil, err
+	}
+	config := *cfg
+	config.Prefix = path
+	config.Version = mapping.APIVersion
+	config.Codec = mapping.Codec
+	return client.RESTClientFor(&config)
+}
+
 // BindFlags adds any flags that are common to all kubectl sub commands.
 func (f *Factory) BindFlags(flags *pflag.FlagSet) {
 	// any flags defined by external projects (not part of pflags)
@@ -272,6 +378,20 @@ func getServicePorts(spec api.ServiceSpec) []string {
 	return result
 }
 
+// getSchema fetches the swagger declaration the server publishes for a version of
+// the API group served at groupPath, at /swaggerapi/<groupPath>/<version>.
+func getSchema(c *client.Client, groupPath, version string) (*swagger.ApiDeclaration, error) {
+	data, err := c.RESTClient.Get().AbsPath("/swaggerapi", groupPath, version).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	schema := &swagger.ApiDeclaration{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
 type clientSwaggerSchema struct {
 	c *client.Client
 	t runtime.ObjectTyper
@@ -17,12 +17,20 @@ limitations under the License.
 package util
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/client"
 	"k8s.io/kubernetes/pkg/client/clientcmd"
 	clientcmdapi "k8s.io/kubernetes/pkg/client/clientcmd/api"
+	explatest "k8s.io/kubernetes/pkg/expapi/latest"
 	"k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
@@ -166,3 +174,50 @@ func TestFlagUnderscoreRenaming(t *testing.T) {
 		t.Fatalf("Expected flag name to be valid-flag, got %s", factory.flags.Lookup("valid_flag").Name)
 	}
 }
+
+func TestAPIGroupCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests[req.URL.Path]++
+		var obj interface{}
+		switch req.URL.Path {
+		case "/api":
+			obj = &api.APIVersions{Versions: []string{testapi.Version()}}
+		case "/groups":
+			experimental := api.GroupVersion{GroupVersion: explatest.Group + "/" + explatest.Version, Version: explatest.Version}
+			obj = &api.APIGroupList{Groups: []api.APIGroup{{
+				Name:             explatest.Group,
+				Versions:         []api.GroupVersion{experimental},
+				PreferredVersion: experimental,
+			}}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	cfg := &client.Config{Host: server.URL, GroupPrefix: "/groups", Version: testapi.Version()}
+	groups := &apiGroupCache{mapper: latest.RESTMapper}
+	for i := 0; i < 3; i++ {
+		groups.RESTMapper(cfg)
+	}
+	if requests["/api"] != 1 || requests["/groups"] != 1 {
+		t.Errorf("expected the server to be discovered once, got requests %v", requests)
+	}
+
+	mapping := &meta.RESTMapping{Group: explatest.Group, APIVersion: explatest.Version, Codec: explatest.Codec}
+	restClient, err := groups.RESTClient(cfg, mapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path := restClient.Get().Resource("jobs").URL().Path; path != "/groups/experimental/v1/jobs" {
+		t.Errorf("expected the discovered group path, got %s", path)
+	}
+}
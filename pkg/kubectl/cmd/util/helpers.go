@@ -64,13 +64,47 @@ func AddSourceToErr(verb string, source string, err error) error {
 	return err
 }
 
+// DefaultErrorExitCode is the exit code CheckErr exits with.
+const DefaultErrorExitCode = 1
+
+// fatalErrHandler is called with the message to print and the code to exit
+// with when a command fails.
+var fatalErrHandler = fatal
+
+// BehaviorOnFatal overrides the default behavior when a fatal error occurs,
+// which is to print the message and call os.Exit with the given code. It is
+// meant for tests that exercise the exit codes of commands.
+func BehaviorOnFatal(f func(string, int)) {
+	fatalErrHandler = f
+}
+
+// DefaultBehaviorOnFatal restores the default behavior when a fatal error
+// occurs.
+func DefaultBehaviorOnFatal() {
+	fatalErrHandler = fatal
+}
+
 // CheckErr prints a user friendly error to STDERR and exits with a non-zero
 // exit code. Unrecognized errors will be printed with an "error: " prefix.
 //
 // This method is generic to the command in use and may be used by non-Kubectl
 // commands.
 func CheckErr(err error) {
-	checkErr(err, fatal)
+	CheckErrWithCode(err, DefaultErrorExitCode)
+}
+
+// CheckErrWithCode is like CheckErr, but exits with code. It is used by
+// commands whose other exit codes carry a meaning.
+func CheckErrWithCode(err error, code int) {
+	checkErr(err, func(msg string) {
+		fatalErrHandler(msg, code)
+	})
+}
+
+// ExitWithCode exits with code without printing anything. Like CheckErr, it
+// can be overridden with BehaviorOnFatal.
+func ExitWithCode(code int) {
+	fatalErrHandler("", code)
 }
 
 func checkErr(err error, handleErr func(string)) {
@@ -182,19 +216,21 @@ func messageForError(err error) string {
 	return msg
 }
 
-// fatal prints the message and then exits. If V(2) or greater, glog.Fatal
-// is invoked for extended information.
-func fatal(msg string) {
-	// add newline if needed
-	if !strings.HasSuffix(msg, "\n") {
-		msg += "\n"
-	}
+// fatal prints the message, if any, and then exits with code. If V(2) or
+// greater, glog.Fatal is invoked for extended information.
+func fatal(msg string, code int) {
+	if len(msg) > 0 {
+		// add newline if needed
+		if !strings.HasSuffix(msg, "\n") {
+			msg += "\n"
+		}
 
-	if glog.V(2) {
-		glog.FatalDepth(2, msg)
+		if glog.V(2) {
+			glog.FatalDepth(2, msg)
+		}
+		fmt.Fprint(os.Stderr, msg)
 	}
-	fmt.Fprint(os.Stderr, msg)
-	os.Exit(1)
+	os.Exit(code)
 }
 
 func UsageError(cmd *cobra.Command, format string, args ...interface{}) error {
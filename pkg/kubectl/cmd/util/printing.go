@@ -19,6 +19,7 @@ package util
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/kubectl"
@@ -28,10 +29,10 @@ import (
 
 // AddPrinterFlags adds printing related flags to a command (e.g. output format, no headers, template path)
 func AddPrinterFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml|template|templatefile|wide.")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml|template|templatefile|wide|jsonpath|jsonpath-file|custom-columns|custom-columns-file. Formats that take an argument may be given as -o format=argument.")
 	cmd.Flags().String("output-version", "", "Output the formatted object with the given version (default api-version).")
 	cmd.Flags().Bool("no-headers", false, "When using the default output, don't print headers.")
-	cmd.Flags().StringP("template", "t", "", "Template string or path to template file to use when -o=template or -o=templatefile.  The template format is golang templates [http://golang.org/pkg/text/template/#pkg-overview]")
+	cmd.Flags().StringP("template", "t", "", "Template string or path to template file to use when -o=template, -o=templatefile, -o=jsonpath or -o=jsonpath-file.  The template format is golang templates [http://golang.org/pkg/text/template/#pkg-overview] or jsonpath templates.")
 }
 
 // AddOutputFlagsForMutation adds output related flags to a command. Used by mutations only.
@@ -85,6 +86,11 @@ func PrinterForCommand(cmd *cobra.Command) (kubectl.ResourcePrinter, bool, error
 	if len(outputFormat) == 0 && len(templateFile) != 0 {
 		outputFormat = "template"
 	}
+	// -o format=argument supplies the argument inline, e.g. -o jsonpath={.metadata.name}
+	if index := strings.Index(outputFormat, "="); index != -1 {
+		templateFile = outputFormat[index+1:]
+		outputFormat = outputFormat[:index]
+	}
 
 	return kubectl.GetPrinter(outputFormat, templateFile)
 }
@@ -0,0 +1,248 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+const (
+	wait_long = `Wait for a condition on one or many resources.
+
+The resources are selected by filenames, resources and names, or resources and
+label selector, and each of them is watched until it meets the condition.
+
+--for=delete waits for the resources to be deleted. --for=condition=CONDITION
+waits for a resource to report CONDITION with the status True, or with the
+status given as --for=condition=CONDITION=STATUS. Pods and nodes may wait for
+any of their status conditions, and replication controllers for the condition
+Ready, which is met once the controller has as many replicas as it desires.
+
+The exit status is 124 when the timeout expires before every resource meets the
+condition, so scripts can tell a timeout apart from other errors.`
+	wait_example = `// Wait for the pods with label app=x to become ready.
+$ kubectl wait --for=condition=Ready pods -l app=x --timeout=5m
+
+// Wait for the frontend replication controller to reach its replica count.
+$ kubectl wait --for=condition=Ready rc frontend
+
+// Wait for the pod busybox1 to be deleted.
+$ kubectl wait --for=delete pod/busybox1`
+)
+
+// waitTimeoutExitCode is the exit status of kubectl wait when the timeout
+// expires, the same status timeout(1) uses.
+const waitTimeoutExitCode = 124
+
+func NewCmdWait(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	var filenames util.StringList
+	cmd := &cobra.Command{
+		Use:     "wait ([-f FILENAME] | TYPE [NAME | -l label | --all]) --for=delete|--for=condition=CONDITION[=STATUS]",
+		Short:   "Wait for a condition on one or many resources.",
+		Long:    wait_long,
+		Example: wait_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunWait(f, out, cmd, args, filenames)
+			if _, ok := err.(*waitTimeoutError); ok {
+				cmdutil.CheckErrWithCode(err, waitTimeoutExitCode)
+			}
+			cmdutil.CheckErr(err)
+		},
+	}
+	usage := "Filename, directory, or URL to a file identifying the resource to wait for"
+	kubectl.AddJsonFilenameFlag(cmd, &filenames, usage)
+	cmd.Flags().String("for", "", "The condition to wait on: delete or condition=CONDITION[=STATUS].")
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on.")
+	cmd.Flags().Bool("all", false, "[-all] to select all the specified resources.")
+	cmd.Flags().Duration("timeout", 30*time.Second, "The length of time to wait before giving up. Zero means check once and don't wait, negative means wait forever.")
+	cmd.MarkFlagRequired("for")
+	return cmd
+}
+
+// waitTimeoutError is returned by RunWait when the timeout expires before a
+// resource meets the condition.
+type waitTimeoutError struct {
+	resource string
+	name     string
+}
+
+func (e *waitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for the condition on %s/%s", e.resource, e.name)
+}
+
+// waitFor is a parsed --for flag.
+type waitFor struct {
+	delete    bool
+	condition string
+	status    api.ConditionStatus
+}
+
+func parseWaitFor(value string) (*waitFor, error) {
+	if value == "delete" {
+		return &waitFor{delete: true}, nil
+	}
+	if !strings.HasPrefix(value, "condition=") {
+		return nil, fmt.Errorf("--for must be delete or condition=CONDITION[=STATUS], got %q", value)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, "condition="), "=", 2)
+	if len(parts[0]) == 0 {
+		return nil, fmt.Errorf("--for=condition requires a condition name")
+	}
+	w := &waitFor{condition: parts[0], status: api.ConditionTrue}
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "true":
+			w.status = api.ConditionTrue
+		case "false":
+			w.status = api.ConditionFalse
+		case "unknown":
+			w.status = api.ConditionUnknown
+		default:
+			return nil, fmt.Errorf("condition status must be True, False or Unknown, got %q", parts[1])
+		}
+	}
+	return w, nil
+}
+
+// conditionFor returns the watch condition that tells whether a resource of
+// kind meets w.
+func (w *waitFor) conditionFor(kind string) (watch.ConditionFunc, error) {
+	if w.delete {
+		return client.ObjectDeleted, nil
+	}
+	switch kind {
+	case "Pod":
+		return client.PodHasCondition(api.PodConditionType(w.condition), w.status), nil
+	case "Node":
+		return client.NodeHasCondition(api.NodeConditionType(w.condition), w.status), nil
+	case "ReplicationController":
+		if w.condition == "Ready" && w.status == api.ConditionTrue {
+			return client.ControllerHasDesiredReplicasEvent, nil
+		}
+	}
+	return nil, fmt.Errorf("waiting for condition %s=%s is not supported for a %s", w.condition, w.status, kind)
+}
+
+func RunWait(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string, filenames util.StringList) error {
+	w, err := parseWaitFor(cmdutil.GetFlagString(cmd, "for"))
+	if err != nil {
+		return cmdutil.UsageError(cmd, "%v", err)
+	}
+	timeout := cmdutil.GetFlagDuration(cmd, "timeout")
+	deadline := time.Now().Add(timeout)
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		SelectorParam(cmdutil.GetFlagString(cmd, "selector")).
+		SelectAllParam(cmdutil.GetFlagBool(cmd, "all")).
+		ResourceTypeOrNameArgs(false, args...).
+		Flatten().
+		Do()
+	if w.delete {
+		// Resources that are already gone have nothing left to wait for.
+		r.IgnoreErrors(errors.IsNotFound)
+	}
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 && !w.delete {
+		return fmt.Errorf("no matching resources found")
+	}
+
+	conditions := make([]watch.ConditionFunc, len(infos))
+	for i, info := range infos {
+		if conditions[i], err = w.conditionFor(info.Mapping.Kind); err != nil {
+			return err
+		}
+	}
+	operation := "condition met"
+	if w.delete {
+		operation = "deleted"
+	}
+	for i, info := range infos {
+		if err := waitForInfo(info, conditions[i], timeout, deadline); err != nil {
+			return err
+		}
+		cmdutil.PrintSuccess(mapper, false, out, info.Mapping.Resource, info.Name, operation)
+	}
+	return nil
+}
+
+// waitForInfo watches the resource of info until it meets condition, starting
+// from the state the builder retrieved. A negative timeout waits forever.
+func waitForInfo(info *resource.Info, condition watch.ConditionFunc, timeout time.Duration, deadline time.Time) error {
+	event := watch.Event{Type: watch.Added, Object: info.Object}
+	for {
+		done, err := condition(event)
+		if err != nil || done {
+			return err
+		}
+		var remaining time.Duration
+		if timeout >= 0 {
+			if remaining = deadline.Sub(time.Now()); remaining <= 0 {
+				return &waitTimeoutError{info.Mapping.Resource, info.Name}
+			}
+		}
+
+		watcher, err := info.Watch(info.ResourceVersion)
+		if err != nil {
+			return err
+		}
+		switch _, err := watch.Until(remaining, watcher, condition); err {
+		case nil:
+			return nil
+		case wait.ErrWaitTimeout:
+			return &waitTimeoutError{info.Mapping.Resource, info.Name}
+		case watch.ErrWatchClosed:
+			// The server ends watches periodically; continue from the latest
+			// state of the resource.
+			if err := info.Get(); err != nil {
+				if !errors.IsNotFound(err) {
+					return err
+				}
+				event = watch.Event{Type: watch.Deleted, Object: info.Object}
+				continue
+			}
+			event = watch.Event{Type: watch.Modified, Object: info.Object}
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+func waitPod(ready api.ConditionStatus) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10", Labels: map[string]string{"app": "x"}},
+		Spec: api.PodSpec{
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
+		Status: api.PodStatus{
+			Phase:      api.PodRunning,
+			Conditions: []api.PodCondition{{Type: api.PodReady, Status: ready}},
+		},
+	}
+}
+
+func TestParseWaitFor(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  *waitFor
+		expectErr bool
+	}{
+		{value: "delete", expected: &waitFor{delete: true}},
+		{value: "condition=Ready", expected: &waitFor{condition: "Ready", status: api.ConditionTrue}},
+		{value: "condition=Ready=false", expected: &waitFor{condition: "Ready", status: api.ConditionFalse}},
+		{value: "condition=Ready=Unknown", expected: &waitFor{condition: "Ready", status: api.ConditionUnknown}},
+		{value: "condition=Ready=maybe", expectErr: true},
+		{value: "condition=", expectErr: true},
+		{value: "ready", expectErr: true},
+	}
+	for _, test := range tests {
+		w, err := parseWaitFor(test.value)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(w, test.expected) {
+			t.Errorf("%s: expected %#v, got %#v", test.value, test.expected, w)
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		forValue string
+		args     []string
+		selector string
+		pod      *api.Pod
+		events   []watch.Event
+		expected string
+	}{
+		{
+			name:     "already ready",
+			forValue: "condition=Ready",
+			args:     []string{"pods"},
+			selector: "app=x",
+			pod:      waitPod(api.ConditionTrue),
+			expected: "pod \"foo\" condition met\n",
+		},
+		{
+			name:     "becomes ready",
+			forValue: "condition=Ready",
+			args:     []string{"pods"},
+			selector: "app=x",
+			pod:      waitPod(api.ConditionFalse),
+			events:   []watch.Event{{Type: watch.Modified, Object: waitPod(api.ConditionTrue)}},
+			expected: "pod \"foo\" condition met\n",
+		},
+		{
+			name:     "deleted",
+			forValue: "delete",
+			args:     []string{"pods", "foo"},
+			pod:      waitPod(api.ConditionTrue),
+			events:   []watch.Event{{Type: watch.Deleted, Object: waitPod(api.ConditionTrue)}},
+			expected: "pod \"foo\" deleted\n",
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		watched := false
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/pods" && m == "GET":
+					if selector := req.URL.Query().Get("labelSelector"); selector != test.selector {
+						t.Errorf("%s: unexpected selector %q", test.name, selector)
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: []api.Pod{*test.pod}})}, nil
+				case p == "/namespaces/test/pods/foo" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, test.pod)}, nil
+				case p == "/watch/namespaces/test/pods/foo" && m == "GET":
+					watched = true
+					if rv := req.URL.Query().Get("resourceVersion"); rv != "10" {
+						t.Errorf("%s: expected to watch from resource version 10, got %q", test.name, rv)
+					}
+					return &http.Response{StatusCode: 200, Body: watchBody(codec, test.events)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %s %#v", test.name, req.Method, req.URL)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdWait(f, buf)
+		cmd.Flags().Set("for", test.forValue)
+		if len(test.selector) != 0 {
+			cmd.Flags().Set("selector", test.selector)
+		}
+		cmd.Run(cmd, test.args)
+
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, buf.String())
+		}
+		if watched != (len(test.events) != 0) {
+			t.Errorf("%s: expected a watch only when the condition is not met yet", test.name)
+		}
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/pods/foo" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, waitPod(api.ConditionFalse))}, nil
+			default:
+				t.Fatalf("unexpected request: %s %#v", req.Method, req.URL)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdWait(f, buf)
+	cmd.Flags().Set("for", "condition=Ready")
+	cmd.Flags().Set("timeout", "0")
+	err := RunWait(f, buf, cmd, []string{"pods", "foo"}, nil)
+	if _, ok := err.(*waitTimeoutError); !ok {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if expected := "timed out waiting for the condition on pods/foo"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestWaitTimeoutExitCode(t *testing.T) {
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: objBody(codec, waitPod(api.ConditionFalse))}, nil
+		}),
+	}
+	tf.Namespace = "test"
+
+	var msg string
+	cmdutil.BehaviorOnFatal(func(m string, code int) {
+		msg = m
+		panic(exitCode(code))
+	})
+	defer cmdutil.DefaultBehaviorOnFatal()
+
+	cmd := NewCmdWait(f, bytes.NewBuffer([]byte{}))
+	cmd.Flags().Set("for", "condition=Ready")
+	cmd.Flags().Set("timeout", "0")
+	code := func() (code exitCode) {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(exitCode)
+			}
+		}()
+		cmd.Run(cmd, []string{"pods", "foo"})
+		return 0
+	}()
+	if code != waitTimeoutExitCode {
+		t.Errorf("expected exit code %d, got %d", waitTimeoutExitCode, code)
+	}
+	if expected := "error: timed out waiting for the condition on pods/foo\n"; msg != expected {
+		t.Errorf("expected %q, got %q", expected, msg)
+	}
+}
+
+func TestWaitUnsupportedCondition(t *testing.T) {
+	w := &waitFor{condition: "Complete", status: api.ConditionTrue}
+	if _, err := w.conditionFor("ReplicationController"); err == nil {
+		t.Errorf("expected an error for an unsupported condition")
+	}
+	if _, err := w.conditionFor("Pod"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/jsonpath"
+)
+
+var jsonRegexp = regexp.MustCompile("^\\{\\.?([^{}]+)\\}$|^\\.?([^{}]+)$")
+
+// massageJSONPath attempts to be flexible with JSONPath expressions. It accepts
+// metadata.name, {metadata.name}, .metadata.name and {.metadata.name}, and
+// transforms them all into the complete jsonpath expression {.metadata.name}.
+func massageJSONPath(pathExpression string) (string, error) {
+	if len(pathExpression) == 0 {
+		return pathExpression, nil
+	}
+	submatches := jsonRegexp.FindStringSubmatch(pathExpression)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path string, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'")
+	}
+	if len(submatches) != 3 {
+		return "", fmt.Errorf("unexpected submatch list: %v", submatches)
+	}
+	var fieldSpec string
+	if len(submatches[1]) != 0 {
+		fieldSpec = submatches[1]
+	} else {
+		fieldSpec = submatches[2]
+	}
+	return fmt.Sprintf("{.%s}", fieldSpec), nil
+}
+
+// NewCustomColumnsPrinterFromSpec creates a custom columns printer from a comma separated list of <header>:<jsonpath-field-spec> pairs.
+// e.g. NAME:metadata.name,API_VERSION:apiVersion creates a printer that prints:
+//
+//	NAME               API_VERSION
+//	foo                bar
+func NewCustomColumnsPrinterFromSpec(spec string) (*CustomColumnsPrinter, error) {
+	if len(spec) == 0 {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+	parts := strings.Split(spec, ",")
+	columns := make([]Column, len(parts))
+	for ix := range parts {
+		colSpec := strings.Split(parts[ix], ":")
+		if len(colSpec) != 2 {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", parts[ix])
+		}
+		spec, err := massageJSONPath(colSpec[1])
+		if err != nil {
+			return nil, err
+		}
+		columns[ix] = Column{Header: colSpec[0], FieldSpec: spec}
+	}
+	return NewCustomColumnsPrinter(columns)
+}
+
+func splitOnWhitespace(line string) []string {
+	lineScanner := bufio.NewScanner(bytes.NewBufferString(line))
+	lineScanner.Split(bufio.ScanWords)
+	result := []string{}
+	for lineScanner.Scan() {
+		result = append(result, lineScanner.Text())
+	}
+	return result
+}
+
+// NewCustomColumnsPrinterFromTemplate creates a custom columns printer from a template stream.  The template is expected
+// to consist of two lines, whitespace separated.  The first line is the header line, the second line is the jsonpath field spec
+// For example the template below:
+//
+//	NAME               API_VERSION
+//	{metadata.name}    {apiVersion}
+func NewCustomColumnsPrinterFromTemplate(templateReader io.Reader) (*CustomColumnsPrinter, error) {
+	scanner := bufio.NewScanner(templateReader)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("invalid template, missing header line. Expected format is one line of space separated headers, one line of space separated column specs.")
+	}
+	headers := splitOnWhitespace(scanner.Text())
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("invalid template, missing spec line. Expected format is one line of space separated headers, one line of space separated column specs.")
+	}
+	specs := splitOnWhitespace(scanner.Text())
+
+	if len(headers) != len(specs) {
+		return nil, fmt.Errorf("number of headers (%d) and field specifications (%d) don't match", len(headers), len(specs))
+	}
+
+	columns := make([]Column, len(headers))
+	for ix := range headers {
+		spec, err := massageJSONPath(specs[ix])
+		if err != nil {
+			return nil, err
+		}
+		columns[ix] = Column{
+			Header:    headers[ix],
+			FieldSpec: spec,
+		}
+	}
+	return NewCustomColumnsPrinter(columns)
+}
+
+// Column represents a user specified column
+type Column struct {
+	// The header to print above the column, general style is ALL_CAPS
+	Header string
+	// The pointer to the field in the object to print in JSONPath form
+	// e.g. {.ObjectMeta.Name}, see pkg/util/jsonpath for more details.
+	FieldSpec string
+}
+
+// CustomColumnsPrinter is a printer that knows how to print arbitrary columns
+// of data from templates specified in the `Columns` array
+type CustomColumnsPrinter struct {
+	Columns []Column
+
+	parsers []*jsonpath.JSONPath
+}
+
+// NewCustomColumnsPrinter parses the field spec of each column and returns a
+// printer for them.
+func NewCustomColumnsPrinter(columns []Column) (*CustomColumnsPrinter, error) {
+	parsers := make([]*jsonpath.JSONPath, len(columns))
+	for ix := range columns {
+		parsers[ix] = jsonpath.New(fmt.Sprintf("column%d", ix))
+		if err := parsers[ix].Parse(columns[ix].FieldSpec); err != nil {
+			return nil, fmt.Errorf("error parsing field spec %s for column %s: %v", columns[ix].FieldSpec, columns[ix].Header, err)
+		}
+	}
+	return &CustomColumnsPrinter{Columns: columns, parsers: parsers}, nil
+}
+
+// PrintObj prints one row per item of a list, or a single row for any other
+// object.
+func (s *CustomColumnsPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	headers := make([]string, len(s.Columns))
+	for ix := range s.Columns {
+		headers[ix] = s.Columns[ix].Header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var content interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	items := []interface{}{content}
+	if runtime.IsListType(obj) {
+		list, _ := content.(map[string]interface{})
+		items, _ = list["items"].([]interface{})
+	}
+	for _, item := range items {
+		if err := s.printOneObject(item, w); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (s *CustomColumnsPrinter) printOneObject(obj interface{}, out io.Writer) error {
+	columns := make([]string, len(s.parsers))
+	for ix, parser := range s.parsers {
+		// fields that are absent from this object are shown as <none>
+		results, err := parser.FindResults(obj)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			columns[ix] = "<none>"
+			continue
+		}
+		values := make([]string, 0, len(results[0]))
+		for _, value := range results[0] {
+			buf := &bytes.Buffer{}
+			if err := parser.PrintResults(buf, []reflect.Value{value}); err != nil {
+				return err
+			}
+			values = append(values, buf.String())
+		}
+		columns[ix] = strings.Join(values, ",")
+	}
+	fmt.Fprintln(out, strings.Join(columns, "\t"))
+	return nil
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestMassageJSONPath(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  string
+		expectErr bool
+	}{
+		{input: "foo.bar", expected: "{.foo.bar}"},
+		{input: "{foo.bar}", expected: "{.foo.bar}"},
+		{input: ".foo.bar", expected: "{.foo.bar}"},
+		{input: "{.foo.bar}", expected: "{.foo.bar}"},
+		{input: "", expected: ""},
+		{input: "{foo.bar", expectErr: true},
+		{input: "foo.bar}", expectErr: true},
+		{input: "{foo.bar}}", expectErr: true},
+		{input: "{{foo.bar}", expectErr: true},
+	}
+	for _, test := range tests {
+		output, err := massageJSONPath(test.input)
+		if err != nil && !test.expectErr {
+			t.Errorf("input: %s, unexpected error: %v", test.input, err)
+			continue
+		}
+		if err == nil && test.expectErr {
+			t.Errorf("input: %s, expected error, but got none", test.input)
+			continue
+		}
+		if output != test.expected {
+			t.Errorf("input: %s, expected: %s, saw: %s", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestNewColumnPrinterFromSpec(t *testing.T) {
+	tests := []struct {
+		spec            string
+		expectedColumns []Column
+		expectErr       bool
+		name            string
+	}{
+		{
+			spec:      "",
+			expectErr: true,
+			name:      "empty",
+		},
+		{
+			spec:      "invalid",
+			expectErr: true,
+			name:      "invalid1",
+		},
+		{
+			spec:      "invalid=foobar",
+			expectErr: true,
+			name:      "invalid2",
+		},
+		{
+			spec:      "invalid,foobar:blah",
+			expectErr: true,
+			name:      "invalid3",
+		},
+		{
+			spec: "NAME:metadata.name,API_VERSION:apiVersion",
+			name: "ok",
+			expectedColumns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+				{
+					Header:    "API_VERSION",
+					FieldSpec: "{.apiVersion}",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		printer, err := NewCustomColumnsPrinterFromSpec(test.spec)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("[%s] unexpected non-error", test.name)
+			}
+			continue
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.expectedColumns, printer.Columns) {
+			t.Errorf("[%s]\nexpected:\n%v\nsaw:\n%v\n", test.name, test.expectedColumns, printer.Columns)
+		}
+	}
+}
+
+const exampleTemplateOne = `NAME               API_VERSION
+{metadata.name}    {apiVersion}`
+
+const exampleTemplateTwo = `NAME               		API_VERSION
+							{metadata.name}    {apiVersion}`
+
+func TestNewColumnPrinterFromTemplate(t *testing.T) {
+	tests := []struct {
+		spec            string
+		expectedColumns []Column
+		expectErr       bool
+		name            string
+	}{
+		{
+			spec:      "",
+			expectErr: true,
+			name:      "empty",
+		},
+		{
+			spec:      "invalid",
+			expectErr: true,
+			name:      "invalid1",
+		},
+		{
+			spec:      "invalid=foobar",
+			expectErr: true,
+			name:      "invalid2",
+		},
+		{
+			spec:      "invalid,foobar:blah",
+			expectErr: true,
+			name:      "invalid3",
+		},
+		{
+			spec: exampleTemplateOne,
+			name: "ok",
+			expectedColumns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+				{
+					Header:    "API_VERSION",
+					FieldSpec: "{.apiVersion}",
+				},
+			},
+		},
+		{
+			spec: exampleTemplateTwo,
+			name: "ok-2",
+			expectedColumns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+				{
+					Header:    "API_VERSION",
+					FieldSpec: "{.apiVersion}",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		reader := bytes.NewBufferString(test.spec)
+		printer, err := NewCustomColumnsPrinterFromTemplate(reader)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("[%s] unexpected non-error", test.name)
+			}
+			continue
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.expectedColumns, printer.Columns) {
+			t.Errorf("[%s]\nexpected:\n%v\nsaw:\n%v\n", test.name, test.expectedColumns, printer.Columns)
+		}
+	}
+}
+
+func TestColumnPrint(t *testing.T) {
+	tests := []struct {
+		columns        []Column
+		obj            runtime.Object
+		expectedOutput string
+	}{
+		{
+			columns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+			},
+			obj: &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+			expectedOutput: `NAME
+foo
+`,
+		},
+		{
+			columns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+				{
+					Header:    "NODE",
+					FieldSpec: "{.spec.nodeName}",
+				},
+			},
+			obj: &api.PodList{
+				Items: []api.Pod{
+					{ObjectMeta: api.ObjectMeta{Name: "foo"}, Spec: api.PodSpec{NodeName: "node1"}},
+					{ObjectMeta: api.ObjectMeta{Name: "bar"}},
+				},
+			},
+			expectedOutput: `NAME      NODE
+foo       node1
+bar       <none>
+`,
+		},
+		{
+			columns: []Column{
+				{
+					Header:    "NAME",
+					FieldSpec: "{.metadata.name}",
+				},
+				{
+					Header:    "IMAGES",
+					FieldSpec: "{.spec.containers[*].image}",
+				},
+			},
+			obj: &api.Pod{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec:       api.PodSpec{Containers: []api.Container{{Image: "nginx"}, {Image: "redis"}}},
+			},
+			expectedOutput: `NAME      IMAGES
+foo       nginx,redis
+`,
+		},
+	}
+
+	for _, test := range tests {
+		printer, err := NewCustomColumnsPrinter(test.columns)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		buffer := &bytes.Buffer{}
+		if err := printer.PrintObj(test.obj, buffer); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if buffer.String() != test.expectedOutput {
+			t.Errorf("\nexpected:\n'%s'\nsaw\n'%s'\n", test.expectedOutput, buffer.String())
+		}
+	}
+}
+
+func TestColumnPrintBadFieldSpec(t *testing.T) {
+	_, err := NewCustomColumnsPrinter([]Column{{Header: "NAME", FieldSpec: "{.metadata.name"}})
+	if err == nil || !strings.Contains(err.Error(), "NAME") {
+		t.Errorf("expected an error naming the column, got %v", err)
+	}
+}
@@ -863,6 +863,12 @@ func describeNode(node *api.Node, pods []*api.Pod, events *api.EventList) (strin
 				fmt.Fprintf(out, " %s:\t%s\n", resource, value.String())
 			}
 		}
+		if len(node.Status.Allocatable) > 0 {
+			fmt.Fprintf(out, "Allocatable:\n")
+			for resource, value := range node.Status.Allocatable {
+				fmt.Fprintf(out, " %s:\t%s\n", resource, value.String())
+			}
+		}
 
 		fmt.Fprintf(out, "Version:\n")
 		fmt.Fprintf(out, " Kernel Version:\t%s\n", node.Status.NodeInfo.KernelVersion)
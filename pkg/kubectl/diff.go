@@ -0,0 +1,217 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	forkedjson "k8s.io/kubernetes/third_party/forked/json"
+)
+
+// serverPopulatedMetadata are the metadata fields of an object that are set
+// by the server rather than by its configuration.
+var serverPopulatedMetadata = []string{
+	"selfLink",
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+}
+
+// GetDiffConfigurations returns the live object and the configuration of the
+// object of info as YAML, normalized so that they can be compared. The status
+// and the metadata populated by the server are left out of both. The fields of
+// the live object that the configuration does not set are left out too, since
+// they were defaulted by the server, unless they were set by the configuration
+// last applied to the object, since applying the configuration removes them.
+// The same holds for the items of lists that are merged by a key, such as the
+// volumes of a pod.
+// A nil live object, for an object that does not exist yet, is returned as
+// nil.
+func GetDiffConfigurations(info *resource.Info, live runtime.Object) (current, configured []byte, err error) {
+	configuredObj, err := diffObject(info.Mapping.Codec, info.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	if configured, err = yaml.Marshal(configuredObj); err != nil {
+		return nil, nil, err
+	}
+	if live == nil {
+		return nil, configured, nil
+	}
+
+	currentObj, err := diffObject(info.Mapping.Codec, live)
+	if err != nil {
+		return nil, nil, err
+	}
+	annotations, err := info.Mapping.MetadataAccessor.Annotations(live)
+	if err != nil {
+		return nil, nil, err
+	}
+	var originalObj map[string]interface{}
+	if original, ok := annotations[LastAppliedConfigAnnotation]; ok {
+		if err := json.Unmarshal([]byte(original), &originalObj); err != nil {
+			return nil, nil, err
+		}
+	}
+	var t reflect.Type
+	if versioned, err := api.Scheme.New(info.Mapping.APIVersion, info.Mapping.Kind); err == nil {
+		t = reflect.TypeOf(versioned)
+	}
+	pruneDefaultedFields(currentObj, configuredObj, originalObj, t)
+	if current, err = yaml.Marshal(currentObj); err != nil {
+		return nil, nil, err
+	}
+	return current, configured, nil
+}
+
+// diffObject returns obj as a JSON object without its status and server
+// populated metadata.
+func diffObject(codec runtime.Codec, obj runtime.Object) (map[string]interface{}, error) {
+	data, err := codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	delete(config, "status")
+	removeNullFields(config)
+	if metadata, ok := config["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverPopulatedMetadata {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, LastAppliedConfigAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return config, nil
+}
+
+// pruneDefaultedFields removes the fields of current that are neither set in
+// configured nor in original, recursing into the objects and the lists of
+// objects that are set in both. The type t of the objects, if known, provides
+// the merge keys of their lists.
+func pruneDefaultedFields(current, configured, original map[string]interface{}, t reflect.Type) {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for k, v := range current {
+		configuredValue, ok := configured[k]
+		if !ok {
+			if _, ok := original[k]; !ok {
+				delete(current, k)
+			}
+			continue
+		}
+		var fieldType reflect.Type
+		var mergeKey string
+		if t != nil {
+			if ft, _, key, err := forkedjson.LookupPatchMetadata(t, k); err == nil {
+				fieldType, mergeKey = ft, key
+			}
+		}
+		switch typed := v.(type) {
+		case map[string]interface{}:
+			configuredMap, _ := configuredValue.(map[string]interface{})
+			originalMap, _ := original[k].(map[string]interface{})
+			if configuredMap != nil {
+				pruneDefaultedFields(typed, configuredMap, originalMap, fieldType)
+			}
+		case []interface{}:
+			configuredList, _ := configuredValue.([]interface{})
+			originalList, _ := original[k].([]interface{})
+			var elemType reflect.Type
+			if fieldType != nil && fieldType.Kind() == reflect.Slice {
+				elemType = fieldType.Elem()
+			}
+			current[k] = pruneDefaultedItems(typed, configuredList, originalList, elemType, mergeKey)
+		}
+	}
+}
+
+// pruneDefaultedItems prunes the defaulted fields of the objects in the list
+// current and returns the pruned list. The items of lists with a merge key are
+// matched by the key, and the items that are neither in configured nor in
+// original, such as the volumes added by admission control, are removed like
+// defaulted fields. Other lists are matched item by item, and only when they
+// have the same length.
+func pruneDefaultedItems(current, configured, original []interface{}, elemType reflect.Type, mergeKey string) []interface{} {
+	if len(mergeKey) == 0 {
+		if len(configured) != len(current) {
+			return current
+		}
+		for i := range current {
+			currentItem, _ := current[i].(map[string]interface{})
+			configuredItem, _ := configured[i].(map[string]interface{})
+			var originalItem map[string]interface{}
+			if len(original) == len(current) {
+				originalItem, _ = original[i].(map[string]interface{})
+			}
+			if currentItem != nil && configuredItem != nil {
+				pruneDefaultedFields(currentItem, configuredItem, originalItem, elemType)
+			}
+		}
+		return current
+	}
+
+	pruned := []interface{}{}
+	for _, item := range current {
+		currentItem, ok := item.(map[string]interface{})
+		if !ok {
+			pruned = append(pruned, item)
+			continue
+		}
+		key, ok := currentItem[mergeKey]
+		if !ok {
+			pruned = append(pruned, item)
+			continue
+		}
+		configuredItem := findItemByKey(configured, mergeKey, key)
+		originalItem := findItemByKey(original, mergeKey, key)
+		if configuredItem == nil {
+			if originalItem != nil {
+				pruned = append(pruned, item)
+			}
+			continue
+		}
+		pruneDefaultedFields(currentItem, configuredItem, originalItem, elemType)
+		pruned = append(pruned, currentItem)
+	}
+	return pruned
+}
+
+// findItemByKey returns the object in list whose mergeKey field is value, or nil.
+func findItemByKey(list []interface{}, mergeKey string, value interface{}) map[string]interface{} {
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok && reflect.DeepEqual(m[mergeKey], value) {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful/swagger"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+const explainWidth = 80
+
+// SplitAndParseResourceRequest separates the resource from the dotted field
+// path of an explain request, e.g. "pods.spec.containers" yields "pods" and
+// ["spec", "containers"].
+func SplitAndParseResourceRequest(inResource string) (string, []string, error) {
+	parts := strings.Split(inResource, ".")
+	for _, part := range parts {
+		if len(part) == 0 {
+			return "", nil, fmt.Errorf("invalid field path %q", inResource)
+		}
+	}
+	return parts[0], parts[1:], nil
+}
+
+// PrintModelDescription prints the type and description of the field at
+// fieldsPath within the resource described by mapping, followed by the fields
+// it contains. When recursive is true the whole field tree below it is
+// printed instead of the direct fields and their descriptions.
+func PrintModelDescription(mapping *meta.RESTMapping, fieldsPath []string, w io.Writer, schema *swagger.ApiDeclaration, recursive bool) error {
+	modelName := mapping.APIVersion + "." + mapping.Kind
+	model, ok := schema.Models.At(modelName)
+	if !ok {
+		return fmt.Errorf("couldn't find the schema for %s %s", mapping.GroupVersion(), mapping.Kind)
+	}
+
+	fmt.Fprintf(w, "KIND:     %s\n", mapping.Kind)
+	fmt.Fprintf(w, "VERSION:  %s\n\n", mapping.GroupVersion())
+
+	var field *swagger.ModelProperty
+	var fieldName string
+	isObject := true
+	for i, name := range fieldsPath {
+		if !isObject {
+			return fmt.Errorf("field %q of type %s has no field %q", fieldName, propertyType(field), name)
+		}
+		prop, ok := model.Properties.At(name)
+		if !ok {
+			return fmt.Errorf("field %q does not exist in %s", name, strings.Join(append([]string{mapping.Kind}, fieldsPath[:i]...), "."))
+		}
+		field, fieldName = &prop, name
+		model, isObject = propertyModel(schema, field)
+	}
+
+	switch {
+	case field == nil:
+		fmt.Fprintln(w, "DESCRIPTION:")
+		printDescription(w, model.Description, 5)
+	case isObject:
+		fmt.Fprintf(w, "RESOURCE: %s <%s>\n\n", fieldName, propertyType(field))
+		fmt.Fprintln(w, "DESCRIPTION:")
+		printDescription(w, field.Description, 5)
+		if len(model.Description) > 0 {
+			fmt.Fprintln(w)
+			printDescription(w, model.Description, 5)
+		}
+	default:
+		fmt.Fprintf(w, "FIELD:    %s <%s>\n\n", fieldName, propertyType(field))
+		fmt.Fprintln(w, "DESCRIPTION:")
+		printDescription(w, field.Description, 5)
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FIELDS:")
+	if recursive {
+		printFieldTree(w, schema, &model, 3, util.NewStringSet(model.Id))
+	} else {
+		printFields(w, &model)
+	}
+	return nil
+}
+
+// printFields prints the name, type and description of the direct fields of model.
+func printFields(w io.Writer, model *swagger.Model) {
+	required := util.NewStringSet(model.Required...)
+	for _, name := range sortedPropertyNames(model) {
+		prop, _ := model.Properties.At(name)
+		fmt.Fprintf(w, "   %s\t<%s>%s\n", name, propertyType(&prop), requiredSuffix(required, name))
+		printDescription(w, prop.Description, 5)
+		fmt.Fprintln(w)
+	}
+}
+
+// printFieldTree prints the names and types of every field below model.
+// Models already being printed further up the tree are not expanded again,
+// so self referencing types terminate.
+func printFieldTree(w io.Writer, schema *swagger.ApiDeclaration, model *swagger.Model, indent int, visiting util.StringSet) {
+	required := util.NewStringSet(model.Required...)
+	for _, name := range sortedPropertyNames(model) {
+		prop, _ := model.Properties.At(name)
+		fmt.Fprintf(w, "%s%s\t<%s>%s\n", strings.Repeat(" ", indent), name, propertyType(&prop), requiredSuffix(required, name))
+		nested, ok := propertyModel(schema, &prop)
+		if !ok || visiting.Has(nested.Id) {
+			continue
+		}
+		visiting.Insert(nested.Id)
+		printFieldTree(w, schema, &nested, indent+3, visiting)
+		visiting.Delete(nested.Id)
+	}
+}
+
+// propertyModel returns the model a property refers to, directly or as the
+// element type of an array, and whether there was one.
+func propertyModel(schema *swagger.ApiDeclaration, prop *swagger.ModelProperty) (swagger.Model, bool) {
+	var ref string
+	switch {
+	case prop.Ref != nil:
+		ref = *prop.Ref
+	case prop.Items != nil && prop.Items.Ref != nil:
+		ref = *prop.Items.Ref
+	default:
+		return swagger.Model{}, false
+	}
+	return schema.Models.At(ref)
+}
+
+// propertyType returns a short human readable type for a property.
+func propertyType(prop *swagger.ModelProperty) string {
+	switch {
+	case prop.Ref != nil:
+		return "Object"
+	case prop.Type == nil:
+		return "unknown"
+	case *prop.Type == "array" && prop.Items != nil:
+		if prop.Items.Ref != nil {
+			return "[]Object"
+		}
+		if prop.Items.Type != nil {
+			return "[]" + *prop.Items.Type
+		}
+	}
+	return *prop.Type
+}
+
+func requiredSuffix(required util.StringSet, name string) string {
+	if required.Has(name) {
+		return " -required-"
+	}
+	return ""
+}
+
+func sortedPropertyNames(model *swagger.Model) []string {
+	names := []string{}
+	for _, prop := range model.Properties.List {
+		names = append(names, prop.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printDescription prints desc indented and wrapped to explainWidth columns.
+func printDescription(w io.Writer, desc string, indent int) {
+	if len(desc) == 0 {
+		desc = "<empty>"
+	}
+	prefix := strings.Repeat(" ", indent)
+	line := ""
+	for _, word := range strings.Fields(desc) {
+		if len(line) > 0 && indent+len(line)+1+len(word) > explainWidth {
+			fmt.Fprintf(w, "%s%s\n", prefix, line)
+			line = ""
+		}
+		if len(line) > 0 {
+			line += " "
+		}
+		line += word
+	}
+	fmt.Fprintf(w, "%s%s\n", prefix, line)
+}
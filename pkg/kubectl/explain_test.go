@@ -0,0 +1,150 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful/swagger"
+
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/testapi"
+)
+
+func loadSchemaForTest(t *testing.T) *swagger.ApiDeclaration {
+	data, err := ioutil.ReadFile("../../api/swagger-spec/" + testapi.Version() + ".json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := &swagger.ApiDeclaration{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestSplitAndParseResourceRequest(t *testing.T) {
+	resource, fields, err := SplitAndParseResourceRequest("pods.spec.containers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resource != "pods" || len(fields) != 2 || fields[0] != "spec" || fields[1] != "containers" {
+		t.Errorf("unexpected result: %s %v", resource, fields)
+	}
+	if _, _, err := SplitAndParseResourceRequest("pods..spec"); err == nil {
+		t.Errorf("expected an error for an empty field name")
+	}
+}
+
+func TestPrintModelDescription(t *testing.T) {
+	schema := loadSchemaForTest(t)
+	mapping, err := latest.RESTMapper.RESTMapping("Pod", testapi.Version())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		fields    []string
+		recursive bool
+		contains  []string
+		excludes  []string
+		expectErr bool
+	}{
+		{
+			name:     "resource",
+			contains: []string{"KIND:     Pod", "FIELDS:", "   spec\t<Object>", "   metadata\t<Object>"},
+			excludes: []string{"RESOURCE:", "containers"},
+		},
+		{
+			name:     "object field",
+			fields:   []string{"spec", "containers", "livenessProbe"},
+			contains: []string{"RESOURCE: livenessProbe <Object>", "periodic probe of container liveness", "   httpGet\t<Object>", "   initialDelaySeconds\t<integer>"},
+			excludes: []string{"      host\t<string>"},
+		},
+		{
+			name:     "required fields",
+			fields:   []string{"spec"},
+			contains: []string{"   containers\t<[]Object> -required-"},
+		},
+		{
+			name:     "primitive field",
+			fields:   []string{"spec", "containers", "image"},
+			contains: []string{"FIELD:    image <string>", "DESCRIPTION:"},
+			excludes: []string{"FIELDS:"},
+		},
+		{
+			name:      "recursive",
+			fields:    []string{"spec", "containers", "livenessProbe"},
+			recursive: true,
+			contains:  []string{"   httpGet\t<Object>\n      host\t<string>"},
+			excludes:  []string{"HTTP-based handler"},
+		},
+		{
+			name:      "missing field",
+			fields:    []string{"spec", "foo"},
+			expectErr: true,
+		},
+		{
+			name:      "field of a primitive",
+			fields:    []string{"spec", "containers", "image", "foo"},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		err := PrintModelDescription(mapping, test.fields, buf, schema, test.recursive)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: unexpected non-error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		out := buf.String()
+		for _, s := range test.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: expected output to contain %q:\n%s", test.name, s, out)
+			}
+		}
+		for _, s := range test.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%s: expected output not to contain %q:\n%s", test.name, s, out)
+			}
+		}
+	}
+}
+
+func TestPrintModelDescriptionWraps(t *testing.T) {
+	buf := &bytes.Buffer{}
+	printDescription(buf, strings.Repeat("word ", 40), 5)
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		if len(line) > explainWidth {
+			t.Errorf("line longer than %d columns: %q", explainWidth, line)
+		}
+		if !strings.HasPrefix(line, "     word") {
+			t.Errorf("unexpected indentation: %q", line)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// NewDiscoveryRESTMapper returns a RESTMapper for the API groups the server
+// reports through discovery, searched in the order the server lists them. Groups
+// with a RESTMapper in known use it. The kinds of every other group are mapped
+// from the resources the server lists for them, and their objects are handled as
+// runtime.Unstructured; delegate decodes everything else those groups return,
+// such as Status.
+func NewDiscoveryRESTMapper(discovery client.DiscoveryInterface, known map[string]meta.RESTMapper, delegate runtime.Codec) (meta.GroupRESTMapper, error) {
+	groups, err := discovery.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	mapper := meta.GroupRESTMapper{}
+	for _, group := range groups.Groups {
+		groupMapper, ok := known[group.Name]
+		if !ok {
+			if groupMapper, err = newUnstructuredRESTMapper(discovery, group, delegate); err != nil {
+				return nil, err
+			}
+		}
+		mapper = append(mapper, meta.GroupMapper{Group: group.Name, RESTMapper: groupMapper})
+	}
+	return mapper, nil
+}
+
+// newUnstructuredRESTMapper maps the kinds served in each version of group, with
+// the preferred version as the default.
+func newUnstructuredRESTMapper(discovery client.ServerResourcesInterface, group api.APIGroup, delegate runtime.Codec) (meta.RESTMapper, error) {
+	groupVersions := []api.GroupVersion{}
+	if len(group.PreferredVersion.Version) > 0 {
+		groupVersions = append(groupVersions, group.PreferredVersion)
+	}
+	for _, groupVersion := range group.Versions {
+		if groupVersion != group.PreferredVersion {
+			groupVersions = append(groupVersions, groupVersion)
+		}
+	}
+
+	versions := []string{}
+	for _, groupVersion := range groupVersions {
+		versions = append(versions, groupVersion.Version)
+	}
+	interfaces := &meta.VersionInterfaces{
+		Codec:            unstructuredCodec{delegate},
+		ObjectConvertor:  unstructuredConvertor{},
+		MetadataAccessor: meta.NewAccessor(),
+	}
+	mapper := meta.NewDefaultRESTMapper(versions, func(string) (*meta.VersionInterfaces, error) {
+		return interfaces, nil
+	})
+	for _, groupVersion := range groupVersions {
+		resources, err := discovery.ServerResourcesForGroupVersion(groupVersion.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources.APIResources {
+			// subresources such as pods/status are served by the kind of their parent
+			if strings.Contains(resource.Name, "/") || len(resource.Kind) == 0 {
+				continue
+			}
+			scope := meta.RESTScopeRoot
+			if resource.Namespaced {
+				scope = meta.RESTScopeNamespace
+			}
+			mapper.Add(scope, resource.Kind, groupVersion.Version, false)
+		}
+	}
+	return mapper, nil
+}
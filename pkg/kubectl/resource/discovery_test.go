@@ -0,0 +1,156 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+type fakeDiscovery struct {
+	groups    []api.APIGroup
+	resources map[string]*api.APIResourceList
+}
+
+func (d *fakeDiscovery) ServerGroups() (*api.APIGroupList, error) {
+	return &api.APIGroupList{Groups: d.groups}, nil
+}
+
+func (d *fakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*api.APIResourceList, error) {
+	resources, ok := d.resources[groupVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown group version %q", groupVersion)
+	}
+	return resources, nil
+}
+
+func (d *fakeDiscovery) ServerResources() (map[string]*api.APIResourceList, error) {
+	return d.resources, nil
+}
+
+func TestDiscoveryRESTMapper(t *testing.T) {
+	discovery := &fakeDiscovery{
+		groups: []api.APIGroup{
+			{
+				Versions: []api.GroupVersion{{GroupVersion: "v1", Version: "v1"}},
+			},
+			{
+				Name: "company.com",
+				Versions: []api.GroupVersion{
+					{GroupVersion: "company.com/v1", Version: "v1"},
+					{GroupVersion: "company.com/v2", Version: "v2"},
+				},
+				PreferredVersion: api.GroupVersion{GroupVersion: "company.com/v2", Version: "v2"},
+			},
+		},
+		resources: map[string]*api.APIResourceList{
+			"company.com/v1": {
+				GroupVersion: "company.com/v1",
+				APIResources: []api.APIResource{
+					{Name: "crontabs", Namespaced: true, Kind: "CronTab"},
+					{Name: "crontabs/status", Namespaced: true, Kind: "CronTab"},
+				},
+			},
+			"company.com/v2": {
+				GroupVersion: "company.com/v2",
+				APIResources: []api.APIResource{
+					{Name: "crontabs", Namespaced: true, Kind: "CronTab"},
+					{Name: "schedules", Namespaced: false, Kind: "Schedule"},
+				},
+			},
+		},
+	}
+	mapper, err := NewDiscoveryRESTMapper(discovery, map[string]meta.RESTMapper{"": latest.RESTMapper}, latest.Codec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mapping, err := mapper.RESTMapping("Pod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Group != "" || mapping.Resource != "pods" {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+
+	version, kind, err := mapper.VersionAndKindForResource("crontabs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "company.com/v2" || kind != "CronTab" {
+		t.Errorf("unexpected version and kind: %s %s", version, kind)
+	}
+	mapping, err = mapper.RESTMapping("CronTab", "company.com/v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Resource != "crontabs" || mapping.GroupVersion() != "company.com/v1" || mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+	mapping, err = mapper.RESTMapping("Schedule")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.GroupVersion() != "company.com/v2" || mapping.Scope.Name() != meta.RESTScopeNameRoot {
+		t.Errorf("unexpected mapping: %#v", mapping)
+	}
+	if _, err := mapper.RESTMapping("Schedule", "company.com/v1"); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+
+	obj, err := mapping.Codec.Decode([]byte(`{"kind":"Schedule","apiVersion":"company.com/v2","metadata":{"name":"foo"},"spec":"* * * * *"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := obj.(*runtime.Unstructured); !ok {
+		t.Fatalf("expected an unstructured object, got %#v", obj)
+	}
+	name, err := mapping.MetadataAccessor.Name(obj)
+	if err != nil || name != "foo" {
+		t.Errorf("unexpected name %q: %v", name, err)
+	}
+	if _, err := mapping.Codec.Encode(obj); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	status, err := mapping.Codec.Decode([]byte(`{"kind":"Status","apiVersion":"` + latest.Version + `","status":"Failure","code":404}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := status.(*api.Status); !ok {
+		t.Errorf("expected a status, got %#v", status)
+	}
+}
+
+func TestDiscoveryRESTMapperError(t *testing.T) {
+	discovery := &fakeDiscovery{
+		groups: []api.APIGroup{
+			{
+				Name:     "company.com",
+				Versions: []api.GroupVersion{{GroupVersion: "company.com/v1", Version: "v1"}},
+			},
+		},
+	}
+	if _, err := NewDiscoveryRESTMapper(discovery, nil, latest.Codec); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+}
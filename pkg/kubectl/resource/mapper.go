@@ -48,13 +48,15 @@ func (m *Mapper) InfoForData(data []byte, source string) (*Info, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to get type info from %q: %v", source, err)
 	}
-	if ok := registered.IsRegisteredAPIVersion(version); !ok {
-		return nil, fmt.Errorf("API version %q in %q isn't supported, only supports API versions %q", version, source, registered.RegisteredVersions)
-	}
 	if kind == "" {
 		return nil, fmt.Errorf("kind not set in %q", source)
 	}
 	mapping, err := m.RESTMapping(kind, version)
+	// versions outside of the registered ones are accepted only when the mapper
+	// serves them directly, as it does for the versions of API groups
+	if ok := registered.IsRegisteredAPIVersion(version); !ok && (err != nil || mapping.GroupVersion() != version) {
+		return nil, fmt.Errorf("API version %q in %q isn't supported, only supports API versions %q", version, source, registered.RegisteredVersions)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to recognize %q: %v", source, err)
 	}
@@ -0,0 +1,90 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// unstructuredCodec decodes objects of group qualified API versions, which have
+// no registered types on the client, into runtime.Unstructured. Everything else
+// (such as the Status returned for errors) is handled by its delegate.
+type unstructuredCodec struct {
+	delegate runtime.Codec
+}
+
+func (c unstructuredCodec) Decode(data []byte) (runtime.Object, error) {
+	version, _, err := runtime.UnstructuredJSONScheme.DataVersionAndKind(data)
+	if group, _ := meta.SplitGroupVersion(version); err == nil && len(group) > 0 {
+		return runtime.UnstructuredJSONScheme.Decode(data)
+	}
+	return c.delegate.Decode(data)
+}
+
+func (c unstructuredCodec) DecodeInto(data []byte, obj runtime.Object) error {
+	if _, ok := obj.(*runtime.Unstructured); ok {
+		return runtime.UnstructuredJSONScheme.DecodeInto(data, obj)
+	}
+	return c.delegate.DecodeInto(data, obj)
+}
+
+func (c unstructuredCodec) DecodeIntoWithSpecifiedVersionKind(data []byte, obj runtime.Object, kind, version string) error {
+	if _, ok := obj.(*runtime.Unstructured); ok {
+		return runtime.UnstructuredJSONScheme.DecodeInto(data, obj)
+	}
+	return c.delegate.DecodeIntoWithSpecifiedVersionKind(data, obj, kind, version)
+}
+
+func (c unstructuredCodec) Encode(obj runtime.Object) ([]byte, error) {
+	if unstructured, ok := obj.(*runtime.Unstructured); ok {
+		return json.Marshal(unstructured.Object)
+	}
+	return c.delegate.Encode(obj)
+}
+
+// unstructuredConvertor implements runtime.ObjectConvertor for objects that
+// have no internal representation and are never converted.
+type unstructuredConvertor struct{}
+
+func (unstructuredConvertor) Convert(in, out interface{}) error {
+	return fmt.Errorf("unstructured objects cannot be converted")
+}
+
+func (unstructuredConvertor) ConvertToVersion(in runtime.Object, outVersion string) (runtime.Object, error) {
+	return in, nil
+}
+
+func (unstructuredConvertor) ConvertFieldLabel(version, kind, label, value string) (string, string, error) {
+	return label, value, nil
+}
+
+// UnstructuredObjectTyper returns the version and kind recorded on
+// runtime.Unstructured objects, and defers to the wrapped typer otherwise.
+type UnstructuredObjectTyper struct {
+	runtime.ObjectTyper
+}
+
+func (t UnstructuredObjectTyper) ObjectVersionAndKind(obj runtime.Object) (string, string, error) {
+	if unstructured, ok := obj.(*runtime.Unstructured); ok {
+		return unstructured.APIVersion, unstructured.Kind, nil
+	}
+	return t.ObjectTyper.ObjectVersionAndKind(obj)
+}
@@ -182,6 +182,40 @@ func TestPrintBadTemplateFile(t *testing.T) {
 	}
 }
 
+func TestPrintJSONPath(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	printer, found, err := GetPrinter("jsonpath", "{.metadata.name}")
+	if err != nil || !found {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	unversionedPod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}
+	obj, err := api.Scheme.ConvertToVersion(unversionedPod, testapi.Version())
+	for i := 0; i < 2; i++ {
+		buf.Reset()
+		if err := printer.PrintObj(obj, buf); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if buf.String() != "foo" {
+			t.Errorf("unexpected output: %s", buf.String())
+		}
+	}
+}
+
+func TestPrintEmptyJSONPath(t *testing.T) {
+	if _, _, err := GetPrinter("jsonpath", ""); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+	if _, _, err := GetPrinter("jsonpath-file", ""); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+}
+
+func TestPrintBadJSONPath(t *testing.T) {
+	if _, _, err := GetPrinter("jsonpath", "{.metadata.name"); err == nil {
+		t.Errorf("unexpected non-error")
+	}
+}
+
 func testPrinter(t *testing.T, printer ResourcePrinter, unmarshalFunc func(data []byte, v interface{}) error) {
 	buf := bytes.NewBuffer([]byte{})
 
@@ -450,6 +484,14 @@ func TestPrinters(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	jsonpathPrinter, err := NewJSONPathPrinter("{.metadata.name}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	customColumnsPrinter, err := NewCustomColumnsPrinterFromSpec("NAME:.metadata.name")
+	if err != nil {
+		t.Fatal(err)
+	}
 	printers := map[string]ResourcePrinter{
 		"humanReadable":        NewHumanReadablePrinter(true, false, false, []string{}),
 		"humanReadableHeaders": NewHumanReadablePrinter(false, false, false, []string{}),
@@ -457,6 +499,8 @@ func TestPrinters(t *testing.T) {
 		"yaml":                 &YAMLPrinter{},
 		"template":             templatePrinter,
 		"template2":            templatePrinter2,
+		"jsonpath":             jsonpathPrinter,
+		"customColumns":        customColumnsPrinter,
 	}
 	objects := map[string]runtime.Object{
 		"pod":             &api.Pod{ObjectMeta: om("pod")},
@@ -471,6 +515,7 @@ func TestPrinters(t *testing.T) {
 	// map of printer name to set of objects it should fail on.
 	expectedErrors := map[string]util.StringSet{
 		"template2": util.NewStringSet("pod", "emptyPodList", "endpoints"),
+		"jsonpath":  util.NewStringSet("emptyPodList", "nonEmptyPodList", "endpoints"),
 	}
 
 	for pName, p := range printers {
@@ -1098,3 +1143,26 @@ func TestPrintPodWithLabels(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestPrintUnstructured(t *testing.T) {
+	list := &runtime.Unstructured{
+		TypeMeta: runtime.TypeMeta{Kind: "CronTabList", APIVersion: "company.com/v1"},
+		Object: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"kind":       "CronTab",
+					"apiVersion": "company.com/v1",
+					"metadata":   map[string]interface{}{"name": "foo", "namespace": "bar", "labels": map[string]interface{}{"a": "b"}},
+				},
+			},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	printer := NewHumanReadablePrinter(true, true, false, nil)
+	if err := printer.PrintObj(list, buffer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []string{"bar", "foo", "CronTab", "a=b"}, strings.Fields(buffer.String()); strings.Join(e, " ") != strings.Join(a, " ") {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
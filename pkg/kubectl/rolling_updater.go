@@ -77,6 +77,9 @@ type RollingUpdaterConfig struct {
 	// scaled up and down each interval. If UpdatePercent is negative, the order
 	// of scaling will be down/up instead of up/down.
 	UpdatePercent *int
+	// ChangeCause is recorded on the new controller as the reason for its
+	// revision, typically the command that started the update.
+	ChangeCause string
 }
 
 // RollingUpdaterCleanupPolicy is a cleanup action to take after the
@@ -403,6 +406,9 @@ func (r *RollingUpdater) Update(config *RollingUpdaterConfig) error {
 		}
 		newRc.ObjectMeta.Annotations[desiredReplicasAnnotation] = fmt.Sprintf("%d", desired)
 		newRc.ObjectMeta.Annotations[sourceIdAnnotation] = sourceId
+		if err := recordRollout(oldRc, newRc, config.ChangeCause); err != nil {
+			return err
+		}
 		newRc.Spec.Replicas = 0
 		newRc, err = r.c.CreateReplicationController(r.ns, newRc)
 		if err != nil {
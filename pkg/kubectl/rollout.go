@@ -0,0 +1,310 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	// revisionAnnotation records the rollout revision of a controller created
+	// by a rolling update.
+	revisionAnnotation = kubectlAnnotationPrefix + "revision"
+	// changeCauseAnnotation records the command that created the revision.
+	changeCauseAnnotation = kubectlAnnotationPrefix + "change-cause"
+	// rolloutHistoryAnnotation holds the pod templates of earlier revisions,
+	// since the controllers that ran them are deleted once an update completes.
+	rolloutHistoryAnnotation = kubectlAnnotationPrefix + "rollout-history"
+
+	// rolloutHistoryLimit is the number of earlier revisions kept on a controller.
+	rolloutHistoryLimit = 10
+)
+
+// RolloutRevision is a single entry in the rollout history of a replication
+// controller.
+type RolloutRevision struct {
+	Revision    int64
+	ChangeCause string
+	Template    *api.PodTemplateSpec
+}
+
+// RolloutHistory returns the recorded revisions of rc, oldest first. The last
+// entry is the revision rc is currently running. A controller that has never
+// been rolled out is reported as revision 1.
+func RolloutHistory(rc *api.ReplicationController) ([]RolloutRevision, error) {
+	revisions := []RolloutRevision{}
+	if data, found := rc.Annotations[rolloutHistoryAnnotation]; found {
+		obj, err := latest.Codec.Decode([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the rollout history of %s: %v", rc.Name, err)
+		}
+		list, ok := obj.(*api.PodTemplateList)
+		if !ok {
+			return nil, fmt.Errorf("unexpected rollout history for %s: %T", rc.Name, obj)
+		}
+		for i := range list.Items {
+			revision, err := revisionOf(&list.Items[i].ObjectMeta)
+			if err != nil {
+				return nil, err
+			}
+			revisions = append(revisions, RolloutRevision{
+				Revision:    revision,
+				ChangeCause: list.Items[i].Annotations[changeCauseAnnotation],
+				Template:    &list.Items[i].Template,
+			})
+		}
+	}
+	revision, err := revisionOf(&rc.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	revisions = append(revisions, RolloutRevision{
+		Revision:    revision,
+		ChangeCause: rc.Annotations[changeCauseAnnotation],
+		Template:    rc.Spec.Template,
+	})
+	sort.Sort(byRevision(revisions))
+	return revisions, nil
+}
+
+// FindRolloutRevision returns the entry of history for revision. A revision of
+// 0 selects the revision that ran before the current one.
+func FindRolloutRevision(history []RolloutRevision, revision int64) (*RolloutRevision, error) {
+	if revision == 0 {
+		if len(history) < 2 {
+			return nil, fmt.Errorf("no rollout history found")
+		}
+		return &history[len(history)-2], nil
+	}
+	for i := range history {
+		if history[i].Revision == revision {
+			return &history[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find revision %d", revision)
+}
+
+// DescribeRolloutHistory lists the revisions recorded for the named controller.
+func DescribeRolloutHistory(name string, history []RolloutRevision) (string, error) {
+	return tabbedString(func(out io.Writer) error {
+		fmt.Fprintf(out, "replicationcontrollers %q:\n", name)
+		fmt.Fprintf(out, "REVISION\tCHANGE-CAUSE\n")
+		for _, entry := range history {
+			changeCause := entry.ChangeCause
+			if len(changeCause) == 0 {
+				changeCause = "<none>"
+			}
+			fmt.Fprintf(out, "%d\t%s\n", entry.Revision, changeCause)
+		}
+		return nil
+	})
+}
+
+// DescribeRolloutRevision describes the pod template of a single revision of
+// the named controller.
+func DescribeRolloutRevision(name string, revision *RolloutRevision) (string, error) {
+	return tabbedString(func(out io.Writer) error {
+		fmt.Fprintf(out, "replicationcontrollers %q with revision #%d\n", name, revision.Revision)
+		changeCause := revision.ChangeCause
+		if len(changeCause) == 0 {
+			changeCause = "<none>"
+		}
+		fmt.Fprintf(out, "Change Cause:\t%s\n", changeCause)
+		if revision.Template == nil {
+			fmt.Fprintf(out, "Image(s):\t%s\n", "<no template>")
+			return nil
+		}
+		fmt.Fprintf(out, "Labels:\t%s\n", formatLabels(revision.Template.Labels))
+		fmt.Fprintf(out, "Image(s):\t%s\n", makeImageList(&revision.Template.Spec))
+		return nil
+	})
+}
+
+// revisionOf parses the revision annotation of meta, defaulting to 1.
+func revisionOf(meta *api.ObjectMeta) (int64, error) {
+	value, found := meta.Annotations[revisionAnnotation]
+	if !found {
+		return 1, nil
+	}
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse annotation for %s: %s=%s", meta.Name, revisionAnnotation, value)
+	}
+	return revision, nil
+}
+
+// recordRollout annotates newRc as the revision following oldRc, carrying the
+// history of oldRc forward. Earlier revisions running the same pod spec as
+// newRc are dropped, so rolling back to a revision moves it to the front.
+func recordRollout(oldRc, newRc *api.ReplicationController, changeCause string) error {
+	history, err := RolloutHistory(oldRc)
+	if err != nil {
+		return err
+	}
+	next := history[len(history)-1].Revision + 1
+
+	var newTemplate *api.PodTemplateSpec
+	if newRc.Spec.Template != nil {
+		if newTemplate, err = defaultedTemplate(newRc.Spec.Template); err != nil {
+			return err
+		}
+	}
+	list := &api.PodTemplateList{}
+	for _, entry := range history {
+		if entry.Template == nil {
+			continue
+		}
+		if newTemplate != nil {
+			template, err := defaultedTemplate(entry.Template)
+			if err != nil {
+				return err
+			}
+			if api.Semantic.DeepEqual(template.Spec, newTemplate.Spec) {
+				continue
+			}
+		}
+		template := api.PodTemplate{Template: *entry.Template}
+		template.Annotations = map[string]string{
+			revisionAnnotation: strconv.FormatInt(entry.Revision, 10),
+		}
+		if len(entry.ChangeCause) != 0 {
+			template.Annotations[changeCauseAnnotation] = entry.ChangeCause
+		}
+		list.Items = append(list.Items, template)
+	}
+	if len(list.Items) > rolloutHistoryLimit {
+		list.Items = list.Items[len(list.Items)-rolloutHistoryLimit:]
+	}
+
+	if newRc.Annotations == nil {
+		newRc.Annotations = map[string]string{}
+	}
+	newRc.Annotations[revisionAnnotation] = strconv.FormatInt(next, 10)
+	if len(changeCause) != 0 {
+		newRc.Annotations[changeCauseAnnotation] = changeCause
+	} else {
+		delete(newRc.Annotations, changeCauseAnnotation)
+	}
+	if len(list.Items) == 0 {
+		delete(newRc.Annotations, rolloutHistoryAnnotation)
+		return nil
+	}
+	data, err := latest.Codec.Encode(list)
+	if err != nil {
+		return err
+	}
+	newRc.Annotations[rolloutHistoryAnnotation] = string(data)
+	return nil
+}
+
+// defaultedTemplate round trips template through the codec used for the
+// history, so that templates read back from it compare equal to live ones.
+func defaultedTemplate(template *api.PodTemplateSpec) (*api.PodTemplateSpec, error) {
+	data, err := latest.Codec.Encode(&api.PodTemplate{Template: *template})
+	if err != nil {
+		return nil, err
+	}
+	obj, err := latest.Codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return &obj.(*api.PodTemplate).Template, nil
+}
+
+type byRevision []RolloutRevision
+
+func (r byRevision) Len() int           { return len(r) }
+func (r byRevision) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byRevision) Less(i, j int) bool { return r[i].Revision < r[j].Revision }
+
+// CreateControllerForRevision returns a controller that replaces oldRc with
+// the pod template of revision. The controller is named and labeled the same
+// way as CreateNewControllerFromCurrentController names a controller for a new
+// image, so it can be rolled out with a RollingUpdater.
+func CreateControllerForRevision(oldRc *api.ReplicationController, revision *RolloutRevision, codec runtime.Codec, deploymentKey string) (*api.ReplicationController, error) {
+	if revision.Template == nil {
+		return nil, fmt.Errorf("revision %d of %s has no pod template", revision.Revision, oldRc.Name)
+	}
+	obj, err := api.Scheme.Copy(oldRc)
+	if err != nil {
+		return nil, err
+	}
+	newRc := obj.(*api.ReplicationController)
+	template, err := api.Scheme.Copy(&api.PodTemplate{Template: *revision.Template})
+	if err != nil {
+		return nil, err
+	}
+	newRc.Spec.Template = &template.(*api.PodTemplate).Template
+
+	newHash, err := api.HashObject(newRc, codec)
+	if err != nil {
+		return nil, err
+	}
+	newRc.Name = fmt.Sprintf("%s-%s", oldRc.Name, newHash)
+
+	if newRc.Spec.Selector == nil {
+		newRc.Spec.Selector = map[string]string{}
+	}
+	if newRc.Spec.Template.Labels == nil {
+		newRc.Spec.Template.Labels = map[string]string{}
+	}
+	newRc.Spec.Selector[deploymentKey] = newHash
+	newRc.Spec.Template.Labels[deploymentKey] = newHash
+	newRc.ResourceVersion = ""
+	return newRc, nil
+}
+
+// RolloutStatus reports the progress of the latest rollout of the named
+// replication controller. The rollout is done once no rolling update is in
+// flight and the controller reports its desired number of replicas.
+func RolloutStatus(c client.Interface, namespace, name string) (status string, done bool, err error) {
+	rc, err := c.ReplicationControllers(namespace).Get(name)
+	if err != nil {
+		return "", false, err
+	}
+	// An update in progress (or one that preserved the old controller) is
+	// tracked on the controller it rolls out to.
+	if next, found := GetNextControllerAnnotation(rc); found && next != rc.Name {
+		nextRc, err := c.ReplicationControllers(namespace).Get(next)
+		switch {
+		case err == nil:
+			rc = nextRc
+		case !errors.IsNotFound(err):
+			return "", false, err
+		}
+	}
+	if desired, found := rc.Annotations[desiredReplicasAnnotation]; found {
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %s new replicas have been created...\n", rc.Status.Replicas, desired), false, nil
+	}
+	done, err = client.ControllerHasDesiredReplicas(c, rc)()
+	if err != nil {
+		return "", false, err
+	}
+	if !done {
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d replicas have been created...\n", rc.Status.Replicas, rc.Spec.Replicas), false, nil
+	}
+	return fmt.Sprintf("replication controller %q successfully rolled out\n", rc.Name), true, nil
+}
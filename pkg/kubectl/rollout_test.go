@@ -0,0 +1,286 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/client/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+func imageRc(name, image string) *api.ReplicationController {
+	return &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 1,
+			Selector: map[string]string{"app": "foo"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec: api.PodSpec{
+					Containers: []api.Container{{Name: "foo", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func historyRevisions(t *testing.T, rc *api.ReplicationController) ([]int64, []string) {
+	history, err := RolloutHistory(rc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	revisions, images := []int64{}, []string{}
+	for _, entry := range history {
+		revisions = append(revisions, entry.Revision)
+		images = append(images, entry.Template.Spec.Containers[0].Image)
+	}
+	return revisions, images
+}
+
+func TestRolloutHistory(t *testing.T) {
+	rc := imageRc("foo", "foo:v1")
+	revisions, images := historyRevisions(t, rc)
+	if !api.Semantic.DeepEqual(revisions, []int64{1}) || !api.Semantic.DeepEqual(images, []string{"foo:v1"}) {
+		t.Fatalf("unexpected history of a new controller: %v %v", revisions, images)
+	}
+
+	for _, image := range []string{"foo:v2", "foo:v3"} {
+		next := imageRc("foo", image)
+		if err := recordRollout(rc, next, "update to "+image); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rc = next
+	}
+	revisions, images = historyRevisions(t, rc)
+	if !api.Semantic.DeepEqual(revisions, []int64{1, 2, 3}) || !api.Semantic.DeepEqual(images, []string{"foo:v1", "foo:v2", "foo:v3"}) {
+		t.Fatalf("unexpected history: %v %v", revisions, images)
+	}
+	if rc.Annotations[changeCauseAnnotation] != "update to foo:v3" {
+		t.Errorf("unexpected change cause: %v", rc.Annotations)
+	}
+
+	// Rolling back to revision 1 moves it to the front of the history.
+	history, _ := RolloutHistory(rc)
+	revision, err := FindRolloutRevision(history, 0)
+	if err != nil || revision.Revision != 2 {
+		t.Fatalf("expected the previous revision to be 2, got %v %v", revision, err)
+	}
+	revision, err = FindRolloutRevision(history, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	next := imageRc("foo", "foo:v1")
+	if err := recordRollout(rc, next, revision.ChangeCause); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	revisions, images = historyRevisions(t, next)
+	if !api.Semantic.DeepEqual(revisions, []int64{2, 3, 4}) || !api.Semantic.DeepEqual(images, []string{"foo:v2", "foo:v3", "foo:v1"}) {
+		t.Fatalf("unexpected history after rollback: %v %v", revisions, images)
+	}
+	if _, found := next.Annotations[changeCauseAnnotation]; found {
+		t.Errorf("expected no change cause, got %v", next.Annotations)
+	}
+	if _, err := FindRolloutRevision(history, 7); err == nil {
+		t.Errorf("expected an error for a missing revision")
+	}
+}
+
+func TestRolloutHistoryLimit(t *testing.T) {
+	rc := imageRc("foo", "foo:v0")
+	for i := 1; i <= rolloutHistoryLimit+5; i++ {
+		next := imageRc("foo", fmt.Sprintf("foo:v%d", i))
+		if err := recordRollout(rc, next, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rc = next
+	}
+	revisions, _ := historyRevisions(t, rc)
+	if len(revisions) != rolloutHistoryLimit+1 {
+		t.Fatalf("expected %d revisions, got %v", rolloutHistoryLimit+1, revisions)
+	}
+	if last := revisions[len(revisions)-1]; last != rolloutHistoryLimit+6 {
+		t.Errorf("expected the current revision to be %d, got %d", rolloutHistoryLimit+6, last)
+	}
+}
+
+func TestRollingUpdaterRecordsRevision(t *testing.T) {
+	oldRc := imageRc("foo", "foo:v1")
+	newRc := imageRc("foo-v2", "foo:v2")
+	newRc.Spec.Selector["version"] = "v2"
+	newRc.Spec.Template.Labels["version"] = "v2"
+
+	var created *api.ReplicationController
+	client := &rollingUpdaterClientImpl{
+		GetReplicationControllerFn: func(namespace, name string) (*api.ReplicationController, error) {
+			if name == newRc.Name && created != nil {
+				return created, nil
+			}
+			return nil, errors.NewNotFound("replicationControllers", name)
+		},
+		UpdateReplicationControllerFn: func(namespace string, rc *api.ReplicationController) (*api.ReplicationController, error) {
+			return rc, nil
+		},
+		CreateReplicationControllerFn: func(namespace string, rc *api.ReplicationController) (*api.ReplicationController, error) {
+			created = rc
+			return rc, nil
+		},
+		DeleteReplicationControllerFn: func(namespace, name string) error {
+			return nil
+		},
+		ControllerHasDesiredReplicasFn: func(rc *api.ReplicationController) wait.ConditionFunc {
+			return func() (done bool, err error) {
+				return true, nil
+			}
+		},
+	}
+	updater := &RollingUpdater{
+		ns: "default",
+		c:  client,
+		scaleAndWait: func(rc *api.ReplicationController, retry *RetryParams, wait *RetryParams) (*api.ReplicationController, error) {
+			return rc, nil
+		},
+	}
+	config := &RollingUpdaterConfig{
+		Out:            ioutil.Discard,
+		OldRc:          oldRc,
+		NewRc:          newRc,
+		Interval:       time.Millisecond,
+		Timeout:        time.Millisecond,
+		CleanupPolicy:  PreserveRollingUpdateCleanupPolicy,
+		UpdateAcceptor: DefaultUpdateAcceptor,
+		ChangeCause:    "kubectl rolling-update foo --image=foo:v2",
+	}
+	if err := updater.Update(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created == nil {
+		t.Fatalf("expected the new controller to be created")
+	}
+	if created.Annotations[revisionAnnotation] != "2" {
+		t.Errorf("expected revision 2, got %v", created.Annotations)
+	}
+	if created.Annotations[changeCauseAnnotation] != config.ChangeCause {
+		t.Errorf("expected change cause %q, got %v", config.ChangeCause, created.Annotations)
+	}
+	revisions, images := historyRevisions(t, created)
+	if !api.Semantic.DeepEqual(revisions, []int64{1, 2}) || !api.Semantic.DeepEqual(images, []string{"foo:v1", "foo:v2"}) {
+		t.Errorf("unexpected history: %v %v", revisions, images)
+	}
+}
+
+func TestCreateControllerForRevision(t *testing.T) {
+	oldRc := imageRc("foo", "foo:v2")
+	oldRc.Spec.Selector["deployment"] = "old"
+	oldRc.Spec.Template.Labels["deployment"] = "old"
+	revision := &RolloutRevision{Revision: 1, Template: imageRc("foo", "foo:v1").Spec.Template}
+
+	newRc, err := CreateControllerForRevision(oldRc, revision, testapi.Codec(), "deployment")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hash := newRc.Spec.Selector["deployment"]
+	if len(hash) == 0 || hash == "old" {
+		t.Fatalf("expected a new deployment hash, got %v", newRc.Spec.Selector)
+	}
+	if newRc.Name != "foo-"+hash {
+		t.Errorf("unexpected name %s", newRc.Name)
+	}
+	if newRc.Spec.Template.Labels["deployment"] != hash || newRc.Spec.Template.Labels["app"] != "foo" {
+		t.Errorf("unexpected template labels %v", newRc.Spec.Template.Labels)
+	}
+	if image := newRc.Spec.Template.Spec.Containers[0].Image; image != "foo:v1" {
+		t.Errorf("expected image foo:v1, got %s", image)
+	}
+	if oldRc.Spec.Template.Spec.Containers[0].Image != "foo:v2" || revision.Template.Labels["deployment"] != "" {
+		t.Errorf("expected the inputs to be left unmodified")
+	}
+}
+
+func namedFake(objects ...*api.ReplicationController) *testclient.Fake {
+	return &testclient.Fake{
+		ReactFn: func(action testclient.Action) (runtime.Object, error) {
+			get, ok := action.(testclient.GetAction)
+			if !ok {
+				return nil, nil
+			}
+			for _, rc := range objects {
+				if rc.Name == get.GetName() {
+					return rc, nil
+				}
+			}
+			return (*api.ReplicationController)(nil), errors.NewNotFound("replicationControllers", get.GetName())
+		},
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	inProgress := imageRc("foo", "foo:v1")
+	SetNextControllerAnnotation(inProgress, "foo-next")
+	next := imageRc("foo-next", "foo:v2")
+	next.Annotations = map[string]string{desiredReplicasAnnotation: "3"}
+	next.Status.Replicas = 2
+
+	scaling := imageRc("foo", "foo:v1")
+	scaling.Spec.Replicas = 3
+	scaling.Status.Replicas = 1
+
+	finished := imageRc("foo", "foo:v2")
+	finished.Status.Replicas = 1
+
+	tests := []struct {
+		name    string
+		objects []*api.ReplicationController
+		status  string
+		done    bool
+	}{
+		{
+			name:    "update in progress",
+			objects: []*api.ReplicationController{inProgress, next},
+			status:  "2 of 3 new replicas",
+		},
+		{
+			name:    "replicas pending",
+			objects: []*api.ReplicationController{scaling},
+			status:  "1 of 3 replicas",
+		},
+		{
+			name:    "finished",
+			objects: []*api.ReplicationController{finished},
+			status:  "successfully rolled out",
+			done:    true,
+		},
+	}
+	for _, test := range tests {
+		status, done, err := RolloutStatus(namedFake(test.objects...), "default", "foo")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if done != test.done || !strings.Contains(status, test.status) {
+			t.Errorf("%s: expected %q (done=%t), got %q (done=%t)", test.name, test.status, test.done, status, done)
+		}
+	}
+}
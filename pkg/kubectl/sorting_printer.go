@@ -0,0 +1,183 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/jsonpath"
+)
+
+// SortingPrinter sorts list types before delegating to another printer.
+// Non-list types are simply passed through.
+type SortingPrinter struct {
+	SortField string
+	Delegate  ResourcePrinter
+}
+
+func (s *SortingPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	if !runtime.IsListType(obj) {
+		return s.Delegate.PrintObj(obj, out)
+	}
+
+	if err := s.sortObj(obj); err != nil {
+		return err
+	}
+	return s.Delegate.PrintObj(obj, out)
+}
+
+func (s *SortingPrinter) sortObj(obj runtime.Object) error {
+	objs, err := runtime.ExtractList(obj)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	sorter, err := SortObjects(objs, s.SortField)
+	if err != nil {
+		return err
+	}
+	return runtime.SetList(obj, sorter.objs)
+}
+
+// SortObjects sorts objs in place by the value of the given jsonpath field,
+// and returns a RuntimeSort that records where each object came from.
+func SortObjects(objs []runtime.Object, fieldInput string) (*RuntimeSort, error) {
+	field, err := massageJSONPath(fieldInput)
+	if err != nil {
+		return nil, err
+	}
+	parser := jsonpath.New("sorting")
+	if err := parser.Parse(field); err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(objs))
+	for ix := range objs {
+		data, err := json.Marshal(objs[ix])
+		if err != nil {
+			return nil, err
+		}
+		var content interface{}
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, err
+		}
+		// objects that do not have the field sort before the ones that do
+		results, err := parser.FindResults(content)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			continue
+		}
+		if len(results[0]) > 1 {
+			return nil, fmt.Errorf("%s matches more than one value, it cannot be used for sorting", fieldInput)
+		}
+		values[ix] = results[0][0].Interface()
+	}
+
+	sorter := NewRuntimeSort(values, objs)
+	sort.Stable(sorter)
+	return sorter, nil
+}
+
+// RuntimeSort is an implementation of the golang sort interface that knows how to sort
+// lists of runtime.Object
+type RuntimeSort struct {
+	values       []interface{}
+	objs         []runtime.Object
+	origPosition []int
+}
+
+func NewRuntimeSort(values []interface{}, objs []runtime.Object) *RuntimeSort {
+	sorter := &RuntimeSort{values: values, objs: objs, origPosition: make([]int, len(objs))}
+	for ix := range objs {
+		sorter.origPosition[ix] = ix
+	}
+	return sorter
+}
+
+func (r *RuntimeSort) Len() int {
+	return len(r.objs)
+}
+
+func (r *RuntimeSort) Swap(i, j int) {
+	r.objs[i], r.objs[j] = r.objs[j], r.objs[i]
+	r.values[i], r.values[j] = r.values[j], r.values[i]
+	r.origPosition[i], r.origPosition[j] = r.origPosition[j], r.origPosition[i]
+}
+
+func (r *RuntimeSort) Less(i, j int) bool {
+	return isLess(r.values[i], r.values[j])
+}
+
+// OriginalPosition returns the starting (original) position of a particular index.  e.g. If OriginalPosition(0) returns 5 than the
+// the item currently at position 0 was at position 5 in the original unsorted array.
+func (r *RuntimeSort) OriginalPosition(ix int) int {
+	if ix < 0 || ix >= len(r.origPosition) {
+		return -1
+	}
+	return r.origPosition[ix]
+}
+
+// isLess compares two values decoded from JSON. Strings that hold
+// timestamps or resource quantities are compared by what they represent,
+// values of different kinds are ordered by kind so that sorting is stable.
+func isLess(i, j interface{}) bool {
+	if i == nil || j == nil {
+		return i == nil && j != nil
+	}
+	switch a := i.(type) {
+	case string:
+		if b, ok := j.(string); ok {
+			return isLessString(a, b)
+		}
+	case float64:
+		if b, ok := j.(float64); ok {
+			return a < b
+		}
+	case bool:
+		if b, ok := j.(bool); ok {
+			return !a && b
+		}
+	}
+	// mismatched or composite values are ordered by their kind, then textually
+	ki, kj := reflect.ValueOf(i).Kind(), reflect.ValueOf(j).Kind()
+	if ki != kj {
+		return ki < kj
+	}
+	return fmt.Sprintf("%v", i) < fmt.Sprintf("%v", j)
+}
+
+func isLessString(a, b string) bool {
+	if ta, err := time.Parse(time.RFC3339, a); err == nil {
+		if tb, err := time.Parse(time.RFC3339, b); err == nil {
+			return ta.Before(tb)
+		}
+	}
+	if qa, err := resource.ParseQuantity(a); err == nil {
+		if qb, err := resource.ParseQuantity(b); err == nil {
+			return qa.Amount.Cmp(qb.Amount) < 0
+		}
+	}
+	return a < b
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+func TestSortingPrinter(t *testing.T) {
+	int64Ptr := func(val int64) *int64 { return &val }
+
+	a := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:              "a",
+			CreationTimestamp: util.Unix(300, 0),
+		},
+		Spec: api.PodSpec{
+			ActiveDeadlineSeconds: int64Ptr(20),
+			Containers: []api.Container{{
+				Resources: api.ResourceRequirements{
+					Limits: api.ResourceList{api.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+	}
+
+	b := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:              "b",
+			CreationTimestamp: util.Unix(100, 0),
+		},
+		Spec: api.PodSpec{
+			ActiveDeadlineSeconds: int64Ptr(5),
+			Containers: []api.Container{{
+				Resources: api.ResourceRequirements{
+					Limits: api.ResourceList{api.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			}},
+		},
+	}
+
+	c := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:              "c",
+			CreationTimestamp: util.Unix(200, 0),
+		},
+		Spec: api.PodSpec{
+			ActiveDeadlineSeconds: int64Ptr(100),
+			Containers: []api.Container{{
+				Resources: api.ResourceRequirements{
+					Limits: api.ResourceList{api.ResourceMemory: resource.MustParse("2G")},
+				},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		obj      runtime.Object
+		field    string
+		expected []string
+	}{
+		{
+			name:     "in-order-already",
+			obj:      &api.PodList{Items: []api.Pod{*a, *b, *c}},
+			field:    "{.metadata.name}",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "reverse-order",
+			obj:      &api.PodList{Items: []api.Pod{*c, *b, *a}},
+			field:    "{.metadata.name}",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "numbers",
+			obj:      &api.PodList{Items: []api.Pod{*a, *b, *c}},
+			field:    ".spec.activeDeadlineSeconds",
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name:     "timestamps",
+			obj:      &api.PodList{Items: []api.Pod{*a, *b, *c}},
+			field:    "metadata.creationTimestamp",
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			name:     "quantities",
+			obj:      &api.PodList{Items: []api.Pod{*c, *a, *b}},
+			field:    "{.spec.containers[0].resources.limits.memory}",
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name:     "missing-field",
+			obj:      &api.PodList{Items: []api.Pod{*a, *b, {ObjectMeta: api.ObjectMeta{Name: "d"}}}},
+			field:    ".spec.containers[0].image",
+			expected: []string{"d", "a", "b"},
+		},
+	}
+	for _, test := range tests {
+		sorter := &SortingPrinter{SortField: test.field}
+		if err := sorter.sortObj(test.obj); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		names := []string{}
+		for _, pod := range test.obj.(*api.PodList).Items {
+			names = append(names, pod.Name)
+		}
+		if !reflect.DeepEqual(test.expected, names) {
+			t.Errorf("%s: expected %v, saw %v", test.name, test.expected, names)
+		}
+	}
+}
+
+func TestSortingPrinterMultipleValues(t *testing.T) {
+	list := &api.PodList{Items: []api.Pod{
+		{Spec: api.PodSpec{Containers: []api.Container{{Name: "a"}, {Name: "b"}}}},
+		{Spec: api.PodSpec{Containers: []api.Container{{Name: "c"}}}},
+	}}
+	sorter := &SortingPrinter{SortField: ".spec.containers[*].name"}
+	if err := sorter.sortObj(list); err == nil {
+		t.Errorf("expected an error for a field matching several values")
+	}
+}
+
+func TestSortObjectsOriginalPosition(t *testing.T) {
+	objs := []runtime.Object{
+		&api.Pod{ObjectMeta: api.ObjectMeta{Name: "c"}},
+		&api.Pod{ObjectMeta: api.ObjectMeta{Name: "a"}},
+		&api.Pod{ObjectMeta: api.ObjectMeta{Name: "b"}},
+	}
+	sorter, err := SortObjects(objs, ".metadata.name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	positions := []int{sorter.OriginalPosition(0), sorter.OriginalPosition(1), sorter.OriginalPosition(2)}
+	if !reflect.DeepEqual(positions, []int{1, 2, 0}) {
+		t.Errorf("unexpected original positions: %v", positions)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"net/http"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/auth/user"
+)
+
+// AuthInterface contains all methods required by the auth filters of the
+// kubelet server.
+type AuthInterface interface {
+	authenticator.Request
+	authorizer.Authorizer
+	// GetRequestAttributes returns the attributes the authorizer decides on
+	// for a request made by the given user.
+	GetRequestAttributes(u user.Info, req *http.Request) authorizer.Attributes
+}
+
+// kubeletAuth implements AuthInterface.
+type kubeletAuth struct {
+	authenticator.Request
+	authorizer.Authorizer
+	nodeName string
+}
+
+// NewKubeletAuth returns an AuthInterface that authenticates requests with the
+// given authenticator and authorizes them as actions on subresources of the
+// node object of nodeName.
+func NewKubeletAuth(authenticator authenticator.Request, authorizer authorizer.Authorizer, nodeName string) AuthInterface {
+	return &kubeletAuth{authenticator, authorizer, nodeName}
+}
+
+// GetRequestAttributes maps the HTTP method of a request to a verb and its
+// path to a subresource of the node: /stats, /spec and /metrics are "stats",
+// /logs is "log" and everything else, including the endpoints that run
+// commands in containers, is "proxy".
+func (a *kubeletAuth) GetRequestAttributes(u user.Info, req *http.Request) authorizer.Attributes {
+	verb := methodToVerb(req.Method)
+	return authorizer.AttributesRecord{
+		User:        u,
+		ReadOnly:    verb == "get",
+		Verb:        verb,
+		Resource:    "nodes",
+		Subresource: pathToSubresource(req.URL.Path),
+		Name:        a.nodeName,
+	}
+}
+
+func methodToVerb(method string) string {
+	switch method {
+	case "POST":
+		return "create"
+	case "PUT":
+		return "update"
+	case "PATCH":
+		return "patch"
+	case "DELETE":
+		return "delete"
+	}
+	return "get"
+}
+
+func pathToSubresource(path string) string {
+	switch {
+	case path == "/metrics", strings.HasPrefix(path, "/stats/"), strings.HasPrefix(path, "/spec/"):
+		return "stats"
+	case strings.HasPrefix(path, "/logs/"):
+		return "log"
+	}
+	return "proxy"
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// Cache stores the status of pods as reported by the container runtime, so
+// that the pod workers do not need to inspect the runtime themselves. The
+// cache is kept up to date by the pod lifecycle event generator, which records
+// the time of each of its updates. A status in the cache is at least as new as
+// the time of the last update of the whole cache.
+type Cache interface {
+	// Get returns a copy of the status of the pod, and whether it is cached.
+	Get(uid types.UID) (*api.PodStatus, bool)
+	// GetNewerThan blocks until the status of the pod is newer than minTime,
+	// then behaves like Get.
+	GetNewerThan(uid types.UID, minTime time.Time) (*api.PodStatus, bool)
+	// Set records the status of the pod, observed at timestamp.
+	Set(uid types.UID, status *api.PodStatus, timestamp time.Time)
+	// Delete removes the status of the pod.
+	Delete(uid types.UID)
+	// UpdateTime records that all the statuses in the cache are current as
+	// of timestamp.
+	UpdateTime(timestamp time.Time)
+}
+
+type cacheEntry struct {
+	status *api.PodStatus
+	// The time the status was observed.
+	modified time.Time
+}
+
+type cache struct {
+	lock sync.Mutex
+	// Signaled whenever the cache is updated.
+	updated *sync.Cond
+	pods    map[types.UID]*cacheEntry
+	// All the statuses in the cache are at least as new as timestamp.
+	timestamp time.Time
+}
+
+// NewCache creates an empty pod status cache.
+func NewCache() Cache {
+	c := &cache{pods: map[types.UID]*cacheEntry{}}
+	c.updated = sync.NewCond(&c.lock)
+	return c
+}
+
+func (c *cache) Get(uid types.UID) (*api.PodStatus, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.get(uid)
+}
+
+func (c *cache) GetNewerThan(uid types.UID, minTime time.Time) (*api.PodStatus, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for !c.isNewerThan(uid, minTime) {
+		c.updated.Wait()
+	}
+	return c.get(uid)
+}
+
+func (c *cache) Set(uid types.UID, status *api.PodStatus, timestamp time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pods[uid] = &cacheEntry{status: status, modified: timestamp}
+	c.updated.Broadcast()
+}
+
+func (c *cache) Delete(uid types.UID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.pods, uid)
+}
+
+func (c *cache) UpdateTime(timestamp time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if timestamp.After(c.timestamp) {
+		c.timestamp = timestamp
+	}
+	c.updated.Broadcast()
+}
+
+func (c *cache) isNewerThan(uid types.UID, minTime time.Time) bool {
+	if c.timestamp.After(minTime) {
+		return true
+	}
+	entry, ok := c.pods[uid]
+	return ok && entry.modified.After(minTime)
+}
+
+// get returns a copy of the cached status, since the callers fill in the
+// fields the runtime does not know about, e.g. the readiness of containers.
+func (c *cache) get(uid types.UID) (*api.PodStatus, bool) {
+	entry, ok := c.pods[uid]
+	if !ok {
+		return nil, false
+	}
+	status := *entry.status
+	status.Conditions = append([]api.PodCondition(nil), entry.status.Conditions...)
+	status.ContainerStatuses = append([]api.ContainerStatus(nil), entry.status.ContainerStatuses...)
+	status.InitContainerStatuses = append([]api.ContainerStatus(nil), entry.status.InitContainerStatuses...)
+	return &status, true
+}
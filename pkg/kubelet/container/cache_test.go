@@ -0,0 +1,110 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+func TestCacheSetAndGet(t *testing.T) {
+	cache := NewCache()
+	uid := types.UID("1234")
+	if _, ok := cache.Get(uid); ok {
+		t.Errorf("expected a cache miss")
+	}
+
+	status := &api.PodStatus{
+		PodIP:             "1.2.3.4",
+		ContainerStatuses: []api.ContainerStatus{{Name: "foo"}},
+	}
+	cache.Set(uid, status, time.Now())
+	actual, ok := cache.Get(uid)
+	if !ok {
+		t.Fatalf("expected a cache hit")
+	}
+	if !reflect.DeepEqual(status, actual) {
+		t.Errorf("expected %#v, got %#v", status, actual)
+	}
+
+	// Modifying the returned status does not modify the cache.
+	actual.ContainerStatuses[0].Ready = true
+	actual.Conditions = append(actual.Conditions, api.PodCondition{Type: api.PodReady})
+	actual, _ = cache.Get(uid)
+	if actual.ContainerStatuses[0].Ready || len(actual.Conditions) != 0 {
+		t.Errorf("expected the cached status to be unmodified, got %#v", actual)
+	}
+
+	cache.Delete(uid)
+	if _, ok := cache.Get(uid); ok {
+		t.Errorf("expected a cache miss after deletion")
+	}
+}
+
+func TestCacheGetNewerThan(t *testing.T) {
+	cache := NewCache()
+	uid := types.UID("1234")
+	start := time.Now()
+	cache.Set(uid, &api.PodStatus{PodIP: "1.2.3.4"}, start)
+
+	// The status is already newer.
+	if status, ok := cache.GetNewerThan(uid, start.Add(-time.Second)); !ok || status.PodIP != "1.2.3.4" {
+		t.Errorf("expected the cached status, got %#v", status)
+	}
+
+	tests := []struct {
+		update func()
+		podIP  string
+	}{
+		{
+			// A newer status of the pod.
+			func() { cache.Set(uid, &api.PodStatus{PodIP: "5.6.7.8"}, start.Add(time.Second)) },
+			"5.6.7.8",
+		},
+		{
+			// The whole cache is updated.
+			func() { cache.UpdateTime(start.Add(3 * time.Second)) },
+			"5.6.7.8",
+		},
+	}
+	for i, tt := range tests {
+		minTime := start.Add(time.Duration(2*i) * time.Second)
+		done := make(chan *api.PodStatus)
+		go func() {
+			status, _ := cache.GetNewerThan(uid, minTime)
+			done <- status
+		}()
+		select {
+		case <-done:
+			t.Fatalf("%d: expected GetNewerThan to block", i)
+		case <-time.After(50 * time.Millisecond):
+		}
+		tt.update()
+		select {
+		case status := <-done:
+			if status.PodIP != tt.podIP {
+				t.Errorf("%d: expected pod IP %q, got %q", i, tt.podIP, status.PodIP)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%d: expected GetNewerThan to return after the update", i)
+		}
+	}
+}
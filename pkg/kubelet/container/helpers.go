@@ -85,6 +85,58 @@ func ShouldContainerBeRestarted(container *api.Container, pod *api.Pod, podStatu
 	return true
 }
 
+// PodInitializing is the reason reported for a pod, and for the waiting
+// containers of the pod, while its init containers have not all succeeded.
+const PodInitializing = "PodInitializing"
+
+// lastTermination returns the termination state of the most recent run of
+// a container, or nil if the container is running or never terminated.
+func lastTermination(status *api.ContainerStatus) *api.ContainerStateTerminated {
+	if status.State.Terminated != nil {
+		return status.State.Terminated
+	}
+	if status.State.Waiting != nil {
+		// The container may be waiting to be restarted, e.g. in a crash
+		// loop backoff.
+		return status.LastTerminationState.Terminated
+	}
+	return nil
+}
+
+// FindActiveInitContainer looks at the statuses of the init containers of a
+// pod and returns the next init container to start, whether that container
+// is started again because its last run failed, and whether all the init
+// containers have succeeded. next is nil and done is false while an init
+// container is running.
+func FindActiveInitContainer(pod *api.Pod, podStatus *api.PodStatus) (next *api.Container, failed bool, done bool) {
+	initContainers := pod.Spec.InitContainers
+	if len(initContainers) == 0 {
+		return nil, false, true
+	}
+	// Walk the init containers backwards; the first one that ran decides.
+	for i := len(initContainers) - 1; i >= 0; i-- {
+		status, found := api.GetContainerStatus(podStatus.InitContainerStatuses, initContainers[i].Name)
+		if !found {
+			continue
+		}
+		if status.State.Running != nil {
+			return nil, false, false
+		}
+		terminated := lastTermination(&status)
+		switch {
+		case terminated == nil:
+			continue
+		case terminated.ExitCode != 0:
+			return &initContainers[i], true, false
+		case i == len(initContainers)-1:
+			return nil, false, true
+		default:
+			return &initContainers[i+1], false, false
+		}
+	}
+	return &initContainers[0], false, false
+}
+
 // HashContainer returns the hash of the container. It is used to compare
 // the running container with its desired spec.
 func HashContainer(container *api.Container) uint64 {
@@ -134,3 +134,78 @@ func TestExpandCommandAndArgs(t *testing.T) {
 
 	}
 }
+
+func TestFindActiveInitContainer(t *testing.T) {
+	pod := &api.Pod{Spec: api.PodSpec{
+		InitContainers: []api.Container{{Name: "init1"}, {Name: "init2"}},
+		Containers:     []api.Container{{Name: "app"}},
+	}}
+	running := api.ContainerState{Running: &api.ContainerStateRunning{}}
+	succeeded := api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 0}}
+	failed := api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 1}}
+	waiting := api.ContainerState{Waiting: &api.ContainerStateWaiting{}}
+
+	cases := []struct {
+		name     string
+		statuses []api.ContainerStatus
+		next     string
+		failed   bool
+		done     bool
+	}{
+		{
+			name: "nothing ran yet",
+			next: "init1",
+		},
+		{
+			name:     "first init container is waiting to be created",
+			statuses: []api.ContainerStatus{{Name: "init1", State: waiting}},
+			next:     "init1",
+		},
+		{
+			name:     "first init container is running",
+			statuses: []api.ContainerStatus{{Name: "init1", State: running}},
+		},
+		{
+			name:     "first init container failed",
+			statuses: []api.ContainerStatus{{Name: "init1", State: failed}},
+			next:     "init1",
+			failed:   true,
+		},
+		{
+			name:     "first init container is backing off after a failure",
+			statuses: []api.ContainerStatus{{Name: "init1", State: waiting, LastTerminationState: failed}},
+			next:     "init1",
+			failed:   true,
+		},
+		{
+			name:     "first init container succeeded",
+			statuses: []api.ContainerStatus{{Name: "init1", State: succeeded}, {Name: "init2", State: waiting}},
+			next:     "init2",
+		},
+		{
+			name:     "second init container failed",
+			statuses: []api.ContainerStatus{{Name: "init1", State: succeeded}, {Name: "init2", State: failed}},
+			next:     "init2",
+			failed:   true,
+		},
+		{
+			name:     "all init containers succeeded",
+			statuses: []api.ContainerStatus{{Name: "init1", State: succeeded}, {Name: "init2", State: succeeded}},
+			done:     true,
+		},
+	}
+	for _, tc := range cases {
+		next, failed, done := FindActiveInitContainer(pod, &api.PodStatus{InitContainerStatuses: tc.statuses})
+		nextName := ""
+		if next != nil {
+			nextName = next.Name
+		}
+		if nextName != tc.next || failed != tc.failed || done != tc.done {
+			t.Errorf("%s: expected (%q, %v, %v), got (%q, %v, %v)", tc.name, tc.next, tc.failed, tc.done, nextName, failed, done)
+		}
+	}
+
+	if next, _, done := FindActiveInitContainer(&api.Pod{}, &api.PodStatus{}); next != nil || !done {
+		t.Errorf("expected a pod without init containers to be initialized, got next %v, done %v", next, done)
+	}
+}
@@ -29,6 +29,8 @@ func TestFieldPath(t *testing.T) {
 		{Name: "bar"},
 		{Name: ""},
 		{Name: "baz"},
+	}, InitContainers: []api.Container{
+		{Name: "init"},
 	}}}
 	table := map[string]struct {
 		pod       *api.Pod
@@ -40,6 +42,7 @@ func TestFieldPath(t *testing.T) {
 		"basic2":           {pod, &api.Container{Name: "baz"}, "spec.containers{baz}", true},
 		"emptyName":        {pod, &api.Container{Name: ""}, "spec.containers[2]", true},
 		"basicSamePointer": {pod, &pod.Spec.Containers[0], "spec.containers{foo}", true},
+		"initContainer":    {pod, &api.Container{Name: "init"}, "spec.initContainers{init}", true},
 		"missing":          {pod, &api.Container{Name: "qux"}, "", false},
 	}
 
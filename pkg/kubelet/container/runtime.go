@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/volume"
 )
 
@@ -52,10 +53,13 @@ type Runtime interface {
 	// specifies whether the runtime returns all containers including those already
 	// exited and dead containers (used for garbage collection).
 	GetPods(all bool) ([]*Pod, error)
-	// Syncs the running pod into the desired pod.
-	SyncPod(pod *api.Pod, runningPod Pod, podStatus api.PodStatus, pullSecrets []api.Secret) error
-	// KillPod kills all the containers of a pod.
-	KillPod(pod Pod) error
+	// Syncs the running pod into the desired pod. Restarts of failing
+	// containers and failing image pulls are delayed according to backOff.
+	SyncPod(pod *api.Pod, runningPod Pod, podStatus api.PodStatus, pullSecrets []api.Secret, backOff *util.Backoff) error
+	// KillPod kills all the containers of a pod. Pod is the spec of the pod, if it
+	// is still known, and is used to determine how long its containers may take to
+	// stop.
+	KillPod(pod *api.Pod, runningPod Pod) error
 	// GetPodStatus retrieves the status of the pod, including the information of
 	// all containers in the pod. Clients of this interface assume the containers
 	// statuses in a pod always have a deterministic ordering (eg: sorted by name).
@@ -71,10 +75,14 @@ type Runtime interface {
 	RemoveImage(image ImageSpec) error
 	// TODO(vmarmol): Unify pod and containerID args.
 	// GetContainerLogs returns logs of a specific container. By
-	// default, it returns a snapshot of the container log. Set 'follow' to true to
-	// stream the log. Set 'follow' to false and specify the number of lines (e.g.
-	// "100" or "all") to tail the log.
-	GetContainerLogs(pod *api.Pod, containerID, tail string, follow bool, stdout, stderr io.Writer) (err error)
+	// default, it returns a snapshot of the container log. Set logOptions.Follow
+	// to true to stream the log, and logOptions.TailLines, SinceSeconds or
+	// SinceTime to limit the lines returned.
+	GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) (err error)
+	// Returns the filesystem path of the network namespace of the specified
+	// container, e.g. the pod infra container.
+	// TODO(yifan): Use strong type for containerID.
+	GetNetNs(containerID string) (string, error)
 	// ContainerCommandRunner encapsulates the command runner interfaces for testability.
 	ContainerCommandRunner
 	// ContainerAttach encapsulates the attaching to containers for testability
@@ -181,8 +189,21 @@ type Container struct {
 	// The timestamp of the creation time of the container.
 	// TODO(yifan): Consider to move it to api.ContainerStatus.
 	Created int64
+	// The state of the container.
+	State ContainerState
 }
 
+// ContainerState is the coarse state of a container, as reported when listing
+// containers.
+type ContainerState string
+
+const (
+	ContainerStateRunning ContainerState = "running"
+	ContainerStateExited  ContainerState = "exited"
+	// The runtime does not report the state of the container.
+	ContainerStateUnknown ContainerState = "unknown"
+)
+
 // Basic information about a container image.
 type Image struct {
 	// ID of the image.
@@ -30,4 +30,16 @@ type containerManager interface {
 	// Returns resources allocated to system containers in the machine.
 	// These containers include the system and Kubernetes services.
 	SystemContainersLimit() api.ResourceList
+
+	// Returns the cgroup the containers of the pod should run in. Empty if
+	// the container runtime default should be used.
+	GetPodCgroupParent(pod *api.Pod) string
+
+	// Creates the cgroup of the pod, if pods have their own cgroups, and
+	// updates its resource limits.
+	EnsurePodCgroup(pod *api.Pod) error
+
+	// Updates the cgroups of the quality of service classes to the given
+	// active pods, and removes the cgroups of the pods that are not active.
+	UpdatePodCgroups(activePods []*api.Pod) error
 }
@@ -20,8 +20,11 @@ package kubelet
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -33,8 +36,10 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/oom"
 )
 
 const (
@@ -44,8 +49,28 @@ const (
 	DockerMemoryLimitThresholdPercent = 70
 	// The minimum memory limit allocated to docker container: 150Mi
 	MinDockerMemoryLimit = 150 * 1024 * 1024
+
+	// The cgroup holding the containers of all pods, under the cgroup root.
+	podsCgroupName = "kubepods"
+	// The cgroups holding Burstable and BestEffort pods, under podsCgroupName.
+	// Guaranteed pods have their cgroups directly under podsCgroupName.
+	burstableCgroupName  = "burstable"
+	bestEffortCgroupName = "besteffort"
+	// The prefix of the cgroup of each pod, followed by the pod UID.
+	podCgroupNamePrefix = "pod"
+
+	// CPU shares of a cgroup that requests no cpu, the kernel minimum.
+	minCpuShares = 2
 )
 
+// The cgroup subsystems through which the resources of pods are limited.
+var podCgroupSubsystems = map[string]interface {
+	Set(path string, cgroup *configs.Cgroup) error
+}{
+	"cpu":    &fs.CpuGroup{},
+	"memory": &fs.MemoryGroup{},
+}
+
 // A non-user container tracked by the Kubelet.
 type systemContainer struct {
 	// Absolute name of the container.
@@ -72,6 +97,19 @@ func newSystemContainer(containerName string) *systemContainer {
 type containerManagerImpl struct {
 	// External containers being managed.
 	systemContainers []*systemContainer
+
+	// The cgroup pods run under, as given by --cgroup-root.
+	cgroupRoot string
+
+	// The cgroup holding the cgroups of all pods. Empty if pods run directly
+	// under the cgroup root.
+	podsCgroup string
+
+	// Resources reserved for the system and the Kubernetes components. The
+	// cgroup of all pods is limited to the rest of the node's capacity.
+	reservation Reservation
+
+	cadvisorInterface cadvisor.Interface
 }
 
 var _ containerManager = &containerManagerImpl{}
@@ -79,7 +117,10 @@ var _ containerManager = &containerManagerImpl{}
 // TODO(vmarmol): Add limits to the system containers.
 // Takes the absolute name of the specified containers.
 // Empty container name disables use of the specified container.
-func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonContainerName, systemContainerName, kubeletContainerName string) (containerManager, error) {
+// If a cgroup root is given, each pod gets its own cgroup in a hierarchy
+// under it, grouped by quality of service class, which is limited to the
+// resources of the node that are not reserved.
+func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonContainerName, systemContainerName, kubeletContainerName, cgroupRoot string, reservation Reservation) (containerManager, error) {
 	systemContainers := []*systemContainer{}
 
 	if dockerDaemonContainerName != "" {
@@ -108,7 +149,7 @@ func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonConta
 			},
 		}
 		cont.ensureStateFunc = func(manager *fs.Manager) error {
-			return ensureDockerInContainer(cadvisorInterface, -900, dockerContainer)
+			return ensureDockerInContainer(cadvisorInterface, oom.NewOomAdjuster(), -900, dockerContainer)
 		}
 		systemContainers = append(systemContainers, cont)
 	}
@@ -138,9 +179,16 @@ func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonConta
 
 	// TODO(vmarmol): Add Kube-proxy container.
 
-	return &containerManagerImpl{
-		systemContainers: systemContainers,
-	}, nil
+	cm := &containerManagerImpl{
+		systemContainers:  systemContainers,
+		cgroupRoot:        cgroupRoot,
+		reservation:       reservation,
+		cadvisorInterface: cadvisorInterface,
+	}
+	if cgroupRoot != "" {
+		cm.podsCgroup = path.Join(cgroupRoot, podsCgroupName)
+	}
+	return cm, nil
 }
 
 // Create a cgroup container manager.
@@ -154,6 +202,12 @@ func createManager(containerName string) *fs.Manager {
 }
 
 func (cm *containerManagerImpl) Start() error {
+	if cm.podsCgroup != "" {
+		if err := cm.ensureQOSCgroups(); err != nil {
+			return err
+		}
+	}
+
 	// Don't run a background thread if there are no ensureStateFuncs.
 	numEnsureStateFuncs := 0
 	for _, cont := range cm.systemContainers {
@@ -194,8 +248,180 @@ func (cm *containerManagerImpl) SystemContainersLimit() api.ResourceList {
 	}
 }
 
+// Creates the cgroups holding the pods of each quality of service class. The
+// cgroup of all pods is limited to the allocatable resources of the node.
+func (cm *containerManagerImpl) ensureQOSCgroups() error {
+	info, err := cm.cadvisorInterface.MachineInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get machine info: %v", err)
+	}
+	allocatable := allocatableFromCapacity(CapacityFromMachineInfo(info), cm.reservation)
+	qosCgroups := []*configs.Cgroup{
+		{
+			Name:       cm.podsCgroup,
+			CpuShares:  milliCpuToShares(allocatable.Cpu().MilliValue()),
+			Memory:     allocatable.Memory().Value(),
+			MemorySwap: -1,
+		},
+		{Name: cm.qosCgroupName(api.PodQOSBurstable)},
+		// BestEffort pods only get the cpu time no other pod uses.
+		{Name: cm.qosCgroupName(api.PodQOSBestEffort), CpuShares: minCpuShares},
+	}
+	for _, cgroup := range qosCgroups {
+		if err := ensureCgroup(cgroup); err != nil {
+			return fmt.Errorf("failed to create cgroup %q: %v", cgroup.Name, err)
+		}
+	}
+	return nil
+}
+
+// Returns the cgroup holding the pods of the given quality of service class.
+func (cm *containerManagerImpl) qosCgroupName(qosClass api.PodQOSClass) string {
+	switch qosClass {
+	case api.PodQOSBurstable:
+		return path.Join(cm.podsCgroup, burstableCgroupName)
+	case api.PodQOSBestEffort:
+		return path.Join(cm.podsCgroup, bestEffortCgroupName)
+	}
+	return cm.podsCgroup
+}
+
+func (cm *containerManagerImpl) GetPodCgroupParent(pod *api.Pod) string {
+	if cm.podsCgroup == "" {
+		return cm.cgroupRoot
+	}
+	return path.Join(cm.qosCgroupName(qos.GetPodQOS(pod)), podCgroupNamePrefix+string(pod.UID))
+}
+
+func (cm *containerManagerImpl) EnsurePodCgroup(pod *api.Pod) error {
+	if cm.podsCgroup == "" {
+		return nil
+	}
+	return ensureCgroup(podCgroupConfig(cm.GetPodCgroupParent(pod), pod))
+}
+
+func (cm *containerManagerImpl) UpdatePodCgroups(activePods []*api.Pod) error {
+	if cm.podsCgroup == "" {
+		return nil
+	}
+	errs := []error{}
+
+	// Burstable pods share the cpu in proportion to their requests with the
+	// Guaranteed pods, whose cgroups are siblings of the burstable cgroup.
+	activeUIDs := util.NewStringSet()
+	burstableCpuRequest := int64(0)
+	for _, pod := range activePods {
+		activeUIDs.Insert(string(pod.UID))
+		if qos.GetPodQOS(pod) == api.PodQOSBurstable {
+			burstableCpuRequest += podCpuRequest(pod)
+		}
+	}
+	burstable := &configs.Cgroup{
+		Name:      cm.qosCgroupName(api.PodQOSBurstable),
+		CpuShares: milliCpuToShares(burstableCpuRequest),
+	}
+	if err := ensureCgroup(burstable); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update cgroup %q: %v", burstable.Name, err))
+	}
+
+	// Remove the cgroups of the pods that are gone. Cgroups that still hold
+	// processes cannot be removed, and are retried on the next update.
+	for _, subsystem := range []string{"cpu", "memory"} {
+		mountpoint, err := cgroups.FindCgroupMountpoint(subsystem)
+		if err != nil {
+			continue
+		}
+		for _, qosClass := range []api.PodQOSClass{api.PodQOSGuaranteed, api.PodQOSBurstable, api.PodQOSBestEffort} {
+			dir := filepath.Join(mountpoint, cm.qosCgroupName(qosClass))
+			entries, err := ioutil.ReadDir(dir)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() || !strings.HasPrefix(entry.Name(), podCgroupNamePrefix) {
+					continue
+				}
+				if activeUIDs.Has(strings.TrimPrefix(entry.Name(), podCgroupNamePrefix)) {
+					continue
+				}
+				if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+					glog.V(4).Infof("Unable to remove cgroup of inactive pod %q: %v", entry.Name(), err)
+				}
+			}
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+// Returns the configuration of the cgroup of a pod. Its cpu shares are
+// proportional to the cpu requested by its containers, and its memory is
+// limited to the sum of their limits if all of them are limited.
+func podCgroupConfig(name string, pod *api.Pod) *configs.Cgroup {
+	memoryLimit := int64(0)
+	for _, container := range pod.Spec.Containers {
+		limit, ok := container.Resources.Limits[api.ResourceMemory]
+		if !ok {
+			memoryLimit = 0
+			break
+		}
+		memoryLimit += limit.Value()
+	}
+	return &configs.Cgroup{
+		Name:       name,
+		CpuShares:  milliCpuToShares(podCpuRequest(pod)),
+		Memory:     memoryLimit,
+		MemorySwap: -1,
+	}
+}
+
+// Returns the cpu requested by the containers of a pod, in millicores. A
+// container that sets a limit but no request requests its limit.
+func podCpuRequest(pod *api.Pod) int64 {
+	total := int64(0)
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[api.ResourceCPU]; ok {
+			total += request.MilliValue()
+		} else if limit, ok := container.Resources.Limits[api.ResourceCPU]; ok {
+			total += limit.MilliValue()
+		}
+	}
+	return total
+}
+
+// Converts millicores to cpu shares, with 1024 shares per core.
+func milliCpuToShares(milliCpu int64) int64 {
+	shares := milliCpu * 1024 / 1000
+	if shares < minCpuShares {
+		return minCpuShares
+	}
+	return shares
+}
+
+// Creates the cgroup in the subsystems pods are limited through, and applies
+// its limits. Unlike fs.Manager.Apply, no process is moved into the cgroup.
+func ensureCgroup(cgroup *configs.Cgroup) error {
+	for name, subsystem := range podCgroupSubsystems {
+		mountpoint, err := cgroups.FindCgroupMountpoint(name)
+		if err != nil {
+			if cgroups.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		dir := filepath.Join(mountpoint, cgroup.Name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := subsystem.Set(dir, cgroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Ensures that the Docker daemon is in the desired container.
-func ensureDockerInContainer(cadvisor cadvisor.Interface, oomScoreAdj int, manager *fs.Manager) error {
+func ensureDockerInContainer(cadvisor cadvisor.Interface, oomAdjuster *oom.OomAdjuster, oomScoreAdj int, manager *fs.Manager) error {
 	// What container is Docker in?
 	out, err := exec.Command("pidof", "docker").Output()
 	if err != nil {
@@ -229,7 +455,7 @@ func ensureDockerInContainer(cadvisor cadvisor.Interface, oomScoreAdj int, manag
 		}
 
 		// Also apply oom_score_adj to processes
-		if err := util.ApplyOomScoreAdj(pid, oomScoreAdj); err != nil {
+		if err := oomAdjuster.ApplyOomScoreAdj(pid, oomScoreAdj); err != nil {
 			errs = append(errs, fmt.Errorf("failed to apply oom score %d to PID %d", oomScoreAdj, pid))
 		}
 	}
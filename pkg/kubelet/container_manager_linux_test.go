@@ -0,0 +1,125 @@
+// +build linux
+
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"path"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+)
+
+func cgroupTestPod(requests, limits api.ResourceList) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{UID: "12345678"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{Name: "foo", Resources: api.ResourceRequirements{Requests: requests, Limits: limits}},
+			},
+		},
+	}
+}
+
+func TestGetPodCgroupParent(t *testing.T) {
+	cpu := resource.MustParse("100m")
+	memory := resource.MustParse("100Mi")
+	testCases := []struct {
+		cgroupRoot string
+		pod        *api.Pod
+		expected   string
+	}{
+		{
+			cgroupRoot: "",
+			pod:        cgroupTestPod(nil, nil),
+			expected:   "",
+		},
+		{
+			cgroupRoot: "/",
+			pod:        cgroupTestPod(nil, nil),
+			expected:   "/kubepods/besteffort/pod12345678",
+		},
+		{
+			cgroupRoot: "/",
+			pod:        cgroupTestPod(api.ResourceList{api.ResourceCPU: cpu}, nil),
+			expected:   "/kubepods/burstable/pod12345678",
+		},
+		{
+			cgroupRoot: "/",
+			pod: cgroupTestPod(nil, api.ResourceList{
+				api.ResourceCPU:    cpu,
+				api.ResourceMemory: memory,
+			}),
+			expected: "/kubepods/pod12345678",
+		},
+	}
+	for i, tc := range testCases {
+		cm := &containerManagerImpl{cgroupRoot: tc.cgroupRoot}
+		if tc.cgroupRoot != "" {
+			cm.podsCgroup = path.Join(tc.cgroupRoot, podsCgroupName)
+		}
+		if actual := cm.GetPodCgroupParent(tc.pod); actual != tc.expected {
+			t.Errorf("%d: expected cgroup parent %q, got %q", i, tc.expected, actual)
+		}
+	}
+}
+
+func TestPodCgroupConfig(t *testing.T) {
+	limits := api.ResourceList{
+		api.ResourceCPU:    resource.MustParse("500m"),
+		api.ResourceMemory: resource.MustParse("100Mi"),
+	}
+	pod := cgroupTestPod(nil, limits)
+	pod.Spec.Containers = append(pod.Spec.Containers, api.Container{
+		Name:      "bar",
+		Resources: api.ResourceRequirements{Limits: limits},
+	})
+	config := podCgroupConfig("pod12345678", pod)
+	if config.CpuShares != 1024 {
+		t.Errorf("expected 1024 cpu shares, got %d", config.CpuShares)
+	}
+	if config.Memory != 200*1024*1024 {
+		t.Errorf("expected a memory limit of %d, got %d", 200*1024*1024, config.Memory)
+	}
+
+	// A single unlimited container leaves the pod unlimited.
+	pod.Spec.Containers = append(pod.Spec.Containers, api.Container{Name: "baz"})
+	config = podCgroupConfig("pod12345678", pod)
+	if config.Memory != 0 {
+		t.Errorf("expected no memory limit, got %d", config.Memory)
+	}
+	if config.CpuShares != 1024 {
+		t.Errorf("expected 1024 cpu shares, got %d", config.CpuShares)
+	}
+}
+
+func TestMilliCpuToShares(t *testing.T) {
+	testCases := map[int64]int64{
+		0:    minCpuShares,
+		1:    minCpuShares,
+		100:  102,
+		1000: 1024,
+		2500: 2560,
+	}
+	for milliCpu, expected := range testCases {
+		if actual := milliCpuToShares(milliCpu); actual != expected {
+			t.Errorf("%d millicores: expected %d shares, got %d", milliCpu, expected, actual)
+		}
+	}
+}
@@ -38,6 +38,18 @@ func (unsupportedContainerManager) SystemContainersLimit() api.ResourceList {
 	return api.ResourceList{}
 }
 
-func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonContainer, systemContainer, kubeletContainer string) (containerManager, error) {
+func (unsupportedContainerManager) GetPodCgroupParent(pod *api.Pod) string {
+	return ""
+}
+
+func (unsupportedContainerManager) EnsurePodCgroup(pod *api.Pod) error {
+	return nil
+}
+
+func (unsupportedContainerManager) UpdatePodCgroups(activePods []*api.Pod) error {
+	return nil
+}
+
+func newContainerManager(cadvisorInterface cadvisor.Interface, dockerDaemonContainer, systemContainer, kubeletContainer, cgroupRoot string, reservation Reservation) (containerManager, error) {
 	return &unsupportedContainerManager{}, nil
 }
@@ -18,6 +18,7 @@ package dockertools
 
 import (
 	"fmt"
+	"strings"
 
 	docker "github.com/fsouza/go-dockerclient"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
@@ -43,9 +44,23 @@ func toRuntimeContainer(c *docker.APIContainers) (*kubecontainer.Container, erro
 		Image:   c.Image,
 		Hash:    hash,
 		Created: c.Created,
+		State:   toRuntimeContainerState(c.Status),
 	}, nil
 }
 
+// Converts the status docker reports when listing a container, e.g. "Up 5
+// minutes" or "Exited (0) 2 seconds ago", to kubecontainer.ContainerState.
+func toRuntimeContainerState(status string) kubecontainer.ContainerState {
+	switch {
+	case strings.HasPrefix(status, "Up"):
+		return kubecontainer.ContainerStateRunning
+	case strings.HasPrefix(status, "Exited"):
+		return kubecontainer.ContainerStateExited
+	default:
+		return kubecontainer.ContainerStateUnknown
+	}
+}
+
 // Converts docker.APIImages to kubecontainer.Image.
 func toRuntimeImage(image *docker.APIImages) (*kubecontainer.Image, error) {
 	if image == nil {
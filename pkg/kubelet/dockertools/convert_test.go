@@ -31,6 +31,7 @@ func TestToRuntimeContainer(t *testing.T) {
 		Image:   "bar_image",
 		Created: 12345,
 		Names:   []string{"/k8s_bar.5678_foo_ns_1234_42"},
+		Status:  "Up 5 minutes",
 	}
 	expected := &kubecontainer.Container{
 		ID:      types.UID("ab2cdf"),
@@ -38,6 +39,7 @@ func TestToRuntimeContainer(t *testing.T) {
 		Image:   "bar_image",
 		Hash:    0x5678,
 		Created: 12345,
+		State:   kubecontainer.ContainerStateRunning,
 	}
 
 	actual, err := toRuntimeContainer(original)
@@ -49,6 +51,24 @@ func TestToRuntimeContainer(t *testing.T) {
 	}
 }
 
+func TestToRuntimeContainerState(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected kubecontainer.ContainerState
+	}{
+		{"Up 5 minutes", kubecontainer.ContainerStateRunning},
+		{"Up About an hour (Paused)", kubecontainer.ContainerStateRunning},
+		{"Exited (0) 2 seconds ago", kubecontainer.ContainerStateExited},
+		{"Created", kubecontainer.ContainerStateUnknown},
+		{"", kubecontainer.ContainerStateUnknown},
+	}
+	for _, tt := range tests {
+		if actual := toRuntimeContainerState(tt.status); actual != tt.expected {
+			t.Errorf("status %q: expected %q, got %q", tt.status, tt.expected, actual)
+		}
+	}
+}
+
 func TestToRuntimeImage(t *testing.T) {
 	original := &docker.APIImages{
 		ID:          "aeeea",
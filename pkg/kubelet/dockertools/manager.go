@@ -34,6 +34,7 @@ import (
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/golang/glog"
 	"github.com/golang/groupcache/lru"
+	cadvisorApi "github.com/google/cadvisor/info/v1"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/latest"
 	"k8s.io/kubernetes/pkg/client/record"
@@ -41,21 +42,18 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/network/cni"
 	"k8s.io/kubernetes/pkg/kubelet/prober"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/probe"
 	"k8s.io/kubernetes/pkg/securitycontext"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/oom"
 )
 
 const (
-	// The oom_score_adj of the POD infrastructure container. The default is 0 for
-	// any other docker containers, so any value below that makes it *less* likely
-	// to get OOM killed.
-	podOomScoreAdj           = -100
-	userContainerOomScoreAdj = 0
-
 	maxReasonCacheEntries = 200
 
 	kubernetesPodLabel       = "io.kubernetes.pod.data"
@@ -64,6 +62,13 @@ const (
 	// we want to able to consider SRV lookup names like _dns._udp.kube-dns.default.svc to be considered relative.
 	// hence, setting ndots to be 5.
 	ndotsDNSOption = "options ndots:5\n"
+
+	// defaultStopTimeoutInSeconds is how long containers are given to stop when their pod
+	// sets no grace period.
+	defaultStopTimeoutInSeconds = 10
+	// minimumGracePeriodInSeconds is the shortest time containers are given to stop, even if
+	// the grace period of their pod is shorter or was used up by the preStop hook.
+	minimumGracePeriodInSeconds = 2
 )
 
 // DockerManager implements the Runtime interface.
@@ -78,15 +83,15 @@ type DockerManager struct {
 
 	// The image name of the pod infra container.
 	podInfraContainerImage string
-	// reasonCache stores the failure reason of the last container creation
-	// and/or start in a string, keyed by <pod_UID>_<container_name>. The goal
+	// reasonCache stores the failure reason and message of the last container
+	// creation and/or start, keyed by <pod_UID>_<container_name>. The goal
 	// is to propagate this reason to the container status. This endeavor is
 	// "best-effort" for two reasons:
 	//   1. The cache is not persisted.
 	//   2. We use an LRU cache to avoid extra garbage collection work. This
 	//      means that some entries may be recycled before a pod has been
 	//      deleted.
-	reasonCache stringCache
+	reasonCache reasonInfoCache
 	// TODO(yifan): Record the pull failure so we can  eliminate the image checking
 	// in GetPodStatus()?
 	puller DockerPuller
@@ -114,6 +119,12 @@ type DockerManager struct {
 
 	// Handler used to execute commands in containers.
 	execHandler ExecHandler
+
+	// Information about the machine, used to compute the oom_score_adj of containers.
+	machineInfo *cadvisorApi.MachineInfo
+
+	// Sets the oom_score_adj of containers according to their quality of service.
+	oomAdjuster *oom.OomAdjuster
 }
 
 func NewDockerManager(
@@ -130,7 +141,9 @@ func NewDockerManager(
 	generator kubecontainer.RunContainerOptionsGenerator,
 	httpClient kubeletTypes.HttpGetter,
 	runtimeHooks kubecontainer.RuntimeHooks,
-	execHandler ExecHandler) *DockerManager {
+	execHandler ExecHandler,
+	machineInfo *cadvisorApi.MachineInfo,
+	oomAdjuster *oom.OomAdjuster) *DockerManager {
 	// Work out the location of the Docker runtime, defaulting to /var/lib/docker
 	// if there are any problems.
 	dockerRoot := "/var/lib/docker"
@@ -161,8 +174,6 @@ func NewDockerManager(
 		}
 	}
 
-	reasonCache := stringCache{cache: lru.New(maxReasonCacheEntries)}
-
 	dm := &DockerManager{
 		client:              client,
 		recorder:            recorder,
@@ -170,7 +181,7 @@ func NewDockerManager(
 		containerRefManager: containerRefManager,
 		os:                  osInterface,
 		podInfraContainerImage: podInfraContainerImage,
-		reasonCache:            reasonCache,
+		reasonCache:            reasonInfoCache{cache: lru.New(maxReasonCacheEntries)},
 		puller:                 newDockerPuller(client, qps, burst),
 		dockerRoot:             dockerRoot,
 		containerLogsDir:       containerLogsDir,
@@ -179,6 +190,8 @@ func NewDockerManager(
 		generator:              generator,
 		runtimeHooks:           runtimeHooks,
 		execHandler:            execHandler,
+		machineInfo:            machineInfo,
+		oomAdjuster:            oomAdjuster,
 	}
 	dm.runner = lifecycle.NewHandlerRunner(httpClient, dm, dm)
 	dm.prober = prober.New(dm, readinessManager, containerRefManager, recorder)
@@ -187,63 +200,156 @@ func NewDockerManager(
 }
 
 // A cache which stores strings keyed by <pod_UID>_<container_name>.
-type stringCache struct {
+// The reason a container is not running, and a message with the details.
+type reasonInfo struct {
+	reason  string
+	message string
+}
+
+type reasonInfoCache struct {
 	lock  sync.RWMutex
 	cache *lru.Cache
 }
 
-func (sc *stringCache) composeKey(uid types.UID, name string) string {
+func (sc *reasonInfoCache) composeKey(uid types.UID, name string) string {
 	return fmt.Sprintf("%s_%s", uid, name)
 }
 
-func (sc *stringCache) Add(uid types.UID, name string, value string) {
+func (sc *reasonInfoCache) Add(uid types.UID, name string, value reasonInfo) {
 	sc.lock.Lock()
 	defer sc.lock.Unlock()
 	sc.cache.Add(sc.composeKey(uid, name), value)
 }
 
-func (sc *stringCache) Remove(uid types.UID, name string) {
+func (sc *reasonInfoCache) Remove(uid types.UID, name string) {
 	sc.lock.Lock()
 	defer sc.lock.Unlock()
 	sc.cache.Remove(sc.composeKey(uid, name))
 }
 
-func (sc *stringCache) Get(uid types.UID, name string) (string, bool) {
+func (sc *reasonInfoCache) Get(uid types.UID, name string) (reasonInfo, bool) {
 	sc.lock.RLock()
 	defer sc.lock.RUnlock()
 	value, ok := sc.cache.Get(sc.composeKey(uid, name))
 	if ok {
-		return value.(string), ok
+		return value.(reasonInfo), ok
 	} else {
-		return "", ok
+		return reasonInfo{}, ok
 	}
 }
 
 // GetContainerLogs returns logs of a specific container. By
-// default, it returns a snapshot of the container log. Set 'follow' to true to
-// stream the log. Set 'follow' to false and specify the number of lines (e.g.
-// "100" or "all") to tail the log.
+// default, it returns a snapshot of the container log. Set logOptions.Follow to
+// true to stream the log, logOptions.TailLines to the number of lines to
+// start from the end of the log, and logOptions.SinceSeconds or SinceTime to
+// drop the lines logged before that time.
 // TODO: Make 'RawTerminal' option  flagable.
-func (dm *DockerManager) GetContainerLogs(pod *api.Pod, containerID, tail string, follow bool, stdout, stderr io.Writer) (err error) {
+func (dm *DockerManager) GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) (err error) {
 	opts := docker.LogsOptions{
 		Container:    containerID,
 		Stdout:       true,
 		Stderr:       true,
 		OutputStream: stdout,
 		ErrorStream:  stderr,
-		Timestamps:   false,
+		Timestamps:   logOptions.Timestamps,
 		RawTerminal:  false,
-		Follow:       follow,
+		Follow:       logOptions.Follow,
 	}
 
-	if !follow {
-		opts.Tail = tail
+	if logOptions.TailLines != nil {
+		opts.Tail = strconv.FormatInt(*logOptions.TailLines, 10)
+	}
+
+	// The docker client we use cannot ask docker for the logs since a point in
+	// time, so request timestamps and drop the older lines here.
+	var since time.Time
+	if logOptions.SinceSeconds != nil {
+		since = time.Now().Add(-time.Duration(*logOptions.SinceSeconds) * time.Second)
+	}
+	if logOptions.SinceTime != nil {
+		since = logOptions.SinceTime.Time
+	}
+	if !since.IsZero() {
+		outFilter := newSinceWriter(stdout, since, logOptions.Timestamps)
+		errFilter := newSinceWriter(stderr, since, logOptions.Timestamps)
+		opts.OutputStream, opts.ErrorStream = outFilter, errFilter
+		opts.Timestamps = true
+		defer func() {
+			if flushErr := outFilter.Flush(); err == nil {
+				err = flushErr
+			}
+			if flushErr := errFilter.Flush(); err == nil {
+				err = flushErr
+			}
+		}()
 	}
 
 	err = dm.client.Logs(opts)
 	return
 }
 
+// sinceWriter filters a docker log stream that was requested with timestamps,
+// dropping the lines logged before since. The timestamp docker prepends to each
+// line is removed again unless timestamps is set.
+type sinceWriter struct {
+	out        io.Writer
+	since      time.Time
+	timestamps bool
+	// partial holds the start of a line that has not been terminated yet.
+	partial []byte
+}
+
+func newSinceWriter(out io.Writer, since time.Time, timestamps bool) *sinceWriter {
+	return &sinceWriter{out: out, since: since, timestamps: timestamps}
+}
+
+func (w *sinceWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := w.partial[:i+1]
+		w.partial = w.partial[i+1:]
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush writes out the last line of the stream if it was not terminated.
+func (w *sinceWriter) Flush() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	line := w.partial
+	w.partial = nil
+	return w.writeLine(line)
+}
+
+func (w *sinceWriter) writeLine(line []byte) error {
+	i := bytes.IndexByte(line, ' ')
+	if i < 0 {
+		// Not a timestamped line; pass it through untouched.
+		_, err := w.out.Write(line)
+		return err
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(line[:i]))
+	if err != nil {
+		_, err := w.out.Write(line)
+		return err
+	}
+	if ts.Before(w.since) {
+		return nil
+	}
+	if !w.timestamps {
+		line = line[i+1:]
+	}
+	_, err = w.out.Write(line)
+	return err
+}
+
 var (
 	// ErrNoContainersInPod is returned when there are no containers for a given pod
 	ErrNoContainersInPod = errors.New("no containers exist for this pod")
@@ -255,6 +361,27 @@ var (
 	ErrContainerCannotRun = errors.New("Container cannot run")
 )
 
+const (
+	// The waiting reason of a container whose restart is delayed because it
+	// keeps failing.
+	CrashLoopBackOff = "CrashLoopBackOff"
+	// The waiting reason of a container whose image pull is delayed because
+	// it keeps failing.
+	ImagePullBackOff = "ImagePullBackOff"
+)
+
+// backOffError is returned when a container is not started, or its image not
+// pulled, because the previous attempts failed too recently.
+type backOffError struct {
+	// The waiting reason of the container.
+	reason  string
+	message string
+}
+
+func (e *backOffError) Error() string {
+	return e.message
+}
+
 // Internal information kept for containers from inspection
 type containerStatusResult struct {
 	status api.ContainerStatus
@@ -338,7 +465,7 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 
 	oldStatuses := make(map[string]api.ContainerStatus, len(pod.Spec.Containers))
 	lastObservedTime := make(map[string]util.Time, len(pod.Spec.Containers))
-	for _, status := range pod.Status.ContainerStatuses {
+	for _, status := range append(append([]api.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
 		oldStatuses[status.Name] = status
 		if status.LastTerminationState.Terminated != nil {
 			lastObservedTime[status.Name] = status.LastTerminationState.Terminated.FinishedAt
@@ -348,7 +475,12 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 	var podStatus api.PodStatus
 	statuses := make(map[string]*api.ContainerStatus, len(pod.Spec.Containers))
 
+	initContainerNames := util.NewStringSet()
 	expectedContainers := make(map[string]api.Container)
+	for _, container := range manifest.InitContainers {
+		expectedContainers[container.Name] = container
+		initContainerNames.Insert(container.Name)
+	}
 	for _, container := range manifest.Containers {
 		expectedContainers[container.Name] = container
 	}
@@ -422,7 +554,7 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 		if dockerContainerName == PodInfraContainerName {
 			// Found network container
 			if result.status.State.Running != nil {
-				podStatus.PodIP = result.ip
+				podStatus.PodIP = dm.determineContainerIP(pod.Namespace, pod.Name, value.ID, result.ip)
 			}
 		} else {
 			// Add user container information.
@@ -436,8 +568,14 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 
 	// Handle the containers for which we cannot find any associated active or
 	// dead docker containers.
-	for _, container := range manifest.Containers {
-		if _, found := statuses[container.Name]; found {
+	for _, container := range append(append([]api.Container{}, manifest.InitContainers...), manifest.Containers...) {
+		if containerStatus, found := statuses[container.Name]; found {
+			// A dead container whose restart is delayed is waiting.
+			reason, ok := dm.reasonCache.Get(uid, container.Name)
+			if ok && reason.reason == CrashLoopBackOff && containerStatus.State.Terminated != nil {
+				containerStatus.LastTerminationState = containerStatus.State
+				containerStatus.State = api.ContainerState{Waiting: &api.ContainerStateWaiting{}}
+			}
 			continue
 		}
 		var containerStatus api.ContainerStatus
@@ -448,6 +586,12 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 			// values if possible.
 			containerStatus.RestartCount = oldStatus.RestartCount
 			containerStatus.LastTerminationState = oldStatus.LastTerminationState
+			if initContainerNames.Has(container.Name) && oldStatus.State.Terminated != nil {
+				// Init containers are not run again once they
+				// succeeded, so keep their last result.
+				statuses[container.Name] = &oldStatus
+				continue
+			}
 		}
 		//Check image is ready on the node or not.
 		image := container.Image
@@ -470,15 +614,32 @@ func (dm *DockerManager) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 		if status.State.Waiting != nil {
 			// For containers in the waiting state, fill in a specific reason if it is recorded.
 			if reason, ok := dm.reasonCache.Get(uid, containerName); ok {
-				status.State.Waiting.Reason = reason
+				status.State.Waiting.Reason = reason.reason
+				status.State.Waiting.Message = reason.message
 			}
 		}
+		if initContainerNames.Has(containerName) {
+			continue
+		}
 		podStatus.ContainerStatuses = append(podStatus.ContainerStatuses, *status)
 	}
 	// Sort the container statuses since clients of this interface expect the list
 	// of containers in a pod to behave like the output of `docker list`, which has a
 	// deterministic order.
 	sort.Sort(kubeletTypes.SortedContainerStatuses(podStatus.ContainerStatuses))
+	// Init container statuses are listed in the order the containers run.
+	for _, container := range manifest.InitContainers {
+		podStatus.InitContainerStatuses = append(podStatus.InitContainerStatuses, *statuses[container.Name])
+	}
+	// The other containers wait until all the init containers succeeded.
+	if _, _, done := kubecontainer.FindActiveInitContainer(pod, &podStatus); !done {
+		for i := range podStatus.ContainerStatuses {
+			status := &podStatus.ContainerStatuses[i]
+			if status.State.Waiting != nil && status.ContainerID == "" {
+				status.State.Waiting = &api.ContainerStateWaiting{Reason: kubecontainer.PodInitializing}
+			}
+		}
+	}
 	return &podStatus, nil
 }
 
@@ -579,7 +740,13 @@ func (dm *DockerManager) runContainer(
 		}
 	}
 	memoryLimit := container.Resources.Limits.Memory().Value()
-	cpuShares := milliCPUToShares(container.Resources.Limits.Cpu().MilliValue())
+	// CPU shares are proportional to the requested cpu. A container that sets
+	// a limit but no request requests its limit.
+	cpuRequest := container.Resources.Requests.Cpu().MilliValue()
+	if cpuRequest == 0 {
+		cpuRequest = container.Resources.Limits.Cpu().MilliValue()
+	}
+	cpuShares := milliCPUToShares(cpuRequest)
 	dockerOpts := docker.CreateContainerOptions{
 		Name: BuildDockerName(dockerName, container),
 		Config: &docker.Config{
@@ -1065,14 +1232,42 @@ func (dm *DockerManager) PortForward(pod *kubecontainer.Pod, port uint16, stream
 	return command.Run()
 }
 
-// Kills all containers in the specified pod
-func (dm *DockerManager) KillPod(pod kubecontainer.Pod) error {
+// Returns the IP address of a pod infra container. The address the network
+// plugin reports, if any, takes precedence over the one docker reports.
+func (dm *DockerManager) determineContainerIP(podNamespace, podName, containerID, dockerIP string) string {
+	netStatus, err := dm.networkPlugin.Status(podNamespace, podName, kubeletTypes.DockerID(containerID))
+	if err != nil {
+		glog.Errorf("NetworkPlugin %s failed on the status hook for pod %q: %v", dm.networkPlugin.Name(), kubecontainer.BuildPodFullName(podName, podNamespace), err)
+		return dockerIP
+	}
+	if netStatus == nil {
+		return dockerIP
+	}
+	return netStatus.IP.String()
+}
+
+// GetNetNs returns the network namespace path of the given container, through
+// the /proc entry of its process.
+func (dm *DockerManager) GetNetNs(containerID string) (string, error) {
+	inspectResult, err := dm.client.InspectContainer(containerID)
+	if err != nil {
+		return "", err
+	}
+	if !inspectResult.State.Running {
+		return "", fmt.Errorf("container %q is not running", containerID)
+	}
+	return fmt.Sprintf("/proc/%d/ns/net", inspectResult.State.Pid), nil
+}
+
+// Kills all containers in the specified pod. The pod, if known, sets the grace period
+// its containers are given to stop.
+func (dm *DockerManager) KillPod(pod *api.Pod, runningPod kubecontainer.Pod) error {
 	// Send the kills in parallel since they may take a long time. Len + 1 since there
 	// can be Len errors + the networkPlugin teardown error.
-	errs := make(chan error, len(pod.Containers)+1)
+	errs := make(chan error, len(runningPod.Containers)+1)
 	wg := sync.WaitGroup{}
 	var networkID types.UID
-	for _, container := range pod.Containers {
+	for _, container := range runningPod.Containers {
 		wg.Add(1)
 		go func(container *kubecontainer.Container) {
 			defer util.HandleCrash()
@@ -1086,20 +1281,20 @@ func (dm *DockerManager) KillPod(pod kubecontainer.Pod) error {
 				networkID = container.ID
 				return
 			}
-			if err := dm.killContainer(container.ID); err != nil {
-				glog.Errorf("Failed to delete container: %v; Skipping pod %q", err, pod.ID)
+			if err := dm.killContainer(container.ID, pod); err != nil {
+				glog.Errorf("Failed to delete container: %v; Skipping pod %q", err, runningPod.ID)
 				errs <- err
 			}
 		}(container)
 	}
 	wg.Wait()
 	if len(networkID) > 0 {
-		if err := dm.networkPlugin.TearDownPod(pod.Namespace, pod.Name, kubeletTypes.DockerID(networkID)); err != nil {
+		if err := dm.networkPlugin.TearDownPod(runningPod.Namespace, runningPod.Name, kubeletTypes.DockerID(networkID)); err != nil {
 			glog.Errorf("Failed tearing down the infra container: %v", err)
 			errs <- err
 		}
-		if err := dm.killContainer(networkID); err != nil {
-			glog.Errorf("Failed to delete container: %v; Skipping pod %q", err, pod.ID)
+		if err := dm.killContainer(networkID, pod); err != nil {
+			glog.Errorf("Failed to delete container: %v; Skipping pod %q", err, runningPod.ID)
 			errs <- err
 		}
 	}
@@ -1126,18 +1321,21 @@ func (dm *DockerManager) KillContainerInPod(container api.Container, pod *api.Po
 	if targetContainer == nil {
 		return fmt.Errorf("unable to find container %q in pod %q", container.Name, targetPod.Name)
 	}
-	return dm.killContainer(targetContainer.ID)
+	return dm.killContainer(targetContainer.ID, pod)
 }
 
 // TODO(vmarmol): Unexport this as it is no longer used externally.
 // KillContainer kills a container identified by containerID.
-// Internally, it invokes docker's StopContainer API with a timeout of 10s.
+// Internally, it invokes docker's StopContainer API with the grace period of the pod
+// the container was created for.
 // TODO: Deprecate this function in favor of KillContainerInPod.
 func (dm *DockerManager) KillContainer(containerID types.UID) error {
-	return dm.killContainer(containerID)
+	return dm.killContainer(containerID, nil)
 }
 
-func (dm *DockerManager) killContainer(containerID types.UID) error {
+// killContainer runs the preStop hook of a container and stops it. The container is given
+// the grace period of pod to stop, or of the pod it was created for if pod is nil.
+func (dm *DockerManager) killContainer(containerID types.UID, pod *api.Pod) error {
 	ID := string(containerID)
 	glog.V(2).Infof("Killing container with id %q", ID)
 	inspect, err := dm.client.InspectContainer(ID)
@@ -1149,32 +1347,38 @@ func (dm *DockerManager) killContainer(containerID types.UID) error {
 	if inspect != nil && inspect.Config != nil && inspect.Config.Labels != nil {
 		preStop, found = inspect.Config.Labels[kubernetesPodLabel]
 	}
+	start := time.Now()
 	if found {
-		var pod api.Pod
-		err := latest.Codec.DecodeInto([]byte(preStop), &pod)
+		var labelPod api.Pod
+		err := latest.Codec.DecodeInto([]byte(preStop), &labelPod)
 		if err != nil {
 			glog.Errorf("Failed to decode prestop: %s, %s", preStop, ID)
 		} else {
+			if pod == nil {
+				pod = &labelPod
+			}
 			name := inspect.Config.Labels[kubernetesContainerLabel]
 			var container *api.Container
-			for ix := range pod.Spec.Containers {
-				if pod.Spec.Containers[ix].Name == name {
-					container = &pod.Spec.Containers[ix]
+			for ix := range labelPod.Spec.Containers {
+				if labelPod.Spec.Containers[ix].Name == name {
+					container = &labelPod.Spec.Containers[ix]
 					break
 				}
 			}
 			if container != nil {
 				glog.V(1).Infof("Running preStop hook")
-				if err := dm.runner.Run(ID, &pod, container, container.Lifecycle.PreStop); err != nil {
+				if err := dm.runner.Run(ID, &labelPod, container, container.Lifecycle.PreStop); err != nil {
 					glog.Errorf("failed to run preStop hook: %v", err)
 				}
 			} else {
-				glog.Errorf("unable to find container %v, %s", pod, name)
+				glog.Errorf("unable to find container %v, %s", labelPod, name)
 			}
 		}
 	}
 	dm.readinessManager.RemoveReadiness(ID)
-	err = dm.client.StopContainer(ID, 10)
+	gracePeriod := stopTimeout(pod, time.Since(start))
+	glog.V(2).Infof("Stopping container %q with a grace period of %ds", ID, gracePeriod)
+	err = dm.client.StopContainer(ID, gracePeriod)
 	ref, ok := dm.containerRefManager.GetRef(ID)
 	if !ok {
 		glog.Warningf("No ref for pod '%v'", ID)
@@ -1185,6 +1389,26 @@ func (dm *DockerManager) killContainer(containerID types.UID) error {
 	return err
 }
 
+// stopTimeout returns the number of seconds a container of pod is given to stop after its
+// preStop hook ran for preStopDuration. A pod that is being deleted uses the grace period
+// requested by the deletion rather than the one in its spec.
+func stopTimeout(pod *api.Pod, preStopDuration time.Duration) uint {
+	gracePeriod := int64(defaultStopTimeoutInSeconds)
+	if pod != nil {
+		switch {
+		case pod.DeletionGracePeriodSeconds != nil:
+			gracePeriod = *pod.DeletionGracePeriodSeconds
+		case pod.Spec.TerminationGracePeriodSeconds != nil:
+			gracePeriod = *pod.Spec.TerminationGracePeriodSeconds
+		}
+	}
+	gracePeriod -= int64(preStopDuration / time.Second)
+	if gracePeriod < minimumGracePeriodInSeconds {
+		gracePeriod = minimumGracePeriodInSeconds
+	}
+	return uint(gracePeriod)
+}
+
 // Run a single container from a pod. Returns the docker container ID
 func (dm *DockerManager) runContainerInPod(pod *api.Pod, container *api.Container, netMode, ipcMode string) (kubeletTypes.DockerID, error) {
 	start := time.Now()
@@ -1215,7 +1439,7 @@ func (dm *DockerManager) runContainerInPod(pod *api.Pod, container *api.Containe
 	if container.Lifecycle != nil && container.Lifecycle.PostStart != nil {
 		handlerErr := dm.runner.Run(id, pod, container, container.Lifecycle.PostStart)
 		if handlerErr != nil {
-			dm.killContainer(types.UID(id))
+			dm.killContainer(types.UID(id), pod)
 			return kubeletTypes.DockerID(""), fmt.Errorf("failed to call event handler: %v", handlerErr)
 		}
 	}
@@ -1231,9 +1455,9 @@ func (dm *DockerManager) runContainerInPod(pod *api.Pod, container *api.Containe
 		glog.Errorf("Failed to create symbolic link to the log file of pod %q container %q: %v", podFullName, container.Name, err)
 	}
 
-	// Set OOM score of POD container to lower than those of the other containers
-	// which have OOM score 0 by default in the pod. This ensures that it is
-	// killed only as a last resort.
+	// Set the OOM score of the container according to the quality of service
+	// of its pod. The POD container gets a lower score than the other containers
+	// in the pod, so that it is killed only as a last resort.
 	containerInfo, err := dm.client.InspectContainer(string(id))
 	if err != nil {
 		return "", err
@@ -1243,8 +1467,18 @@ func (dm *DockerManager) runContainerInPod(pod *api.Pod, container *api.Containe
 	if containerInfo.State.Pid == 0 {
 		return "", fmt.Errorf("failed to get init PID for Docker container %q", string(id))
 	}
+	var oomScoreAdj int
+	if container.Name == PodInfraContainerName {
+		oomScoreAdj = qos.PodInfraOomAdj
+	} else {
+		oomScoreAdj = qos.GetContainerOomScoreAdjust(pod, container, dm.machineInfo.MemoryCapacity)
+	}
+	// Children processes of docker daemon will inheritant the OOM score from docker
+	// daemon process, so the score is always applied explicitly.
+	if err := dm.oomAdjuster.ApplyOomScoreAdj(containerInfo.State.Pid, oomScoreAdj); err != nil {
+		glog.Warningf("Failed to set the OOM score of container %q to %d: %v", id, oomScoreAdj, err)
+	}
 	if container.Name == PodInfraContainerName {
-		util.ApplyOomScoreAdj(containerInfo.State.Pid, podOomScoreAdj)
 		// currently, Docker does not have a flag by which the ndots option can be passed.
 		// (A seperate issue has been filed with Docker to add a ndots flag)
 		// The addNDotsOption call appends the ndots option to the resolv.conf file generated by docker.
@@ -1252,11 +1486,6 @@ func (dm *DockerManager) runContainerInPod(pod *api.Pod, container *api.Containe
 		// we modify it when the pause container is created since it is the first container created in the pod since it holds
 		// the networking namespace.
 		err = addNDotsOption(containerInfo.ResolvConfPath)
-	} else {
-		// Children processes of docker daemon will inheritant the OOM score from docker
-		// daemon process. We explicitly apply OOM score 0 by default to the user
-		// containers to avoid daemons or POD containers are killed by oom killer.
-		util.ApplyOomScoreAdj(containerInfo.State.Pid, userContainerOomScoreAdj)
 	}
 
 	return kubeletTypes.DockerID(id), err
@@ -1304,6 +1533,9 @@ func (dm *DockerManager) createPodInfraContainer(pod *api.Pod) (kubeletTypes.Doc
 
 	if pod.Spec.HostNetwork {
 		netNamespace = "host"
+	} else if dm.networkPlugin.Name() == cni.CNIPluginName {
+		// The CNI plugin sets up the network of the pod, docker must not.
+		netNamespace = "none"
 	} else {
 		// Docker only exports ports from the pod infra container.  Let's
 		// collect all of the relevant ports and export them.
@@ -1363,13 +1595,15 @@ func (dm *DockerManager) createPodInfraContainer(pod *api.Pod) (kubeletTypes.Doc
 //   should be kept running. If startInfraContainer is false then it contains an entry for infraContainerId (mapped to -1).
 //   It shouldn't be the case where containersToStart is empty and containersToKeep contains only infraContainerId. In such case
 //   Infra Container should be killed, hence it's removed from this map.
-// - all running containers which are NOT contained in containersToKeep should be killed.
+// - initContainersToKeep stores mapping from dockerIDs of running init containers to indices of their Specs.
+// - all running containers which are NOT contained in containersToKeep or initContainersToKeep should be killed.
 type empty struct{}
 type PodContainerChangesSpec struct {
-	StartInfraContainer bool
-	InfraContainerId    kubeletTypes.DockerID
-	ContainersToStart   map[int]empty
-	ContainersToKeep    map[kubeletTypes.DockerID]int
+	StartInfraContainer  bool
+	InfraContainerId     kubeletTypes.DockerID
+	ContainersToStart    map[int]empty
+	ContainersToKeep     map[kubeletTypes.DockerID]int
+	InitContainersToKeep map[kubeletTypes.DockerID]int
 }
 
 func (dm *DockerManager) computePodContainerChanges(pod *api.Pod, runningPod kubecontainer.Pod, podStatus api.PodStatus) (PodContainerChangesSpec, error) {
@@ -1384,6 +1618,7 @@ func (dm *DockerManager) computePodContainerChanges(pod *api.Pod, runningPod kub
 
 	containersToStart := make(map[int]empty)
 	containersToKeep := make(map[kubeletTypes.DockerID]int)
+	initContainersToKeep := make(map[kubeletTypes.DockerID]int)
 	createPodInfraContainer := false
 
 	var err error
@@ -1410,6 +1645,16 @@ func (dm *DockerManager) computePodContainerChanges(pod *api.Pod, runningPod kub
 		containersToKeep[podInfraContainerID] = -1
 	}
 
+	// A running init container is left to run to completion, unless the
+	// pod infra container is recreated.
+	if !createPodInfraContainer {
+		for index, container := range pod.Spec.InitContainers {
+			if c := runningPod.FindContainerByName(container.Name); c != nil {
+				initContainersToKeep[kubeletTypes.DockerID(c.ID)] = index
+			}
+		}
+	}
+
 	for index, container := range pod.Spec.Containers {
 		expectedHash := kubecontainer.HashContainer(&container)
 
@@ -1474,13 +1719,15 @@ func (dm *DockerManager) computePodContainerChanges(pod *api.Pod, runningPod kub
 	// If Infra container is the last running one, we don't want to keep it.
 	if !createPodInfraContainer && len(containersToStart) == 0 && len(containersToKeep) == 1 {
 		containersToKeep = make(map[kubeletTypes.DockerID]int)
+		initContainersToKeep = make(map[kubeletTypes.DockerID]int)
 	}
 
 	return PodContainerChangesSpec{
-		StartInfraContainer: createPodInfraContainer,
-		InfraContainerId:    podInfraContainerID,
-		ContainersToStart:   containersToStart,
-		ContainersToKeep:    containersToKeep,
+		StartInfraContainer:  createPodInfraContainer,
+		InfraContainerId:     podInfraContainerID,
+		ContainersToStart:    containersToStart,
+		ContainersToKeep:     containersToKeep,
+		InitContainersToKeep: initContainersToKeep,
 	}, nil
 }
 
@@ -1489,8 +1736,11 @@ func (dm *DockerManager) updateReasonCache(pod *api.Pod, container *api.Containe
 	if err == nil {
 		return
 	}
-	errString := err.Error()
-	dm.reasonCache.Add(pod.UID, container.Name, errString)
+	reason := reasonInfo{reason: err.Error()}
+	if backOffErr, ok := err.(*backOffError); ok {
+		reason = reasonInfo{reason: backOffErr.reason, message: backOffErr.message}
+	}
+	dm.reasonCache.Add(pod.UID, container.Name, reason)
 }
 
 // clearReasonCache removes the entry in the reason cache.
@@ -1498,8 +1748,9 @@ func (dm *DockerManager) clearReasonCache(pod *api.Pod, container *api.Container
 	dm.reasonCache.Remove(pod.UID, container.Name)
 }
 
-// Pull the image for the specified pod and container.
-func (dm *DockerManager) pullImage(pod *api.Pod, container *api.Container, pullSecrets []api.Secret) error {
+// Pull the image for the specified pod and container. Pulls of an image that
+// keep failing are retried with an exponential backoff.
+func (dm *DockerManager) pullImage(pod *api.Pod, container *api.Container, pullSecrets []api.Secret, backOff *util.Backoff) error {
 	spec := kubecontainer.ImageSpec{container.Image}
 	present, err := dm.IsImagePresent(spec)
 
@@ -1517,13 +1768,67 @@ func (dm *DockerManager) pullImage(pod *api.Pod, container *api.Container, pullS
 		return nil
 	}
 
+	backOffKey := fmt.Sprintf("%s_%s", pod.UID, container.Image)
+	if backOff.IsInBackOffSinceUpdate(backOffKey) {
+		if ref, err := kubecontainer.GenerateContainerRef(pod, container); err == nil {
+			dm.recorder.Eventf(ref, "backoff", "Back-off pulling image %q", container.Image)
+		}
+		return &backOffError{
+			reason:  ImagePullBackOff,
+			message: fmt.Sprintf("Back-off %s pulling image %q", backOff.Get(backOffKey), container.Image),
+		}
+	}
+
 	err = dm.PullImage(spec, pullSecrets)
 	dm.runtimeHooks.ReportImagePull(pod, container, err)
+	if err != nil {
+		backOff.Next(backOffKey, backOff.Clock.Now())
+	}
 	return err
 }
 
+// Returns a backOffError if the container failed and its restart is still
+// delayed. Otherwise, records the failure, which delays the next restart.
+// The delay is reset once the container ran long enough without failing.
+func (dm *DockerManager) checkCrashLoopBackOff(pod *api.Pod, container *api.Container, podStatus api.PodStatus, backOff *util.Backoff) error {
+	var finishedAt util.Time
+	statuses := podStatus.ContainerStatuses
+	if _, found := api.GetContainerStatus(podStatus.InitContainerStatuses, container.Name); found {
+		statuses = podStatus.InitContainerStatuses
+	}
+	for _, containerStatus := range statuses {
+		if containerStatus.Name != container.Name {
+			continue
+		}
+		if containerStatus.State.Terminated != nil {
+			finishedAt = containerStatus.State.Terminated.FinishedAt
+		} else if containerStatus.LastTerminationState.Terminated != nil {
+			finishedAt = containerStatus.LastTerminationState.Terminated.FinishedAt
+		}
+		break
+	}
+	if finishedAt.IsZero() {
+		// The container never ran.
+		return nil
+	}
+
+	// Changes to the container spec reset the backoff.
+	backOffKey := fmt.Sprintf("%s_%s_%x", pod.UID, container.Name, kubecontainer.HashContainer(container))
+	if backOff.IsInBackOffSince(backOffKey, finishedAt.Time) {
+		if ref, err := kubecontainer.GenerateContainerRef(pod, container); err == nil {
+			dm.recorder.Eventf(ref, "backoff", "Back-off restarting failed docker container")
+		}
+		return &backOffError{
+			reason:  CrashLoopBackOff,
+			message: fmt.Sprintf("Back-off %s restarting failed container=%s pod=%s", backOff.Get(backOffKey), container.Name, kubecontainer.GetPodFullName(pod)),
+		}
+	}
+	backOff.Next(backOffKey, finishedAt.Time)
+	return nil
+}
+
 // Sync the running pod to match the specified desired pod.
-func (dm *DockerManager) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus api.PodStatus, pullSecrets []api.Secret) error {
+func (dm *DockerManager) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus api.PodStatus, pullSecrets []api.Secret, backOff *util.Backoff) error {
 	start := time.Now()
 	defer func() {
 		metrics.ContainerManagerLatency.WithLabelValues("SyncPod").Observe(metrics.SinceInMicroseconds(start))
@@ -1544,7 +1849,7 @@ func (dm *DockerManager) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, pod
 		}
 
 		// Killing phase: if we want to start new infra container, or nothing is running kill everything (including infra container)
-		err = dm.KillPod(runningPod)
+		err = dm.KillPod(pod, runningPod)
 		if err != nil {
 			return err
 		}
@@ -1552,9 +1857,10 @@ func (dm *DockerManager) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, pod
 		// Otherwise kill any containers in this pod which are not specified as ones to keep.
 		for _, container := range runningPod.Containers {
 			_, keep := containerChanges.ContainersToKeep[kubeletTypes.DockerID(container.ID)]
-			if !keep {
+			_, keepInit := containerChanges.InitContainersToKeep[kubeletTypes.DockerID(container.ID)]
+			if !keep && !keepInit {
 				glog.V(3).Infof("Killing unwanted container %+v", container)
-				err = dm.KillContainer(container.ID)
+				err = dm.killContainer(container.ID, pod)
 				if err != nil {
 					glog.Errorf("Error killing container: %v", err)
 				}
@@ -1578,30 +1884,62 @@ func (dm *DockerManager) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, pod
 		}
 	}
 
+	// Init containers run one at a time, and the other containers are only
+	// started once all of them succeeded.
+	next, failed, done := kubecontainer.FindActiveInitContainer(pod, &podStatus)
+	if failed && pod.Spec.RestartPolicy == api.RestartPolicyNever {
+		glog.V(4).Infof("Init container %q of pod %q failed and is not restarted", next.Name, podFullName)
+		return nil
+	}
+	if next != nil {
+		if len(containerChanges.ContainersToStart) == 0 {
+			glog.V(4).Infof("No containers to start in pod %q, not running init container %q", podFullName, next.Name)
+			return nil
+		}
+		dm.startContainerInPod(pod, next, podStatus, pullSecrets, backOff, podInfraContainerID)
+		return nil
+	}
+	if !done {
+		glog.V(4).Infof("An init container of pod %q is still running", podFullName)
+		return nil
+	}
+
 	// Start everything
 	for idx := range containerChanges.ContainersToStart {
-		container := &pod.Spec.Containers[idx]
-		glog.V(4).Infof("Creating container %+v in pod %v", container, podFullName)
-		err := dm.pullImage(pod, container, pullSecrets)
-		dm.updateReasonCache(pod, container, err)
-		if err != nil {
-			glog.Warningf("Failed to pull image %q from pod %q and container %q: %v", container.Image, kubecontainer.GetPodFullName(pod), container.Name, err)
-			continue
-		}
+		dm.startContainerInPod(pod, &pod.Spec.Containers[idx], podStatus, pullSecrets, backOff, podInfraContainerID)
+	}
+
+	return nil
+}
 
-		// TODO(dawnchen): Check RestartPolicy.DelaySeconds before restart a container
-		namespaceMode := fmt.Sprintf("container:%v", podInfraContainerID)
-		_, err = dm.runContainerInPod(pod, container, namespaceMode, namespaceMode)
+// startContainerInPod pulls the image of a container of the pod and starts
+// it in the namespaces of the pod infra container. Failures are recorded in
+// the reason cache and are reported in the status of the container.
+func (dm *DockerManager) startContainerInPod(pod *api.Pod, container *api.Container, podStatus api.PodStatus, pullSecrets []api.Secret, backOff *util.Backoff, podInfraContainerID kubeletTypes.DockerID) {
+	podFullName := kubecontainer.GetPodFullName(pod)
+	if err := dm.checkCrashLoopBackOff(pod, container, podStatus, backOff); err != nil {
 		dm.updateReasonCache(pod, container, err)
-		if err != nil {
-			// TODO(bburns) : Perhaps blacklist a container after N failures?
-			glog.Errorf("Error running pod %q container %q: %v", kubecontainer.GetPodFullName(pod), container.Name, err)
-			continue
-		}
-		// Successfully started the container; clear the entry in the failure
-		// reason cache.
-		dm.clearReasonCache(pod, container)
+		glog.Infof("%v", err)
+		return
+	}
+	glog.V(4).Infof("Creating container %+v in pod %v", container, podFullName)
+	err := dm.pullImage(pod, container, pullSecrets, backOff)
+	dm.updateReasonCache(pod, container, err)
+	if err != nil {
+		glog.Warningf("Failed to pull image %q from pod %q and container %q: %v", container.Image, podFullName, container.Name, err)
+		return
 	}
 
-	return nil
+	// TODO(dawnchen): Check RestartPolicy.DelaySeconds before restart a container
+	namespaceMode := fmt.Sprintf("container:%v", podInfraContainerID)
+	_, err = dm.runContainerInPod(pod, container, namespaceMode, namespaceMode)
+	dm.updateReasonCache(pod, container, err)
+	if err != nil {
+		// TODO(bburns) : Perhaps blacklist a container after N failures?
+		glog.Errorf("Error running pod %q container %q: %v", podFullName, container.Name, err)
+		return
+	}
+	// Successfully started the container; clear the entry in the failure
+	// reason cache.
+	dm.clearReasonCache(pod, container)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package dockertools
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -873,6 +874,12 @@ func generatePodInfraContainerHash(pod *api.Pod) uint64 {
 // runSyncPod is a helper function to retrieve the running pods from the fake
 // docker client and runs SyncPod for the given pod.
 func runSyncPod(t *testing.T, dm *DockerManager, fakeDocker *FakeDockerClient, pod *api.Pod) {
+	runSyncPodWithBackOff(t, dm, fakeDocker, pod, util.NewBackOff(time.Second, time.Minute))
+}
+
+// runSyncPodWithBackOff is like runSyncPod, but delays container restarts and
+// image pulls according to the given backoff.
+func runSyncPodWithBackOff(t *testing.T, dm *DockerManager, fakeDocker *FakeDockerClient, pod *api.Pod, backOff *util.Backoff) {
 	runningPods, err := dm.GetPods(false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -883,7 +890,7 @@ func runSyncPod(t *testing.T, dm *DockerManager, fakeDocker *FakeDockerClient, p
 		t.Errorf("unexpected error: %v", err)
 	}
 	fakeDocker.ClearCalls()
-	err = dm.SyncPod(pod, runningPod, *podStatus, []api.Secret{})
+	err = dm.SyncPod(pod, runningPod, *podStatus, []api.Secret{}, backOff)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -1472,6 +1479,195 @@ func TestSyncPodWithRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestSyncPodWithInitContainers(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	initContainers := []api.Container{
+		{Name: "init1"},
+		{Name: "init2"},
+	}
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			InitContainers: initContainers,
+			Containers: []api.Container{
+				{Name: "bar"},
+			},
+		},
+	}
+	infraContainer := docker.APIContainers{
+		// pod infra container
+		Names: []string{"/k8s_POD." + strconv.FormatUint(generatePodInfraContainerHash(pod), 16) + "_foo_new_12345678_0"},
+		ID:    "9876",
+	}
+	initContainer := func(index int, id string) docker.APIContainers {
+		return docker.APIContainers{
+			Names: []string{"/k8s_" + initContainers[index].Name + "." + strconv.FormatUint(kubecontainer.HashContainer(&initContainers[index]), 16) + "_foo_new_12345678_0"},
+			ID:    id,
+		}
+	}
+	exited := func(id, name string, exitCode int) *docker.Container {
+		return &docker.Container{
+			ID:     id,
+			Name:   name,
+			Config: &docker.Config{},
+			State: docker.State{
+				ExitCode:   exitCode,
+				StartedAt:  time.Now(),
+				FinishedAt: time.Now(),
+			},
+		}
+	}
+	containerMap := map[string]*docker.Container{
+		"9876": {
+			ID:     "9876",
+			Name:   "POD",
+			Config: &docker.Config{},
+			State: docker.State{
+				StartedAt: time.Now(),
+				Running:   true,
+			},
+		},
+		"1111": {
+			ID:     "1111",
+			Name:   "init1",
+			Config: &docker.Config{},
+			State: docker.State{
+				StartedAt: time.Now(),
+				Running:   true,
+			},
+		},
+		"2222": exited("2222", "init1", 0),
+		"3333": exited("3333", "init1", 1),
+		"4444": exited("4444", "init2", 0),
+	}
+
+	tests := []struct {
+		name    string
+		policy  api.RestartPolicy
+		running []docker.APIContainers
+		exited  []docker.APIContainers
+		calls   []string
+		created []string
+	}{
+		{
+			"first init container is created",
+			api.RestartPolicyAlways,
+			[]docker.APIContainers{infraContainer},
+			nil,
+			[]string{"inspect_container", "create", "start", "inspect_container"},
+			[]string{"init1"},
+		},
+		{
+			"running init container is left alone",
+			api.RestartPolicyAlways,
+			[]docker.APIContainers{infraContainer, initContainer(0, "1111")},
+			nil,
+			[]string{"inspect_container"},
+			[]string{},
+		},
+		{
+			"second init container is created after the first one succeeded",
+			api.RestartPolicyAlways,
+			[]docker.APIContainers{infraContainer},
+			[]docker.APIContainers{initContainer(0, "2222")},
+			[]string{"inspect_container", "create", "start", "inspect_container"},
+			[]string{"init2"},
+		},
+		{
+			"failed init container is restarted",
+			api.RestartPolicyOnFailure,
+			[]docker.APIContainers{infraContainer},
+			[]docker.APIContainers{initContainer(0, "3333")},
+			[]string{"inspect_container", "create", "start", "inspect_container"},
+			[]string{"init1"},
+		},
+		{
+			"failed init container is not restarted",
+			api.RestartPolicyNever,
+			[]docker.APIContainers{infraContainer},
+			[]docker.APIContainers{initContainer(0, "3333")},
+			[]string{"inspect_container"},
+			[]string{},
+		},
+		{
+			"containers are created after all init containers succeeded",
+			api.RestartPolicyAlways,
+			[]docker.APIContainers{infraContainer},
+			[]docker.APIContainers{initContainer(1, "4444"), initContainer(0, "2222")},
+			[]string{"inspect_container", "create", "start", "inspect_container"},
+			[]string{"bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		fakeDocker.ContainerList = tt.running
+		fakeDocker.ExitedContainerList = tt.exited
+		fakeDocker.ContainerMap = containerMap
+		pod.Spec.RestartPolicy = tt.policy
+
+		runSyncPod(t, dm, fakeDocker, pod)
+
+		verifyCalls(t, fakeDocker, tt.calls)
+		if err := fakeDocker.AssertCreated(tt.created); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if err := fakeDocker.AssertStopped([]string{}); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+}
+
+func TestGetPodStatusWithInitContainers(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	initContainer := api.Container{Name: "init"}
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			InitContainers: []api.Container{initContainer},
+			Containers:     []api.Container{{Name: "bar"}},
+		},
+	}
+	fakeDocker.ContainerList = []docker.APIContainers{
+		{
+			Names: []string{"/k8s_init." + strconv.FormatUint(kubecontainer.HashContainer(&initContainer), 16) + "_foo_new_12345678_0"},
+			ID:    "1234",
+		},
+	}
+	fakeDocker.ContainerMap = map[string]*docker.Container{
+		"1234": {
+			ID:     "1234",
+			Name:   "init",
+			Config: &docker.Config{},
+			State: docker.State{
+				StartedAt: time.Now(),
+				Running:   true,
+			},
+		},
+	}
+
+	podStatus, err := dm.GetPodStatus(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(podStatus.InitContainerStatuses) != 1 || podStatus.InitContainerStatuses[0].State.Running == nil {
+		t.Errorf("expected a running init container, got %+v", podStatus.InitContainerStatuses)
+	}
+	if len(podStatus.ContainerStatuses) != 1 {
+		t.Fatalf("expected 1 container status, got %+v", podStatus.ContainerStatuses)
+	}
+	if waiting := podStatus.ContainerStatuses[0].State.Waiting; waiting == nil || waiting.Reason != kubecontainer.PodInitializing {
+		t.Errorf("expected container %q to wait for the pod to initialize, got %+v", "bar", podStatus.ContainerStatuses[0].State)
+	}
+}
+
 func TestGetPodStatusWithLastTermination(t *testing.T) {
 	dm, fakeDocker := newTestDockerManager()
 	containers := []api.Container{
@@ -1716,6 +1912,219 @@ func TestGetPodPullImageFailureReason(t *testing.T) {
 	}
 }
 
+func TestSyncPodCrashLoopBackOff(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	fakeClock := &util.FakeClock{Time: time.Now()}
+	startTime := fakeClock.Now()
+	backOff := util.NewBackOff(time.Second, time.Minute)
+	backOff.Clock = fakeClock
+
+	containers := []api.Container{
+		{Name: "good"},
+		{Name: "bad"},
+	}
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			Containers:    containers,
+			RestartPolicy: api.RestartPolicyAlways,
+		},
+	}
+
+	tests := []struct {
+		// Seconds since the start of the test.
+		tick int
+		// Seconds since the start of the test at which the bad container
+		// last exited.
+		finished int
+		created  []string
+	}{
+		{0, 0, []string{"bad"}},
+		{0, 0, []string{}},
+		{1, 0, []string{"bad"}},
+		{1, 0, []string{}},
+		{2, 0, []string{"bad"}},
+		{3, 0, []string{}},
+		{4, 0, []string{"bad"}},
+		{7, 0, []string{}},
+		{8, 0, []string{"bad"}},
+		// The container ran long enough for the backoff to be reset.
+		{200, 200, []string{"bad"}},
+		{200, 200, []string{}},
+		{201, 200, []string{"bad"}},
+	}
+
+	for i, tt := range tests {
+		fakeClock.Time = startTime.Add(time.Duration(tt.tick) * time.Second)
+		fakeDocker.ContainerList = []docker.APIContainers{
+			{
+				// pod infra container
+				Names: []string{"/k8s_POD." + strconv.FormatUint(generatePodInfraContainerHash(pod), 16) + "_foo_new_12345678_0"},
+				ID:    "9876",
+			},
+			{
+				Names: []string{"/k8s_good." + strconv.FormatUint(kubecontainer.HashContainer(&containers[0]), 16) + "_foo_new_12345678_0"},
+				ID:    "1234",
+			},
+		}
+		fakeDocker.ExitedContainerList = []docker.APIContainers{
+			{
+				Names: []string{"/k8s_bad." + strconv.FormatUint(kubecontainer.HashContainer(&containers[1]), 16) + "_foo_new_12345678_0"},
+				ID:    "5678",
+			},
+		}
+		fakeDocker.ContainerMap = map[string]*docker.Container{
+			"9876": {
+				ID:         "9876",
+				Name:       "POD",
+				Config:     &docker.Config{},
+				HostConfig: &docker.HostConfig{},
+				State: docker.State{
+					StartedAt: startTime,
+					Running:   true,
+				},
+			},
+			"1234": {
+				ID:         "1234",
+				Name:       "good",
+				Config:     &docker.Config{},
+				HostConfig: &docker.HostConfig{},
+				State: docker.State{
+					StartedAt: startTime,
+					Running:   true,
+				},
+			},
+			"5678": {
+				ID:         "5678",
+				Name:       "bad",
+				Config:     &docker.Config{},
+				HostConfig: &docker.HostConfig{},
+				State: docker.State{
+					ExitCode:   42,
+					StartedAt:  startTime,
+					FinishedAt: startTime.Add(time.Duration(tt.finished) * time.Second),
+				},
+			},
+		}
+
+		runSyncPodWithBackOff(t, dm, fakeDocker, pod, backOff)
+		if err := fakeDocker.AssertCreated(tt.created); err != nil {
+			t.Errorf("%d: %v", i, err)
+		}
+
+		status, err := dm.GetPodStatus(pod)
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", i, err)
+		}
+		var badStatus *api.ContainerStatus
+		for j := range status.ContainerStatuses {
+			if status.ContainerStatuses[j].Name == "bad" {
+				badStatus = &status.ContainerStatuses[j]
+			}
+		}
+		if badStatus == nil {
+			t.Fatalf("%d: missing status of the bad container in %#v", i, status.ContainerStatuses)
+		}
+		if len(tt.created) > 0 {
+			continue
+		}
+		// The restart was delayed.
+		if badStatus.State.Waiting == nil || badStatus.State.Waiting.Reason != CrashLoopBackOff {
+			t.Errorf("%d: expected waiting state with reason %q, got %#v", i, CrashLoopBackOff, badStatus.State)
+		} else if badStatus.State.Waiting.Message == "" {
+			t.Errorf("%d: expected a backoff message", i)
+		}
+		if badStatus.LastTerminationState.Terminated == nil || badStatus.LastTerminationState.Terminated.ExitCode != 42 {
+			t.Errorf("%d: expected the last termination state of the bad container, got %#v", i, badStatus.LastTerminationState)
+		}
+	}
+}
+
+func TestSyncPodImagePullBackOff(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	fakeClock := &util.FakeClock{Time: time.Now()}
+	startTime := fakeClock.Now()
+	backOff := util.NewBackOff(time.Second, time.Minute)
+	backOff.Clock = fakeClock
+
+	puller := dm.puller.(*FakeDockerPuller)
+	puller.HasImages = []string{}
+	pullErr := fmt.Errorf("pull image failure")
+
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "bar", Image: "missingImage", ImagePullPolicy: api.PullAlways}},
+		},
+	}
+
+	tests := []struct {
+		// Seconds since the start of the test.
+		tick   int
+		pulled bool
+	}{
+		{0, true},
+		{0, false},
+		{1, true},
+		{2, false},
+		{3, true},
+		{6, false},
+		{7, true},
+	}
+
+	for i, tt := range tests {
+		fakeClock.Time = startTime.Add(time.Duration(tt.tick) * time.Second)
+		// Pretend that the pod infra container has already been created, so
+		// that we can run the user containers.
+		fakeDocker.ContainerList = []docker.APIContainers{
+			{
+				Names: []string{"/k8s_POD." + strconv.FormatUint(generatePodInfraContainerHash(pod), 16) + "_foo_new_12345678_0"},
+				ID:    "9876",
+			},
+		}
+		fakeDocker.ContainerMap = map[string]*docker.Container{
+			"9876": {
+				ID:         "9876",
+				HostConfig: &docker.HostConfig{},
+				Config:     &docker.Config{},
+			},
+		}
+		puller.ImagesPulled = []string{}
+		puller.ErrorsToInject = []error{pullErr}
+
+		runSyncPodWithBackOff(t, dm, fakeDocker, pod, backOff)
+		if pulled := len(puller.ImagesPulled) > 0; pulled != tt.pulled {
+			t.Errorf("%d: expected pulled %v, got %v", i, tt.pulled, pulled)
+		}
+
+		status, err := dm.GetPodStatus(pod)
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", i, err)
+		}
+		if len(status.ContainerStatuses) < 1 {
+			t.Fatalf("%d: expected 1 container status, got %d", i, len(status.ContainerStatuses))
+		}
+		expectedReason := pullErr.Error()
+		if !tt.pulled {
+			expectedReason = ImagePullBackOff
+		}
+		state := status.ContainerStatuses[0].State
+		if state.Waiting == nil {
+			t.Errorf("%d: expected waiting state, got %#v", i, state)
+		} else if state.Waiting.Reason != expectedReason {
+			t.Errorf("%d: expected reason %q, got %q", i, expectedReason, state.Waiting.Reason)
+		}
+	}
+}
+
 func TestGetRestartCount(t *testing.T) {
 	dm, fakeDocker := newTestDockerManager()
 	containers := []api.Container{
@@ -2050,3 +2459,96 @@ func TestGetPodStatusSortedContainers(t *testing.T) {
 		}
 	}
 }
+
+func TestSinceWriter(t *testing.T) {
+	since := time.Date(2015, 9, 1, 10, 0, 0, 0, time.UTC)
+	input := "2015-09-01T09:59:59.999999999Z old line\n" +
+		"2015-09-01T10:00:00.000000000Z first\n" +
+		"2015-09-01T10:00:01.5Z second\n" +
+		"2015-09-01T10:00:02Z unterminated"
+
+	tests := []struct {
+		timestamps bool
+		expected   string
+	}{
+		{
+			timestamps: false,
+			expected:   "first\nsecond\nunterminated",
+		},
+		{
+			timestamps: true,
+			expected:   "2015-09-01T10:00:00.000000000Z first\n2015-09-01T10:00:01.5Z second\n2015-09-01T10:00:02Z unterminated",
+		},
+	}
+	for _, test := range tests {
+		out := &bytes.Buffer{}
+		w := newSinceWriter(out, since, test.timestamps)
+		// Write in small chunks, so lines are split across writes.
+		for i := 0; i < len(input); i += 7 {
+			end := i + 7
+			if end > len(input) {
+				end = len(input)
+			}
+			if _, err := w.Write([]byte(input[i:end])); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("timestamps=%v: expected %q, got %q", test.timestamps, test.expected, out.String())
+		}
+	}
+}
+
+func TestStopTimeout(t *testing.T) {
+	int64Ptr := func(i int64) *int64 { return &i }
+	tests := []struct {
+		name     string
+		pod      *api.Pod
+		preStop  time.Duration
+		expected uint
+	}{
+		{
+			name:     "unknown pod",
+			expected: defaultStopTimeoutInSeconds,
+		},
+		{
+			name:     "no grace period",
+			pod:      &api.Pod{},
+			expected: defaultStopTimeoutInSeconds,
+		},
+		{
+			name:     "spec grace period",
+			pod:      &api.Pod{Spec: api.PodSpec{TerminationGracePeriodSeconds: int64Ptr(30)}},
+			expected: 30,
+		},
+		{
+			name: "deletion grace period",
+			pod: &api.Pod{
+				ObjectMeta: api.ObjectMeta{DeletionGracePeriodSeconds: int64Ptr(5)},
+				Spec:       api.PodSpec{TerminationGracePeriodSeconds: int64Ptr(30)},
+			},
+			expected: 5,
+		},
+		{
+			name:     "reduced by the preStop hook",
+			pod:      &api.Pod{Spec: api.PodSpec{TerminationGracePeriodSeconds: int64Ptr(30)}},
+			preStop:  12 * time.Second,
+			expected: 18,
+		},
+		{
+			name: "minimum",
+			pod: &api.Pod{
+				ObjectMeta: api.ObjectMeta{DeletionGracePeriodSeconds: int64Ptr(0)},
+			},
+			expected: minimumGracePeriodInSeconds,
+		},
+	}
+	for _, test := range tests {
+		if actual := stopTimeout(test.pod, test.preStop); actual != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, actual)
+		}
+	}
+}
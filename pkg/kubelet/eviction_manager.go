@@ -0,0 +1,434 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
+	kubeletUtil "k8s.io/kubernetes/pkg/kubelet/util"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// Evicts pods when the node runs low on memory or disk space, before the
+// kernel starts killing arbitrary processes.
+
+// EvictionSignal is a node resource the eviction manager observes.
+type EvictionSignal string
+
+const (
+	// SignalMemoryAvailable is the memory available on the node: its capacity
+	// minus the working set of all processes.
+	SignalMemoryAvailable EvictionSignal = "memory.available"
+	// SignalNodeFsAvailable is the space available on the root filesystem,
+	// which holds volumes and logs.
+	SignalNodeFsAvailable EvictionSignal = "nodefs.available"
+	// SignalImageFsAvailable is the space available on the filesystem holding
+	// images and container writable layers.
+	SignalImageFsAvailable EvictionSignal = "imagefs.available"
+)
+
+// EvictionThreshold triggers evictions when the observed value of a signal
+// falls below Value for longer than GracePeriod.
+type EvictionThreshold struct {
+	Signal EvictionSignal
+	// Value is the minimum amount of the resource, in bytes, that should remain available.
+	Value int64
+	// GracePeriod is how long the threshold must be met before pods are
+	// evicted. Hard thresholds have no grace period.
+	GracePeriod time.Duration
+}
+
+// EvictionPolicy configures when the kubelet evicts pods.
+type EvictionPolicy struct {
+	Thresholds []EvictionThreshold
+	// PressureTransitionPeriod is how long the node keeps reporting a pressure
+	// condition after the last time one of its thresholds was met.
+	PressureTransitionPeriod time.Duration
+}
+
+// Reason set on the status of evicted pods.
+const evictedReason = "Evicted"
+
+// How often the eviction manager observes the node's resources.
+const evictionMonitoringPeriod = 10 * time.Second
+
+var validEvictionSignals = util.NewStringSet(string(SignalMemoryAvailable), string(SignalNodeFsAvailable), string(SignalImageFsAvailable))
+
+// ParseEvictionThresholds parses the hard and soft thresholds, in the form
+// "memory.available<100Mi,nodefs.available<1Gi", and the grace periods of the
+// soft thresholds, in the form "memory.available=1m30s". Every soft threshold
+// must have a grace period.
+func ParseEvictionThresholds(hard, soft, softGracePeriods string) ([]EvictionThreshold, error) {
+	hardThresholds, err := parseThresholdValues(hard)
+	if err != nil {
+		return nil, err
+	}
+	softThresholds, err := parseThresholdValues(soft)
+	if err != nil {
+		return nil, err
+	}
+	gracePeriods, err := parseGracePeriods(softGracePeriods)
+	if err != nil {
+		return nil, err
+	}
+
+	var thresholds []EvictionThreshold
+	for _, threshold := range hardThresholds {
+		thresholds = append(thresholds, threshold)
+	}
+	for _, threshold := range softThresholds {
+		gracePeriod, ok := gracePeriods[threshold.Signal]
+		if !ok {
+			return nil, fmt.Errorf("soft eviction threshold %q has no grace period", threshold.Signal)
+		}
+		threshold.GracePeriod = gracePeriod
+		thresholds = append(thresholds, threshold)
+	}
+	for signal := range gracePeriods {
+		found := false
+		for _, threshold := range softThresholds {
+			found = found || threshold.Signal == signal
+		}
+		if !found {
+			return nil, fmt.Errorf("grace period for %q has no soft eviction threshold", signal)
+		}
+	}
+	return thresholds, nil
+}
+
+func parseThresholdValues(s string) ([]EvictionThreshold, error) {
+	var thresholds []EvictionThreshold
+	seen := map[EvictionSignal]bool{}
+	for _, item := range splitEvictionList(s) {
+		parts := strings.SplitN(item, "<", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid eviction threshold %q, expected <signal><<quantity>", item)
+		}
+		signal, err := parseEvictionSignal(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		if seen[signal] {
+			return nil, fmt.Errorf("duplicate eviction threshold for %q", signal)
+		}
+		seen[signal] = true
+		value, err := resource.ParseQuantity(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity in eviction threshold %q: %v", item, err)
+		}
+		if value.Value() <= 0 {
+			return nil, fmt.Errorf("eviction threshold %q must be positive", item)
+		}
+		thresholds = append(thresholds, EvictionThreshold{Signal: signal, Value: value.Value()})
+	}
+	return thresholds, nil
+}
+
+func parseGracePeriods(s string) (map[EvictionSignal]time.Duration, error) {
+	gracePeriods := map[EvictionSignal]time.Duration{}
+	for _, item := range splitEvictionList(s) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid eviction grace period %q, expected <signal>=<duration>", item)
+		}
+		signal, err := parseEvictionSignal(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		gracePeriod, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in eviction grace period %q: %v", item, err)
+		}
+		if gracePeriod <= 0 {
+			return nil, fmt.Errorf("eviction grace period %q must be positive", item)
+		}
+		gracePeriods[signal] = gracePeriod
+	}
+	return gracePeriods, nil
+}
+
+func splitEvictionList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseEvictionSignal(s string) (EvictionSignal, error) {
+	s = strings.TrimSpace(s)
+	if !validEvictionSignals.Has(s) {
+		return "", fmt.Errorf("unknown eviction signal %q (valid: %s)", s, strings.Join(validEvictionSignals.List(), ", "))
+	}
+	return EvictionSignal(s), nil
+}
+
+// nodeConditionFor returns the node condition reported while a threshold on
+// the signal is met.
+func nodeConditionFor(signal EvictionSignal) api.NodeConditionType {
+	if signal == SignalMemoryAvailable {
+		return api.NodeMemoryPressure
+	}
+	return api.NodeDiskPressure
+}
+
+// podUsage is the amount of memory and disk space used by the containers of a pod.
+type podUsage struct {
+	Memory int64
+	Disk   int64
+}
+
+// podUsageFunc returns the resources used by a running pod.
+type podUsageFunc func(pod *api.Pod) (podUsage, error)
+
+// evictPodFunc stops a pod and records status as its final status.
+type evictPodFunc func(pod *api.Pod, status api.PodStatus) error
+
+// Implementation is thread-safe.
+type evictionManager interface {
+	// Observes the node's resources, updates the pressure conditions and
+	// evicts at most one of the given pods if a threshold has been met for
+	// longer than its grace period.
+	Synchronize(activePods []*api.Pod) error
+	// Returns true if the node is reporting the given pressure condition.
+	IsUnderPressure(condition api.NodeConditionType) bool
+}
+
+type realEvictionManager struct {
+	cadvisor cadvisor.Interface
+	policy   EvictionPolicy
+	recorder record.EventRecorder
+	clock    util.Clock
+	podUsage podUsageFunc
+	evictPod evictPodFunc
+
+	lock sync.Mutex
+	// the time each threshold that is currently met was first met.
+	thresholdsFirstObserved map[EvictionThreshold]time.Time
+	// the last time each pressure condition was observed.
+	lastObserved map[api.NodeConditionType]time.Time
+}
+
+func newEvictionManager(cadvisorInterface cadvisor.Interface, policy EvictionPolicy, recorder record.EventRecorder, podUsage podUsageFunc, evictPod evictPodFunc) evictionManager {
+	return &realEvictionManager{
+		cadvisor:                cadvisorInterface,
+		policy:                  policy,
+		recorder:                recorder,
+		clock:                   util.RealClock{},
+		podUsage:                podUsage,
+		evictPod:                evictPod,
+		thresholdsFirstObserved: map[EvictionThreshold]time.Time{},
+		lastObserved:            map[api.NodeConditionType]time.Time{},
+	}
+}
+
+func (m *realEvictionManager) IsUnderPressure(condition api.NodeConditionType) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	last, ok := m.lastObserved[condition]
+	return ok && m.clock.Since(last) <= m.policy.PressureTransitionPeriod
+}
+
+func (m *realEvictionManager) Synchronize(activePods []*api.Pod) error {
+	if len(m.policy.Thresholds) == 0 {
+		return nil
+	}
+	observations, err := m.observe()
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	now := m.clock.Now()
+	var exceeded []EvictionThreshold
+	for _, threshold := range m.policy.Thresholds {
+		available, ok := observations[threshold.Signal]
+		if !ok || available >= threshold.Value {
+			delete(m.thresholdsFirstObserved, threshold)
+			continue
+		}
+		m.lastObserved[nodeConditionFor(threshold.Signal)] = now
+		firstObserved, ok := m.thresholdsFirstObserved[threshold]
+		if !ok {
+			firstObserved = now
+			m.thresholdsFirstObserved[threshold] = now
+		}
+		if now.Sub(firstObserved) >= threshold.GracePeriod {
+			glog.Infof("Eviction threshold %s<%d met: %d available", threshold.Signal, threshold.Value, available)
+			exceeded = append(exceeded, threshold)
+		}
+	}
+	m.lock.Unlock()
+
+	if len(exceeded) == 0 {
+		return nil
+	}
+	// Memory is reclaimed first, as running out of it has the most
+	// disruptive consequences.
+	signal := exceeded[0].Signal
+	for _, threshold := range exceeded {
+		if threshold.Signal == SignalMemoryAvailable {
+			signal = threshold.Signal
+		}
+	}
+	return m.evictOne(activePods, signal)
+}
+
+// observe returns the amount of each resource available on the node.
+func (m *realEvictionManager) observe() (map[EvictionSignal]int64, error) {
+	observations := map[EvictionSignal]int64{}
+	for _, threshold := range m.policy.Thresholds {
+		if _, ok := observations[threshold.Signal]; ok {
+			continue
+		}
+		switch threshold.Signal {
+		case SignalMemoryAvailable:
+			machineInfo, err := m.cadvisor.MachineInfo()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get machine info: %v", err)
+			}
+			rootInfo, err := m.cadvisor.ContainerInfo("/", &cadvisorApi.ContainerInfoRequest{NumStats: 1})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get root container info: %v", err)
+			}
+			if len(rootInfo.Stats) == 0 {
+				return nil, fmt.Errorf("no memory stats for the root container")
+			}
+			workingSet := rootInfo.Stats[len(rootInfo.Stats)-1].Memory.WorkingSet
+			observations[SignalMemoryAvailable] = int64(machineInfo.MemoryCapacity) - int64(workingSet)
+		case SignalNodeFsAvailable:
+			fs, err := m.cadvisor.RootFsInfo()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get root fs info: %v", err)
+			}
+			observations[SignalNodeFsAvailable] = int64(fs.Available)
+		case SignalImageFsAvailable:
+			fs, err := m.cadvisor.DockerImagesFsInfo()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get image fs info: %v", err)
+			}
+			observations[SignalImageFsAvailable] = int64(fs.Available)
+		}
+	}
+	return observations, nil
+}
+
+// evictOne evicts the pod that should be reclaimed first to relieve the
+// pressure on the resource behind signal.
+func (m *realEvictionManager) evictOne(activePods []*api.Pod, signal EvictionSignal) error {
+	candidates := make([]evictionCandidate, 0, len(activePods))
+	for _, pod := range activePods {
+		// Static pods usually run the node's critical components.
+		if isStaticPod(pod) {
+			continue
+		}
+		usage, err := m.podUsage(pod)
+		if err != nil {
+			glog.V(4).Infof("Unable to get the resource usage of pod %q: %v", kubeletUtil.FormatPodName(pod), err)
+		}
+		candidates = append(candidates, evictionCandidate{pod: pod, usage: usage})
+	}
+	if len(candidates) == 0 {
+		glog.Warningf("Eviction threshold on %s met, but no pod can be evicted", signal)
+		return nil
+	}
+	if signal == SignalMemoryAvailable {
+		sort.Sort(byMemoryEvictionOrder(candidates))
+	} else {
+		sort.Sort(byDiskEvictionOrder(candidates))
+	}
+
+	pod := candidates[0].pod
+	resourceName := "memory"
+	if signal != SignalMemoryAvailable {
+		resourceName = "disk space"
+	}
+	message := fmt.Sprintf("The node was low on %s.", resourceName)
+	glog.Infof("Evicting pod %q: %s", kubeletUtil.FormatPodName(pod), message)
+	m.recorder.Eventf(pod, evictedReason, "%s", message)
+	return m.evictPod(pod, api.PodStatus{
+		Phase:   api.PodFailed,
+		Reason:  evictedReason,
+		Message: message,
+	})
+}
+
+type evictionCandidate struct {
+	pod   *api.Pod
+	usage podUsage
+}
+
+// qosRank orders the classes in which pods are evicted.
+var qosRank = map[api.PodQOSClass]int{
+	api.PodQOSBestEffort: 0,
+	api.PodQOSBurstable:  1,
+	api.PodQOSGuaranteed: 2,
+}
+
+// byMemoryEvictionOrder sorts pods by QoS class, then by how much memory they
+// use above their request.
+type byMemoryEvictionOrder []evictionCandidate
+
+func (s byMemoryEvictionOrder) Len() int      { return len(s) }
+func (s byMemoryEvictionOrder) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byMemoryEvictionOrder) Less(i, j int) bool {
+	qi, qj := qosRank[qos.GetPodQOS(s[i].pod)], qosRank[qos.GetPodQOS(s[j].pod)]
+	if qi != qj {
+		return qi < qj
+	}
+	return s[i].usage.Memory-podMemoryRequest(s[i].pod) > s[j].usage.Memory-podMemoryRequest(s[j].pod)
+}
+
+// byDiskEvictionOrder sorts pods by QoS class, then by the disk space they use.
+type byDiskEvictionOrder []evictionCandidate
+
+func (s byDiskEvictionOrder) Len() int      { return len(s) }
+func (s byDiskEvictionOrder) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byDiskEvictionOrder) Less(i, j int) bool {
+	qi, qj := qosRank[qos.GetPodQOS(s[i].pod)], qosRank[qos.GetPodQOS(s[j].pod)]
+	if qi != qj {
+		return qi < qj
+	}
+	return s[i].usage.Disk > s[j].usage.Disk
+}
+
+// podMemoryRequest returns the memory requested by the containers of a pod.
+// Containers that set a limit but no request request their limit.
+func podMemoryRequest(pod *api.Pod) int64 {
+	total := int64(0)
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[api.ResourceMemory]; ok {
+			total += request.Value()
+		} else if limit, ok := container.Resources.Limits[api.ResourceMemory]; ok {
+			total += limit.Value()
+		}
+	}
+	return total
+}
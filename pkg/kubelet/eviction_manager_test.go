@@ -0,0 +1,301 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+	cadvisorApiV2 "github.com/google/cadvisor/info/v2"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+func TestParseEvictionThresholds(t *testing.T) {
+	tests := []struct {
+		hard, soft, gracePeriods string
+		expected                 []EvictionThreshold
+		expectErr                bool
+	}{
+		{},
+		{
+			hard: "memory.available<100Mi, nodefs.available<1Gi",
+			expected: []EvictionThreshold{
+				{Signal: SignalMemoryAvailable, Value: 100 * 1024 * 1024},
+				{Signal: SignalNodeFsAvailable, Value: 1024 * 1024 * 1024},
+			},
+		},
+		{
+			hard:         "memory.available<100Mi",
+			soft:         "memory.available<200Mi,imagefs.available<2Gi",
+			gracePeriods: "memory.available=1m30s,imagefs.available=5m",
+			expected: []EvictionThreshold{
+				{Signal: SignalMemoryAvailable, Value: 100 * 1024 * 1024},
+				{Signal: SignalMemoryAvailable, Value: 200 * 1024 * 1024, GracePeriod: 90 * time.Second},
+				{Signal: SignalImageFsAvailable, Value: 2 * 1024 * 1024 * 1024, GracePeriod: 5 * time.Minute},
+			},
+		},
+		{hard: "cpu.available<1", expectErr: true},
+		{hard: "memory.available>100Mi", expectErr: true},
+		{hard: "memory.available<lots", expectErr: true},
+		{hard: "memory.available<0", expectErr: true},
+		{hard: "memory.available<1Mi,memory.available<2Mi", expectErr: true},
+		{soft: "memory.available<100Mi", expectErr: true},
+		{soft: "memory.available<100Mi", gracePeriods: "memory.available=soon", expectErr: true},
+		{hard: "memory.available<100Mi", gracePeriods: "memory.available=1m", expectErr: true},
+	}
+	for i, test := range tests {
+		thresholds, err := ParseEvictionThresholds(test.hard, test.soft, test.gracePeriods)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%d: expected an error, got %v", i, thresholds)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, thresholds) {
+			t.Errorf("%d: expected %v, got %v", i, test.expected, thresholds)
+		}
+	}
+}
+
+// evictionTestPod returns a pod whose single container requests and is
+// limited to the given amounts of memory. Empty amounts are left unset.
+func evictionTestPod(name, request, limit string) *api.Pod {
+	resources := api.ResourceRequirements{Requests: api.ResourceList{}, Limits: api.ResourceList{}}
+	if request != "" {
+		resources.Requests[api.ResourceMemory] = resource.MustParse(request)
+	}
+	if limit != "" {
+		resources.Limits[api.ResourceMemory] = resource.MustParse(limit)
+		resources.Limits[api.ResourceCPU] = resource.MustParse("100m")
+		if request != "" {
+			resources.Requests[api.ResourceCPU] = resource.MustParse("100m")
+		}
+	}
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:        name,
+			Namespace:   "test",
+			UID:         types.UID(name),
+			Annotations: map[string]string{ConfigSourceAnnotationKey: ApiserverSource},
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "c", Resources: resources}},
+		},
+	}
+}
+
+type fakeEvictionTarget struct {
+	usage   map[types.UID]podUsage
+	evicted []*api.Pod
+	status  api.PodStatus
+}
+
+func (f *fakeEvictionTarget) podUsage(pod *api.Pod) (podUsage, error) {
+	usage, ok := f.usage[pod.UID]
+	if !ok {
+		return podUsage{}, fmt.Errorf("no usage for pod %q", pod.Name)
+	}
+	return usage, nil
+}
+
+func (f *fakeEvictionTarget) evictPod(pod *api.Pod, status api.PodStatus) error {
+	f.evicted = append(f.evicted, pod)
+	f.status = status
+	return nil
+}
+
+func newTestEvictionManager(policy EvictionPolicy, target *fakeEvictionTarget) (*realEvictionManager, *cadvisor.Mock, *util.FakeClock) {
+	mockCadvisor := &cadvisor.Mock{}
+	clock := &util.FakeClock{Time: time.Now()}
+	m := newEvictionManager(mockCadvisor, policy, &record.FakeRecorder{}, target.podUsage, target.evictPod).(*realEvictionManager)
+	m.clock = clock
+	return m, mockCadvisor, clock
+}
+
+func mockMemoryAvailable(mockCadvisor *cadvisor.Mock, capacity int64, workingSet uint64) {
+	mockCadvisor.On("MachineInfo").Return(&cadvisorApi.MachineInfo{MemoryCapacity: capacity}, nil)
+	mockCadvisor.On("ContainerInfo", "/", &cadvisorApi.ContainerInfoRequest{NumStats: 1}).Return(&cadvisorApi.ContainerInfo{
+		Stats: []*cadvisorApi.ContainerStats{{Memory: cadvisorApi.MemoryStats{WorkingSet: workingSet}}},
+	}, nil)
+}
+
+func TestEvictionManagerMemoryPressure(t *testing.T) {
+	guaranteed := evictionTestPod("guaranteed", "100", "100")
+	burstable := evictionTestPod("burstable", "100", "")
+	bestEffort := evictionTestPod("best-effort", "", "")
+	target := &fakeEvictionTarget{usage: map[types.UID]podUsage{
+		guaranteed.UID: {Memory: 100},
+		burstable.UID:  {Memory: 300},
+		bestEffort.UID: {Memory: 10},
+	}}
+	policy := EvictionPolicy{
+		Thresholds:               []EvictionThreshold{{Signal: SignalMemoryAvailable, Value: 100}},
+		PressureTransitionPeriod: 5 * time.Minute,
+	}
+	m, mockCadvisor, clock := newTestEvictionManager(policy, target)
+	mockMemoryAvailable(mockCadvisor, 1000, 950)
+
+	if err := m.Synchronize([]*api.Pod{guaranteed, burstable, bestEffort}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 1 || target.evicted[0] != bestEffort {
+		t.Fatalf("expected the best-effort pod to be evicted, got %v", target.evicted)
+	}
+	if target.status.Phase != api.PodFailed || target.status.Reason != "Evicted" {
+		t.Errorf("unexpected status for the evicted pod: %+v", target.status)
+	}
+	if !m.IsUnderPressure(api.NodeMemoryPressure) {
+		t.Errorf("expected the node to be under memory pressure")
+	}
+	if m.IsUnderPressure(api.NodeDiskPressure) {
+		t.Errorf("unexpected disk pressure")
+	}
+
+	// Burstable pods using more than their request go next.
+	if err := m.Synchronize([]*api.Pod{guaranteed, burstable}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 2 || target.evicted[1] != burstable {
+		t.Fatalf("expected the burstable pod to be evicted, got %v", target.evicted)
+	}
+
+	// The pressure condition is kept for the transition period after the
+	// threshold stops being met.
+	mockCadvisor.ExpectedCalls = nil
+	mockMemoryAvailable(mockCadvisor, 1000, 500)
+	clock.Time = clock.Time.Add(time.Minute)
+	if err := m.Synchronize([]*api.Pod{guaranteed}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 2 {
+		t.Errorf("unexpected eviction: %v", target.evicted)
+	}
+	if !m.IsUnderPressure(api.NodeMemoryPressure) {
+		t.Errorf("expected the node to still be under memory pressure")
+	}
+	clock.Time = clock.Time.Add(5 * time.Minute)
+	if m.IsUnderPressure(api.NodeMemoryPressure) {
+		t.Errorf("expected the memory pressure to be over")
+	}
+}
+
+func TestEvictionManagerSoftThreshold(t *testing.T) {
+	pod := evictionTestPod("pod", "", "")
+	target := &fakeEvictionTarget{usage: map[types.UID]podUsage{pod.UID: {Disk: 10}}}
+	policy := EvictionPolicy{
+		Thresholds: []EvictionThreshold{{Signal: SignalNodeFsAvailable, Value: 100, GracePeriod: time.Minute}},
+	}
+	m, mockCadvisor, clock := newTestEvictionManager(policy, target)
+	mockCadvisor.On("RootFsInfo").Return(cadvisorApiV2.FsInfo{Available: 50}, nil)
+
+	if err := m.Synchronize([]*api.Pod{pod}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 0 {
+		t.Errorf("unexpected eviction before the grace period: %v", target.evicted)
+	}
+	if !m.IsUnderPressure(api.NodeDiskPressure) {
+		t.Errorf("expected the node to be under disk pressure")
+	}
+
+	clock.Time = clock.Time.Add(time.Minute)
+	if err := m.Synchronize([]*api.Pod{pod}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 1 {
+		t.Errorf("expected the pod to be evicted after the grace period, got %v", target.evicted)
+	}
+}
+
+func TestEvictionManagerDiskPressure(t *testing.T) {
+	small := evictionTestPod("small", "", "")
+	large := evictionTestPod("large", "", "")
+	guaranteed := evictionTestPod("guaranteed", "100", "100")
+	static := evictionTestPod("static", "", "")
+	static.Annotations[ConfigSourceAnnotationKey] = FileSource
+	target := &fakeEvictionTarget{usage: map[types.UID]podUsage{
+		small.UID:      {Disk: 10},
+		large.UID:      {Disk: 1000},
+		guaranteed.UID: {Disk: 5000},
+		static.UID:     {Disk: 10000},
+	}}
+	policy := EvictionPolicy{
+		Thresholds: []EvictionThreshold{{Signal: SignalImageFsAvailable, Value: 100}},
+	}
+	m, mockCadvisor, _ := newTestEvictionManager(policy, target)
+	mockCadvisor.On("DockerImagesFsInfo").Return(cadvisorApiV2.FsInfo{Available: 50}, nil)
+
+	if err := m.Synchronize([]*api.Pod{static, guaranteed, small, large}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 1 || target.evicted[0] != large {
+		t.Fatalf("expected the pod using the most disk space to be evicted, got %v", target.evicted)
+	}
+
+	// Static pods are never evicted.
+	if err := m.Synchronize([]*api.Pod{static}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(target.evicted) != 1 {
+		t.Errorf("unexpected eviction: %v", target.evicted)
+	}
+}
+
+func TestHandleNodePressure(t *testing.T) {
+	testKubelet := newTestKubelet(t)
+	kl := testKubelet.kubelet
+	m, mockCadvisor, _ := newTestEvictionManager(EvictionPolicy{
+		Thresholds:               []EvictionThreshold{{Signal: SignalMemoryAvailable, Value: 100}},
+		PressureTransitionPeriod: time.Minute,
+	}, &fakeEvictionTarget{})
+	mockMemoryAvailable(mockCadvisor, 1000, 950)
+	if err := m.Synchronize(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kl.evictionManager = m
+
+	guaranteed := evictionTestPod("guaranteed", "100", "100")
+	bestEffort := evictionTestPod("best-effort", "", "")
+	running := evictionTestPod("running", "", "")
+	pods := []*api.Pod{guaranteed, bestEffort, running}
+	podSyncTypes := map[types.UID]SyncPodType{
+		guaranteed.UID: SyncPodCreate,
+		bestEffort.UID: SyncPodCreate,
+		running.UID:    SyncPodUpdate,
+	}
+	admitted := kl.handleNodePressure(pods, podSyncTypes)
+	if !reflect.DeepEqual([]*api.Pod{guaranteed, running}, admitted) {
+		t.Errorf("expected the new best-effort pod to be rejected, got %v", admitted)
+	}
+	status, ok := kl.statusManager.GetPodStatus(kubecontainer.GetPodFullName(bestEffort))
+	if !ok || status.Phase != api.PodFailed || status.Reason != string(api.NodeMemoryPressure) {
+		t.Errorf("unexpected status for the rejected pod: %+v", status)
+	}
+}
@@ -50,6 +50,8 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/envvars"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/pleg"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	"k8s.io/kubernetes/pkg/kubelet/rkt"
 	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
 	kubeletUtil "k8s.io/kubernetes/pkg/kubelet/util"
@@ -60,6 +62,7 @@ import (
 	utilErrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/mount"
 	nodeutil "k8s.io/kubernetes/pkg/util/node"
+	"k8s.io/kubernetes/pkg/util/oom"
 	"k8s.io/kubernetes/pkg/version"
 	"k8s.io/kubernetes/pkg/volume"
 	"k8s.io/kubernetes/pkg/watch"
@@ -78,6 +81,15 @@ const (
 
 	// Location of container logs.
 	containerLogsDir = "/var/log/containers"
+
+	// The maximum delay between restarts of a failing container, or pulls of
+	// an image that cannot be pulled.
+	MaxContainerBackOff = 300 * time.Second
+
+	// The period between relists of the containers by the PLEG.
+	plegRelistPeriod = time.Second
+	// The number of PLEG events buffered before the kubelet handles them.
+	plegChannelCapacity = 1000
 )
 
 var (
@@ -94,6 +106,9 @@ type SyncHandler interface {
 	// syncing began (for use in monitoring).
 	SyncPods(pods []*api.Pod, podSyncTypes map[types.UID]SyncPodType, mirrorPods map[string]*api.Pod,
 		startTime time.Time) error
+
+	// Syncs only the specified pods, e.g. because their containers changed.
+	HandlePodSyncs(pods []*api.Pod)
 }
 
 type SourcesReadyFn func() bool
@@ -138,6 +153,8 @@ func NewMainKubelet(
 	cadvisorInterface cadvisor.Interface,
 	imageGCPolicy ImageGCPolicy,
 	diskSpacePolicy DiskSpacePolicy,
+	evictionPolicy EvictionPolicy,
+	reservation Reservation,
 	cloud cloudprovider.Interface,
 	nodeStatusUpdateFrequency time.Duration,
 	resourceContainer string,
@@ -232,6 +249,7 @@ func NewMainKubelet(
 		kubeClient:                     kubeClient,
 		rootDirectory:                  rootDirectory,
 		resyncInterval:                 resyncInterval,
+		backOff:                        util.NewBackOff(resyncInterval, MaxContainerBackOff),
 		containerRefManager:            containerRefManager,
 		readinessManager:               readinessManager,
 		httpClient:                     &http.Client{},
@@ -260,13 +278,14 @@ func NewMainKubelet(
 		resourceContainer:              resourceContainer,
 		os:                             osInterface,
 		oomWatcher:                     oomWatcher,
-		cgroupRoot:                     cgroupRoot,
 		mounter:                        mounter,
 		configureCBR0:                  configureCBR0,
 		podCIDR:                        podCIDR,
 		pods:                           pods,
+		reservation:                    reservation,
 		syncLoopMonitor:                util.AtomicValue{},
 	}
+	klet.evictionManager = newEvictionManager(cadvisorInterface, evictionPolicy, recorder, klet.getPodUsage, klet.evictPod)
 
 	if plug, err := network.InitNetworkPlugin(networkPlugins, networkPluginName, &networkHost{klet}); err != nil {
 		return nil, err
@@ -274,6 +293,12 @@ func NewMainKubelet(
 		klet.networkPlugin = plug
 	}
 
+	machineInfo, err := klet.GetCachedMachineInfo()
+	if err != nil {
+		return nil, err
+	}
+	oomAdjuster := oom.NewOomAdjuster()
+
 	// Initialize the runtime.
 	switch containerRuntime {
 	case "docker":
@@ -292,7 +317,9 @@ func NewMainKubelet(
 			klet,
 			klet.httpClient,
 			newKubeletRuntimeHooks(recorder),
-			dockerExecHandler)
+			dockerExecHandler,
+			machineInfo,
+			oomAdjuster)
 	case "rkt":
 		conf := &rkt.Config{InsecureSkipVerify: true}
 		rktRuntime, err := rkt.New(
@@ -315,7 +342,7 @@ func NewMainKubelet(
 
 	// Setup container manager, can fail if the devices hierarchy is not mounted
 	// (it is required by Docker however).
-	containerManager, err := newContainerManager(cadvisorInterface, dockerDaemonContainer, systemContainer, resourceContainer)
+	containerManager, err := newContainerManager(cadvisorInterface, dockerDaemonContainer, systemContainer, resourceContainer, cgroupRoot, reservation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the Container Manager: %v", err)
 	}
@@ -341,7 +368,9 @@ func NewMainKubelet(
 		return nil, err
 	}
 	klet.runtimeCache = runtimeCache
-	klet.podWorkers = newPodWorkers(runtimeCache, klet.syncPod, recorder)
+	klet.podCache = kubecontainer.NewCache()
+	klet.podWorkers = newPodWorkers(runtimeCache, klet.podCache, klet.syncPod, recorder)
+	klet.pleg = pleg.NewGenericPLEG(klet.containerRuntime, klet.podManager, klet.podCache, plegChannelCapacity, plegRelistPeriod)
 
 	metrics.Register(runtimeCache)
 
@@ -385,6 +414,15 @@ type Kubelet struct {
 
 	podManager podManager
 
+	// Caches the status of the pods reported by the container runtime.
+	podCache kubecontainer.Cache
+	// Observes the containers of the pods, updates the pod cache and
+	// triggers the sync of the pods whose containers changed.
+	pleg pleg.PodLifecycleEventGenerator
+
+	// Delays restarts of failing containers and retries of failing image pulls.
+	backOff *util.Backoff
+
 	// Needed to report events for containers belonging to deleted/modified pods.
 	// Tracks references for reporting events
 	containerRefManager *kubecontainer.RefManager
@@ -452,6 +490,9 @@ type Kubelet struct {
 	// Diskspace manager.
 	diskSpaceManager diskSpaceManager
 
+	// Evicts pods when the node is low on memory or disk space.
+	evictionManager evictionManager
+
 	// Cached MachineInfo returned by cadvisor.
 	machineInfo *cadvisorApi.MachineInfo
 
@@ -492,9 +533,6 @@ type Kubelet struct {
 	// Watcher of out of memory events.
 	oomWatcher OOMWatcher
 
-	// If non-empty, pass this to the container runtime as the root cgroup.
-	cgroupRoot string
-
 	// Mounter to use for volumes.
 	mounter mount.Interface
 
@@ -509,6 +547,10 @@ type Kubelet struct {
 	// Number of Pods which can be run by this Kubelet
 	pods int
 
+	// Resources reserved for the system and the Kubernetes components, which
+	// are not allocatable to pods.
+	reservation Reservation
+
 	// Monitor Kubelet's sync loop
 	syncLoopMonitor util.AtomicValue
 }
@@ -723,9 +765,11 @@ func (kl *Kubelet) Run(updates <-chan PodUpdate) {
 	}
 
 	go util.Until(kl.updateRuntimeUp, 5*time.Second, util.NeverStop)
+	go util.Until(kl.synchronizeEviction, evictionMonitoringPeriod, util.NeverStop)
 
 	// Run the system oom watcher forever.
 	kl.statusManager.Start()
+	kl.pleg.Start()
 	kl.syncLoop(updates, kl)
 }
 
@@ -891,7 +935,7 @@ func makePortMappings(container *api.Container) (ports []kubecontainer.PortMappi
 // the container runtime to set parameters for launching a container.
 func (kl *Kubelet) GenerateRunContainerOptions(pod *api.Pod, container *api.Container) (*kubecontainer.RunContainerOptions, error) {
 	var err error
-	opts := &kubecontainer.RunContainerOptions{CgroupParent: kl.cgroupRoot}
+	opts := &kubecontainer.RunContainerOptions{CgroupParent: kl.containerManager.GetPodCgroupParent(pod)}
 
 	vol, ok := kl.volumeManager.GetVolumes(pod.UID)
 	if !ok {
@@ -1106,9 +1150,10 @@ func parseResolvConf(reader io.Reader) (nameservers []string, searches []string,
 	return nameservers, searches, nil
 }
 
-// Kill all running containers in a pod (includes the pod infra container).
-func (kl *Kubelet) killPod(pod kubecontainer.Pod) error {
-	return kl.containerRuntime.KillPod(pod)
+// Kill all running containers in a pod (includes the pod infra container). The pod
+// is nil for pods that are no longer known to the kubelet.
+func (kl *Kubelet) killPod(pod *api.Pod, runningPod kubecontainer.Pod) error {
+	return kl.containerRuntime.KillPod(pod, runningPod)
 }
 
 type empty struct{}
@@ -1163,10 +1208,13 @@ func (kl *Kubelet) syncPod(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecont
 		}
 	}()
 
-	// Kill pods we can't run.
+	// Kill pods we can't run, and pods that have been gracefully deleted. Once the containers
+	// have stopped, the status manager confirms the deletion with the apiserver.
 	err := canRunPod(pod)
-	if err != nil {
-		kl.killPod(runningPod)
+	if err != nil || pod.DeletionTimestamp != nil {
+		if err := kl.killPod(pod, runningPod); err != nil {
+			glog.Errorf("Failed killing pod %q: %v", podFullName, err)
+		}
 		return err
 	}
 
@@ -1175,6 +1223,12 @@ func (kl *Kubelet) syncPod(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecont
 		return err
 	}
 
+	// Create the cgroup of the pod before any of its containers.
+	if err := kl.containerManager.EnsurePodCgroup(pod); err != nil {
+		glog.Errorf("Unable to create the cgroup of pod %q: %v", podFullName, err)
+		return err
+	}
+
 	// Starting phase:
 	ref, err := api.GetReference(pod)
 	if err != nil {
@@ -1240,15 +1294,16 @@ func (kl *Kubelet) syncPod(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecont
 		return err
 	}
 
-	err = kl.containerRuntime.SyncPod(pod, runningPod, podStatus, pullSecrets)
+	err = kl.containerRuntime.SyncPod(pod, runningPod, podStatus, pullSecrets, kl.backOff)
 	if err != nil {
 		return err
 	}
 
 	if isStaticPod(pod) {
-		if mirrorPod != nil && !kl.podManager.IsMirrorPodOf(mirrorPod, pod) {
-			// The mirror pod is semantically different from the static pod. Remove
-			// it. The mirror pod will get recreated later.
+		if mirrorPod != nil && (mirrorPod.DeletionTimestamp != nil || !kl.podManager.IsMirrorPodOf(mirrorPod, pod)) {
+			// The mirror pod is semantically different from the static pod, or it
+			// has been deleted through the API. Remove it. The mirror pod will get
+			// recreated later.
 			glog.Errorf("Deleting mirror pod %q because it is outdated", podFullName)
 			if err := kl.podManager.DeleteMirrorPod(podFullName); err != nil {
 				glog.Errorf("Failed deleting mirror pod %q: %v", podFullName, err)
@@ -1462,6 +1517,11 @@ func (kl *Kubelet) SyncPods(allPods []*api.Pod, podSyncTypes map[types.UID]SyncP
 		glog.Errorf("Failed killing unwanted containers: %v", err)
 	}
 
+	// Remove the cgroups of the killed pods.
+	if err := kl.containerManager.UpdatePodCgroups(pods); err != nil {
+		glog.Errorf("Failed updating pod cgroups: %v", err)
+	}
+
 	// Note that we just killed the unwanted pods. This may not have reflected
 	// in the cache. We need to bypass the cache to get the latest set of
 	// running pods to clean up the volumes.
@@ -1495,6 +1555,9 @@ func (kl *Kubelet) SyncPods(allPods []*api.Pod, podSyncTypes map[types.UID]SyncP
 	// Remove any orphaned mirror pods.
 	kl.podManager.DeleteOrphanedMirrorPods()
 
+	// Forget the backoff of containers that have not failed for a while.
+	kl.backOff.GC()
+
 	return err
 }
 
@@ -1517,7 +1580,7 @@ func (kl *Kubelet) killUnwantedPods(desiredPods map[types.UID]empty,
 			}()
 			glog.V(1).Infof("Killing unwanted pod %q", pod.Name)
 			// Stop the containers.
-			err = kl.killPod(*pod)
+			err = kl.killPod(nil, *pod)
 			if err != nil {
 				glog.Errorf("Failed killing the pod %q: %v", pod.Name, err)
 				return
@@ -1579,8 +1642,8 @@ func (kl *Kubelet) checkCapacityExceeded(pods []*api.Pod) (fitting []*api.Pod, n
 	// Respect the pod creation order when resolving conflicts.
 	sort.Sort(podsByCreationTime(pods))
 
-	capacity := CapacityFromMachineInfo(info)
-	return predicates.CheckPodsExceedingCapacity(pods, capacity)
+	allocatable := allocatableFromCapacity(CapacityFromMachineInfo(info), kl.reservation)
+	return predicates.CheckPodsExceedingCapacity(pods, allocatable)
 }
 
 // handleOutOfDisk detects if pods can't fit due to lack of disk space.
@@ -1631,6 +1694,54 @@ func (kl *Kubelet) handleOutOfDisk(pods []*api.Pod, podSyncTypes map[types.UID]S
 	return fitting
 }
 
+// synchronizeEviction evicts a pod if the node is running low on memory or
+// disk space.
+func (kl *Kubelet) synchronizeEviction() {
+	allPods := kl.podManager.GetPods()
+	if err := kl.evictionManager.Synchronize(kl.filterOutTerminatedPods(allPods)); err != nil {
+		glog.Errorf("Failed to synchronize the eviction manager: %v", err)
+	}
+}
+
+// handleNodePressure rejects new pods that would be evicted because of the
+// pressure on the node's resources: best-effort pods under memory pressure,
+// and all pods under disk pressure.
+func (kl *Kubelet) handleNodePressure(pods []*api.Pod, podSyncTypes map[types.UID]SyncPodType) []*api.Pod {
+	if len(podSyncTypes) == 0 {
+		// regular sync. no new pods
+		return pods
+	}
+	memoryPressure := kl.evictionManager.IsUnderPressure(api.NodeMemoryPressure)
+	diskPressure := kl.evictionManager.IsUnderPressure(api.NodeDiskPressure)
+	if !memoryPressure && !diskPressure {
+		return pods
+	}
+
+	var fitting []*api.Pod
+	for i := range pods {
+		pod := pods[i]
+		// Only reject pods that didn't start yet.
+		if podSyncTypes[pod.UID] == SyncPodCreate {
+			var reason, resourceName string
+			if diskPressure {
+				reason, resourceName = string(api.NodeDiskPressure), "disk space"
+			} else if qos.GetPodQOS(pod) == api.PodQOSBestEffort {
+				reason, resourceName = string(api.NodeMemoryPressure), "memory"
+			}
+			if reason != "" {
+				kl.recorder.Eventf(pod, reason, "Cannot start the pod because the node is low on %s.", resourceName)
+				kl.statusManager.SetPodStatus(pod, api.PodStatus{
+					Phase:   api.PodFailed,
+					Reason:  reason,
+					Message: fmt.Sprintf("Pod cannot be started because the node is low on %s.", resourceName)})
+				continue
+			}
+		}
+		fitting = append(fitting, pod)
+	}
+	return fitting
+}
+
 // checkNodeSelectorMatching detects pods that do not match node's labels.
 func (kl *Kubelet) checkNodeSelectorMatching(pods []*api.Pod) (fitting []*api.Pod, notFitting []*api.Pod) {
 	if kl.standaloneMode {
@@ -1713,6 +1824,9 @@ func (kl *Kubelet) admitPods(allPods []*api.Pod, podSyncTypes map[types.UID]Sync
 	// Reject new creation requests if diskspace is running low.
 	admittedPods := kl.handleOutOfDisk(fitting, podSyncTypes)
 
+	// Reject new creation requests the eviction manager would reclaim.
+	admittedPods = kl.handleNodePressure(admittedPods, podSyncTypes)
+
 	return admittedPods
 }
 
@@ -1751,6 +1865,14 @@ func (kl *Kubelet) syncLoopIteration(updates <-chan PodUpdate, handler SyncHandl
 		kl.podManager.UpdatePods(u, podSyncTypes)
 		unsyncedPod = true
 		kl.syncLoopMonitor.Store(time.Now())
+	case e := <-kl.pleg.Watch():
+		// The containers of a pod changed; only sync this pod.
+		glog.V(4).Infof("Pod lifecycle event %+v", e)
+		if pod, ok := kl.podManager.GetPodByUID(e.ID); ok {
+			handler.HandlePodSyncs([]*api.Pod{pod})
+		}
+		kl.syncLoopMonitor.Store(time.Now())
+		return
 	case <-time.After(kl.resyncInterval):
 		glog.V(4).Infof("Periodic sync")
 	}
@@ -1775,6 +1897,17 @@ func (kl *Kubelet) syncLoopIteration(updates <-chan PodUpdate, handler SyncHandl
 	kl.syncLoopMonitor.Store(time.Now())
 }
 
+// HandlePodSyncs syncs the given pods without syncing all the other pods.
+func (kl *Kubelet) HandlePodSyncs(pods []*api.Pod) {
+	start := time.Now()
+	_, mirrorPods := kl.podManager.GetPodsAndMirrorMap()
+	for _, pod := range pods {
+		kl.podWorkers.UpdatePod(pod, mirrorPods[kubecontainer.GetPodFullName(pod)], func() {
+			metrics.PodWorkerLatency.WithLabelValues(SyncPodSync.String()).Observe(metrics.SinceInMicroseconds(start))
+		})
+	}
+}
+
 func (kl *Kubelet) LatestLoopEntryTime() time.Time {
 	val := kl.syncLoopMonitor.Load()
 	if val == nil {
@@ -1803,6 +1936,9 @@ func (kl *Kubelet) validateContainerStatus(podStatus *api.PodStatus, containerNa
 	var cID string
 
 	cStatus, found := api.GetContainerStatus(podStatus.ContainerStatuses, containerName)
+	if !found {
+		cStatus, found = api.GetContainerStatus(podStatus.InitContainerStatuses, containerName)
+	}
 	if !found {
 		return "", fmt.Errorf("container %q not found in pod", containerName)
 	}
@@ -1823,7 +1959,7 @@ func (kl *Kubelet) validateContainerStatus(podStatus *api.PodStatus, containerNa
 // GetKubeletContainerLogs returns logs from the container
 // TODO: this method is returning logs of random container attempts, when it should be returning the most recent attempt
 // or all of them.
-func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
+func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 	// TODO(vmarmol): Refactor to not need the pod status and verification.
 	// Pod workers periodically write status to statusManager. If status is not
 	// cached there, something is wrong (or kubelet just restarted and hasn't
@@ -1836,7 +1972,7 @@ func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName, tail stri
 		// No log is available if pod is not in a "known" phase (e.g. Unknown).
 		return err
 	}
-	containerID, err := kl.validateContainerStatus(&podStatus, containerName, previous)
+	containerID, err := kl.validateContainerStatus(&podStatus, containerName, logOptions.Previous)
 	if err != nil {
 		// No log is available if the container status is missing or is in the
 		// waiting state.
@@ -1846,7 +1982,7 @@ func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName, tail stri
 	if !ok {
 		return fmt.Errorf("unable to get logs for container %q in pod %q: unable to find pod", containerName, podFullName)
 	}
-	return kl.containerRuntime.GetContainerLogs(pod, containerID, tail, follow, stdout, stderr)
+	return kl.containerRuntime.GetContainerLogs(pod, containerID, logOptions, stdout, stderr)
 }
 
 // GetHostname Returns the hostname as the kubelet sees it.
@@ -2040,6 +2176,13 @@ func (kl *Kubelet) setNodeStatus(node *api.Node) error {
 		node.Status.Capacity = CapacityFromMachineInfo(info)
 		node.Status.Capacity[api.ResourcePods] = *resource.NewQuantity(
 			int64(kl.pods), resource.DecimalSI)
+		if fsInfo, err := kl.cadvisor.RootFsInfo(); err != nil {
+			glog.Errorf("Error getting root filesystem info: %v", err)
+		} else if fsInfo.Capacity > 0 {
+			node.Status.Capacity[api.ResourceStorage] = *resource.NewQuantity(
+				int64(fsInfo.Capacity), resource.BinarySI)
+		}
+		node.Status.Allocatable = allocatableFromCapacity(node.Status.Capacity, kl.reservation)
 		if node.Status.NodeInfo.BootID != "" &&
 			node.Status.NodeInfo.BootID != info.BootID {
 			// TODO: This requires a transaction, either both node status is updated
@@ -2118,6 +2261,8 @@ func (kl *Kubelet) setNodeStatus(node *api.Node) error {
 			kl.recordNodeStatusEvent("NodeNotReady")
 		}
 	}
+	kl.setNodePressureCondition(node, api.NodeMemoryPressure, currentTime)
+	kl.setNodePressureCondition(node, api.NodeDiskPressure, currentTime)
 	if oldNodeUnschedulable != node.Spec.Unschedulable {
 		if node.Spec.Unschedulable {
 			kl.recordNodeStatusEvent("NodeNotSchedulable")
@@ -2129,6 +2274,46 @@ func (kl *Kubelet) setNodeStatus(node *api.Node) error {
 	return nil
 }
 
+// setNodePressureCondition reports whether the eviction manager observed the
+// given pressure condition on the node.
+func (kl *Kubelet) setNodePressureCondition(node *api.Node, conditionType api.NodeConditionType, currentTime util.Time) {
+	newCondition := api.NodeCondition{
+		Type:              conditionType,
+		Status:            api.ConditionFalse,
+		Reason:            fmt.Sprintf("kubelet has no %s", conditionType),
+		LastHeartbeatTime: currentTime,
+	}
+	if kl.evictionManager.IsUnderPressure(conditionType) {
+		newCondition.Status = api.ConditionTrue
+		newCondition.Reason = fmt.Sprintf("kubelet has %s", conditionType)
+	}
+
+	var oldCondition *api.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == conditionType {
+			oldCondition = &node.Status.Conditions[i]
+		}
+	}
+	if oldCondition != nil && oldCondition.Status == newCondition.Status {
+		newCondition.LastTransitionTime = oldCondition.LastTransitionTime
+	} else {
+		newCondition.LastTransitionTime = currentTime
+	}
+	if oldCondition != nil {
+		*oldCondition = newCondition
+	} else {
+		node.Status.Conditions = append(node.Status.Conditions, newCondition)
+	}
+
+	if (oldCondition == nil && newCondition.Status == api.ConditionTrue) || (oldCondition != nil && oldCondition.Status != newCondition.Status) {
+		if newCondition.Status == api.ConditionTrue {
+			kl.recordNodeStatusEvent("NodeHas" + string(conditionType))
+		} else {
+			kl.recordNodeStatusEvent("NodeHasNo" + string(conditionType))
+		}
+	}
+}
+
 func (kl *Kubelet) containerRuntimeUp() bool {
 	kl.runtimeMutex.Lock()
 	defer kl.runtimeMutex.Unlock()
@@ -2185,7 +2370,18 @@ func GetPhase(spec *api.PodSpec, info []api.ContainerStatus) api.PodPhase {
 					failed++
 				}
 			} else if containerStatus.State.Waiting != nil {
-				waiting++
+				if lastTerminated := containerStatus.LastTerminationState.Terminated; lastTerminated != nil {
+					// The container is waiting to be restarted, e.g. in a
+					// crash loop backoff.
+					stopped++
+					if lastTerminated.ExitCode == 0 {
+						succeeded++
+					} else {
+						failed++
+					}
+				} else {
+					waiting++
+				}
 			} else {
 				unknown++
 			}
@@ -2252,6 +2448,17 @@ func getPodReadyCondition(spec *api.PodSpec, statuses []api.ContainerStatus) []a
 	return ready
 }
 
+// getRuntimePodStatus returns the status of the pod reported by the container
+// runtime. The status is read from the pod cache, and the runtime is only
+// queried for pods the cache does not know about yet, e.g. pods without
+// containers.
+func (kl *Kubelet) getRuntimePodStatus(pod *api.Pod) (*api.PodStatus, error) {
+	if status, ok := kl.podCache.Get(pod.UID); ok {
+		return status, nil
+	}
+	return kl.containerRuntime.GetPodStatus(pod)
+}
+
 // By passing the pod directly, this method avoids pod lookup, which requires
 // grabbing a lock.
 func (kl *Kubelet) generatePodStatus(pod *api.Pod) (api.PodStatus, error) {
@@ -2275,7 +2482,7 @@ func (kl *Kubelet) generatePodStatus(pod *api.Pod) (api.PodStatus, error) {
 	}
 
 	spec := &pod.Spec
-	podStatus, err := kl.containerRuntime.GetPodStatus(pod)
+	podStatus, err := kl.getRuntimePodStatus(pod)
 
 	if err != nil {
 		// Error handling
@@ -2305,6 +2512,18 @@ func (kl *Kubelet) generatePodStatus(pod *api.Pod) (api.PodStatus, error) {
 		}
 	}
 
+	if next, failed, done := kubecontainer.FindActiveInitContainer(pod, podStatus); !done {
+		// The containers of the pod do not start before all the init
+		// containers succeeded.
+		if failed && spec.RestartPolicy == api.RestartPolicyNever {
+			podStatus.Phase = api.PodFailed
+			podStatus.Message = fmt.Sprintf("Init container %q failed", next.Name)
+		} else {
+			podStatus.Phase = api.PodPending
+			podStatus.Reason = kubecontainer.PodInitializing
+		}
+	}
+
 	podStatus.Conditions = append(podStatus.Conditions, getPodReadyCondition(spec, podStatus.ContainerStatuses)...)
 
 	if !kl.standaloneMode {
@@ -2433,6 +2652,47 @@ func (kl *Kubelet) GetContainerInfo(podFullName string, podUID types.UID, contai
 	return &ci, nil
 }
 
+// getPodUsage returns the memory working set and the disk space used by the
+// running containers of a pod.
+func (kl *Kubelet) getPodUsage(pod *api.Pod) (podUsage, error) {
+	pods, err := kl.runtimeCache.GetPods()
+	if err != nil {
+		return podUsage{}, err
+	}
+	runningPod := kubecontainer.Pods(pods).FindPodByID(pod.UID)
+	usage := podUsage{}
+	for _, container := range runningPod.Containers {
+		info, err := kl.cadvisor.DockerContainer(string(container.ID), &cadvisorApi.ContainerInfoRequest{NumStats: 1})
+		if err != nil {
+			return podUsage{}, err
+		}
+		if len(info.Stats) == 0 {
+			continue
+		}
+		stats := info.Stats[len(info.Stats)-1]
+		usage.Memory += int64(stats.Memory.WorkingSet)
+		for _, fs := range stats.Filesystem {
+			usage.Disk += int64(fs.Usage)
+		}
+	}
+	return usage, nil
+}
+
+// evictPod records the final status of a pod and kills its containers, so
+// the resources it uses are reclaimed without waiting for the next sync.
+func (kl *Kubelet) evictPod(pod *api.Pod, status api.PodStatus) error {
+	kl.statusManager.SetPodStatus(pod, status)
+	pods, err := kl.runtimeCache.GetPods()
+	if err != nil {
+		return err
+	}
+	runningPod := kubecontainer.Pods(pods).FindPodByID(pod.UID)
+	if runningPod.IsEmpty() {
+		return nil
+	}
+	return kl.killPod(pod, runningPod)
+}
+
 // Returns stats (from Cadvisor) for a non-Kubernetes container.
 func (kl *Kubelet) GetRawContainerInfo(containerName string, req *cadvisorApi.ContainerInfoRequest, subcontainers bool) (map[string]*cadvisorApi.ContainerInfo, error) {
 	if subcontainers {
@@ -2460,8 +2720,8 @@ func (kl *Kubelet) GetCachedMachineInfo() (*cadvisorApi.MachineInfo, error) {
 	return kl.machineInfo, nil
 }
 
-func (kl *Kubelet) ListenAndServe(address net.IP, port uint, tlsOptions *TLSOptions, enableDebuggingHandlers bool) {
-	ListenAndServeKubeletServer(kl, address, port, tlsOptions, enableDebuggingHandlers)
+func (kl *Kubelet) ListenAndServe(address net.IP, port uint, tlsOptions *TLSOptions, auth AuthInterface, enableDebuggingHandlers bool) {
+	ListenAndServeKubeletServer(kl, address, port, tlsOptions, auth, enableDebuggingHandlers)
 }
 
 func (kl *Kubelet) ListenAndServeReadOnly(address net.IP, port uint) {
@@ -44,6 +44,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/container"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/pleg"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
@@ -118,16 +119,20 @@ func newTestKubelet(t *testing.T) *TestKubelet {
 		t.Fatalf("can't initialize disk space manager: %v", err)
 	}
 	kubelet.diskSpaceManager = diskSpaceManager
+	kubelet.evictionManager = newEvictionManager(mockCadvisor, EvictionPolicy{}, fakeRecorder, kubelet.getPodUsage, kubelet.evictPod)
+	kubelet.backOff = util.NewBackOff(time.Second, time.Minute)
 
 	kubelet.containerRuntime = fakeRuntime
 	kubelet.runtimeCache = kubecontainer.NewFakeRuntimeCache(kubelet.containerRuntime)
+	kubelet.podCache = kubecontainer.NewCache()
+	kubelet.pleg = pleg.NewGenericPLEG(fakeRuntime, kubelet.podManager, kubelet.podCache, 100, time.Hour)
 	kubelet.podWorkers = &fakePodWorkers{
 		syncPodFn:    kubelet.syncPod,
 		runtimeCache: kubelet.runtimeCache,
 		t:            t,
 	}
 	kubelet.volumeManager = newVolumeManager()
-	kubelet.containerManager, _ = newContainerManager(mockCadvisor, "", "", "")
+	kubelet.containerManager, _ = newContainerManager(mockCadvisor, "", "", "", "", Reservation{})
 	kubelet.networkConfigured = true
 	return &TestKubelet{kubelet, fakeRuntime, mockCadvisor, fakeKubeClient, fakeMirrorClient}
 }
@@ -301,6 +306,63 @@ func TestKubeletDirsCompat(t *testing.T) {
 
 var emptyPodUIDs map[types.UID]SyncPodType
 
+type fakeSyncHandler struct {
+	syncedAll  bool
+	syncedPods []*api.Pod
+}
+
+func (h *fakeSyncHandler) SyncPods(pods []*api.Pod, podSyncTypes map[types.UID]SyncPodType, mirrorPods map[string]*api.Pod, startTime time.Time) error {
+	h.syncedAll = true
+	return nil
+}
+
+func (h *fakeSyncHandler) HandlePodSyncs(pods []*api.Pod) {
+	h.syncedPods = append(h.syncedPods, pods...)
+}
+
+func TestSyncLoopHandlesPodLifecycleEvents(t *testing.T) {
+	testKubelet := newTestKubelet(t)
+	kubelet := testKubelet.kubelet
+	kubelet.resyncInterval = time.Hour
+	kubelet.lastTimestampRuntimeUp = time.Now()
+	kubelet.networkConfigured = true
+	pods := []*api.Pod{
+		{
+			ObjectMeta: api.ObjectMeta{
+				UID:       "12345678",
+				Name:      "foo",
+				Namespace: "new",
+			},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{
+				UID:       "87654321",
+				Name:      "bar",
+				Namespace: "new",
+			},
+		},
+	}
+	kubelet.podManager.SetPods(pods)
+	handler := &fakeSyncHandler{}
+
+	// Only the pod whose containers changed is synced.
+	kubelet.pleg.Watch() <- &pleg.PodLifecycleEvent{ID: "12345678", Type: pleg.ContainerDied, ContainerID: "1234"}
+	kubelet.syncLoopIteration(make(chan PodUpdate), handler)
+	if handler.syncedAll {
+		t.Errorf("expected no sync of all the pods")
+	}
+	if !reflect.DeepEqual(handler.syncedPods, pods[:1]) {
+		t.Errorf("expected synced pods %#v, got %#v", pods[:1], handler.syncedPods)
+	}
+
+	// The events of unknown pods are ignored.
+	kubelet.pleg.Watch() <- &pleg.PodLifecycleEvent{ID: "unknown", Type: pleg.ContainerStarted, ContainerID: "5678"}
+	kubelet.syncLoopIteration(make(chan PodUpdate), handler)
+	if handler.syncedAll || len(handler.syncedPods) != 1 {
+		t.Errorf("expected no sync for the events of unknown pods, got %#v", handler.syncedPods)
+	}
+}
+
 func TestSyncLoopTimeUpdate(t *testing.T) {
 	testKubelet := newTestKubelet(t)
 	testKubelet.fakeCadvisor.On("MachineInfo").Return(&cadvisorApi.MachineInfo{}, nil)
@@ -1396,6 +1458,19 @@ func failedState(cName string) api.ContainerStatus {
 		},
 	}
 }
+func waitingStateWithLastTermination(cName string) api.ContainerStatus {
+	return api.ContainerStatus{
+		Name: cName,
+		State: api.ContainerState{
+			Waiting: &api.ContainerStateWaiting{},
+		},
+		LastTerminationState: api.ContainerState{
+			Terminated: &api.ContainerStateTerminated{
+				ExitCode: -1,
+			},
+		},
+	}
+}
 
 func TestPodPhaseWithRestartAlways(t *testing.T) {
 	desiredState := api.PodSpec{
@@ -1464,6 +1539,19 @@ func TestPodPhaseWithRestartAlways(t *testing.T) {
 			api.PodPending,
 			"mixed state #2 with restart always",
 		},
+		{
+			&api.Pod{
+				Spec: desiredState,
+				Status: api.PodStatus{
+					ContainerStatuses: []api.ContainerStatus{
+						runningState("containerA"),
+						waitingStateWithLastTermination("containerB"),
+					},
+				},
+			},
+			api.PodRunning,
+			"backoff crashloop container with restart always",
+		},
 	}
 	for _, test := range tests {
 		if status := GetPhase(&test.pod.Spec, test.pod.Status.ContainerStatuses); status != test.status {
@@ -2252,6 +2340,11 @@ func TestValidateContainerStatus(t *testing.T) {
 	}, "blah", false); err == nil {
 		t.Errorf("expected error with invalid container name")
 	}
+	if _, err := kubelet.validateContainerStatus(&api.PodStatus{
+		InitContainerStatuses: testCases[1].statuses,
+	}, containerName, false); err != nil {
+		t.Errorf("unexpected error for a terminated init container - %v", err)
+	}
 	if _, err := kubelet.validateContainerStatus(&api.PodStatus{
 		ContainerStatuses: testCases[0].statuses,
 	}, containerName, true); err != nil {
@@ -2280,6 +2373,17 @@ func TestUpdateNewNodeStatus(t *testing.T) {
 	}
 	mockCadvisor := testKubelet.fakeCadvisor
 	mockCadvisor.On("MachineInfo").Return(machineInfo, nil)
+	mockCadvisor.On("RootFsInfo").Return(cadvisorApiv2.FsInfo{Capacity: 4096}, nil)
+	kubelet.reservation = Reservation{
+		System: api.ResourceList{
+			api.ResourceCPU:    *resource.NewMilliQuantity(200, resource.DecimalSI),
+			api.ResourceMemory: *resource.NewQuantity(100, resource.BinarySI),
+		},
+		Kubernetes: api.ResourceList{
+			api.ResourceCPU:     *resource.NewMilliQuantity(300, resource.DecimalSI),
+			api.ResourceStorage: *resource.NewQuantity(1024, resource.BinarySI),
+		},
+	}
 	versionInfo := &cadvisorApi.VersionInfo{
 		KernelVersion:      "3.16.0-0.bpo.4-amd64",
 		ContainerOsVersion: "Debian GNU/Linux 7 (wheezy)",
@@ -2298,6 +2402,20 @@ func TestUpdateNewNodeStatus(t *testing.T) {
 					LastHeartbeatTime:  util.Time{},
 					LastTransitionTime: util.Time{},
 				},
+				{
+					Type:               api.NodeMemoryPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no MemoryPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
+				{
+					Type:               api.NodeDiskPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no DiskPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
 			},
 			NodeInfo: api.NodeSystemInfo{
 				MachineID:               "123",
@@ -2310,9 +2428,16 @@ func TestUpdateNewNodeStatus(t *testing.T) {
 				KubeProxyVersion:        version.Get().String(),
 			},
 			Capacity: api.ResourceList{
-				api.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
-				api.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
-				api.ResourcePods:   *resource.NewQuantity(0, resource.DecimalSI),
+				api.ResourceCPU:     *resource.NewMilliQuantity(2000, resource.DecimalSI),
+				api.ResourceMemory:  *resource.NewQuantity(1024, resource.BinarySI),
+				api.ResourcePods:    *resource.NewQuantity(0, resource.DecimalSI),
+				api.ResourceStorage: *resource.NewQuantity(4096, resource.BinarySI),
+			},
+			Allocatable: api.ResourceList{
+				api.ResourceCPU:     *resource.NewMilliQuantity(1500, resource.DecimalSI),
+				api.ResourceMemory:  *resource.NewQuantity(924, resource.BinarySI),
+				api.ResourcePods:    *resource.NewQuantity(0, resource.DecimalSI),
+				api.ResourceStorage: *resource.NewQuantity(3072, resource.BinarySI),
 			},
 			Addresses: []api.NodeAddress{{Type: api.NodeLegacyHostIP, Address: "127.0.0.1"}},
 		},
@@ -2339,8 +2464,10 @@ func TestUpdateNewNodeStatus(t *testing.T) {
 	if updatedNode.Status.Conditions[0].LastTransitionTime.IsZero() {
 		t.Errorf("unexpected zero last transition timestamp")
 	}
-	updatedNode.Status.Conditions[0].LastHeartbeatTime = util.Time{}
-	updatedNode.Status.Conditions[0].LastTransitionTime = util.Time{}
+	for i := range updatedNode.Status.Conditions {
+		updatedNode.Status.Conditions[i].LastHeartbeatTime = util.Time{}
+		updatedNode.Status.Conditions[i].LastTransitionTime = util.Time{}
+	}
 	if !reflect.DeepEqual(expectedNode, updatedNode) {
 		t.Errorf("unexpected objects: %s", util.ObjectDiff(expectedNode, updatedNode))
 	}
@@ -2381,6 +2508,7 @@ func TestUpdateExistingNodeStatus(t *testing.T) {
 		MemoryCapacity: 1024,
 	}
 	mockCadvisor.On("MachineInfo").Return(machineInfo, nil)
+	mockCadvisor.On("RootFsInfo").Return(cadvisorApiv2.FsInfo{}, nil)
 	versionInfo := &cadvisorApi.VersionInfo{
 		KernelVersion:      "3.16.0-0.bpo.4-amd64",
 		ContainerOsVersion: "Debian GNU/Linux 7 (wheezy)",
@@ -2399,6 +2527,20 @@ func TestUpdateExistingNodeStatus(t *testing.T) {
 					LastHeartbeatTime:  util.Time{}, // placeholder
 					LastTransitionTime: util.Time{}, // placeholder
 				},
+				{
+					Type:               api.NodeMemoryPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no MemoryPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
+				{
+					Type:               api.NodeDiskPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no DiskPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
 			},
 			NodeInfo: api.NodeSystemInfo{
 				MachineID:               "123",
@@ -2415,6 +2557,11 @@ func TestUpdateExistingNodeStatus(t *testing.T) {
 				api.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
 				api.ResourcePods:   *resource.NewQuantity(0, resource.DecimalSI),
 			},
+			Allocatable: api.ResourceList{
+				api.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+				api.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
+				api.ResourcePods:   *resource.NewQuantity(0, resource.DecimalSI),
+			},
 			Addresses: []api.NodeAddress{{Type: api.NodeLegacyHostIP, Address: "127.0.0.1"}},
 		},
 	}
@@ -2443,8 +2590,10 @@ func TestUpdateExistingNodeStatus(t *testing.T) {
 		t.Errorf("expected \n%#v\n, got \n%#v", updatedNode.Status.Conditions[0].LastTransitionTime.Rfc3339Copy(),
 			util.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC))
 	}
-	updatedNode.Status.Conditions[0].LastHeartbeatTime = util.Time{}
-	updatedNode.Status.Conditions[0].LastTransitionTime = util.Time{}
+	for i := range updatedNode.Status.Conditions {
+		updatedNode.Status.Conditions[i].LastHeartbeatTime = util.Time{}
+		updatedNode.Status.Conditions[i].LastTransitionTime = util.Time{}
+	}
 	if !reflect.DeepEqual(expectedNode, updatedNode) {
 		t.Errorf("expected \n%v\n, got \n%v", expectedNode, updatedNode)
 	}
@@ -2471,6 +2620,7 @@ func TestUpdateNodeStatusWithoutContainerRuntime(t *testing.T) {
 		MemoryCapacity: 1024,
 	}
 	mockCadvisor.On("MachineInfo").Return(machineInfo, nil)
+	mockCadvisor.On("RootFsInfo").Return(cadvisorApiv2.FsInfo{}, nil)
 	versionInfo := &cadvisorApi.VersionInfo{
 		KernelVersion:      "3.16.0-0.bpo.4-amd64",
 		ContainerOsVersion: "Debian GNU/Linux 7 (wheezy)",
@@ -2490,6 +2640,20 @@ func TestUpdateNodeStatusWithoutContainerRuntime(t *testing.T) {
 					LastHeartbeatTime:  util.Time{},
 					LastTransitionTime: util.Time{},
 				},
+				{
+					Type:               api.NodeMemoryPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no MemoryPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
+				{
+					Type:               api.NodeDiskPressure,
+					Status:             api.ConditionFalse,
+					Reason:             fmt.Sprintf("kubelet has no DiskPressure"),
+					LastHeartbeatTime:  util.Time{},
+					LastTransitionTime: util.Time{},
+				},
 			},
 			NodeInfo: api.NodeSystemInfo{
 				MachineID:               "123",
@@ -2506,6 +2670,11 @@ func TestUpdateNodeStatusWithoutContainerRuntime(t *testing.T) {
 				api.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
 				api.ResourcePods:   *resource.NewQuantity(0, resource.DecimalSI),
 			},
+			Allocatable: api.ResourceList{
+				api.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+				api.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
+				api.ResourcePods:   *resource.NewQuantity(0, resource.DecimalSI),
+			},
 			Addresses: []api.NodeAddress{{Type: api.NodeLegacyHostIP, Address: "127.0.0.1"}},
 		},
 	}
@@ -2533,8 +2702,10 @@ func TestUpdateNodeStatusWithoutContainerRuntime(t *testing.T) {
 	if updatedNode.Status.Conditions[0].LastTransitionTime.IsZero() {
 		t.Errorf("unexpected zero last transition timestamp")
 	}
-	updatedNode.Status.Conditions[0].LastHeartbeatTime = util.Time{}
-	updatedNode.Status.Conditions[0].LastTransitionTime = util.Time{}
+	for i := range updatedNode.Status.Conditions {
+		updatedNode.Status.Conditions[i].LastHeartbeatTime = util.Time{}
+		updatedNode.Status.Conditions[i].LastTransitionTime = util.Time{}
+	}
 	if !reflect.DeepEqual(expectedNode, updatedNode) {
 		t.Errorf("unexpected objects: %s", util.ObjectDiff(expectedNode, updatedNode))
 	}
@@ -2862,6 +3033,45 @@ func TestHostNetworkDisallowed(t *testing.T) {
 	}
 }
 
+func TestSyncPodKillsDeletedPod(t *testing.T) {
+	testKubelet := newTestKubelet(t)
+	testKubelet.fakeCadvisor.On("MachineInfo").Return(&cadvisorApi.MachineInfo{}, nil)
+	kubelet := testKubelet.kubelet
+	fakeRuntime := testKubelet.fakeRuntime
+
+	now := util.Now()
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:               "12345678",
+			Name:              "foo",
+			Namespace:         "new",
+			DeletionTimestamp: &now,
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{Name: "foo"},
+			},
+		},
+	}
+	if err := kubelet.syncPod(pod, nil, container.Pod{}, SyncPodUpdate); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, f := range fakeRuntime.CalledFunctions {
+		if f == "SyncPod" {
+			t.Errorf("expected deleted pod not to be started: %v", fakeRuntime.CalledFunctions)
+		}
+	}
+	found := false
+	for _, f := range fakeRuntime.CalledFunctions {
+		if f == "KillPod" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected deleted pod to be killed: %v", fakeRuntime.CalledFunctions)
+	}
+}
+
 func TestPrivilegeContainerAllowed(t *testing.T) {
 	testKubelet := newTestKubelet(t)
 	kubelet := testKubelet.kubelet
@@ -2962,6 +3172,7 @@ func TestRegisterExistingNodeWithApiserver(t *testing.T) {
 	}
 	mockCadvisor := testKubelet.fakeCadvisor
 	mockCadvisor.On("MachineInfo").Return(machineInfo, nil)
+	mockCadvisor.On("RootFsInfo").Return(cadvisorApiv2.FsInfo{}, nil)
 	versionInfo := &cadvisorApi.VersionInfo{
 		KernelVersion:      "3.16.0-0.bpo.4-amd64",
 		ContainerOsVersion: "Debian GNU/Linux 7 (wheezy)",
@@ -3083,6 +3294,57 @@ func TestIsPodPastActiveDeadline(t *testing.T) {
 	}
 }
 
+func TestGeneratePodStatusWithInitContainers(t *testing.T) {
+	testKubelet := newTestKubelet(t)
+	testKubelet.fakeCadvisor.On("MachineInfo").Return(&cadvisorApi.MachineInfo{}, nil)
+	kubelet := testKubelet.kubelet
+	fakeRuntime := testKubelet.fakeRuntime
+
+	failed := api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 1}}
+	succeeded := api.ContainerState{Terminated: &api.ContainerStateTerminated{ExitCode: 0}}
+	running := api.ContainerState{Running: &api.ContainerStateRunning{}}
+	waiting := api.ContainerState{Waiting: &api.ContainerStateWaiting{Reason: "PodInitializing"}}
+	tests := []struct {
+		restartPolicy api.RestartPolicy
+		initState     api.ContainerState
+		state         api.ContainerState
+		phase         api.PodPhase
+		reason        string
+	}{
+		{api.RestartPolicyAlways, running, waiting, api.PodPending, "PodInitializing"},
+		{api.RestartPolicyAlways, failed, waiting, api.PodPending, "PodInitializing"},
+		{api.RestartPolicyOnFailure, failed, waiting, api.PodPending, "PodInitializing"},
+		{api.RestartPolicyNever, failed, waiting, api.PodFailed, ""},
+		{api.RestartPolicyAlways, succeeded, running, api.PodRunning, ""},
+	}
+	for i, test := range tests {
+		pod := &api.Pod{
+			ObjectMeta: api.ObjectMeta{
+				UID:       "12345678",
+				Name:      "foo",
+				Namespace: "new",
+			},
+			Spec: api.PodSpec{
+				InitContainers: []api.Container{{Name: "init"}},
+				Containers:     []api.Container{{Name: "bar"}},
+				RestartPolicy:  test.restartPolicy,
+			},
+		}
+		fakeRuntime.PodStatus = api.PodStatus{
+			InitContainerStatuses: []api.ContainerStatus{{Name: "init", State: test.initState}},
+			ContainerStatuses:     []api.ContainerStatus{{Name: "bar", State: test.state}},
+		}
+		status, err := kubelet.generatePodStatus(pod)
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if status.Phase != test.phase || status.Reason != test.reason {
+			t.Errorf("[%d] expected phase %q with reason %q, got %q with reason %q", i, test.phase, test.reason, status.Phase, status.Reason)
+		}
+	}
+}
+
 func TestSyncPodsSetStatusToFailedForPodsThatRunTooLong(t *testing.T) {
 	testKubelet := newTestKubelet(t)
 	fakeRuntime := testKubelet.fakeRuntime
@@ -0,0 +1,336 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cni implements a network plugin that sets up the network of pods
+// through CNI (Container Network Interface) plugins.
+//
+// Networks are configured by JSON files with a .conf extension in a network
+// configuration directory (by default /etc/cni/net.d), e.g.
+//   {"name": "mynet", "type": "bridge", ...}
+// The network of the first file, in lexicographic order, is used for all pods.
+// Its type names the CNI plugin executable, looked up in the CNI plugin
+// directory (by default /opt/cni/bin), which is invoked with the ADD command
+// when a pod's infra container is created and with the DEL command before it
+// is deleted. The configuration is passed to the executable on its stdin and
+// the invocation parameters through CNI_* environment variables, see
+// https://github.com/appc/cni/blob/master/SPEC.md.
+package cni
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+const (
+	CNIPluginName = "cni"
+
+	// The default directory of the network configuration files.
+	DefaultNetDir = "/etc/cni/net.d"
+	// The default directory of the CNI plugin executables.
+	DefaultCNIDir = "/opt/cni/bin"
+	// The name of the network interface of pods.
+	DefaultInterfaceName = "eth0"
+
+	addCmd = "ADD"
+	delCmd = "DEL"
+)
+
+type cniNetworkPlugin struct {
+	netDir string
+	cniDir string
+	host   network.Host
+
+	// The network all pods are added to, loaded on Init.
+	defaultNetwork *cniNetwork
+
+	// Runs commands in the network namespaces of pods.
+	execer utilexec.Interface
+
+	// The addresses of the pods set up by the plugin, by infra container ID.
+	lock   sync.Mutex
+	podIPs map[kubeletTypes.DockerID]net.IP
+}
+
+type cniNetwork struct {
+	name string
+	// The path of the CNI plugin executable.
+	pluginPath string
+	// The network configuration, passed to the CNI plugin as is.
+	config []byte
+}
+
+// The fields of a network configuration the kubelet reads. The rest of the
+// configuration is specific to the CNI plugin.
+type netConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// The result of a CNI plugin ADD command.
+type cniResult struct {
+	IP4 *ipConfig `json:"ip4,omitempty"`
+	IP6 *ipConfig `json:"ip6,omitempty"`
+}
+
+type ipConfig struct {
+	// The address of the interface, in CIDR notation.
+	IP      string `json:"ip"`
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// The error a failing CNI plugin writes to its stdout.
+type cniError struct {
+	Code    uint   `json:"code"`
+	Msg     string `json:"msg"`
+	Details string `json:"details,omitempty"`
+}
+
+// ProbeNetworkPlugins returns the CNI network plugin, which loads its network
+// configuration from netDir and its CNI plugins from cniDir.
+func ProbeNetworkPlugins(netDir, cniDir string) []network.NetworkPlugin {
+	return []network.NetworkPlugin{
+		&cniNetworkPlugin{
+			netDir: netDir,
+			cniDir: cniDir,
+			execer: utilexec.New(),
+			podIPs: map[kubeletTypes.DockerID]net.IP{},
+		},
+	}
+}
+
+func (plugin *cniNetworkPlugin) Init(host network.Host) error {
+	defaultNetwork, err := loadDefaultNetwork(plugin.netDir, plugin.cniDir)
+	if err != nil {
+		return err
+	}
+	glog.V(1).Infof("Using CNI network %q with plugin %s", defaultNetwork.name, defaultNetwork.pluginPath)
+	plugin.defaultNetwork = defaultNetwork
+	plugin.host = host
+	return nil
+}
+
+// Loads the first valid network configuration of the directory, in
+// lexicographic order of the file names.
+func loadDefaultNetwork(netDir, cniDir string) (*cniNetwork, error) {
+	files, err := ioutil.ReadDir(netDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CNI network configurations: %v", err)
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".conf" {
+			continue
+		}
+		confPath := filepath.Join(netDir, f.Name())
+		data, err := ioutil.ReadFile(confPath)
+		if err != nil {
+			glog.Warningf("Failed to read CNI network configuration %s: %v", confPath, err)
+			continue
+		}
+		conf := netConfig{}
+		if err := json.Unmarshal(data, &conf); err != nil {
+			glog.Warningf("Invalid CNI network configuration %s: %v", confPath, err)
+			continue
+		}
+		if conf.Type == "" {
+			glog.Warningf("Invalid CNI network configuration %s: no plugin type", confPath)
+			continue
+		}
+		return &cniNetwork{
+			name:       conf.Name,
+			pluginPath: filepath.Join(cniDir, conf.Type),
+			config:     data,
+		}, nil
+	}
+	return nil, fmt.Errorf("no valid CNI network configuration found in %s", netDir)
+}
+
+func (plugin *cniNetworkPlugin) Name() string {
+	return CNIPluginName
+}
+
+func (plugin *cniNetworkPlugin) SetUpPod(namespace string, name string, id kubeletTypes.DockerID) error {
+	if plugin.usesHostNetwork(namespace, name) {
+		return nil
+	}
+	netns, err := plugin.host.GetRuntime().GetNetNs(string(id))
+	if err != nil {
+		return fmt.Errorf("CNI failed to get the network namespace of pod %s/%s: %v", namespace, name, err)
+	}
+	output, err := plugin.defaultNetwork.invoke(addCmd, namespace, name, id, netns)
+	if err != nil {
+		return fmt.Errorf("CNI failed to set up the network of pod %s/%s: %v", namespace, name, err)
+	}
+	ip, err := parseResult(output)
+	if err != nil {
+		return fmt.Errorf("CNI failed to set up the network of pod %s/%s: %v", namespace, name, err)
+	}
+	glog.V(4).Infof("CNI network %q assigned %s to pod %s/%s", plugin.defaultNetwork.name, ip, namespace, name)
+
+	plugin.lock.Lock()
+	defer plugin.lock.Unlock()
+	plugin.podIPs[id] = ip
+	return nil
+}
+
+func (plugin *cniNetworkPlugin) TearDownPod(namespace string, name string, id kubeletTypes.DockerID) error {
+	if plugin.usesHostNetwork(namespace, name) {
+		return nil
+	}
+	plugin.lock.Lock()
+	delete(plugin.podIPs, id)
+	plugin.lock.Unlock()
+
+	// The CNI plugin still releases the resources of the pod, e.g. its
+	// address, when the network namespace is already gone.
+	netns, err := plugin.host.GetRuntime().GetNetNs(string(id))
+	if err != nil {
+		glog.V(4).Infof("CNI failed to get the network namespace of pod %s/%s: %v", namespace, name, err)
+		netns = ""
+	}
+	if _, err := plugin.defaultNetwork.invoke(delCmd, namespace, name, id, netns); err != nil {
+		return fmt.Errorf("CNI failed to tear down the network of pod %s/%s: %v", namespace, name, err)
+	}
+	return nil
+}
+
+// Status returns the address assigned to the pod when its network was set up,
+// or the address of its interface if the network was set up before the
+// kubelet started.
+func (plugin *cniNetworkPlugin) Status(namespace string, name string, id kubeletTypes.DockerID) (*network.PodNetworkStatus, error) {
+	if plugin.usesHostNetwork(namespace, name) {
+		return nil, nil
+	}
+	plugin.lock.Lock()
+	ip, found := plugin.podIPs[id]
+	plugin.lock.Unlock()
+	if found {
+		return &network.PodNetworkStatus{IP: ip}, nil
+	}
+
+	netns, err := plugin.host.GetRuntime().GetNetNs(string(id))
+	if err != nil {
+		return nil, fmt.Errorf("CNI failed to get the network namespace of pod %s/%s: %v", namespace, name, err)
+	}
+	output, err := plugin.execer.Command("nsenter", "--net="+netns, "-F", "--",
+		"ip", "-o", "-4", "addr", "show", "dev", DefaultInterfaceName, "scope", "global").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected command output %s: %v", output, err)
+	}
+	ip, err = parseInterfaceAddress(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	plugin.lock.Lock()
+	defer plugin.lock.Unlock()
+	plugin.podIPs[id] = ip
+	return &network.PodNetworkStatus{IP: ip}, nil
+}
+
+// Returns whether the pod uses the network of the host, which is not set up by
+// the plugin.
+func (plugin *cniNetworkPlugin) usesHostNetwork(namespace, name string) bool {
+	pod, found := plugin.host.GetPodByName(namespace, name)
+	return found && pod.Spec.HostNetwork
+}
+
+// Invokes the CNI plugin of the network with the given command for a pod, and
+// returns its output.
+func (cniNet *cniNetwork) invoke(command, namespace, name string, id kubeletTypes.DockerID, netns string) ([]byte, error) {
+	args := []string{
+		"IgnoreUnknown=1",
+		"K8S_POD_NAMESPACE=" + namespace,
+		"K8S_POD_NAME=" + name,
+		"K8S_POD_INFRA_CONTAINER_ID=" + string(id),
+	}
+	cmd := exec.Command(cniNet.pluginPath)
+	cmd.Env = append(os.Environ(),
+		"CNI_COMMAND="+command,
+		"CNI_CONTAINERID="+string(id),
+		"CNI_NETNS="+netns,
+		"CNI_IFNAME="+DefaultInterfaceName,
+		"CNI_ARGS="+strings.Join(args, ";"),
+		"CNI_PATH="+filepath.Dir(cniNet.pluginPath),
+	)
+	cmd.Stdin = bytes.NewReader(cniNet.config)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	glog.V(5).Infof("Invoking CNI plugin %s %s for pod %s/%s", cniNet.pluginPath, command, namespace, name)
+	if err := cmd.Run(); err != nil {
+		pluginErr := cniError{}
+		if json.Unmarshal(stdout.Bytes(), &pluginErr) == nil && pluginErr.Msg != "" {
+			if pluginErr.Details != "" {
+				return nil, fmt.Errorf("%s (code %d): %s", pluginErr.Msg, pluginErr.Code, pluginErr.Details)
+			}
+			return nil, fmt.Errorf("%s (code %d)", pluginErr.Msg, pluginErr.Code)
+		}
+		return nil, fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// Returns the address of the pod from the result of a CNI plugin ADD command.
+func parseResult(output []byte) (net.IP, error) {
+	result := cniResult{}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("invalid CNI plugin result %q: %v", output, err)
+	}
+	config := result.IP4
+	if config == nil {
+		config = result.IP6
+	}
+	if config == nil {
+		return nil, fmt.Errorf("CNI plugin result has no address: %q", output)
+	}
+	ip, _, err := net.ParseCIDR(config.IP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address in CNI plugin result: %v", err)
+	}
+	return ip, nil
+}
+
+// Returns the address in the output of "ip -o addr show", e.g.
+//   2: eth0    inet 10.1.2.3/24 scope global eth0\       valid_lft forever preferred_lft forever
+func parseInterfaceAddress(output string) (net.IP, error) {
+	fields := strings.Fields(output)
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] != "inet" && fields[i] != "inet6" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(fields[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of interface %s: %v", DefaultInterfaceName, err)
+		}
+		return ip, nil
+	}
+	return nil, fmt.Errorf("no address on interface %s: %q", DefaultInterfaceName, output)
+}
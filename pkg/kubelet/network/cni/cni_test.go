@@ -0,0 +1,234 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+const (
+	testNetConfig = `{"name": "testnet", "type": "fake-cni"}`
+	testNetNs     = "/proc/1234/ns/net"
+)
+
+// A CNI plugin that records its invocations to a file, and either succeeds or
+// fails depending on a file next to it.
+const fakePluginScript = `#!/bin/sh
+dir=$(dirname "$0")
+echo "$CNI_COMMAND $CNI_CONTAINERID $CNI_NETNS $CNI_IFNAME $CNI_PATH $CNI_ARGS" >> "$dir/calls"
+cat > "$dir/stdin"
+if [ -f "$dir/fail" ]; then
+	echo '{"code": 100, "msg": "no more addresses"}'
+	exit 1
+fi
+echo '{"ip4": {"ip": "10.1.2.3/24", "gateway": "10.1.2.1"}}'
+`
+
+type fakeNetNsRuntime struct {
+	kubecontainer.FakeRuntime
+}
+
+func (r *fakeNetNsRuntime) GetNetNs(containerID string) (string, error) {
+	return testNetNs, nil
+}
+
+type fakeNetworkHost struct {
+	pods []*api.Pod
+}
+
+func (fnh *fakeNetworkHost) GetPodByName(namespace, name string) (*api.Pod, bool) {
+	for _, pod := range fnh.pods {
+		if pod.Namespace == namespace && pod.Name == name {
+			return pod, true
+		}
+	}
+	return nil, false
+}
+
+func (fnh *fakeNetworkHost) GetKubeClient() client.Interface {
+	return nil
+}
+
+func (fnh *fakeNetworkHost) GetRuntime() kubecontainer.Runtime {
+	return &fakeNetNsRuntime{}
+}
+
+// Installs the fake CNI plugin and a network configuration using it, and
+// returns the directory holding them.
+func installPluginUnderTest(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "cni")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	for _, d := range []string{"net.d", "bin"} {
+		if err := os.Mkdir(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "net.d", "10-testnet.conf"), []byte(testNetConfig), 0644); err != nil {
+		t.Fatalf("Failed to write network configuration: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "bin", "fake-cni"), []byte(fakePluginScript), 0755); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	return dir
+}
+
+func newPluginUnderTest(t *testing.T, dir string, host network.Host) *cniNetworkPlugin {
+	plug, err := network.InitNetworkPlugin(ProbeNetworkPlugins(filepath.Join(dir, "net.d"), filepath.Join(dir, "bin")), CNIPluginName, host)
+	if err != nil {
+		t.Fatalf("Failed to select the CNI plugin: %v", err)
+	}
+	return plug.(*cniNetworkPlugin)
+}
+
+func readPluginCalls(t *testing.T, dir string) []string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "bin", "calls"))
+	if err != nil {
+		t.Fatalf("Failed to read plugin calls: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestCNIPlugin(t *testing.T) {
+	dir := installPluginUnderTest(t)
+	defer os.RemoveAll(dir)
+	plug := newPluginUnderTest(t, dir, &fakeNetworkHost{})
+
+	if err := plug.SetUpPod("podNamespace", "podName", "infraID"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	calls := readPluginCalls(t, dir)
+	expectedCall := fmt.Sprintf("ADD infraID %s eth0 %s IgnoreUnknown=1;K8S_POD_NAMESPACE=podNamespace;K8S_POD_NAME=podName;K8S_POD_INFRA_CONTAINER_ID=infraID",
+		testNetNs, filepath.Join(dir, "bin"))
+	if len(calls) != 1 || calls[0] != expectedCall {
+		t.Errorf("Expected plugin call %q, got %q", expectedCall, calls)
+	}
+	stdin, err := ioutil.ReadFile(filepath.Join(dir, "bin", "stdin"))
+	if err != nil {
+		t.Fatalf("Failed to read plugin stdin: %v", err)
+	}
+	if string(stdin) != testNetConfig {
+		t.Errorf("Expected the network configuration %q on the plugin stdin, got %q", testNetConfig, stdin)
+	}
+
+	status, err := plug.Status("podNamespace", "podName", "infraID")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status == nil || status.IP.String() != "10.1.2.3" {
+		t.Errorf("Expected pod IP 10.1.2.3, got %v", status)
+	}
+
+	if err := plug.TearDownPod("podNamespace", "podName", "infraID"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	calls = readPluginCalls(t, dir)
+	if len(calls) != 2 || !strings.HasPrefix(calls[1], "DEL infraID ") {
+		t.Errorf("Expected a DEL plugin call, got %q", calls)
+	}
+}
+
+func TestCNIPluginFailure(t *testing.T) {
+	dir := installPluginUnderTest(t)
+	defer os.RemoveAll(dir)
+	plug := newPluginUnderTest(t, dir, &fakeNetworkHost{})
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "bin", "fail"), []byte{}, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	err := plug.SetUpPod("podNamespace", "podName", "infraID")
+	if err == nil || !strings.Contains(err.Error(), "no more addresses") {
+		t.Errorf("Expected the plugin error, got %v", err)
+	}
+}
+
+func TestCNIPluginSkipsHostNetworkPods(t *testing.T) {
+	dir := installPluginUnderTest(t)
+	defer os.RemoveAll(dir)
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Namespace: "podNamespace", Name: "podName"},
+		Spec:       api.PodSpec{HostNetwork: true},
+	}
+	plug := newPluginUnderTest(t, dir, &fakeNetworkHost{pods: []*api.Pod{pod}})
+
+	if err := plug.SetUpPod("podNamespace", "podName", "infraID"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bin", "calls")); !os.IsNotExist(err) {
+		t.Errorf("Expected the plugin not to be called for a host network pod")
+	}
+	status, err := plug.Status("podNamespace", "podName", "infraID")
+	if err != nil || status != nil {
+		t.Errorf("Expected no status for a host network pod, got %v, %v", status, err)
+	}
+}
+
+func TestCNIPluginStatusFromNetNs(t *testing.T) {
+	dir := installPluginUnderTest(t)
+	defer os.RemoveAll(dir)
+	plug := newPluginUnderTest(t, dir, &fakeNetworkHost{})
+
+	fcmd := utilexec.FakeCmd{
+		CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+			func() ([]byte, error) {
+				return []byte("2: eth0    inet 10.1.2.4/24 scope global eth0\\       valid_lft forever preferred_lft forever\n"), nil
+			},
+		},
+	}
+	plug.execer = &utilexec.FakeExec{
+		CommandScript: []utilexec.FakeCommandAction{
+			func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+
+	// The pod was set up before the kubelet started.
+	status, err := plug.Status("podNamespace", "podName", "infraID")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status == nil || status.IP.String() != "10.1.2.4" {
+		t.Errorf("Expected pod IP 10.1.2.4, got %v", status)
+	}
+	expectedArgv := []string{"nsenter", "--net=" + testNetNs, "-F", "--", "ip", "-o", "-4", "addr", "show", "dev", "eth0", "scope", "global"}
+	if strings.Join(fcmd.Argv, " ") != strings.Join(expectedArgv, " ") {
+		t.Errorf("Expected command %v, got %v", expectedArgv, fcmd.Argv)
+	}
+}
+
+func TestInitWithoutNetworks(t *testing.T) {
+	dir := installPluginUnderTest(t)
+	defer os.RemoveAll(dir)
+	if err := os.Remove(filepath.Join(dir, "net.d", "10-testnet.conf")); err != nil {
+		t.Fatalf("Failed to remove network configuration: %v", err)
+	}
+	_, err := network.InitNetworkPlugin(ProbeNetworkPlugins(filepath.Join(dir, "net.d"), filepath.Join(dir, "bin")), CNIPluginName, &fakeNetworkHost{})
+	if err == nil {
+		t.Errorf("Expected an error without network configurations")
+	}
+}
@@ -18,11 +18,13 @@ package network
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/errors"
@@ -47,6 +49,18 @@ type NetworkPlugin interface {
 
 	// TearDownPod is the method called before a pod's infra container will be deleted
 	TearDownPod(namespace string, name string, podInfraContainerID kubeletTypes.DockerID) error
+
+	// Status is the method called to obtain the network status of a pod,
+	// e.g. its IP address. A nil status means the plugin does not manage the
+	// addresses of the pod, and those reported by the runtime are used.
+	Status(namespace string, name string, podInfraContainerID kubeletTypes.DockerID) (*PodNetworkStatus, error)
+}
+
+// PodNetworkStatus stores the network status of a pod (currently just the
+// primary IP address).
+type PodNetworkStatus struct {
+	// IP is the primary IP address of the pod.
+	IP net.IP
 }
 
 // Host is an interface that plugins can use to access the kubelet.
@@ -56,6 +70,9 @@ type Host interface {
 
 	// GetKubeClient returns a client interface
 	GetKubeClient() client.Interface
+
+	// GetRuntime returns the container runtime of the kubelet
+	GetRuntime() kubecontainer.Runtime
 }
 
 // InitNetworkPlugin inits the plugin that matches networkPluginName. Plugins must have unique names.
@@ -120,3 +137,7 @@ func (plugin *noopNetworkPlugin) SetUpPod(namespace string, name string, id kube
 func (plugin *noopNetworkPlugin) TearDownPod(namespace string, name string, id kubeletTypes.DockerID) error {
 	return nil
 }
+
+func (plugin *noopNetworkPlugin) Status(namespace string, name string, id kubeletTypes.DockerID) (*PodNetworkStatus, error) {
+	return nil, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pleg
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/api"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// PodGetter looks up the desired pods, whose spec the container runtime needs
+// to report the status of their containers.
+type PodGetter interface {
+	GetPodByName(namespace, name string) (*api.Pod, bool)
+}
+
+// GenericPLEG relists the containers of the container runtime periodically,
+// and generates events by comparing each listing with the previous one. It
+// also keeps the pod status cache current. The status of a pod is refreshed
+// when its containers changed, and while it has waiting containers, since
+// their reasons, e.g. a back-off, change without any container changing.
+type GenericPLEG struct {
+	// The period between relists.
+	relistPeriod time.Duration
+	// The container runtime.
+	runtime kubecontainer.Runtime
+	// Looks up the spec of the pods.
+	podGetter PodGetter
+	// The cache of the pod statuses.
+	cache kubecontainer.Cache
+	// The channel the events are sent to.
+	eventChannel chan *PodLifecycleEvent
+	// The state of the containers of each pod, as of the last relist.
+	podRecords map[types.UID]map[types.UID]kubecontainer.ContainerState
+}
+
+// The state of a container that is not listed by the runtime.
+const nonExistent kubecontainer.ContainerState = "non-existent"
+
+// NewGenericPLEG creates a pod lifecycle event generator that relists the
+// containers of the runtime every relistPeriod.
+func NewGenericPLEG(runtime kubecontainer.Runtime, podGetter PodGetter, cache kubecontainer.Cache,
+	channelCapacity int, relistPeriod time.Duration) PodLifecycleEventGenerator {
+	return &GenericPLEG{
+		relistPeriod: relistPeriod,
+		runtime:      runtime,
+		podGetter:    podGetter,
+		cache:        cache,
+		eventChannel: make(chan *PodLifecycleEvent, channelCapacity),
+		podRecords:   make(map[types.UID]map[types.UID]kubecontainer.ContainerState),
+	}
+}
+
+// Start relists the containers periodically in a goroutine.
+func (g *GenericPLEG) Start() {
+	go util.Until(g.relist, g.relistPeriod, util.NeverStop)
+}
+
+// Watch returns the channel of the events.
+func (g *GenericPLEG) Watch() chan *PodLifecycleEvent {
+	return g.eventChannel
+}
+
+// relist lists the containers of the runtime, generates the events of the
+// changes since the last relist, and updates the pod status cache.
+func (g *GenericPLEG) relist() {
+	glog.V(5).Infof("GenericPLEG: relisting")
+	// Record the timestamp before listing, so that the cache is at least as
+	// new as the timestamp.
+	timestamp := time.Now()
+	pods, err := g.runtime.GetPods(true)
+	if err != nil {
+		glog.Errorf("GenericPLEG: unable to list the pods: %v", err)
+		return
+	}
+
+	runtimePods := make(map[types.UID]*kubecontainer.Pod)
+	records := make(map[types.UID]map[types.UID]kubecontainer.ContainerState)
+	for _, pod := range pods {
+		runtimePods[pod.ID] = pod
+		containers := make(map[types.UID]kubecontainer.ContainerState)
+		for _, c := range pod.Containers {
+			containers[c.ID] = c.State
+		}
+		records[pod.ID] = containers
+	}
+
+	podIDs := make(map[types.UID]bool)
+	for podID := range g.podRecords {
+		podIDs[podID] = true
+	}
+	for podID := range records {
+		podIDs[podID] = true
+	}
+
+	var events []*PodLifecycleEvent
+	for podID := range podIDs {
+		podEvents := computeEvents(podID, g.podRecords[podID], records[podID])
+		g.updateCache(podID, runtimePods[podID], len(podEvents) > 0, timestamp)
+		events = append(events, podEvents...)
+	}
+	g.cache.UpdateTime(timestamp)
+	g.podRecords = records
+
+	// Send the events once the cache is updated, so that the pod workers see
+	// the changes.
+	for _, e := range events {
+		select {
+		case g.eventChannel <- e:
+		default:
+			glog.Errorf("GenericPLEG: the event channel is full, discarding event %+v", e)
+		}
+	}
+}
+
+// updateCache refreshes the cached status of the pod if its containers
+// changed, if it has waiting containers, or if it is not cached yet.
+func (g *GenericPLEG) updateCache(podID types.UID, runtimePod *kubecontainer.Pod, changed bool, timestamp time.Time) {
+	if runtimePod == nil {
+		// All the containers of the pod are gone.
+		g.cache.Delete(podID)
+		return
+	}
+	if status, ok := g.cache.Get(podID); ok && !changed && !hasWaitingContainers(status) {
+		return
+	}
+	pod, found := g.podGetter.GetPodByName(runtimePod.Namespace, runtimePod.Name)
+	if !found || pod.UID != podID {
+		// The pod is not desired; the kubelet kills its containers without
+		// needing its status.
+		g.cache.Delete(podID)
+		return
+	}
+	status, err := g.runtime.GetPodStatus(pod)
+	if err != nil {
+		// Leave it to the pod worker to query the runtime and handle the error.
+		glog.Errorf("GenericPLEG: unable to get the status of pod %q: %v", kubecontainer.GetPodFullName(pod), err)
+		g.cache.Delete(podID)
+		return
+	}
+	g.cache.Set(podID, status, timestamp)
+}
+
+func hasWaitingContainers(status *api.PodStatus) bool {
+	for _, c := range status.ContainerStatuses {
+		if c.State.Waiting != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// computeEvents returns the events of the changes of the containers of a pod
+// between two relists.
+func computeEvents(podID types.UID, oldContainers, newContainers map[types.UID]kubecontainer.ContainerState) []*PodLifecycleEvent {
+	var events []*PodLifecycleEvent
+	for id, oldState := range oldContainers {
+		if _, ok := newContainers[id]; !ok {
+			events = append(events, generateEvents(podID, id, oldState, nonExistent)...)
+		}
+	}
+	for id, newState := range newContainers {
+		oldState, ok := oldContainers[id]
+		if !ok {
+			oldState = nonExistent
+		}
+		events = append(events, generateEvents(podID, id, oldState, newState)...)
+	}
+	return events
+}
+
+func generateEvents(podID, containerID types.UID, oldState, newState kubecontainer.ContainerState) []*PodLifecycleEvent {
+	if oldState == newState {
+		return nil
+	}
+	glog.V(4).Infof("GenericPLEG: container %q of pod %q: %v -> %v", containerID, podID, oldState, newState)
+	switch newState {
+	case kubecontainer.ContainerStateRunning:
+		return []*PodLifecycleEvent{{ID: podID, Type: ContainerStarted, ContainerID: containerID}}
+	case kubecontainer.ContainerStateExited:
+		return []*PodLifecycleEvent{{ID: podID, Type: ContainerDied, ContainerID: containerID}}
+	case nonExistent:
+		if oldState == kubecontainer.ContainerStateExited {
+			return []*PodLifecycleEvent{{ID: podID, Type: ContainerRemoved, ContainerID: containerID}}
+		}
+		// The container was removed without being observed dead.
+		return []*PodLifecycleEvent{
+			{ID: podID, Type: ContainerDied, ContainerID: containerID},
+			{ID: podID, Type: ContainerRemoved, ContainerID: containerID},
+		}
+	default:
+		// The state of the container is unknown; there is nothing to report.
+		return nil
+	}
+}
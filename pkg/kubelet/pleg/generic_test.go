@@ -0,0 +1,222 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pleg
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+type fakePodGetter map[string]*api.Pod
+
+func (f fakePodGetter) GetPodByName(namespace, name string) (*api.Pod, bool) {
+	pod, ok := f[kubecontainer.BuildPodFullName(name, namespace)]
+	return pod, ok
+}
+
+func newTestGenericPLEG(podGetter PodGetter) (*GenericPLEG, *kubecontainer.FakeRuntime, kubecontainer.Cache) {
+	fakeRuntime := &kubecontainer.FakeRuntime{}
+	cache := kubecontainer.NewCache()
+	pleg := NewGenericPLEG(fakeRuntime, podGetter, cache, 100, time.Hour)
+	return pleg.(*GenericPLEG), fakeRuntime, cache
+}
+
+func createTestContainer(id string, state kubecontainer.ContainerState) *kubecontainer.Container {
+	return &kubecontainer.Container{ID: types.UID(id), State: state}
+}
+
+type sortableEvents []*PodLifecycleEvent
+
+func (a sortableEvents) Len() int      { return len(a) }
+func (a sortableEvents) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortableEvents) Less(i, j int) bool {
+	if a[i].ID != a[j].ID {
+		return a[i].ID < a[j].ID
+	}
+	if a[i].ContainerID != a[j].ContainerID {
+		return a[i].ContainerID < a[j].ContainerID
+	}
+	return a[i].Type < a[j].Type
+}
+
+func getEventsFromChannel(ch <-chan *PodLifecycleEvent) []*PodLifecycleEvent {
+	var events []*PodLifecycleEvent
+	for len(ch) > 0 {
+		events = append(events, <-ch)
+	}
+	return events
+}
+
+func verifyEvents(t *testing.T, expected, actual []*PodLifecycleEvent) {
+	sort.Sort(sortableEvents(expected))
+	sort.Sort(sortableEvents(actual))
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected events %#v, got %#v", expected, actual)
+	}
+}
+
+func TestRelisting(t *testing.T) {
+	pleg, runtime, _ := newTestGenericPLEG(fakePodGetter{})
+	ch := pleg.Watch()
+
+	// The first relist reports all the containers.
+	runtime.PodList = []*kubecontainer.Pod{
+		{
+			ID: "1234",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c1", kubecontainer.ContainerStateExited),
+				createTestContainer("c2", kubecontainer.ContainerStateRunning),
+				createTestContainer("c3", kubecontainer.ContainerStateUnknown),
+			},
+		},
+		{
+			ID: "4567",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c1", kubecontainer.ContainerStateExited),
+			},
+		},
+	}
+	pleg.relist()
+	verifyEvents(t, []*PodLifecycleEvent{
+		{ID: "1234", Type: ContainerDied, ContainerID: "c1"},
+		{ID: "1234", Type: ContainerStarted, ContainerID: "c2"},
+		{ID: "4567", Type: ContainerDied, ContainerID: "c1"},
+	}, getEventsFromChannel(ch))
+
+	// Nothing changed.
+	pleg.relist()
+	verifyEvents(t, nil, getEventsFromChannel(ch))
+
+	runtime.PodList = []*kubecontainer.Pod{
+		{
+			ID: "1234",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c2", kubecontainer.ContainerStateExited),
+				createTestContainer("c3", kubecontainer.ContainerStateRunning),
+			},
+		},
+		{
+			ID: "4567",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c4", kubecontainer.ContainerStateRunning),
+			},
+		},
+	}
+	pleg.relist()
+	verifyEvents(t, []*PodLifecycleEvent{
+		{ID: "1234", Type: ContainerRemoved, ContainerID: "c1"},
+		{ID: "1234", Type: ContainerDied, ContainerID: "c2"},
+		{ID: "1234", Type: ContainerStarted, ContainerID: "c3"},
+		{ID: "4567", Type: ContainerRemoved, ContainerID: "c1"},
+		{ID: "4567", Type: ContainerStarted, ContainerID: "c4"},
+	}, getEventsFromChannel(ch))
+
+	// A running container is removed.
+	runtime.PodList = []*kubecontainer.Pod{
+		{
+			ID: "1234",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c2", kubecontainer.ContainerStateExited),
+				createTestContainer("c3", kubecontainer.ContainerStateRunning),
+			},
+		},
+	}
+	pleg.relist()
+	verifyEvents(t, []*PodLifecycleEvent{
+		{ID: "4567", Type: ContainerDied, ContainerID: "c4"},
+		{ID: "4567", Type: ContainerRemoved, ContainerID: "c4"},
+	}, getEventsFromChannel(ch))
+}
+
+func TestRelistUpdatesCache(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "1234",
+			Name:      "foo",
+			Namespace: "ns",
+		},
+	}
+	pleg, runtime, cache := newTestGenericPLEG(fakePodGetter{"foo_ns": pod})
+
+	runtime.PodList = []*kubecontainer.Pod{
+		{
+			ID:        "1234",
+			Name:      "foo",
+			Namespace: "ns",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c1", kubecontainer.ContainerStateRunning),
+			},
+		},
+		{
+			// Not a desired pod.
+			ID:        "4567",
+			Name:      "bar",
+			Namespace: "ns",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c2", kubecontainer.ContainerStateRunning),
+			},
+		},
+	}
+	runtime.PodStatus = api.PodStatus{
+		PodIP: "1.2.3.4",
+		ContainerStatuses: []api.ContainerStatus{
+			{Name: "c1", State: api.ContainerState{Running: &api.ContainerStateRunning{}}},
+		},
+	}
+	start := time.Now()
+	pleg.relist()
+	status, ok := cache.GetNewerThan("1234", start)
+	if !ok || !reflect.DeepEqual(*status, runtime.PodStatus) {
+		t.Errorf("expected cached status %#v, got %#v", runtime.PodStatus, status)
+	}
+	if _, ok := cache.Get("4567"); ok {
+		t.Errorf("expected no status of the undesired pod")
+	}
+
+	// The status of pods without changes and waiting containers is not
+	// queried again.
+	runtime.CalledFunctions = []string{}
+	pleg.relist()
+	if err := runtime.AssertCalls([]string{"GetPods"}); err != nil {
+		t.Error(err)
+	}
+
+	// Pods with waiting containers are refreshed on every relist.
+	runtime.PodStatus.ContainerStatuses[0].State = api.ContainerState{
+		Waiting: &api.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+	}
+	cache.Set("1234", &runtime.PodStatus, time.Now())
+	runtime.CalledFunctions = []string{}
+	pleg.relist()
+	pleg.relist()
+	if err := runtime.AssertCalls([]string{"GetPods", "GetPodStatus", "GetPods", "GetPodStatus"}); err != nil {
+		t.Error(err)
+	}
+
+	// The status is removed with the containers of the pod.
+	runtime.PodList = []*kubecontainer.Pod{}
+	pleg.relist()
+	if _, ok := cache.Get("1234"); ok {
+		t.Errorf("expected no status of the pod without containers")
+	}
+}
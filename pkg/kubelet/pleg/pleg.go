@@ -0,0 +1,55 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pleg implements the pod lifecycle event generator, which observes
+// the containers of the container runtime and reports their changes, so that
+// the kubelet only syncs the pods that changed.
+package pleg
+
+import (
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// PodLifeCycleEventType is the type of a change of the containers of a pod.
+type PodLifeCycleEventType string
+
+const (
+	// A container of the pod started running.
+	ContainerStarted PodLifeCycleEventType = "ContainerStarted"
+	// A container of the pod exited.
+	ContainerDied PodLifeCycleEventType = "ContainerDied"
+	// A container of the pod was removed.
+	ContainerRemoved PodLifeCycleEventType = "ContainerRemoved"
+)
+
+// PodLifecycleEvent is a change of the containers of a pod.
+type PodLifecycleEvent struct {
+	// The UID of the pod.
+	ID types.UID
+	// The type of the change.
+	Type PodLifeCycleEventType
+	// The ID of the container that changed.
+	ContainerID types.UID
+}
+
+// PodLifecycleEventGenerator reports the changes of the containers of the
+// container runtime as pod lifecycle events.
+type PodLifecycleEventGenerator interface {
+	// Start starts observing the container runtime.
+	Start()
+	// Watch returns the channel of the events.
+	Watch() chan *PodLifecycleEvent
+}
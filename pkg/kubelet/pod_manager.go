@@ -43,6 +43,7 @@ import (
 
 type podManager interface {
 	GetPods() []*api.Pod
+	GetPodByUID(uid types.UID) (*api.Pod, bool)
 	GetPodByFullName(podFullName string) (*api.Pod, bool)
 	GetPodByName(namespace, name string) (*api.Pod, bool)
 	GetPodsAndMirrorMap() ([]*api.Pod, map[string]*api.Pod)
@@ -221,6 +222,15 @@ func (pm *basicPodManager) GetPodsAndMirrorMap() ([]*api.Pod, map[string]*api.Po
 	return podsMapToPods(pm.podByUID), mirrorPods
 }
 
+// GetPodByUID provides the (non-mirror) pod that matches the UID, as well as
+// whether the pod was found.
+func (pm *basicPodManager) GetPodByUID(uid types.UID) (*api.Pod, bool) {
+	pm.lock.RLock()
+	defer pm.lock.RUnlock()
+	pod, ok := pm.podByUID[uid]
+	return pod, ok
+}
+
 // GetPodByName provides the (non-mirror) pod that matches namespace and name,
 // as well as whether the pod was found.
 func (pm *basicPodManager) GetPodByName(namespace, name string) (*api.Pod, bool) {
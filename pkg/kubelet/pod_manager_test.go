@@ -105,5 +105,12 @@ func TestGetSetPods(t *testing.T) {
 	if !ok || !reflect.DeepEqual(actualPod, staticPod) {
 		t.Errorf("unable to get pod by name; expected: %#v, got: %#v", staticPod, actualPod)
 	}
+	actualPod, ok = podManager.GetPodByUID(staticPod.UID)
+	if !ok || !reflect.DeepEqual(actualPod, staticPod) {
+		t.Errorf("unable to get pod by UID; expected: %#v, got: %#v", staticPod, actualPod)
+	}
+	if _, ok := podManager.GetPodByUID(mirrorPod.UID); ok {
+		t.Errorf("expected mirror pods not to be found by UID")
+	}
 
 }
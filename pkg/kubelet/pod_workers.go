@@ -52,6 +52,9 @@ type podWorkers struct {
 	lastUndeliveredWorkUpdate map[types.UID]workUpdate
 	// runtimeCache is used for listing running containers.
 	runtimeCache kubecontainer.RuntimeCache
+	// podCache stores the status of the pods, so that the workers do not need
+	// to inspect the containers.
+	podCache kubecontainer.Cache
 
 	// This function is run to sync the desired stated of pod.
 	// NOTE: This function has to be thread-safe - it can be called for
@@ -76,13 +79,14 @@ type workUpdate struct {
 	updateType SyncPodType
 }
 
-func newPodWorkers(runtimeCache kubecontainer.RuntimeCache, syncPodFn syncPodFnType,
+func newPodWorkers(runtimeCache kubecontainer.RuntimeCache, podCache kubecontainer.Cache, syncPodFn syncPodFnType,
 	recorder record.EventRecorder) *podWorkers {
 	return &podWorkers{
 		podUpdates:                map[types.UID]chan workUpdate{},
 		isWorking:                 map[types.UID]bool{},
 		lastUndeliveredWorkUpdate: map[types.UID]workUpdate{},
 		runtimeCache:              runtimeCache,
+		podCache:                  podCache,
 		syncPodFn:                 syncPodFn,
 		recorder:                  recorder,
 	}
@@ -104,6 +108,9 @@ func (p *podWorkers) managePodLoop(podUpdates <-chan workUpdate) {
 				glog.Errorf("Error getting pods while syncing pod: %v", err)
 				return
 			}
+			// Likewise, wait for the cached status of the pod to reflect the
+			// previous processing.
+			p.podCache.GetNewerThan(newWork.pod.UID, minRuntimeCacheTime)
 
 			err = p.syncPodFn(newWork.pod, newWork.mirrorPod,
 				kubecontainer.Pods(pods).FindPodByID(newWork.pod.UID), newWork.updateType)
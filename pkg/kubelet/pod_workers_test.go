@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/kubelet/network"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 func newPod(uid, name string) *api.Pod {
@@ -48,6 +49,14 @@ func createFakeRuntimeCache(fakeRecorder *record.FakeRecorder) kubecontainer.Run
 	return kubecontainer.NewFakeRuntimeCache(dockerManager)
 }
 
+// createFakePodCache returns a pod status cache that is updated periodically,
+// like the PLEG does.
+func createFakePodCache() kubecontainer.Cache {
+	podCache := kubecontainer.NewCache()
+	go util.Until(func() { podCache.UpdateTime(time.Now()) }, 10*time.Millisecond, util.NeverStop)
+	return podCache
+}
+
 func createPodWorkers() (*podWorkers, map[types.UID][]string) {
 	lock := sync.Mutex{}
 	processed := make(map[types.UID][]string)
@@ -55,6 +64,7 @@ func createPodWorkers() (*podWorkers, map[types.UID][]string) {
 	fakeRuntimeCache := createFakeRuntimeCache(fakeRecorder)
 	podWorkers := newPodWorkers(
 		fakeRuntimeCache,
+		createFakePodCache(),
 		func(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecontainer.Pod, updateType SyncPodType) error {
 			func() {
 				lock.Lock()
@@ -120,11 +130,55 @@ func TestUpdatePod(t *testing.T) {
 	}
 }
 
+func TestPodWorkerWaitsForPodCache(t *testing.T) {
+	fakeRecorder := &record.FakeRecorder{}
+	podCache := kubecontainer.NewCache()
+	synced := make(chan time.Time, 1)
+	podWorkers := newPodWorkers(
+		createFakeRuntimeCache(fakeRecorder),
+		podCache,
+		func(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecontainer.Pod, updateType SyncPodType) error {
+			synced <- time.Now()
+			return nil
+		},
+		fakeRecorder,
+	)
+
+	// The pod is not synced before the cache was updated.
+	podWorkers.UpdatePod(newPod("u1", "n1"), nil, func() {})
+	select {
+	case <-synced:
+		t.Fatalf("expected the pod worker to wait for the pod cache")
+	case <-time.After(50 * time.Millisecond):
+	}
+	podCache.UpdateTime(time.Now())
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the pod to be synced after the pod cache was updated")
+	}
+
+	// The next sync waits for a cache update newer than the previous sync.
+	podWorkers.UpdatePod(newPod("u1", "n1"), nil, func() {})
+	select {
+	case <-synced:
+		t.Fatalf("expected the pod worker to wait for the pod cache")
+	case <-time.After(50 * time.Millisecond):
+	}
+	podCache.UpdateTime(time.Now())
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the pod to be synced after the pod cache was updated")
+	}
+}
+
 func TestUpdateType(t *testing.T) {
 	syncType := make(chan SyncPodType)
 	fakeRecorder := &record.FakeRecorder{}
 	podWorkers := newPodWorkers(
 		createFakeRuntimeCache(fakeRecorder),
+		createFakePodCache(),
 		func(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecontainer.Pod, updateType SyncPodType) error {
 			func() {
 				syncType <- updateType
@@ -230,7 +284,7 @@ func TestFakePodWorkers(t *testing.T) {
 	kubeletForRealWorkers := &simpleFakeKubelet{}
 	kubeletForFakeWorkers := &simpleFakeKubelet{}
 
-	realPodWorkers := newPodWorkers(fakeRuntimeCache, kubeletForRealWorkers.syncPodWithWaitGroup, fakeRecorder)
+	realPodWorkers := newPodWorkers(fakeRuntimeCache, createFakePodCache(), kubeletForRealWorkers.syncPodWithWaitGroup, fakeRecorder)
 	fakePodWorkers := &fakePodWorkers{kubeletForFakeWorkers.syncPod, fakeRuntimeCache, t}
 
 	tests := []struct {
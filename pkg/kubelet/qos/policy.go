@@ -0,0 +1,64 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qos
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+const (
+	// PodInfraOomAdj is the oom_score_adj of pod infra containers. They use
+	// almost no memory, and killing one kills all the containers of its pod.
+	PodInfraOomAdj int = -899
+	// Guaranteed containers are killed only after every other container, but
+	// before the kubelet and the docker daemon, which run with -900.
+	guaranteedOomScoreAdj int = -899
+	// Best-effort containers are killed first.
+	besteffortOomScoreAdj int = 1000
+)
+
+// GetContainerOomScoreAdjust returns the oom_score_adj of a container of a
+// pod. Containers of Burstable pods get a score between those of Guaranteed
+// and BestEffort containers, that decreases as their memory request grows
+// relative to the memory capacity of the node.
+func GetContainerOomScoreAdjust(pod *api.Pod, container *api.Container, memoryCapacity int64) int {
+	switch GetPodQOS(pod) {
+	case api.PodQOSGuaranteed:
+		return guaranteedOomScoreAdj
+	case api.PodQOSBestEffort:
+		return besteffortOomScoreAdj
+	}
+
+	if memoryCapacity <= 0 {
+		return besteffortOomScoreAdj - 1
+	}
+	memoryRequest := container.Resources.Requests.Memory().Value()
+	if memoryRequest == 0 {
+		// A container that sets a limit but no request requests its limit.
+		memoryRequest = container.Resources.Limits.Memory().Value()
+	}
+	oomScoreAdjust := 1000 - (1000*memoryRequest)/memoryCapacity
+	// Keep Burstable containers strictly between Guaranteed and BestEffort
+	// ones, and above the user processes of the node, which run with 0 or 1.
+	if oomScoreAdjust < 2 {
+		return 2
+	}
+	if oomScoreAdjust >= int64(besteffortOomScoreAdj) {
+		return besteffortOomScoreAdj - 1
+	}
+	return int(oomScoreAdjust)
+}
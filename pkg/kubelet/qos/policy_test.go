@@ -0,0 +1,69 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qos
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestGetContainerOomScoreAdjust(t *testing.T) {
+	const memoryCapacity = 4000000000
+	tests := []struct {
+		name      string
+		container api.Container
+		expected  int
+	}{
+		{
+			name:      "best effort",
+			container: container(nil, nil),
+			expected:  1000,
+		},
+		{
+			name:      "guaranteed",
+			container: container(resourceList("1", "1G"), resourceList("1", "1G")),
+			expected:  -899,
+		},
+		{
+			name:      "burstable requesting a quarter of the memory",
+			container: container(resourceList("", "1G"), nil),
+			expected:  750,
+		},
+		{
+			name:      "burstable limiting a quarter of the memory",
+			container: container(nil, resourceList("", "1G")),
+			expected:  750,
+		},
+		{
+			name:      "burstable requesting all the memory",
+			container: container(resourceList("", "4G"), nil),
+			expected:  2,
+		},
+		{
+			name:      "burstable requesting no memory",
+			container: container(resourceList("100m", ""), nil),
+			expected:  999,
+		},
+	}
+	for _, test := range tests {
+		pod := &api.Pod{Spec: api.PodSpec{Containers: []api.Container{test.container}}}
+		if oomScoreAdjust := GetContainerOomScoreAdjust(pod, &pod.Spec.Containers[0], memoryCapacity); oomScoreAdjust != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, oomScoreAdjust)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package qos computes the quality of service class of pods from the
+// resource requests and limits of their containers.
+package qos
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// computeResources are the resources the quality of service is derived from.
+var computeResources = []api.ResourceName{api.ResourceCPU, api.ResourceMemory}
+
+// GetPodQOS returns the quality of service class of a pod. A container that
+// sets a limit but no request for a resource requests its limit.
+func GetPodQOS(pod *api.Pod) api.PodQOSClass {
+	bestEffort := true
+	guaranteed := true
+	for _, container := range pod.Spec.Containers {
+		for _, name := range computeResources {
+			limit, hasLimit := container.Resources.Limits[name]
+			request, hasRequest := container.Resources.Requests[name]
+			if hasLimit || hasRequest {
+				bestEffort = false
+			}
+			if !hasLimit || (hasRequest && request.MilliValue() != limit.MilliValue()) {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case bestEffort:
+		return api.PodQOSBestEffort
+	case guaranteed:
+		return api.PodQOSGuaranteed
+	}
+	return api.PodQOSBurstable
+}
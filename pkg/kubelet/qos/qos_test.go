@@ -0,0 +1,89 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qos
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+)
+
+func resourceList(cpu, memory string) api.ResourceList {
+	list := api.ResourceList{}
+	if cpu != "" {
+		list[api.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[api.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func container(requests, limits api.ResourceList) api.Container {
+	return api.Container{Resources: api.ResourceRequirements{Requests: requests, Limits: limits}}
+}
+
+func TestGetPodQOS(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []api.Container
+		expected   api.PodQOSClass
+	}{
+		{
+			name:       "no resources",
+			containers: []api.Container{container(nil, nil), container(nil, nil)},
+			expected:   api.PodQOSBestEffort,
+		},
+		{
+			name:       "limits only",
+			containers: []api.Container{container(nil, resourceList("100m", "100Mi"))},
+			expected:   api.PodQOSGuaranteed,
+		},
+		{
+			name:       "requests equal limits",
+			containers: []api.Container{container(resourceList("0.1", "100Mi"), resourceList("100m", "100Mi"))},
+			expected:   api.PodQOSGuaranteed,
+		},
+		{
+			name:       "requests below limits",
+			containers: []api.Container{container(resourceList("50m", "100Mi"), resourceList("100m", "100Mi"))},
+			expected:   api.PodQOSBurstable,
+		},
+		{
+			name:       "memory limit only",
+			containers: []api.Container{container(nil, resourceList("", "100Mi"))},
+			expected:   api.PodQOSBurstable,
+		},
+		{
+			name:       "one container without resources",
+			containers: []api.Container{container(nil, resourceList("100m", "100Mi")), container(nil, nil)},
+			expected:   api.PodQOSBurstable,
+		},
+		{
+			name:       "requests only",
+			containers: []api.Container{container(resourceList("100m", ""), nil)},
+			expected:   api.PodQOSBurstable,
+		},
+	}
+	for _, test := range tests {
+		pod := &api.Pod{Spec: api.PodSpec{Containers: test.containers}}
+		if qos := GetPodQOS(pod); qos != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, qos)
+		}
+	}
+}
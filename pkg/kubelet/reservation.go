@@ -0,0 +1,97 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// Resources reserved for the system daemons and the Kubernetes components,
+// which are not available to pods.
+type Reservation struct {
+	// Resources reserved for the daemons of the operating system, e.g. sshd.
+	System api.ResourceList
+	// Resources reserved for the Kubernetes components, e.g. the kubelet and
+	// the container runtime.
+	Kubernetes api.ResourceList
+}
+
+// The resources that can be reserved.
+var reservableResources = util.NewStringSet(string(api.ResourceCPU), string(api.ResourceMemory), string(api.ResourceStorage))
+
+// ParseReservation parses the resources reserved for the Kubernetes components
+// and for the system, given as resource names mapped to quantities (e.g.
+// cpu=500m,memory=1Gi,storage=10Gi).
+func ParseReservation(kubeReserved, systemReserved util.ConfigurationMap) (Reservation, error) {
+	kubernetes, err := parseResourceList(kubeReserved)
+	if err != nil {
+		return Reservation{}, err
+	}
+	system, err := parseResourceList(systemReserved)
+	if err != nil {
+		return Reservation{}, err
+	}
+	return Reservation{System: system, Kubernetes: kubernetes}, nil
+}
+
+func parseResourceList(m util.ConfigurationMap) (api.ResourceList, error) {
+	rl := api.ResourceList{}
+	for name, value := range m {
+		if !reservableResources.Has(name) {
+			return nil, fmt.Errorf("cannot reserve %q resource, valid resources are %v", name, reservableResources.List())
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for %q resource: %v", value, name, err)
+		}
+		if q.MilliValue() < 0 {
+			return nil, fmt.Errorf("negative quantity %q for %q resource", value, name)
+		}
+		rl[api.ResourceName(name)] = *q
+	}
+	return rl, nil
+}
+
+// Returns the resources of a node that are available to pods, which are its
+// capacity minus the reserved resources. Resources that are not reserved are
+// entirely available.
+func allocatableFromCapacity(capacity api.ResourceList, reservation Reservation) api.ResourceList {
+	allocatable := api.ResourceList{}
+	for name, quantity := range capacity {
+		// CPU is accounted in millicores, other resources in units.
+		if name == api.ResourceCPU {
+			value := quantity.MilliValue() - reservation.System.Cpu().MilliValue() - reservation.Kubernetes.Cpu().MilliValue()
+			allocatable[name] = *resource.NewMilliQuantity(nonNegative(value), quantity.Format)
+		} else {
+			system, kubernetes := reservation.System[name], reservation.Kubernetes[name]
+			value := quantity.Value() - system.Value() - kubernetes.Value()
+			allocatable[name] = *resource.NewQuantity(nonNegative(value), quantity.Format)
+		}
+	}
+	return allocatable
+}
+
+func nonNegative(value int64) int64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+func TestParseReservation(t *testing.T) {
+	testCases := []struct {
+		kubeReserved   util.ConfigurationMap
+		systemReserved util.ConfigurationMap
+		expected       Reservation
+		expectErr      bool
+	}{
+		{
+			kubeReserved:   util.ConfigurationMap{},
+			systemReserved: util.ConfigurationMap{},
+			expected:       Reservation{System: api.ResourceList{}, Kubernetes: api.ResourceList{}},
+		},
+		{
+			kubeReserved:   util.ConfigurationMap{"cpu": "200m", "memory": "150Mi"},
+			systemReserved: util.ConfigurationMap{"memory": "100Mi", "storage": "1Gi"},
+			expected: Reservation{
+				System: api.ResourceList{
+					api.ResourceMemory:  resource.MustParse("100Mi"),
+					api.ResourceStorage: resource.MustParse("1Gi"),
+				},
+				Kubernetes: api.ResourceList{
+					api.ResourceCPU:    resource.MustParse("200m"),
+					api.ResourceMemory: resource.MustParse("150Mi"),
+				},
+			},
+		},
+		{
+			kubeReserved:   util.ConfigurationMap{"pods": "10"},
+			systemReserved: util.ConfigurationMap{},
+			expectErr:      true,
+		},
+		{
+			kubeReserved:   util.ConfigurationMap{},
+			systemReserved: util.ConfigurationMap{"memory": "lots"},
+			expectErr:      true,
+		},
+		{
+			kubeReserved:   util.ConfigurationMap{"cpu": "-1"},
+			systemReserved: util.ConfigurationMap{},
+			expectErr:      true,
+		},
+	}
+	for i, tc := range testCases {
+		reservation, err := ParseReservation(tc.kubeReserved, tc.systemReserved)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("%d: expected an error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if !api.Semantic.DeepEqual(tc.expected, reservation) {
+			t.Errorf("%d: expected %v, got %v", i, tc.expected, reservation)
+		}
+	}
+}
+
+func TestAllocatableFromCapacity(t *testing.T) {
+	capacity := api.ResourceList{
+		api.ResourceCPU:     *resource.NewMilliQuantity(2000, resource.DecimalSI),
+		api.ResourceMemory:  *resource.NewQuantity(1024, resource.BinarySI),
+		api.ResourcePods:    *resource.NewQuantity(40, resource.DecimalSI),
+		api.ResourceStorage: *resource.NewQuantity(4096, resource.BinarySI),
+	}
+	reservation := Reservation{
+		System: api.ResourceList{
+			api.ResourceCPU:    *resource.NewMilliQuantity(500, resource.DecimalSI),
+			api.ResourceMemory: *resource.NewQuantity(1000, resource.BinarySI),
+		},
+		Kubernetes: api.ResourceList{
+			api.ResourceCPU:    *resource.NewMilliQuantity(250, resource.DecimalSI),
+			api.ResourceMemory: *resource.NewQuantity(1000, resource.BinarySI),
+		},
+	}
+	expected := api.ResourceList{
+		api.ResourceCPU: *resource.NewMilliQuantity(1250, resource.DecimalSI),
+		// Reservations beyond the capacity leave nothing to allocate.
+		api.ResourceMemory:  *resource.NewQuantity(0, resource.BinarySI),
+		api.ResourcePods:    *resource.NewQuantity(40, resource.DecimalSI),
+		api.ResourceStorage: *resource.NewQuantity(4096, resource.BinarySI),
+	}
+	if actual := allocatableFromCapacity(capacity, reservation); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if actual := allocatableFromCapacity(capacity, Reservation{}); !reflect.DeepEqual(capacity, actual) {
+		t.Errorf("expected the whole capacity %v, got %v", capacity, actual)
+	}
+}
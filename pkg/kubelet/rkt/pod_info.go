@@ -133,8 +133,8 @@ func (p *podInfo) getContainerStatus(container *kubecontainer.Container) api.Con
 		exitCode, ok := p.exitCodes[status.ImageID]
 		if !ok {
 			glog.Warningf("rkt: Cannot get exit code for container %v", container)
+			exitCode = -1
 		}
-		exitCode = -1
 		status.State = api.ContainerState{
 			Terminated: &api.ContainerStateTerminated{
 				ExitCode:  exitCode,
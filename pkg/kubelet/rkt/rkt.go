@@ -45,6 +45,7 @@ import (
 	"k8s.io/kubernetes/pkg/probe"
 	"k8s.io/kubernetes/pkg/securitycontext"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 const (
@@ -64,6 +65,9 @@ const (
 	unitKubernetesSection = "X-Kubernetes"
 	unitPodName           = "POD"
 	unitRktID             = "RktID"
+	// initUnitInfix separates the pod UID from the container name in the
+	// unit file names of init containers.
+	initUnitInfix = "_init_"
 
 	dockerPrefix = "docker://"
 
@@ -77,6 +81,9 @@ const (
 	defaultGracePeriod = "1m"
 	// Duration to wait before expiring prepared pods.
 	defaultExpirePrepared = "1m"
+
+	// The timestamp layout accepted by journalctl's --since flag.
+	journalctlTimeFormat = "2006-01-02 15:04:05"
 )
 
 // runtime implements the Containerruntime for rkt. The implementation
@@ -191,6 +198,24 @@ func makePodServiceFileName(uid types.UID) string {
 	return fmt.Sprintf("%s_%s.service", kubernetesUnitPrefix, uid)
 }
 
+// makeInitServiceFilePrefix returns the prefix of the unit file names of the
+// init containers of a pod.
+func makeInitServiceFilePrefix(uid types.UID) string {
+	return fmt.Sprintf("%s_%s%s", kubernetesUnitPrefix, uid, initUnitInfix)
+}
+
+// makeInitServiceFileName constructs the unit file name for an init container
+// of a pod. Every init container runs in a rkt pod of its own.
+func makeInitServiceFileName(uid types.UID, name string) string {
+	return fmt.Sprintf("%s%s.service", makeInitServiceFilePrefix(uid), name)
+}
+
+// isInitServiceFileName returns true if name is the unit file name of an
+// init container.
+func isInitServiceFileName(name string) bool {
+	return strings.Contains(name, initUnitInfix)
+}
+
 type resource struct {
 	limit   string
 	request string
@@ -376,13 +401,14 @@ func (r *runtime) getImageManifest(image string) (*appcschema.ImageManifest, err
 	return &manifest, json.Unmarshal([]byte(output[0]), &manifest)
 }
 
-// makePodManifest transforms a kubelet pod spec to the rkt pod manifest.
+// makePodManifest transforms the given containers of a kubelet pod spec to
+// the rkt pod manifest.
 // TODO(yifan): Use the RunContainerOptions generated by GenerateRunContainerOptions().
-func (r *runtime) makePodManifest(pod *api.Pod) (*appcschema.PodManifest, error) {
+func (r *runtime) makePodManifest(pod *api.Pod, containers []api.Container) (*appcschema.PodManifest, error) {
 	var globalPortMappings []kubecontainer.PortMapping
 	manifest := appcschema.BlankPodManifest()
 
-	for _, c := range pod.Spec.Containers {
+	for _, c := range containers {
 		imgManifest, err := r.getImageManifest(c.Image)
 		if err != nil {
 			return nil, err
@@ -460,14 +486,14 @@ func newUnitOption(section, name, value string) *unit.UnitOption {
 }
 
 // TODO(yifan): Remove the receiver once we can solve the appName->imageID problem.
-func (r *runtime) apiPodToruntimePod(uuid string, pod *api.Pod) *kubecontainer.Pod {
+func (r *runtime) apiPodToruntimePod(uuid string, pod *api.Pod, containers []api.Container) *kubecontainer.Pod {
 	p := &kubecontainer.Pod{
 		ID:        pod.UID,
 		Name:      pod.Name,
 		Namespace: pod.Namespace,
 	}
-	for i := range pod.Spec.Containers {
-		c := &pod.Spec.Containers[i]
+	for i := range containers {
+		c := &containers[i]
 		img, err := r.getImageByName(c.Image)
 		if err != nil {
 			glog.Warningf("rkt: Cannot get image for %q: %v", c.Image, err)
@@ -486,22 +512,21 @@ func (r *runtime) apiPodToruntimePod(uuid string, pod *api.Pod) *kubecontainer.P
 // preparePod will:
 //
 // 1. Invoke 'rkt prepare' to prepare the pod, and get the rkt pod uuid.
-// 2. Creates the unit file and save it under systemdUnitDir.
+// 2. Creates the unit file unitName and save it under systemdUnitDir.
 //
-// On success, it will return a string that represents name of the unit file
-// and a boolean that indicates if the unit file needs to be reloaded (whether
-// the file is already existed).
-func (r *runtime) preparePod(pod *api.Pod) (string, bool, error) {
+// The rkt pod runs the given containers of pod. On success, it will return a boolean that indicates if the unit file needs
+// to be reloaded (whether the file is already existed).
+func (r *runtime) preparePod(pod *api.Pod, containers []api.Container, unitName string) (bool, error) {
 	cmds := []string{"prepare", "--quiet", "--pod-manifest"}
 
 	// Generate the pod manifest from the pod spec.
-	manifest, err := r.makePodManifest(pod)
+	manifest, err := r.makePodManifest(pod, containers)
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	manifestFile, err := ioutil.TempFile("", "manifest")
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	defer func() {
 		manifestFile.Close()
@@ -512,29 +537,29 @@ func (r *runtime) preparePod(pod *api.Pod) (string, bool, error) {
 
 	data, err := json.Marshal(manifest)
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	// Since File.Write returns error if the written length is less than len(data),
 	// so check error is enough for us.
 	if _, err := manifestFile.Write(data); err != nil {
-		return "", false, err
+		return false, err
 	}
 
 	cmds = append(cmds, manifestFile.Name())
 	output, err := r.runCommand(cmds...)
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	if len(output) != 1 {
-		return "", false, fmt.Errorf("cannot get uuid from 'rkt prepare'")
+		return false, fmt.Errorf("cannot get uuid from 'rkt prepare'")
 	}
 	uuid := output[0]
 	glog.V(4).Infof("'rkt prepare' returns %q.", uuid)
 
-	p := r.apiPodToruntimePod(uuid, pod)
+	p := r.apiPodToruntimePod(uuid, pod, containers)
 	b, err := json.Marshal(p)
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 
 	runPrepared := fmt.Sprintf("%s run-prepared --private-net=%v %s", r.rktBinAbsPath, !pod.Spec.HostNetwork, uuid)
@@ -547,29 +572,42 @@ func (r *runtime) preparePod(pod *api.Pod) (string, bool, error) {
 	// Save the unit file under systemd's service directory.
 	// TODO(yifan) Garbage collect 'dead' service files.
 	needReload := false
-	unitName := makePodServiceFileName(pod.UID)
 	if _, err := os.Stat(path.Join(systemdServiceDir, unitName)); err == nil {
 		needReload = true
 	}
 	unitFile, err := os.Create(path.Join(systemdServiceDir, unitName))
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	defer unitFile.Close()
 
 	_, err = io.Copy(unitFile, unit.Serialize(units))
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
-	return unitName, needReload, nil
+	return needReload, nil
 }
 
 // RunPod first creates the unit file for a pod, and then calls
 // StartUnit over d-bus.
 func (r *runtime) RunPod(pod *api.Pod) error {
 	glog.V(4).Infof("Rkt starts to run pod: name %q.", pod.Name)
+	return r.runUnit(pod, pod.Spec.Containers, makePodServiceFileName(pod.UID))
+}
+
+// runInitContainer runs an init container of a pod in a rkt pod of its own,
+// so that it can run to completion before the other containers are started.
+// Unlike with docker, the init container does not share the network
+// namespace of the other containers of the pod.
+func (r *runtime) runInitContainer(pod *api.Pod, container *api.Container) error {
+	glog.V(4).Infof("Rkt starts to run init container %q of pod %q.", container.Name, pod.Name)
+	return r.runUnit(pod, []api.Container{*container}, makeInitServiceFileName(pod.UID, container.Name))
+}
 
-	name, needReload, err := r.preparePod(pod)
+// runUnit creates the unit file name that runs the given containers of a pod
+// in a rkt pod, and then calls StartUnit over d-bus.
+func (r *runtime) runUnit(pod *api.Pod, containers []api.Container, name string) error {
+	needReload, err := r.preparePod(pod, containers, name)
 	if err != nil {
 		return err
 	}
@@ -594,16 +632,30 @@ func (r *runtime) RunPod(pod *api.Pod) error {
 // 1, Construct the pod by the information stored in the unit file.
 // 2, Construct the pod status from pod info.
 func (r *runtime) makeRuntimePod(unitName string, podInfos map[string]*podInfo) (*kubecontainer.Pod, error) {
-	f, err := os.Open(path.Join(systemdServiceDir, unitName))
+	pod, rktID, err := readPodUnit(unitName)
 	if err != nil {
 		return nil, err
 	}
+	info, found := podInfos[rktID]
+	if !found {
+		return nil, fmt.Errorf("rkt: cannot find info for pod %q, rkt uuid: %q", pod.Name, rktID)
+	}
+	pod.Status = info.toPodStatus(pod)
+	return pod, nil
+}
+
+// readPodUnit reads the pod and the rkt uuid stored in the unit file unitName.
+func readPodUnit(unitName string) (*kubecontainer.Pod, string, error) {
+	f, err := os.Open(path.Join(systemdServiceDir, unitName))
+	if err != nil {
+		return nil, "", err
+	}
 	defer f.Close()
 
 	var pod kubecontainer.Pod
 	opts, err := unit.Deserialize(f)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var rktID string
@@ -615,24 +667,19 @@ func (r *runtime) makeRuntimePod(unitName string, podInfos map[string]*podInfo)
 		case unitPodName:
 			err = json.Unmarshal([]byte(opt.Value), &pod)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
 		case unitRktID:
 			rktID = opt.Value
 		default:
-			return nil, fmt.Errorf("rkt: Unexpected key: %q", opt.Name)
+			return nil, "", fmt.Errorf("rkt: Unexpected key: %q", opt.Name)
 		}
 	}
 
 	if len(rktID) == 0 {
-		return nil, fmt.Errorf("rkt: cannot find rkt ID of pod %v, unit file is broken", pod)
-	}
-	info, found := podInfos[rktID]
-	if !found {
-		return nil, fmt.Errorf("rkt: cannot find info for pod %q, rkt uuid: %q", pod.Name, rktID)
+		return nil, "", fmt.Errorf("rkt: cannot find rkt ID of pod %v, unit file is broken", pod)
 	}
-	pod.Status = info.toPodStatus(&pod)
-	return &pod, nil
+	return &pod, rktID, nil
 }
 
 // GetPods runs 'systemctl list-unit' and 'rkt list' to get the list of rkt pods.
@@ -655,6 +702,7 @@ func (r *runtime) GetPods(all bool) ([]*kubecontainer.Pod, error) {
 	}
 
 	var pods []*kubecontainer.Pod
+	var initPods []*kubecontainer.Pod
 	for _, u := range units {
 		if strings.HasPrefix(u.Name, kubernetesUnitPrefix) {
 			if !all && u.SubState != "running" {
@@ -665,18 +713,55 @@ func (r *runtime) GetPods(all bool) ([]*kubecontainer.Pod, error) {
 				glog.Warningf("rkt: Cannot construct pod from unit file: %v.", err)
 				continue
 			}
+			// All the containers of a pod run in the same unit.
+			state := kubecontainer.ContainerStateExited
+			if u.SubState == "running" {
+				state = kubecontainer.ContainerStateRunning
+			}
+			for _, c := range pod.Containers {
+				c.State = state
+			}
+			if isInitServiceFileName(u.Name) {
+				initPods = append(initPods, pod)
+				continue
+			}
 			pods = append(pods, pod)
 		}
 	}
+	// Init containers run in units of their own. Report them as containers
+	// of their pod, so that they are killed together with it.
+	for _, initPod := range initPods {
+		pod := kubecontainer.Pods(pods).FindPodByID(initPod.ID)
+		if len(pod.Containers) == 0 {
+			initPod.Status = api.PodStatus{}
+			pods = append(pods, initPod)
+			continue
+		}
+		for i := range pods {
+			if pods[i].ID == initPod.ID {
+				pods[i].Containers = append(pods[i].Containers, initPod.Containers...)
+			}
+		}
+	}
 	return pods, nil
 }
 
 // KillPod invokes 'systemctl kill' to kill the unit that runs the pod.
-func (r *runtime) KillPod(pod kubecontainer.Pod) error {
-	glog.V(4).Infof("Rkt is killing pod: name %q.", pod.Name)
+func (r *runtime) KillPod(pod *api.Pod, runningPod kubecontainer.Pod) error {
+	glog.V(4).Infof("Rkt is killing pod: name %q.", runningPod.Name)
 
 	// TODO(yifan): More graceful stop. Replace with StopUnit and wait for a timeout.
-	r.systemd.KillUnit(makePodServiceFileName(pod.ID), int32(syscall.SIGKILL))
+	r.systemd.KillUnit(makePodServiceFileName(runningPod.ID), int32(syscall.SIGKILL))
+	units, err := r.systemd.ListUnits()
+	if err != nil {
+		return err
+	}
+	initPrefix := makeInitServiceFilePrefix(runningPod.ID)
+	for _, u := range units {
+		if strings.HasPrefix(u.Name, initPrefix) && u.SubState == "running" {
+			r.systemd.KillUnit(u.Name, int32(syscall.SIGKILL))
+		}
+	}
 	return r.systemd.Reload()
 }
 
@@ -687,11 +772,73 @@ func (r *runtime) GetPodStatus(pod *api.Pod) (*api.PodStatus, error) {
 	if err != nil {
 		return nil, err
 	}
+	var initStatuses []api.ContainerStatus
+	if len(pod.Spec.InitContainers) > 0 {
+		// The statuses are read from the unit files rather than from the
+		// listed units, since systemd unloads the units of init containers
+		// that exited.
+		podInfos, err := r.getPodInfos()
+		if err != nil {
+			return nil, err
+		}
+		initStatuses = r.getInitContainerStatuses(pod, podInfos)
+	}
 	p := kubecontainer.Pods(pods).FindPodByID(pod.UID)
-	if len(p.Containers) == 0 {
+	if len(p.Containers) == 0 && len(initStatuses) == 0 {
 		return nil, fmt.Errorf("cannot find status for pod: %q", kubecontainer.BuildPodFullName(pod.Name, pod.Namespace))
 	}
-	return &p.Status, nil
+	status := p.Status
+	status.InitContainerStatuses = initStatuses
+	return &status, nil
+}
+
+// isPodStarted returns true if the rkt pod that runs the containers of pod has
+// been created, i.e. all its init containers succeeded once.
+func isPodStarted(pod *api.Pod) bool {
+	_, err := os.Stat(path.Join(systemdServiceDir, makePodServiceFileName(pod.UID)))
+	return err == nil
+}
+
+// getInitContainerStatuses returns the statuses of the init containers of pod
+// that have been started.
+func (r *runtime) getInitContainerStatuses(pod *api.Pod, podInfos map[string]*podInfo) []api.ContainerStatus {
+	started := isPodStarted(pod)
+	var statuses []api.ContainerStatus
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		runtimePod, rktID, err := readPodUnit(makeInitServiceFileName(pod.UID, container.Name))
+		var info *podInfo
+		if err == nil && len(runtimePod.Containers) == 1 {
+			info = podInfos[rktID]
+		}
+		if info == nil {
+			if started {
+				// The rkt pod of the init container has been garbage
+				// collected, but it must have succeeded, since the other
+				// containers of the pod only start after that.
+				statuses = append(statuses, api.ContainerStatus{
+					Name:  container.Name,
+					Image: container.Image,
+					State: api.ContainerState{
+						Terminated: &api.ContainerStateTerminated{ExitCode: 0},
+					},
+				})
+			}
+			continue
+		}
+		status := info.getContainerStatus(runtimePod.Containers[0])
+		if status.State.Waiting != nil {
+			// The unit of the init container has been started, and rkt is
+			// still setting up its pod.
+			status.State = api.ContainerState{
+				Running: &api.ContainerStateRunning{
+					StartedAt: util.Unix(runtimePod.Containers[0].Created, 0),
+				},
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
 }
 
 // Version invokes 'rkt version' to get the version information of the rkt
@@ -822,10 +969,29 @@ func (r *runtime) RemoveImage(image kubecontainer.ImageSpec) error {
 	return fmt.Errorf("rkt: RemoveImages unimplemented")
 }
 
+func (r *runtime) GetNetNs(containerID string) (string, error) {
+	return "", fmt.Errorf("rkt: GetNetNs unimplemented")
+}
+
 // SyncPod syncs the running pod to match the specified desired pod.
-func (r *runtime) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus api.PodStatus, pullSecrets []api.Secret) error {
+func (r *runtime) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus api.PodStatus, pullSecrets []api.Secret, backOff *util.Backoff) error {
 	podFullName := kubecontainer.GetPodFullName(pod)
+	// The init containers run in rkt pods of their own, see syncInitContainers.
+	var containers []*kubecontainer.Container
+	for _, c := range runningPod.Containers {
+		if !isInitContainer(pod, c.Name) {
+			containers = append(containers, c)
+		}
+	}
+	runningPod.Containers = containers
+
 	if len(runningPod.Containers) == 0 {
+		if !isPodStarted(pod) {
+			done, err := r.syncInitContainers(pod, podStatus, backOff)
+			if err != nil || !done {
+				return err
+			}
+		}
 		glog.V(4).Infof("Pod %q is not running, will start it", podFullName)
 		return r.RunPod(pod)
 	}
@@ -881,7 +1047,7 @@ func (r *runtime) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus
 
 	if restartPod {
 		// TODO(yifan): Handle network plugin.
-		if err := r.KillPod(runningPod); err != nil {
+		if err := r.KillPod(pod, runningPod); err != nil {
 			return err
 		}
 		if err := r.RunPod(pod); err != nil {
@@ -891,26 +1057,73 @@ func (r *runtime) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus
 	return nil
 }
 
+// syncInitContainers runs the init containers of a pod one after the other,
+// each in a rkt pod of its own. It starts the next init container, if there
+// is one to start, and returns true once all of them have succeeded. Init
+// containers only run before the containers of the pod are started for the
+// first time.
+func (r *runtime) syncInitContainers(pod *api.Pod, podStatus api.PodStatus, backOff *util.Backoff) (bool, error) {
+	next, failed, done := kubecontainer.FindActiveInitContainer(pod, &podStatus)
+	if done {
+		return true, nil
+	}
+	if next == nil {
+		// An init container is running.
+		return false, nil
+	}
+	if failed {
+		if pod.Spec.RestartPolicy == api.RestartPolicyNever {
+			return false, nil
+		}
+		backOffKey := fmt.Sprintf("%s_%s_%x", pod.UID, next.Name, kubecontainer.HashContainer(next))
+		if backOff.IsInBackOffSinceUpdate(backOffKey) {
+			glog.V(4).Infof("Back-off %s restarting failed init container %q of pod %q", backOff.Get(backOffKey), next.Name, kubecontainer.GetPodFullName(pod))
+			return false, nil
+		}
+		backOff.Next(backOffKey, backOff.Clock.Now())
+	}
+	return false, r.runInitContainer(pod, next)
+}
+
+// isInitContainer returns true if name is the name of an init container of pod.
+func isInitContainer(pod *api.Pod, name string) bool {
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetContainerLogs uses journalctl to get the logs of the container.
-// By default, it returns a snapshot of the container log. Set |follow| to true to
-// stream the log. Set |follow| to false and specify the number of lines (e.g.
-// "100" or "all") to tail the log.
+// By default, it returns a snapshot of the container log. Set |logOptions.Follow|
+// to true to stream the log, and |logOptions.TailLines| to the number of lines to
+// start from the end of the log.
 // TODO(yifan): Currently, it fetches all the containers' log within a pod. We will
 // be able to fetch individual container's log once https://github.com/coreos/rkt/pull/841
 // landed.
-func (r *runtime) GetContainerLogs(pod *api.Pod, containerID string, tail string, follow bool, stdout, stderr io.Writer) error {
+// TODO(yifan): journalctl always prefixes its entries with a timestamp, so
+// |logOptions.Timestamps| is ignored.
+func (r *runtime) GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 	unitName := makePodServiceFileName(pod.UID)
 	cmd := exec.Command("journalctl", "-u", unitName)
-	if follow {
+	if logOptions.Follow {
 		cmd.Args = append(cmd.Args, "-f")
 	}
-	if tail == "all" {
+	if logOptions.TailLines == nil {
 		cmd.Args = append(cmd.Args, "-a")
 	} else {
-		_, err := strconv.Atoi(tail)
-		if err == nil {
-			cmd.Args = append(cmd.Args, "-n", tail)
-		}
+		cmd.Args = append(cmd.Args, "-n", strconv.FormatInt(*logOptions.TailLines, 10))
+	}
+	var since time.Time
+	if logOptions.SinceSeconds != nil {
+		since = time.Now().Add(-time.Duration(*logOptions.SinceSeconds) * time.Second)
+	}
+	if logOptions.SinceTime != nil {
+		since = logOptions.SinceTime.Time
+	}
+	if !since.IsZero() {
+		cmd.Args = append(cmd.Args, "--since", since.Format(journalctlTimeFormat))
 	}
 	cmd.Stdout, cmd.Stderr = stdout, stderr
 	return cmd.Start()
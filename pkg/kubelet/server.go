@@ -37,10 +37,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/healthz"
 	"k8s.io/kubernetes/pkg/httplog"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/flushwriter"
 	"k8s.io/kubernetes/pkg/util/httpstream"
 	"k8s.io/kubernetes/pkg/util/httpstream/spdy"
@@ -49,6 +52,7 @@ import (
 // Server is a http.Handler which exposes kubelet functionality over HTTP.
 type Server struct {
 	host HostInterface
+	auth AuthInterface
 	mux  *http.ServeMux
 }
 
@@ -59,9 +63,10 @@ type TLSOptions struct {
 }
 
 // ListenAndServeKubeletServer initializes a server to respond to HTTP network requests on the Kubelet.
-func ListenAndServeKubeletServer(host HostInterface, address net.IP, port uint, tlsOptions *TLSOptions, enableDebuggingHandlers bool) {
+// If auth is nil, requests are served without authentication or authorization.
+func ListenAndServeKubeletServer(host HostInterface, address net.IP, port uint, tlsOptions *TLSOptions, auth AuthInterface, enableDebuggingHandlers bool) {
 	glog.Infof("Starting to listen on %s:%d", address, port)
-	handler := NewServer(host, enableDebuggingHandlers)
+	handler := NewServer(host, auth, enableDebuggingHandlers)
 	s := &http.Server{
 		Addr:           net.JoinHostPort(address.String(), strconv.FormatUint(uint64(port), 10)),
 		Handler:        &handler,
@@ -78,7 +83,7 @@ func ListenAndServeKubeletServer(host HostInterface, address net.IP, port uint,
 // ListenAndServeKubeletReadOnlyServer initializes a server to respond to HTTP network requests on the Kubelet.
 func ListenAndServeKubeletReadOnlyServer(host HostInterface, address net.IP, port uint) {
 	glog.V(1).Infof("Starting to listen read-only on %s:%d", address, port)
-	s := NewServer(host, false)
+	s := NewServer(host, nil, false)
 	s.mux.Handle("/metrics", prometheus.Handler())
 
 	server := &http.Server{
@@ -102,7 +107,7 @@ type HostInterface interface {
 	RunInContainer(name string, uid types.UID, container string, cmd []string) ([]byte, error)
 	ExecInContainer(name string, uid types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	AttachContainer(name string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error
-	GetKubeletContainerLogs(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error
+	GetKubeletContainerLogs(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error
 	ServeLogs(w http.ResponseWriter, req *http.Request)
 	PortForward(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error
 	StreamingConnectionIdleTimeout() time.Duration
@@ -112,9 +117,11 @@ type HostInterface interface {
 }
 
 // NewServer initializes and configures a kubelet.Server object to handle HTTP requests.
-func NewServer(host HostInterface, enableDebuggingHandlers bool) Server {
+// If auth is not nil, every request must be authenticated and authorized by it.
+func NewServer(host HostInterface, auth AuthInterface, enableDebuggingHandlers bool) Server {
 	server := Server{
 		host: host,
+		auth: auth,
 		mux:  http.NewServeMux(),
 	}
 	server.InstallDefaultHandlers()
@@ -245,10 +252,11 @@ func (s *Server) handleContainerLogs(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	uriValues := u.Query()
-	follow, _ := strconv.ParseBool(uriValues.Get("follow"))
-	previous, _ := strconv.ParseBool(uriValues.Get("previous"))
-	tail := uriValues.Get("tail")
+	logOptions, err := parsePodLogOptions(u.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	pod, ok := s.host.GetPodByName(podNamespace, podID)
 	if !ok {
@@ -274,13 +282,54 @@ func (s *Server) handleContainerLogs(w http.ResponseWriter, req *http.Request) {
 	fw := flushwriter.Wrap(w)
 	w.Header().Set("Transfer-Encoding", "chunked")
 	w.WriteHeader(http.StatusOK)
-	err = s.host.GetKubeletContainerLogs(kubecontainer.GetPodFullName(pod), containerName, tail, follow, previous, fw, fw)
+	err = s.host.GetKubeletContainerLogs(kubecontainer.GetPodFullName(pod), containerName, logOptions, fw, fw)
 	if err != nil {
 		s.error(w, err)
 		return
 	}
 }
 
+// parsePodLogOptions builds the log options of a containerLogs request from its
+// query parameters. The legacy "tail" parameter ("all" or a number of lines) is
+// still honored when "tailLines" is not set.
+func parsePodLogOptions(query url.Values) (*api.PodLogOptions, error) {
+	logOptions := &api.PodLogOptions{}
+	logOptions.Follow, _ = strconv.ParseBool(query.Get("follow"))
+	logOptions.Previous, _ = strconv.ParseBool(query.Get("previous"))
+	logOptions.Timestamps, _ = strconv.ParseBool(query.Get("timestamps"))
+
+	tail := query.Get("tailLines")
+	if len(tail) == 0 {
+		tail = query.Get("tail")
+	}
+	if len(tail) > 0 && tail != "all" {
+		lines, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tailLines %q: %v", tail, err)
+		}
+		logOptions.TailLines = &lines
+	}
+	if since := query.Get("sinceSeconds"); len(since) > 0 {
+		seconds, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sinceSeconds %q: %v", since, err)
+		}
+		logOptions.SinceSeconds = &seconds
+	}
+	if since := query.Get("sinceTime"); len(since) > 0 {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sinceTime %q: %v", since, err)
+		}
+		sinceTime := util.NewTime(t)
+		logOptions.SinceTime = &sinceTime
+	}
+	if errs := validation.ValidatePodLogOptions(logOptions); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return logOptions, nil
+}
+
 // encodePods creates an api.PodList object from pods and returns the encoded
 // PodList.
 func encodePods(pods []*api.Pod) (data []byte, err error) {
@@ -672,9 +721,34 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			http.StatusSwitchingProtocols,
 		),
 	).Log()
+	if s.auth != nil && !s.authorize(w, req) {
+		return
+	}
 	s.mux.ServeHTTP(w, req)
 }
 
+// authorize authenticates and authorizes a request with s.auth, and writes
+// the error response if the request may not proceed.
+func (s *Server) authorize(w http.ResponseWriter, req *http.Request) bool {
+	u, ok, err := s.auth.AuthenticateRequest(req)
+	if err != nil {
+		glog.Errorf("Unable to authenticate the request due to an error: %v", err)
+	}
+	if err != nil || !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	attrs := s.auth.GetRequestAttributes(u, req)
+	if err := s.auth.Authorize(attrs); err != nil {
+		msg := fmt.Sprintf("Forbidden (user=%s, verb=%s, subresource=%s)", u.GetName(), attrs.GetVerb(), attrs.GetSubresource())
+		glog.V(2).Infof("%s: %v", msg, err)
+		http.Error(w, msg, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
 type StatsRequest struct {
 	// The name of the container for which to request stats.
 	// Default: /
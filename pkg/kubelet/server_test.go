@@ -34,9 +34,13 @@ import (
 
 	cadvisorApi "github.com/google/cadvisor/info/v1"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/auth/user"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/httpstream"
 	"k8s.io/kubernetes/pkg/util/httpstream/spdy"
 )
@@ -54,7 +58,7 @@ type fakeKubelet struct {
 	execFunc                           func(pod string, uid types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	attachFunc                         func(pod string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	portForwardFunc                    func(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error
-	containerLogsFunc                  func(podFullName, containerName, tail string, follow, pervious bool, stdout, stderr io.Writer) error
+	containerLogsFunc                  func(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error
 	streamingConnectionIdleTimeoutFunc func() time.Duration
 	hostnameFunc                       func() string
 	resyncInterval                     time.Duration
@@ -101,8 +105,8 @@ func (fk *fakeKubelet) ServeLogs(w http.ResponseWriter, req *http.Request) {
 	fk.logFunc(w, req)
 }
 
-func (fk *fakeKubelet) GetKubeletContainerLogs(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
-	return fk.containerLogsFunc(podFullName, containerName, tail, follow, previous, stdout, stderr)
+func (fk *fakeKubelet) GetKubeletContainerLogs(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
+	return fk.containerLogsFunc(podFullName, containerName, logOptions, stdout, stderr)
 }
 
 func (fk *fakeKubelet) GetHostname() string {
@@ -147,7 +151,7 @@ func newServerTest() *serverTestFramework {
 			}, true
 		},
 	}
-	server := NewServer(fw.fakeKubelet, true)
+	server := NewServer(fw.fakeKubelet, nil, true)
 	fw.serverUnderTest = &server
 	fw.testHTTPServer = httptest.NewServer(fw.serverUnderTest)
 	return fw
@@ -558,22 +562,16 @@ func setPodByNameFunc(fw *serverTestFramework, namespace, pod, container string)
 	}
 }
 
-func setGetContainerLogsFunc(fw *serverTestFramework, t *testing.T, expectedPodName, expectedContainerName, expectedTail string, expectedFollow, expectedPrevious bool, output string) {
-	fw.fakeKubelet.containerLogsFunc = func(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
+func setGetContainerLogsFunc(fw *serverTestFramework, t *testing.T, expectedPodName, expectedContainerName string, expectedLogOptions *api.PodLogOptions, output string) {
+	fw.fakeKubelet.containerLogsFunc = func(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 		if podFullName != expectedPodName {
 			t.Errorf("expected %s, got %s", expectedPodName, podFullName)
 		}
 		if containerName != expectedContainerName {
 			t.Errorf("expected %s, got %s", expectedContainerName, containerName)
 		}
-		if tail != expectedTail {
-			t.Errorf("expected %s, got %s", expectedTail, tail)
-		}
-		if follow != expectedFollow {
-			t.Errorf("expected %t, got %t", expectedFollow, follow)
-		}
-		if previous != expectedPrevious {
-			t.Errorf("expected %t, got %t", expectedPrevious, previous)
+		if !api.Semantic.DeepEqual(logOptions, expectedLogOptions) {
+			t.Errorf("expected %#v, got %#v", expectedLogOptions, logOptions)
 		}
 
 		io.WriteString(stdout, output)
@@ -588,11 +586,8 @@ func TestContainerLogs(t *testing.T) {
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := ""
-	expectedFollow := false
-	expectedPrevious := false
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{}, output)
 	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName)
 	if err != nil {
 		t.Errorf("Got error GETing: %v", err)
@@ -616,11 +611,9 @@ func TestContainerLogsWithTail(t *testing.T) {
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := "5"
-	expectedFollow := false
-	expectedPrevious := false
+	expectedTail := int64(5)
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{TailLines: &expectedTail}, output)
 	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?tail=5")
 	if err != nil {
 		t.Errorf("Got error GETing: %v", err)
@@ -644,11 +637,8 @@ func TestContainerLogsWithFollow(t *testing.T) {
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := ""
-	expectedFollow := true
-	expectedPrevious := false
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{Follow: true}, output)
 	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?follow=1")
 	if err != nil {
 		t.Errorf("Got error GETing: %v", err)
@@ -665,6 +655,61 @@ func TestContainerLogsWithFollow(t *testing.T) {
 	}
 }
 
+func TestContainerLogsWithOptions(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	expectedTail := int64(10)
+	expectedSince := util.NewTime(time.Date(2015, 8, 1, 10, 0, 0, 0, time.UTC))
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{
+		Previous:   true,
+		Timestamps: true,
+		TailLines:  &expectedTail,
+		SinceTime:  &expectedSince,
+	}, output)
+	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?previous=true&timestamps=true&tailLines=10&sinceTime=2015-08-01T10:00:00Z")
+	if err != nil {
+		t.Errorf("Got error GETing: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Errorf("Error reading container logs: %v", err)
+	}
+	result := string(body)
+	if result != output {
+		t.Errorf("Expected: '%v', got: '%v'", output, result)
+	}
+}
+
+func TestContainerLogsWithInvalidOptions(t *testing.T) {
+	fw := newServerTest()
+	podNamespace := "other"
+	podName := "foo"
+	expectedContainerName := "baz"
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	fw.fakeKubelet.containerLogsFunc = func(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
+		t.Errorf("unexpected call with options %#v", logOptions)
+		return nil
+	}
+	for _, query := range []string{"?tailLines=foo", "?sinceSeconds=-1", "?sinceSeconds=10&sinceTime=2015-08-01T10:00:00Z"} {
+		resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + query)
+		if err != nil {
+			t.Errorf("Got error GETing: %v", err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, resp.StatusCode)
+		}
+	}
+}
+
 func TestServeExecInContainerIdleTimeout(t *testing.T) {
 	fw := newServerTest()
 
@@ -1342,3 +1387,75 @@ func TestServePortForward(t *testing.T) {
 		<-portForwardFuncDone
 	}
 }
+
+func TestAuthFilters(t *testing.T) {
+	fw := newServerTest()
+	fw.fakeKubelet.podsFunc = func() []*api.Pod { return []*api.Pod{} }
+	fw.fakeKubelet.machineInfoFunc = func() (*cadvisorApi.MachineInfo, error) { return &cadvisorApi.MachineInfo{}, nil }
+	fw.fakeKubelet.logFunc = func(w http.ResponseWriter, req *http.Request) {}
+
+	var attributes authorizer.Attributes
+	authn := authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+		switch req.Header.Get("Authorization") {
+		case "Bearer admin":
+			return &user.DefaultInfo{Name: "admin"}, true, nil
+		case "Bearer viewer":
+			return &user.DefaultInfo{Name: "viewer"}, true, nil
+		}
+		return nil, false, nil
+	})
+	authz := authorizer.AuthorizerFunc(func(a authorizer.Attributes) error {
+		attributes = a
+		if a.GetUserName() == "viewer" && a.GetSubresource() == "proxy" {
+			return fmt.Errorf("viewers may not proxy")
+		}
+		return nil
+	})
+	fw.serverUnderTest.auth = NewKubeletAuth(authn, authz, "node1")
+
+	tests := []struct {
+		method, path, token string
+		code                int
+		verb, subresource   string
+	}{
+		{method: "GET", path: "/pods", token: "", code: http.StatusUnauthorized},
+		{method: "GET", path: "/pods", token: "unknown", code: http.StatusUnauthorized},
+		{method: "GET", path: "/pods", token: "admin", code: http.StatusOK, verb: "get", subresource: "proxy"},
+		{method: "GET", path: "/pods", token: "viewer", code: http.StatusForbidden, verb: "get", subresource: "proxy"},
+		{method: "GET", path: "/spec/", token: "viewer", code: http.StatusOK, verb: "get", subresource: "stats"},
+		{method: "GET", path: "/logs/", token: "viewer", code: http.StatusOK, verb: "get", subresource: "log"},
+		{method: "POST", path: "/run/ns/pod/container", token: "viewer", code: http.StatusForbidden, verb: "create", subresource: "proxy"},
+	}
+	for _, test := range tests {
+		attributes = nil
+		req, err := http.NewRequest(test.method, fw.testHTTPServer.URL+test.path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(test.token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", test.method, test.path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.code {
+			t.Errorf("%s %s as %q: expected status %d, got %d", test.method, test.path, test.token, test.code, resp.StatusCode)
+		}
+		if len(test.verb) == 0 {
+			if attributes != nil {
+				t.Errorf("%s %s as %q: expected the request not to be authorized, got %#v", test.method, test.path, test.token, attributes)
+			}
+			continue
+		}
+		if attributes == nil {
+			t.Errorf("%s %s as %q: expected the request to be authorized", test.method, test.path, test.token)
+			continue
+		}
+		if attributes.GetVerb() != test.verb || attributes.GetSubresource() != test.subresource ||
+			attributes.GetResource() != "nodes" || attributes.GetName() != "node1" || attributes.GetUserName() != test.token {
+			t.Errorf("%s %s as %q: unexpected attributes %#v", test.method, test.path, test.token, attributes)
+		}
+	}
+}
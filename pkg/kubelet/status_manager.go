@@ -26,6 +26,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
 	kubeletUtil "k8s.io/kubernetes/pkg/kubelet/util"
 	"k8s.io/kubernetes/pkg/util"
@@ -95,11 +96,22 @@ func (s *statusManager) SetPodStatus(pod *api.Pod, status api.PodStatus) {
 	defer s.podStatusesLock.Unlock()
 	oldStatus, found := s.podStatuses[podFullName]
 
+	// Pod phase progresses monotonically. A pod that was terminated by the
+	// kubelet (e.g. evicted) must not be reported as running by a sync that
+	// started before it was killed.
+	if found && podIsTerminated(&oldStatus) && !podIsTerminated(&status) {
+		glog.V(3).Infof("Ignoring status %+v for terminated pod %q", status, kubeletUtil.FormatPodName(pod))
+		return
+	}
+
 	// ensure that the start time does not change across updates.
 	if found && oldStatus.StartTime != nil {
 		status.StartTime = oldStatus.StartTime
 	}
 
+	// the quality of service class only depends on the immutable pod spec.
+	status.QOSClass = qos.GetPodQOS(pod)
+
 	// if the status has no start time, we need to set an initial time
 	// TODO(yujuhong): Consider setting StartTime when generating the pod
 	// status instead, which would allow statusManager to become a simple cache
@@ -122,7 +134,9 @@ func (s *statusManager) SetPodStatus(pod *api.Pod, status api.PodStatus) {
 	// Currently this routine is not called for the same pod from multiple
 	// workers and/or the kubelet but dropping the lock before sending the
 	// status down the channel feels like an easy way to get a bullet in foot.
-	if !found || !isStatusEqual(&oldStatus, &status) {
+	// Pods that are being deleted are always synced, so that the deletion can be confirmed
+	// once their containers have stopped.
+	if !found || !isStatusEqual(&oldStatus, &status) || pod.DeletionTimestamp != nil {
 		s.podStatuses[podFullName] = status
 		s.podStatusChannel <- podStatusSyncRequest{pod, status}
 	} else {
@@ -167,7 +181,17 @@ func (s *statusManager) syncBatch() error {
 		// TODO: handle conflict as a retry, make that easier too.
 		if err == nil {
 			glog.V(3).Infof("Status for pod %q updated successfully", kubeletUtil.FormatPodName(pod))
-			return nil
+			if statusPod.DeletionTimestamp == nil || !notRunning(status.ContainerStatuses) {
+				return nil
+			}
+			// the pod has been gracefully deleted and all of its containers have stopped,
+			// so confirm the deletion with a zero grace period.
+			err = s.kubeClient.Pods(statusPod.Namespace).Delete(statusPod.Name, api.NewDeleteOptions(0))
+			if err == nil {
+				glog.V(3).Infof("Pod %q fully terminated and removed from etcd", kubeletUtil.FormatPodName(pod))
+				go s.DeletePodStatus(podFullName)
+				return nil
+			}
 		}
 	}
 
@@ -181,3 +205,14 @@ func (s *statusManager) syncBatch() error {
 	go s.DeletePodStatus(podFullName)
 	return fmt.Errorf("error updating status for pod %q: %v", kubeletUtil.FormatPodName(pod), err)
 }
+
+// notRunning returns true if every status is terminated or waiting, or the status list
+// is empty.
+func notRunning(statuses []api.ContainerStatus) bool {
+	for _, status := range statuses {
+		if status.State.Terminated == nil && status.State.Waiting == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kubernetes/pkg/client"
 	"k8s.io/kubernetes/pkg/client/testclient"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
 )
 
@@ -98,6 +99,9 @@ func TestNewStatus(t *testing.T) {
 	if status.StartTime.IsZero() {
 		t.Errorf("SetPodStatus did not set a proper start time value")
 	}
+	if status.QOSClass != api.PodQOSBestEffort {
+		t.Errorf("Unexpected QoS class, expected %q, actual %q", api.PodQOSBestEffort, status.QOSClass)
+	}
 }
 
 func TestNewStatusPreservesPodStartTime(t *testing.T) {
@@ -128,6 +132,18 @@ func TestChangedStatus(t *testing.T) {
 	verifyUpdates(t, syncer, 2)
 }
 
+func TestTerminatedStatusIsFinal(t *testing.T) {
+	syncer := newTestStatusManager()
+	syncer.SetPodStatus(testPod, api.PodStatus{Phase: api.PodFailed, Reason: "Evicted"})
+	syncer.SetPodStatus(testPod, api.PodStatus{Phase: api.PodRunning})
+	verifyUpdates(t, syncer, 1)
+
+	status, _ := syncer.GetPodStatus(kubecontainer.GetPodFullName(testPod))
+	if status.Phase != api.PodFailed {
+		t.Errorf("expected the pod to stay failed, got %+v", status)
+	}
+}
+
 func TestChangedStatusKeepsStartTime(t *testing.T) {
 	syncer := newTestStatusManager()
 	now := util.Now()
@@ -167,6 +183,49 @@ func TestSyncBatch(t *testing.T) {
 	)
 }
 
+func TestSyncBatchDeletesTerminatedPod(t *testing.T) {
+	now := util.Now()
+	pod := *testPod
+	pod.DeletionTimestamp = &now
+	syncer := newStatusManager(&testclient.Fake{ReactFn: func(action testclient.Action) (runtime.Object, error) {
+		return &pod, nil
+	}})
+	syncer.SetPodStatus(&pod, api.PodStatus{
+		ContainerStatuses: []api.ContainerStatus{
+			{Name: "foo", State: api.ContainerState{Terminated: &api.ContainerStateTerminated{}}},
+		},
+	})
+	if err := syncer.syncBatch(); err != nil {
+		t.Errorf("unexpected syncing error: %v", err)
+	}
+	verifyActions(t, syncer.kubeClient, []testclient.Action{
+		testclient.GetActionImpl{ActionImpl: testclient.ActionImpl{Verb: "get", Resource: "pods"}},
+		testclient.UpdateActionImpl{ActionImpl: testclient.ActionImpl{Verb: "update", Resource: "pods", Subresource: "status"}},
+		testclient.DeleteActionImpl{ActionImpl: testclient.ActionImpl{Verb: "delete", Resource: "pods"}},
+	})
+}
+
+func TestSyncBatchKeepsRunningDeletedPod(t *testing.T) {
+	now := util.Now()
+	pod := *testPod
+	pod.DeletionTimestamp = &now
+	syncer := newStatusManager(&testclient.Fake{ReactFn: func(action testclient.Action) (runtime.Object, error) {
+		return &pod, nil
+	}})
+	syncer.SetPodStatus(&pod, api.PodStatus{
+		ContainerStatuses: []api.ContainerStatus{
+			{Name: "foo", State: api.ContainerState{Running: &api.ContainerStateRunning{}}},
+		},
+	})
+	if err := syncer.syncBatch(); err != nil {
+		t.Errorf("unexpected syncing error: %v", err)
+	}
+	verifyActions(t, syncer.kubeClient, []testclient.Action{
+		testclient.GetActionImpl{ActionImpl: testclient.ActionImpl{Verb: "get", Resource: "pods"}},
+		testclient.UpdateActionImpl{ActionImpl: testclient.ActionImpl{Verb: "update", Resource: "pods", Subresource: "status"}},
+	})
+}
+
 // shuffle returns a new shuffled list of container statuses.
 func shuffle(statuses []api.ContainerStatus) []api.ContainerStatus {
 	numStatuses := len(statuses)
@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	rt "runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,6 +45,7 @@ import (
 	"k8s.io/kubernetes/pkg/auth/authorizer"
 	"k8s.io/kubernetes/pkg/auth/handlers"
 	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/expapi"
 	explatest "k8s.io/kubernetes/pkg/expapi/latest"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/healthz"
@@ -70,6 +72,11 @@ import (
 	etcdallocator "k8s.io/kubernetes/pkg/registry/service/allocator/etcd"
 	ipallocator "k8s.io/kubernetes/pkg/registry/service/ipallocator"
 	serviceaccountetcd "k8s.io/kubernetes/pkg/registry/serviceaccount/etcd"
+	"k8s.io/kubernetes/pkg/registry/subjectaccessreview"
+	thirdpartyresourceetcd "k8s.io/kubernetes/pkg/registry/thirdpartyresource/etcd"
+	"k8s.io/kubernetes/pkg/registry/thirdpartyresourcedata"
+	thirdpartyresourcedataetcd "k8s.io/kubernetes/pkg/registry/thirdpartyresourcedata/etcd"
+	"k8s.io/kubernetes/pkg/registry/tokenreview"
 	"k8s.io/kubernetes/pkg/storage"
 	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
 	"k8s.io/kubernetes/pkg/tools"
@@ -105,10 +112,14 @@ type Config struct {
 	DisableV1 bool
 	EnableExp bool
 	// allow downstream consumers to disable the index route
-	EnableIndex           bool
-	EnableProfiling       bool
-	APIPrefix             string
-	ExpAPIPrefix          string
+	EnableIndex     bool
+	EnableProfiling bool
+	// allow pod watches to be served from an in memory cache, indexed by node
+	EnableWatchCache bool
+	APIPrefix        string
+	// APIGroupPrefix is the prefix API groups are served under, as
+	// <APIGroupPrefix>/<group>/<version>.
+	APIGroupPrefix        string
 	CorsAllowedOriginList util.StringList
 	Authenticator         authenticator.Request
 	// TODO(roberthbailey): Remove once the server no longer supports http basic auth.
@@ -185,7 +196,7 @@ type Master struct {
 	enableSwaggerSupport  bool
 	enableProfiling       bool
 	apiPrefix             string
-	expAPIPrefix          string
+	apiGroupPrefix        string
 	corsAllowedOriginList util.StringList
 	authenticator         authenticator.Request
 	authorizer            authorizer.Authorizer
@@ -207,6 +218,13 @@ type Master struct {
 	// storage contains the RESTful endpoints exposed by this master
 	storage map[string]rest.Storage
 
+	// thirdPartyStorage is the storage for third party resources and their objects
+	thirdPartyStorage storage.Interface
+	// thirdPartyResources holds the third party APIs that have been installed,
+	// keyed by their group version (<group>/<version>)
+	thirdPartyResources map[string]*thirdPartyAPI
+	thirdPartyLock      sync.RWMutex
+
 	// registries are internal client APIs for accessing the storage layer
 	// TODO: define the internal typed interface in a way that clients can
 	// also be replaced
@@ -283,6 +301,9 @@ func setDefaults(c *Config) {
 	if c.CacheTimeout == 0 {
 		c.CacheTimeout = 5 * time.Second
 	}
+	if len(c.APIGroupPrefix) == 0 {
+		c.APIGroupPrefix = "/apis"
+	}
 	for c.PublicAddress == nil || c.PublicAddress.IsUnspecified() {
 		// TODO: This should be done in the caller and just require a
 		// valid value to be passed in.
@@ -340,7 +361,7 @@ func New(c *Config) *Master {
 		enableSwaggerSupport:  c.EnableSwaggerSupport,
 		enableProfiling:       c.EnableProfiling,
 		apiPrefix:             c.APIPrefix,
-		expAPIPrefix:          c.ExpAPIPrefix,
+		apiGroupPrefix:        c.APIGroupPrefix,
 		corsAllowedOriginList: c.CorsAllowedOriginList,
 		authenticator:         c.Authenticator,
 		authorizer:            c.Authorizer,
@@ -348,6 +369,8 @@ func New(c *Config) *Master {
 		v1:                    !c.DisableV1,
 		exp:                   c.EnableExp,
 		requestContextMapper:  c.RequestContextMapper,
+		thirdPartyStorage:     c.ExpDatabaseStorage,
+		thirdPartyResources:   map[string]*thirdPartyAPI{},
 
 		cacheTimeout:      c.CacheTimeout,
 		minRequestTimeout: time.Duration(c.MinRequestTimeout) * time.Second,
@@ -427,7 +450,11 @@ func logStackOnRecover(panicReason interface{}, httpWriter http.ResponseWriter)
 func (m *Master) init(c *Config) {
 	healthzChecks := []healthz.HealthzChecker{}
 	m.clock = util.RealClock{}
-	podStorage := podetcd.NewStorage(c.DatabaseStorage, c.KubeletClient)
+	podStorageInterface := c.DatabaseStorage
+	if c.EnableWatchCache {
+		podStorageInterface = podetcd.NewCacher(c.DatabaseStorage, 1000)
+	}
+	podStorage := podetcd.NewStorage(podStorageInterface, c.KubeletClient)
 
 	podTemplateStorage := podtemplateetcd.NewREST(c.DatabaseStorage)
 
@@ -567,20 +594,32 @@ func (m *Master) init(c *Config) {
 
 	apiserver.InstallSupport(m.muxHelper, m.rootWebService, c.EnableProfiling, healthzChecks...)
 	apiserver.AddApiWebService(m.handlerContainer, c.APIPrefix, apiVersions)
+	apiserver.AddApisWebService(m.handlerContainer, c.APIGroupPrefix, m.apiGroups)
 	defaultVersion := m.defaultAPIGroupVersion()
-	requestInfoResolver := &apiserver.APIRequestInfoResolver{util.NewStringSet(strings.TrimPrefix(defaultVersion.Root, "/")), defaultVersion.Mapper}
-	apiserver.InstallServiceErrorHandler(m.handlerContainer, requestInfoResolver, apiVersions)
+	apiPrefixes := util.NewStringSet(strings.TrimPrefix(defaultVersion.Root, "/"))
 
 	if m.exp {
 		expVersion := m.expapi(c)
 		if err := expVersion.InstallREST(m.handlerContainer); err != nil {
 			glog.Fatalf("Unable to setup experimental api: %v", err)
 		}
-		apiserver.AddApiWebService(m.handlerContainer, c.ExpAPIPrefix, []string{expVersion.Version})
-		expRequestInfoResolver := &apiserver.APIRequestInfoResolver{util.NewStringSet(strings.TrimPrefix(expVersion.Root, "/")), expVersion.Mapper}
-		apiserver.InstallServiceErrorHandler(m.handlerContainer, expRequestInfoResolver, []string{expVersion.Version})
+		apiPrefixes.Insert(strings.TrimPrefix(expVersion.Root, "/"))
+
+		thirdPartyRegistry := expVersion.Storage["thirdpartyresources"].(*thirdpartyresourceetcd.REST)
+		thirdPartyController := ThirdPartyController{
+			master:                     m,
+			thirdPartyResourceRegistry: thirdPartyRegistry,
+		}
+		go util.Forever(func() {
+			if err := thirdPartyController.SyncResources(); err != nil {
+				glog.Warningf("third party resource sync failed: %v", err)
+			}
+		}, 10*time.Second)
 	}
 
+	requestInfoResolver := &apiserver.APIRequestInfoResolver{apiPrefixes, defaultVersion.Mapper}
+	apiserver.InstallServiceErrorHandler(m.handlerContainer, requestInfoResolver, apiVersions)
+
 	// Register root handler.
 	// We do not register this using restful Webservice since we do not want to surface this in api docs.
 	// Allow master to be embedded in contexts which already have something registered at the root
@@ -777,9 +816,20 @@ func (m *Master) api_v1() *apiserver.APIGroupVersion {
 
 // expapi returns the resources and codec for the experimental api
 func (m *Master) expapi(c *Config) *apiserver.APIGroupVersion {
-	storage := map[string]rest.Storage{}
+	storage := map[string]rest.Storage{
+		"thirdpartyresources": thirdpartyresourceetcd.NewREST(c.ExpDatabaseStorage),
+	}
+	// Reviews are only served when the apiserver authenticates or authorizes
+	// requests itself, since they would not reflect any policy otherwise.
+	if c.Authenticator != nil {
+		storage["tokenreviews"] = tokenreview.NewREST(c.Authenticator)
+	}
+	if c.Authorizer != nil {
+		storage["subjectaccessreviews"] = subjectaccessreview.NewREST(c.Authorizer)
+	}
 	return &apiserver.APIGroupVersion{
-		Root: m.expAPIPrefix,
+		Root:  m.apiGroupPrefix + "/" + explatest.Group,
+		Group: explatest.Group,
 
 		Creater:   api.Scheme,
 		Convertor: api.Scheme,
@@ -799,6 +849,198 @@ func (m *Master) expapi(c *Config) *apiserver.APIGroupVersion {
 	}
 }
 
+// thirdPartyAPI is a third party API group version served by the master.
+type thirdPartyAPI struct {
+	// kind is the third party kind served by the API
+	kind string
+	// group and version identify the API
+	group, version string
+	// storage holds the objects of the kind
+	storage *thirdpartyresourcedataetcd.REST
+	// enabled is false once the ThirdPartyResource defining the API has been
+	// removed; go-restful has no way to uninstall a web service, so requests
+	// to a disabled API are rejected by a filter instead.
+	enabled bool
+}
+
+// HasThirdPartyResource returns true if all versions of the given ThirdPartyResource are being served.
+func (m *Master) HasThirdPartyResource(rsrc *expapi.ThirdPartyResource) (bool, error) {
+	_, group, err := expapi.ExtractApiGroupAndKind(rsrc)
+	if err != nil {
+		return false, err
+	}
+	m.thirdPartyLock.RLock()
+	defer m.thirdPartyLock.RUnlock()
+	for _, version := range rsrc.Versions {
+		thirdParty, found := m.thirdPartyResources[meta.JoinGroupVersion(group, version.Name)]
+		if !found || !thirdParty.enabled {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ListThirdPartyResources returns the group versions of the third party APIs currently being served.
+func (m *Master) ListThirdPartyResources() []string {
+	m.thirdPartyLock.RLock()
+	defer m.thirdPartyLock.RUnlock()
+	result := []string{}
+	for groupVersion, thirdParty := range m.thirdPartyResources {
+		if thirdParty.enabled {
+			result = append(result, groupVersion)
+		}
+	}
+	return result
+}
+
+// InstallThirdPartyResource starts serving every version of the given ThirdPartyResource.
+func (m *Master) InstallThirdPartyResource(rsrc *expapi.ThirdPartyResource) error {
+	kind, group, err := expapi.ExtractApiGroupAndKind(rsrc)
+	if err != nil {
+		return err
+	}
+	m.thirdPartyLock.Lock()
+	defer m.thirdPartyLock.Unlock()
+	for _, version := range rsrc.Versions {
+		groupVersion := meta.JoinGroupVersion(group, version.Name)
+		if thirdParty, found := m.thirdPartyResources[groupVersion]; found {
+			if thirdParty.kind != kind {
+				return fmt.Errorf("%s already serves the kind %s", groupVersion, thirdParty.kind)
+			}
+			thirdParty.enabled = true
+			continue
+		}
+		thirdParty := &thirdPartyAPI{
+			kind:    kind,
+			group:   group,
+			version: version.Name,
+			storage: thirdpartyresourcedataetcd.NewREST(m.thirdPartyStorage, group, kind),
+			enabled: true,
+		}
+		apiGroupVersion := m.thirdpartyapi(group, kind, version.Name, thirdParty.storage)
+		if err := apiGroupVersion.InstallREST(m.handlerContainer); err != nil {
+			return fmt.Errorf("unable to setup thirdparty api %s: %v", groupVersion, err)
+		}
+		path := apiGroupVersion.Root + "/" + apiGroupVersion.Version
+		for _, ws := range m.handlerContainer.RegisteredWebServices() {
+			if ws.RootPath() == path {
+				ws.Filter(m.thirdPartyEnabledFilter(groupVersion))
+			}
+		}
+		m.thirdPartyResources[groupVersion] = thirdParty
+	}
+	return nil
+}
+
+// RemoveThirdPartyResource stops serving the third party API with the given group
+// version and deletes all of the objects stored for it.
+func (m *Master) RemoveThirdPartyResource(groupVersion string) error {
+	m.thirdPartyLock.Lock()
+	defer m.thirdPartyLock.Unlock()
+	thirdParty, found := m.thirdPartyResources[groupVersion]
+	if !found || !thirdParty.enabled {
+		return nil
+	}
+	ctx := api.NewContext()
+	existingData, err := thirdParty.storage.List(ctx, labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	for _, item := range existingData.(*expapi.ThirdPartyResourceDataList).Items {
+		if _, err := thirdParty.storage.Delete(api.WithNamespace(ctx, item.Namespace), item.Name, nil); err != nil {
+			return err
+		}
+	}
+	thirdParty.enabled = false
+	return nil
+}
+
+// thirdPartyEnabledFilter rejects requests to the third party API with the given
+// group version once the API has been removed.
+func (m *Master) thirdPartyEnabledFilter(groupVersion string) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		m.thirdPartyLock.RLock()
+		thirdParty, found := m.thirdPartyResources[groupVersion]
+		enabled := found && thirdParty.enabled
+		m.thirdPartyLock.RUnlock()
+		if !enabled {
+			resp.WriteErrorString(http.StatusNotFound, "the third party resource serving "+groupVersion+" has been removed")
+			return
+		}
+		chain.ProcessFilter(req, resp)
+	}
+}
+
+// thirdpartyapi returns the resources and codec for a version of a third party API group.
+func (m *Master) thirdpartyapi(group, kind, version string, storage *thirdpartyresourcedataetcd.REST) *apiserver.APIGroupVersion {
+	return &apiserver.APIGroupVersion{
+		Root:  m.apiGroupPrefix + "/" + group,
+		Group: group,
+
+		Creater:   api.Scheme,
+		Convertor: api.Scheme,
+		Typer:     api.Scheme,
+
+		Mapper:  thirdpartyresourcedata.NewMapper(explatest.RESTMapper, kind, version, group),
+		Codec:   thirdpartyresourcedata.NewCodec(explatest.Codec, kind, meta.JoinGroupVersion(group, version)),
+		Linker:  explatest.SelfLinker,
+		Storage: map[string]rest.Storage{thirdpartyresourcedata.ResourceName(kind): storage},
+		Version: version,
+
+		Admit:   m.admissionControl,
+		Context: m.requestContextMapper,
+
+		ProxyDialerFn:     m.dialer,
+		MinRequestTimeout: m.minRequestTimeout,
+	}
+}
+
+// apiGroups returns the API groups served under the API group prefix: the
+// experimental API if it is enabled, and the groups of third party resources.
+func (m *Master) apiGroups() []api.APIGroup {
+	groups := []api.APIGroup{}
+	if m.exp {
+		version := api.GroupVersion{
+			GroupVersion: meta.JoinGroupVersion(explatest.Group, explatest.Version),
+			Version:      explatest.Version,
+		}
+		groups = append(groups, api.APIGroup{
+			Name:             explatest.Group,
+			Versions:         []api.GroupVersion{version},
+			PreferredVersion: version,
+		})
+	}
+
+	m.thirdPartyLock.RLock()
+	defer m.thirdPartyLock.RUnlock()
+	thirdPartyVersions := map[string][]string{}
+	for _, thirdParty := range m.thirdPartyResources {
+		if thirdParty.enabled {
+			thirdPartyVersions[thirdParty.group] = append(thirdPartyVersions[thirdParty.group], thirdParty.version)
+		}
+	}
+	names := []string{}
+	for name := range thirdPartyVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		versions := thirdPartyVersions[name]
+		sort.Strings(versions)
+		group := api.APIGroup{Name: name}
+		for _, version := range versions {
+			group.Versions = append(group.Versions, api.GroupVersion{
+				GroupVersion: meta.JoinGroupVersion(name, version),
+				Version:      version,
+			})
+		}
+		// third party resources do not declare a preferred version
+		group.PreferredVersion = group.Versions[0]
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // findExternalAddress returns ExternalIP of provided node with fallback to LegacyHostIP.
 func findExternalAddress(node *api.Node) (string, error) {
 	var fallback string
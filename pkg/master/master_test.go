@@ -17,15 +17,28 @@ limitations under the License.
 package master
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/expapi"
 	explatest "k8s.io/kubernetes/pkg/expapi/latest"
 	"k8s.io/kubernetes/pkg/registry/registrytest"
 	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
 	"k8s.io/kubernetes/pkg/tools"
 	"k8s.io/kubernetes/pkg/tools/etcdtest"
+	"k8s.io/kubernetes/plugin/pkg/admission/admit"
+
+	"github.com/emicklei/go-restful"
 )
 
 func TestGetServersToValidate(t *testing.T) {
@@ -50,6 +63,26 @@ func TestGetServersToValidate(t *testing.T) {
 	}
 }
 
+func TestExpapiReviewsRequireAuth(t *testing.T) {
+	master := Master{}
+	config := Config{}
+	storage := master.expapi(&config).Storage
+	for _, resource := range []string{"tokenreviews", "subjectaccessreviews"} {
+		if _, ok := storage[resource]; ok {
+			t.Errorf("%s should not be served without authentication and authorization", resource)
+		}
+	}
+
+	config.Authenticator = authenticator.RequestFunc(func(*http.Request) (user.Info, bool, error) { return nil, false, nil })
+	config.Authorizer = authorizer.AuthorizerFunc(func(authorizer.Attributes) error { return nil })
+	storage = master.expapi(&config).Storage
+	for _, resource := range []string{"tokenreviews", "subjectaccessreviews"} {
+		if _, ok := storage[resource]; !ok {
+			t.Errorf("%s should be served", resource)
+		}
+	}
+}
+
 func TestFindExternalAddress(t *testing.T) {
 	expectedIP := "172.0.0.1"
 
@@ -73,3 +106,117 @@ func TestFindExternalAddress(t *testing.T) {
 		t.Errorf("expected findExternalAddress to fail on a node with missing ip information")
 	}
 }
+
+type Foo struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	SomeField  string `json:"someField"`
+	OtherField int    `json:"otherField"`
+}
+
+func initThirdParty(t *testing.T) (*Master, *tools.FakeEtcdClient, *httptest.Server) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	fakeClient.ChangeIndex = 1
+	master := &Master{
+		handlerContainer:     restful.NewContainer(),
+		apiGroupPrefix:       "/apis",
+		thirdPartyStorage:    etcdstorage.NewEtcdStorage(fakeClient, explatest.Codec, etcdtest.PathPrefix()),
+		thirdPartyResources:  map[string]*thirdPartyAPI{},
+		admissionControl:     admit.NewAlwaysAdmit(),
+		requestContextMapper: api.NewRequestContextMapper(),
+	}
+	rsrc := &expapi.ThirdPartyResource{
+		ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+		Versions:   []expapi.APIVersion{{Name: "v1"}},
+	}
+	if err := master.InstallThirdPartyResource(rsrc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return master, fakeClient, httptest.NewServer(master.handlerContainer)
+}
+
+func TestInstallThirdPartyResource(t *testing.T) {
+	master, _, server := initThirdParty(t)
+	defer server.Close()
+
+	if paths := master.ListThirdPartyResources(); len(paths) != 1 || paths[0] != "company.com/v1" {
+		t.Errorf("unexpected third party APIs: %v", paths)
+	}
+	expectedVersion := api.GroupVersion{GroupVersion: "company.com/v1", Version: "v1"}
+	expectedGroups := []api.APIGroup{{Name: "company.com", Versions: []api.GroupVersion{expectedVersion}, PreferredVersion: expectedVersion}}
+	if groups := master.apiGroups(); !reflect.DeepEqual(expectedGroups, groups) {
+		t.Errorf("expected %#v, got %#v", expectedGroups, groups)
+	}
+
+	resp, err := http.Get(server.URL + "/apis/company.com/v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resources := api.APIResourceList{}
+	err = json.NewDecoder(resp.Body).Decode(&resources)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resources.GroupVersion != "company.com/v1" || len(resources.APIResources) != 1 || resources.APIResources[0].Name != "foos" || resources.APIResources[0].Kind != "Foo" {
+		t.Errorf("unexpected resources: %#v", resources)
+	}
+
+	data, err := json.Marshal(&Foo{
+		TypeMeta:   api.TypeMeta{Kind: "Foo", APIVersion: "company.com/v1"},
+		ObjectMeta: api.ObjectMeta{Name: "test"},
+		SomeField:  "test field",
+		OtherField: 10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err = http.Post(server.URL+"/apis/company.com/v1/namespaces/default/foos", "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(resp.Body)
+		t.Fatalf("unexpected status: %d: %s", resp.StatusCode, string(body))
+	}
+
+	resp, err = http.Get(server.URL + "/apis/company.com/v1/namespaces/default/foos/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	item := Foo{}
+	if err := json.NewDecoder(resp.Body).Decode(&item); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Kind != "Foo" || item.APIVersion != "company.com/v1" || item.Name != "test" || item.SomeField != "test field" || item.OtherField != 10 {
+		t.Errorf("unexpected object: %#v", item)
+	}
+}
+
+func TestRemoveThirdPartyResource(t *testing.T) {
+	master, fakeClient, server := initThirdParty(t)
+	defer server.Close()
+
+	fakeClient.ExpectNotFoundGet(etcdtest.AddPrefix("/ThirdPartyResourceData/company.com/foos"))
+	if err := master.RemoveThirdPartyResource("company.com/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if paths := master.ListThirdPartyResources(); len(paths) != 0 {
+		t.Errorf("unexpected third party APIs: %v", paths)
+	}
+	resp, err := http.Get(server.URL + "/apis/company.com/v1/namespaces/default/foos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected not found, got %d", resp.StatusCode)
+	}
+}
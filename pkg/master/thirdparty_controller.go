@@ -0,0 +1,101 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package master
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	thirdpartyresourceetcd "k8s.io/kubernetes/pkg/registry/thirdpartyresource/etcd"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/errors"
+)
+
+// thirdPartyResourceManager is the part of the master the ThirdPartyController
+// uses to install and remove third party APIs.
+type thirdPartyResourceManager interface {
+	// ListThirdPartyResources returns the group versions of the installed third party APIs.
+	ListThirdPartyResources() []string
+	// HasThirdPartyResource returns true if the given resource is fully installed.
+	HasThirdPartyResource(rsrc *expapi.ThirdPartyResource) (bool, error)
+	// InstallThirdPartyResource starts serving the given resource.
+	InstallThirdPartyResource(rsrc *expapi.ThirdPartyResource) error
+	// RemoveThirdPartyResource stops serving the third party API with the given group version.
+	RemoveThirdPartyResource(groupVersion string) error
+}
+
+// ThirdPartyController keeps the third party APIs served by the master in sync
+// with the ThirdPartyResource objects stored in etcd.
+type ThirdPartyController struct {
+	master                     thirdPartyResourceManager
+	thirdPartyResourceRegistry *thirdpartyresourceetcd.REST
+}
+
+// SyncOneResource installs the given resource if it is not already being served.
+func (t *ThirdPartyController) SyncOneResource(rsrc *expapi.ThirdPartyResource) error {
+	hasResource, err := t.master.HasThirdPartyResource(rsrc)
+	if err != nil {
+		return err
+	}
+	if !hasResource {
+		return t.master.InstallThirdPartyResource(rsrc)
+	}
+	return nil
+}
+
+// SyncResources installs any third party resource that is not yet served, and
+// removes the APIs of resources that no longer exist.
+func (t *ThirdPartyController) SyncResources() error {
+	list, err := t.thirdPartyResourceRegistry.List(api.NewContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	return t.syncResourceList(list.(*expapi.ThirdPartyResourceList))
+}
+
+func (t *ThirdPartyController) syncResourceList(list *expapi.ThirdPartyResourceList) error {
+	existing := util.NewStringSet()
+	errs := []error{}
+	for ix := range list.Items {
+		rsrc := &list.Items[ix]
+		_, group, err := expapi.ExtractApiGroupAndKind(rsrc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, version := range rsrc.Versions {
+			existing.Insert(meta.JoinGroupVersion(group, version.Name))
+		}
+		if err := t.SyncOneResource(rsrc); err != nil {
+			errs = append(errs, fmt.Errorf("unable to install %s: %v", rsrc.Name, err))
+		}
+	}
+
+	for _, groupVersion := range t.master.ListThirdPartyResources() {
+		if existing.Has(groupVersion) {
+			continue
+		}
+		if err := t.master.RemoveThirdPartyResource(groupVersion); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove %s: %v", groupVersion, err))
+		}
+	}
+	return errors.NewAggregate(errs)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package master
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+type FakeAPIInterface struct {
+	removed   []string
+	installed []expapi.ThirdPartyResource
+	apis      []string
+}
+
+func (f *FakeAPIInterface) RemoveThirdPartyResource(groupVersion string) error {
+	f.removed = append(f.removed, groupVersion)
+	return nil
+}
+
+func (f *FakeAPIInterface) InstallThirdPartyResource(rsrc *expapi.ThirdPartyResource) error {
+	f.installed = append(f.installed, *rsrc)
+	_, group, _ := expapi.ExtractApiGroupAndKind(rsrc)
+	for _, version := range rsrc.Versions {
+		f.apis = append(f.apis, meta.JoinGroupVersion(group, version.Name))
+	}
+	return nil
+}
+
+func (f *FakeAPIInterface) HasThirdPartyResource(rsrc *expapi.ThirdPartyResource) (bool, error) {
+	if f.apis == nil {
+		return false, nil
+	}
+	_, group, _ := expapi.ExtractApiGroupAndKind(rsrc)
+	apis := util.NewStringSet(f.apis...)
+	for _, version := range rsrc.Versions {
+		if !apis.Has(meta.JoinGroupVersion(group, version.Name)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *FakeAPIInterface) ListThirdPartyResources() []string {
+	return f.apis
+}
+
+func TestSyncAPIs(t *testing.T) {
+	tests := []struct {
+		list              *expapi.ThirdPartyResourceList
+		apis              []string
+		expectedInstalled []string
+		expectedRemoved   []string
+		name              string
+	}{
+		{
+			list: &expapi.ThirdPartyResourceList{
+				Items: []expapi.ThirdPartyResource{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "foo.example.com"},
+						Versions:   []expapi.APIVersion{{Name: "v1"}},
+					},
+				},
+			},
+			expectedInstalled: []string{"foo.example.com"},
+			name:              "simple add",
+		},
+		{
+			list: &expapi.ThirdPartyResourceList{
+				Items: []expapi.ThirdPartyResource{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "foo.example.com"},
+						Versions:   []expapi.APIVersion{{Name: "v1"}},
+					},
+				},
+			},
+			apis: []string{"example.com/v1"},
+			name: "does nothing",
+		},
+		{
+			list: &expapi.ThirdPartyResourceList{
+				Items: []expapi.ThirdPartyResource{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "foo.example.com"},
+						Versions:   []expapi.APIVersion{{Name: "v1"}},
+					},
+				},
+			},
+			apis: []string{
+				"example.com/v1",
+				"company.com/v1",
+			},
+			expectedRemoved: []string{"company.com/v1"},
+			name:            "removes excess",
+		},
+		{
+			list: &expapi.ThirdPartyResourceList{
+				Items: []expapi.ThirdPartyResource{
+					{
+						ObjectMeta: api.ObjectMeta{Name: "foo.example.com"},
+						Versions:   []expapi.APIVersion{{Name: "v1"}},
+					},
+					{
+						ObjectMeta: api.ObjectMeta{Name: "foo.company.com"},
+						Versions:   []expapi.APIVersion{{Name: "v1"}},
+					},
+				},
+			},
+			apis: []string{
+				"company.com/v1",
+				"company.com/v2",
+			},
+			expectedInstalled: []string{"foo.example.com"},
+			expectedRemoved:   []string{"company.com/v2"},
+			name:              "adds and removes",
+		},
+	}
+
+	for _, test := range tests {
+		fake := FakeAPIInterface{apis: test.apis}
+
+		controller := ThirdPartyController{master: &fake}
+
+		if err := controller.syncResourceList(test.list); err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+		}
+		if len(test.expectedInstalled) != len(fake.installed) {
+			t.Errorf("[%s] unexpected installed APIs: %d, expected %d", test.name, len(fake.installed), len(test.expectedInstalled))
+		} else {
+			names := util.StringSet{}
+			for ix := range fake.installed {
+				names.Insert(fake.installed[ix].Name)
+			}
+			for _, name := range test.expectedInstalled {
+				if !names.Has(name) {
+					t.Errorf("[%s] missing installed API: %s", test.name, name)
+				}
+			}
+		}
+		sort.Strings(fake.removed)
+		sort.Strings(test.expectedRemoved)
+		if len(test.expectedRemoved) != len(fake.removed) || (len(fake.removed) > 0 && !reflect.DeepEqual(test.expectedRemoved, fake.removed)) {
+			t.Errorf("[%s] expected removed: %v, got %v", test.name, test.expectedRemoved, fake.removed)
+		}
+	}
+}
@@ -19,7 +19,6 @@ package controller
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
@@ -101,14 +100,6 @@ func (rcStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// ControllerToSelectableFields returns a label set that represents the object.
-func ControllerToSelectableFields(controller *api.ReplicationController) fields.Set {
-	return fields.Set{
-		"metadata.name":   controller.Name,
-		"status.replicas": strconv.Itoa(controller.Status.Replicas),
-	}
-}
-
 // MatchController is the filter used by the generic etcd backend to route
 // watch events from etcd to clients of the apiserver only interested in specific
 // labels/fields.
@@ -121,7 +112,7 @@ func MatchController(label labels.Selector, field fields.Selector) generic.Match
 			if !ok {
 				return nil, nil, fmt.Errorf("Given object is not a replication controller.")
 			}
-			return labels.Set(rc.ObjectMeta.Labels), ControllerToSelectableFields(rc), nil
+			return labels.Set(rc.ObjectMeta.Labels), api.ReplicationControllerSelectableFields.Set(rc), nil
 		},
 	}
 }
@@ -89,7 +89,5 @@ func EndpointsAttributes(obj runtime.Object) (objLabels labels.Set, objFields fi
 	if !ok {
 		return nil, nil, fmt.Errorf("invalid object type %#v", obj)
 	}
-	return endpoints.Labels, fields.Set{
-		"metadata.name": endpoints.Name,
-	}, nil
+	return endpoints.Labels, api.NameSelectableFields.Set(endpoints), nil
 }
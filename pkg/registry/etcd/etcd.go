@@ -25,6 +25,7 @@ import (
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/endpoint"
+	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/storage"
 	"k8s.io/kubernetes/pkg/watch"
 )
@@ -170,8 +171,10 @@ func (r *Registry) WatchServices(ctx api.Context, label labels.Selector, field f
 		// TODO: use generic.SelectionPredicate
 		return r.Watch(key, version, storage.Everything)
 	}
-	if field.Empty() {
-		return r.WatchList(makeServiceListKey(ctx), version, storage.Everything)
+	if _, err := field.Transform(api.NameSelectableFields.FieldLabelConversionFunc("Service")); err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("only the 'name' and default (everything) field selectors are supported")
+	return r.WatchList(makeServiceListKey(ctx), version, func(obj runtime.Object) bool {
+		return field.Matches(api.NameSelectableFields.Set(obj))
+	})
 }
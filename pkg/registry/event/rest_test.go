@@ -184,6 +184,7 @@ func TestRESTgetAttrs(t *testing.T) {
 	}
 	expect := fields.Set{
 		"metadata.name":                  "f0118",
+		"metadata.namespace":             "",
 		"involvedObject.kind":            "Pod",
 		"involvedObject.name":            "foo",
 		"involvedObject.namespace":       "baz",
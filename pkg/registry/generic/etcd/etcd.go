@@ -281,6 +281,9 @@ func (e *Etcd) Update(ctx api.Context, obj runtime.Object) (runtime.Object, bool
 	doUnconditionalUpdate := resourceVersion == 0 && e.UpdateStrategy.AllowUnconditionalUpdate()
 	// TODO: expose TTL
 	creating := false
+	// finalizing is true if the update is made to an object that is waiting for its
+	// finalizers to be removed, and may complete its deletion.
+	finalizing := false
 	out := e.NewFunc()
 	err = e.Storage.GuaranteedUpdate(key, out, true, func(existing runtime.Object, res storage.ResponseMeta) (runtime.Object, *uint64, error) {
 		version, err := e.Storage.Versioner().ObjectResourceVersion(existing)
@@ -303,6 +306,11 @@ func (e *Etcd) Update(ctx api.Context, obj runtime.Object) (runtime.Object, bool
 		}
 
 		creating = false
+		existingMeta, err := api.ObjectMetaFor(existing)
+		if err != nil {
+			return nil, nil, kubeerr.NewInternalError(err)
+		}
+		finalizing = existingMeta.DeletionTimestamp != nil && len(existingMeta.Finalizers) != 0
 		if doUnconditionalUpdate {
 			// Update the object's resource version to match the latest etcd object's resource version.
 			err = e.Storage.Versioner().UpdateObject(obj, res.Expiration, res.ResourceVersion)
@@ -348,7 +356,14 @@ func (e *Etcd) Update(ctx api.Context, obj runtime.Object) (runtime.Object, bool
 			}
 		}
 	} else {
-		if e.AfterUpdate != nil {
+		deleted := false
+		if finalizing {
+			deleted, err = e.deleteForEmptyFinalizers(key, name, out)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		if !deleted && e.AfterUpdate != nil {
 			if err := e.AfterUpdate(out); err != nil {
 				return nil, false, err
 			}
@@ -384,7 +399,20 @@ func (e *Etcd) Get(ctx api.Context, name string) (runtime.Object, error) {
 	return obj, nil
 }
 
-// Delete removes the item from etcd.
+var (
+	errAlreadyDeleting = fmt.Errorf("abort delete")
+	errDeleteNow       = fmt.Errorf("delete now")
+)
+
+// gracefulDeletionTTLMargin is the number of seconds past the end of its grace period that a
+// gracefully deleted object without finalizers is kept in storage before it expires.
+const gracefulDeletionTTLMargin = 5
+
+// Delete removes the item from etcd. If the object has pending finalizers, or the delete
+// strategy requests a graceful deletion, the object is only marked for deletion by setting its
+// DeletionTimestamp and it is removed once the finalizers are gone and the grace period has
+// been reduced to zero. Objects without finalizers also expire from storage shortly after
+// their grace period ends.
 func (e *Etcd) Delete(ctx api.Context, name string, options *api.DeleteOptions) (runtime.Object, error) {
 	key, err := e.KeyFunc(ctx, name)
 	if err != nil {
@@ -410,13 +438,49 @@ func (e *Etcd) Delete(ctx api.Context, name string, options *api.DeleteOptions)
 	if pendingGraceful {
 		return e.finalizeDelete(obj, false)
 	}
-	if graceful && *options.GracePeriodSeconds != 0 {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return nil, kubeerr.NewInternalError(err)
+	}
+	if len(objectMeta.Finalizers) != 0 || (graceful && *options.GracePeriodSeconds != 0) {
 		trace.Step("Graceful deletion")
 		out := e.NewFunc()
-		if err := e.Storage.Set(key, obj, out, uint64(*options.GracePeriodSeconds)); err != nil {
+		err := e.Storage.GuaranteedUpdate(key, out, false, func(existing runtime.Object, res storage.ResponseMeta) (runtime.Object, *uint64, error) {
+			graceful, pendingGraceful, err := rest.BeforeDelete(e.DeleteStrategy, ctx, existing, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			existingMeta, err := api.ObjectMetaFor(existing)
+			if err != nil {
+				return nil, nil, kubeerr.NewInternalError(err)
+			}
+			if pendingGraceful {
+				return nil, nil, errAlreadyDeleting
+			}
+			if len(existingMeta.Finalizers) != 0 {
+				if !graceful && !rest.MarkForDeletion(existingMeta) {
+					return nil, nil, errAlreadyDeleting
+				}
+				return existing, nil, nil
+			}
+			if !graceful || *options.GracePeriodSeconds == 0 {
+				return nil, nil, errDeleteNow
+			}
+			// expire the object in storage shortly after its grace period ends, in case the
+			// component that should finish the deletion (the kubelet for pods) never does
+			ttl := uint64(*options.GracePeriodSeconds) + gracefulDeletionTTLMargin
+			return existing, &ttl, nil
+		})
+		switch err {
+		case nil:
+			return e.finalizeDelete(out, false)
+		case errDeleteNow:
+			// the object has no finalizers left and no grace period applies, delete it now
+		case errAlreadyDeleting:
+			return e.finalizeDelete(obj, false)
+		default:
 			return nil, etcderr.InterpretUpdateError(err, e.EndpointName, name)
 		}
-		return e.finalizeDelete(out, true)
 	}
 
 	// delete immediately, or no graceful deletion supported
@@ -428,6 +492,31 @@ func (e *Etcd) Delete(ctx api.Context, name string, options *api.DeleteOptions)
 	return e.finalizeDelete(out, true)
 }
 
+// deleteForEmptyFinalizers removes an object that has been marked for deletion once its last
+// finalizer has been removed and no grace period remains. It must only be called for updates
+// of objects that had finalizers, since objects may be marked for deletion without them (as
+// namespaces are while their content is removed). It returns true if the object was removed
+// from etcd.
+func (e *Etcd) deleteForEmptyFinalizers(key, name string, obj runtime.Object) (bool, error) {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return false, kubeerr.NewInternalError(err)
+	}
+	if !rest.ReadyForDeletion(objectMeta) {
+		return false, nil
+	}
+	out := e.NewFunc()
+	if err := e.Storage.Delete(key, out); err != nil {
+		return false, etcderr.InterpretDeleteError(err, e.EndpointName, name)
+	}
+	if e.AfterDelete != nil {
+		if err := e.AfterDelete(out); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func (e *Etcd) finalizeDelete(obj runtime.Object, runHooks bool) (runtime.Object, error) {
 	if runHooks && e.AfterDelete != nil {
 		if err := e.AfterDelete(obj); err != nil {
@@ -483,6 +572,15 @@ func (e *Etcd) WatchPredicate(ctx api.Context, m generic.Matcher, resourceVersio
 		return e.Storage.Watch(key, version, filterFunc)
 	}
 
+	// serve watches of a single value of an index kept by the storage from that index
+	if indexed, ok := e.Storage.(storage.IndexedWatcher); ok {
+		for _, index := range indexed.Indexes() {
+			if value, ok := m.MatchesIndex(index); ok {
+				return indexed.WatchListIndexed(e.KeyRootFunc(ctx), version, index, value, filterFunc)
+			}
+		}
+	}
+
 	return e.Storage.WatchList(e.KeyRootFunc(ctx), version, filterFunc)
 }
 
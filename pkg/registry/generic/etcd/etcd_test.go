@@ -112,6 +112,10 @@ func (sm setMatcher) MatchesSingle() (string, bool) {
 	return "", false
 }
 
+func (sm setMatcher) MatchesIndex(field string) (string, bool) {
+	return "", false
+}
+
 // everythingMatcher matches everything
 type everythingMatcher struct{}
 
@@ -123,6 +127,10 @@ func (everythingMatcher) MatchesSingle() (string, bool) {
 	return "", false
 }
 
+func (everythingMatcher) MatchesIndex(field string) (string, bool) {
+	return "", false
+}
+
 func TestEtcdList(t *testing.T) {
 	podA := &api.Pod{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
@@ -711,6 +719,87 @@ func TestEtcdDelete(t *testing.T) {
 	}
 }
 
+func TestEtcdDeleteWithFinalizers(t *testing.T) {
+	fakeClient, registry := NewTestGenericEtcdRegistry(t)
+	ctx := api.NewDefaultContext()
+	path := etcdtest.AddPrefix("pods/foo")
+	podA := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault, ResourceVersion: "1", Finalizers: []string{"example.com/cleanup"}},
+		Spec:       api.PodSpec{NodeName: "machine"},
+	}
+	fakeClient.Data[path] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(testapi.Codec(), podA),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+
+	if _, err := registry.Delete(ctx, "foo", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := registry.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("expected object to remain while finalizers are pending: %v", err)
+	}
+	pod := obj.(*api.Pod)
+	if pod.DeletionTimestamp == nil {
+		t.Fatalf("expected deletion timestamp to be set: %#v", pod.ObjectMeta)
+	}
+	if pod.DeletionGracePeriodSeconds == nil || *pod.DeletionGracePeriodSeconds != 0 {
+		t.Errorf("expected zero deletion grace period: %#v", pod.ObjectMeta)
+	}
+
+	// a second delete leaves the object in place
+	if _, err := registry.Delete(ctx, "foo", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Get(ctx, "foo"); err != nil {
+		t.Fatalf("expected object to remain while finalizers are pending: %v", err)
+	}
+
+	// removing the last finalizer removes the object
+	pod.Finalizers = nil
+	if _, _, err := registry.Update(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Get(ctx, "foo"); !errors.IsNotFound(err) {
+		t.Errorf("expected object to be deleted once finalizers were removed, got %v", err)
+	}
+}
+
+// An object that is marked for deletion without finalizers is only removed by a delete, not by
+// an update.
+func TestEtcdUpdateMarkedForDeletionWithoutFinalizers(t *testing.T) {
+	fakeClient, registry := NewTestGenericEtcdRegistry(t)
+	ctx := api.NewDefaultContext()
+	path := etcdtest.AddPrefix("pods/foo")
+	now := util.Now()
+	podA := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault, ResourceVersion: "1", DeletionTimestamp: &now},
+		Spec:       api.PodSpec{NodeName: "machine"},
+	}
+	fakeClient.Data[path] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(testapi.Codec(), podA),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+
+	podA.Labels = map[string]string{"foo": "bar"}
+	if _, _, err := registry.Update(ctx, podA); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Get(ctx, "foo"); err != nil {
+		t.Errorf("expected object to remain after update: %v", err)
+	}
+}
+
 func TestEtcdWatch(t *testing.T) {
 	table := map[string]generic.Matcher{
 		"single": setMatcher{util.NewStringSet("foo")},
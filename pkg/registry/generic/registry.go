@@ -59,6 +59,12 @@ func (s *SelectionPredicate) MatchesSingle() (string, bool) {
 	return "", false
 }
 
+// MatchesIndex returns the value s.Field requires for the given field, if it
+// requires one.
+func (s *SelectionPredicate) MatchesIndex(field string) (string, bool) {
+	return s.Field.RequiresExactMatch(field)
+}
+
 // Matcher can return true if an object matches the Matcher's selection
 // criteria. If it is known that the matcher will match only a single object
 // then MatchesSingle should return the key of that object and true. This is an
@@ -73,9 +79,11 @@ type Matcher interface {
 	// include the object's namespace.
 	MatchesSingle() (key string, matchesSingleObject bool)
 
-	// TODO: when we start indexing objects, add something like the below:
-	//         MatchesIndices() (indexName []string, indexValue []string)
-	//       where indexName/indexValue are the same length.
+	// If this matcher only matches objects with a specific value for the given
+	// field, return that value and true here. Storage that indexes objects by
+	// the field uses it to only consider the objects with that value. This is
+	// an optimization only--Matches() should continue to work.
+	MatchesIndex(field string) (value string, matchesIndexValue bool)
 }
 
 // MatcherFunc makes a matcher from the provided function. For easy definition
@@ -97,6 +105,12 @@ func (m matcherFunc) MatchesSingle() (string, bool) {
 	return "", false
 }
 
+// MatchesIndex always returns "", false-- because this is a predicate
+// implementation of Matcher.
+func (m matcherFunc) MatchesIndex(field string) (string, bool) {
+	return "", false
+}
+
 // MatchOnKey returns a matcher that will send only the object matching key
 // through the matching function f. For testing!
 // Note: use SelectionPredicate above for real code!
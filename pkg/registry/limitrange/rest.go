@@ -132,7 +132,11 @@ func (rs *REST) Get(ctx api.Context, name string) (runtime.Object, error) {
 }
 
 func (rs *REST) getAttrs(obj runtime.Object) (objLabels labels.Set, objFields fields.Set, err error) {
-	return labels.Set{}, fields.Set{}, nil
+	limitRange, ok := obj.(*api.LimitRange)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid object type")
+	}
+	return labels.Set(limitRange.Labels), api.NameSelectableFields.Set(limitRange), nil
 }
 
 func (rs *REST) List(ctx api.Context, label labels.Selector, field fields.Selector) (runtime.Object, error) {
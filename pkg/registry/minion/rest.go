@@ -112,14 +112,6 @@ type ResourceGetter interface {
 	Get(api.Context, string) (runtime.Object, error)
 }
 
-// NodeToSelectableFields returns a label set that represents the object.
-func NodeToSelectableFields(node *api.Node) fields.Set {
-	return fields.Set{
-		"metadata.name":      node.Name,
-		"spec.unschedulable": fmt.Sprint(node.Spec.Unschedulable),
-	}
-}
-
 // MatchNode returns a generic matcher for a given label and field selector.
 func MatchNode(label labels.Selector, field fields.Selector) generic.Matcher {
 	return &generic.SelectionPredicate{
@@ -130,7 +122,7 @@ func MatchNode(label labels.Selector, field fields.Selector) generic.Matcher {
 			if !ok {
 				return nil, nil, fmt.Errorf("not a node")
 			}
-			return labels.Set(nodeObj.ObjectMeta.Labels), NodeToSelectableFields(nodeObj), nil
+			return labels.Set(nodeObj.ObjectMeta.Labels), api.NodeSelectableFields.Set(nodeObj), nil
 		},
 	}
 }
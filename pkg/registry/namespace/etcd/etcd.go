@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	etcderr "k8s.io/kubernetes/pkg/api/errors/etcd"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -35,7 +36,6 @@ import (
 // rest implements a RESTStorage for namespaces against etcd
 type REST struct {
 	*etcdgeneric.Etcd
-	status *etcdgeneric.Etcd
 }
 
 // StatusREST implements the REST endpoint for changing the status of a namespace.
@@ -79,7 +79,7 @@ func NewStorage(s storage.Interface) (*REST, *StatusREST, *FinalizeREST) {
 	finalizeStore := *store
 	finalizeStore.UpdateStrategy = namespace.FinalizeStrategy
 
-	return &REST{Etcd: store, status: &statusStore}, &StatusREST{store: &statusStore}, &FinalizeREST{store: &finalizeStore}
+	return &REST{Etcd: store}, &StatusREST{store: &statusStore}, &FinalizeREST{store: &finalizeStore}
 }
 
 // Delete enforces life-cycle rules for namespace termination
@@ -91,13 +91,28 @@ func (r *REST) Delete(ctx api.Context, name string, options *api.DeleteOptions)
 
 	namespace := nsObj.(*api.Namespace)
 
-	// upon first request to delete, we switch the phase to start namespace termination
+	// upon first request to delete, we switch the phase to start namespace termination.
+	// Clients cannot set the deletion timestamp through an update, so it is written to
+	// storage directly.
 	if namespace.DeletionTimestamp.IsZero() {
-		now := util.Now()
-		namespace.DeletionTimestamp = &now
-		namespace.Status.Phase = api.NamespaceTerminating
-		result, _, err := r.status.Update(ctx, namespace)
-		return result, err
+		key, err := r.Etcd.KeyFunc(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		out := r.Etcd.NewFunc()
+		err = r.Etcd.Storage.GuaranteedUpdate(key, out, false, storage.SimpleUpdate(func(existing runtime.Object) (runtime.Object, error) {
+			existingNamespace := existing.(*api.Namespace)
+			if existingNamespace.DeletionTimestamp.IsZero() {
+				now := util.Now()
+				existingNamespace.DeletionTimestamp = &now
+			}
+			existingNamespace.Status.Phase = api.NamespaceTerminating
+			return existingNamespace, nil
+		}))
+		if err != nil {
+			return nil, etcderr.InterpretUpdateError(err, r.Etcd.EndpointName, name)
+		}
+		return out, nil
 	}
 
 	// prior to final deletion, we must ensure that finalizers is empty
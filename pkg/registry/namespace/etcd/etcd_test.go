@@ -224,7 +224,7 @@ func TestListNamespaceListSelection(t *testing.T) {
 		{
 			expectedIDs: util.NewStringSet("foo", "bar", "baz", "qux", "zot"),
 		}, {
-			field:       "name=zot",
+			field:       "metadata.name=zot",
 			expectedIDs: util.NewStringSet("zot"),
 		}, {
 			label:       "label=qux",
@@ -340,6 +340,60 @@ func TestDeleteNamespace(t *testing.T) {
 	}
 }
 
+// The first delete of a namespace only starts its termination: the namespace remains until its
+// content has been removed and its finalizers are cleared.
+func TestDeleteNamespaceStartsTermination(t *testing.T) {
+	fakeEtcdClient, etcdStorage := newEtcdStorage(t)
+	fakeEtcdClient.ChangeIndex = 1
+	storage, statusStorage, _ := NewStorage(etcdStorage)
+	key := etcdtest.AddPrefix("/namespaces/foo")
+	fakeEtcdClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value: runtime.EncodeOrDie(latest.Codec, &api.Namespace{
+					ObjectMeta: api.ObjectMeta{
+						Name: "foo",
+					},
+					Spec: api.NamespaceSpec{
+						Finalizers: []api.FinalizerName{api.FinalizerKubernetes},
+					},
+					Status: api.NamespaceStatus{Phase: api.NamespaceActive},
+				}),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	ctx := api.NewContext()
+	if _, err := storage.Delete(ctx, "foo", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := storage.Get(ctx, "foo")
+	if err != nil {
+		t.Fatalf("expected namespace to remain while terminating: %v", err)
+	}
+	namespace := obj.(*api.Namespace)
+	if namespace.DeletionTimestamp.IsZero() || namespace.Status.Phase != api.NamespaceTerminating {
+		t.Fatalf("expected namespace to be terminating: %#v", namespace)
+	}
+
+	// status updates of a terminating namespace do not remove it
+	if _, _, err := statusStorage.Update(ctx, namespace); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := storage.Get(ctx, "foo"); err != nil {
+		t.Fatalf("expected namespace to remain while terminating: %v", err)
+	}
+
+	// a second delete is refused until the finalizers are cleared
+	if _, err := storage.Delete(ctx, "foo", nil); err == nil {
+		t.Fatalf("expected an error deleting a namespace with finalizers")
+	}
+	if _, err := storage.Get(ctx, "foo"); err != nil {
+		t.Fatalf("expected namespace to remain while terminating: %v", err)
+	}
+}
+
 func TestDeleteNamespaceWithIncompleteFinalizers(t *testing.T) {
 	now := util.Now()
 	fakeEtcdClient, etcdStorage := newEtcdStorage(t)
@@ -137,16 +137,7 @@ func MatchNamespace(label labels.Selector, field fields.Selector) generic.Matche
 		if !ok {
 			return false, fmt.Errorf("not a namespace")
 		}
-		fields := NamespaceToSelectableFields(namespaceObj)
+		fields := api.NamespaceSelectableFields.Set(namespaceObj)
 		return label.Matches(labels.Set(namespaceObj.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// NamespaceToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func NamespaceToSelectableFields(namespace *api.Namespace) labels.Set {
-	return labels.Set{
-		"name":         namespace.Name,
-		"status.phase": string(namespace.Status.Phase),
-	}
-}
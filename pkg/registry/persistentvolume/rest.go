@@ -97,15 +97,7 @@ func MatchPersistentVolumes(label labels.Selector, field fields.Selector) generi
 		if !ok {
 			return false, fmt.Errorf("not a persistentvolume")
 		}
-		fields := PersistentVolumeToSelectableFields(persistentvolumeObj)
+		fields := api.PersistentVolumeSelectableFields.Set(persistentvolumeObj)
 		return label.Matches(labels.Set(persistentvolumeObj.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// PersistentVolumeToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func PersistentVolumeToSelectableFields(persistentvolume *api.PersistentVolume) labels.Set {
-	return labels.Set{
-		"name": persistentvolume.Name,
-	}
-}
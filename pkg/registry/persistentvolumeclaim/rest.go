@@ -97,15 +97,7 @@ func MatchPersistentVolumeClaim(label labels.Selector, field fields.Selector) ge
 		if !ok {
 			return false, fmt.Errorf("not a persistentvolumeclaim")
 		}
-		fields := PersistentVolumeClaimToSelectableFields(persistentvolumeclaimObj)
+		fields := api.PersistentVolumeClaimSelectableFields.Set(persistentvolumeclaimObj)
 		return label.Matches(labels.Set(persistentvolumeclaimObj.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// PersistentVolumeClaimToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func PersistentVolumeClaimToSelectableFields(persistentvolumeclaim *api.PersistentVolumeClaim) labels.Set {
-	return labels.Set{
-		"name": persistentvolumeclaim.Name,
-	}
-}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/errors"
 	etcderr "k8s.io/kubernetes/pkg/api/errors/etcd"
 	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/capabilities"
 	"k8s.io/kubernetes/pkg/client"
 	"k8s.io/kubernetes/pkg/fields"
@@ -56,9 +57,32 @@ type REST struct {
 	etcdgeneric.Etcd
 }
 
+// prefix is the key pods are stored under.
+const prefix = "/pods"
+
+// NewCacher returns a storage.Cacher of pods which keeps an index of them by
+// node, to serve the watches of kubelets from.
+func NewCacher(s storage.Interface, capacity int) *storage.Cacher {
+	return storage.NewCacher(storage.CacherConfig{
+		CacheCapacity: capacity,
+		Storage:       s,
+		KeyPrefix:     prefix,
+		KeyFunc: func(obj runtime.Object) (string, error) {
+			pod, ok := obj.(*api.Pod)
+			if !ok {
+				return "", fmt.Errorf("not a pod: %#v", obj)
+			}
+			return etcdgeneric.NamespaceKeyFunc(api.WithNamespace(api.NewContext(), pod.Namespace), prefix, pod.Name)
+		},
+		NewListFunc: func() runtime.Object { return &api.PodList{} },
+		Indexes: map[string]storage.IndexFunc{
+			"spec.nodeName": storage.IndexFunc(api.PodSelectableFields["spec.nodeName"]),
+		},
+	})
+}
+
 // NewStorage returns a RESTStorage object that will work against pods.
 func NewStorage(s storage.Interface, k client.ConnectionInfoGetter) PodStorage {
-	prefix := "/pods"
 	store := &etcdgeneric.Etcd{
 		NewFunc:     func() runtime.Object { return &api.Pod{} },
 		NewListFunc: func() runtime.Object { return &api.PodList{} },
@@ -214,6 +238,9 @@ func (r *LogREST) Get(ctx api.Context, name string, opts runtime.Object) (runtim
 	if !ok {
 		return nil, fmt.Errorf("Invalid options object: %#v", opts)
 	}
+	if errs := validation.ValidatePodLogOptions(logOpts); len(errs) > 0 {
+		return nil, errors.NewInvalid("podlogs", name, errs)
+	}
 	location, transport, err := pod.LogLocation(r.store, r.kubeletConn, ctx, name, logOpts)
 	if err != nil {
 		return nil, err
@@ -18,6 +18,9 @@ package etcd
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/rest/resttest"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	genericrest "k8s.io/kubernetes/pkg/registry/generic/rest"
 	"k8s.io/kubernetes/pkg/registry/pod"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/securitycontext"
@@ -120,6 +124,9 @@ func TestDelete(t *testing.T) {
 
 	createFn := func() runtime.Object {
 		pod := validChangedPod()
+		pod.Spec.NodeName = "machine"
+		grace := int64(30)
+		pod.Spec.TerminationGracePeriodSeconds = &grace
 		fakeEtcdClient.Data[key] = tools.EtcdResponseWithError{
 			R: &etcd.Response{
 				Node: &etcd.Node{
@@ -134,9 +141,45 @@ func TestDelete(t *testing.T) {
 		if fakeEtcdClient.Data[key].R.Node == nil {
 			return false
 		}
-		return fakeEtcdClient.Data[key].R.Node.TTL == 30
+		obj, err := latest.Codec.Decode([]byte(fakeEtcdClient.Data[key].R.Node.Value))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pod := obj.(*api.Pod)
+		// the pod must expire from etcd after its grace period even if the kubelet never
+		// confirms the deletion
+		if ttl := fakeEtcdClient.Data[key].R.Node.TTL; ttl <= 30 {
+			t.Errorf("expected the pod to expire after its grace period, got TTL %d", ttl)
+		}
+		return pod.DeletionTimestamp != nil && pod.DeletionGracePeriodSeconds != nil && *pod.DeletionGracePeriodSeconds == 30
+	}
+	test.TestDeleteNonExist(createFn)
+	test.TestDeleteGraceful(createFn, 30, gracefulSetFn)
+}
+
+func TestDeleteUnscheduledPodIsImmediate(t *testing.T) {
+	fakeEtcdClient, etcdStorage := newEtcdStorage(t)
+	storage := NewStorage(etcdStorage, nil).Pod
+	ctx := api.NewDefaultContext()
+	key, _ := storage.Etcd.KeyFunc(ctx, "foo")
+	key = etcdtest.AddPrefix(key)
+	pod := validChangedPod()
+	grace := int64(30)
+	pod.Spec.TerminationGracePeriodSeconds = &grace
+	fakeEtcdClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, pod),
+				ModifiedIndex: 1,
+			},
+		},
+	}
+	if _, err := storage.Delete(ctx, "foo", &api.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := storage.Get(ctx, "foo"); !errors.IsNotFound(err) {
+		t.Errorf("expected unscheduled pod to be deleted immediately, got %v", err)
 	}
-	test.TestDelete(createFn, gracefulSetFn)
 }
 
 func expectPod(t *testing.T, out runtime.Object) (*api.Pod, bool) {
@@ -633,6 +676,79 @@ func TestResourceLocation(t *testing.T) {
 	}
 }
 
+type fakeConnectionInfoGetter struct{}
+
+func (fakeConnectionInfoGetter) GetConnectionInfo(host string) (string, uint, http.RoundTripper, error) {
+	return "http", 10250, nil, nil
+}
+
+func TestLogLocation(t *testing.T) {
+	tail, since := int64(20), int64(300)
+	sinceTime := util.NewTime(time.Date(2015, 8, 1, 10, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		opts  api.PodLogOptions
+		query url.Values
+		err   bool
+	}{
+		{
+			opts:  api.PodLogOptions{},
+			query: url.Values{},
+		},
+		{
+			opts:  api.PodLogOptions{Follow: true, Timestamps: true, TailLines: &tail, SinceSeconds: &since},
+			query: url.Values{"follow": {"true"}, "timestamps": {"true"}, "tailLines": {"20"}, "sinceSeconds": {"300"}},
+		},
+		{
+			opts:  api.PodLogOptions{Previous: true, SinceTime: &sinceTime},
+			query: url.Values{"previous": {"true"}, "sinceTime": {"2015-08-01T10:00:00Z"}},
+		},
+		{
+			opts: api.PodLogOptions{SinceSeconds: &since, SinceTime: &sinceTime},
+			err:  true,
+		},
+	}
+
+	ctx := api.NewDefaultContext()
+	for i, tc := range testCases {
+		fakeEtcdClient, etcdStorage := newEtcdStorage(t)
+		storage := NewStorage(etcdStorage, fakeConnectionInfoGetter{})
+		key, _ := storage.Pod.Etcd.KeyFunc(ctx, "foo")
+		key = etcdtest.AddPrefix(key)
+		fakeEtcdClient.Data[key] = tools.EtcdResponseWithError{
+			R: &etcd.Response{
+				Node: &etcd.Node{
+					Value: runtime.EncodeOrDie(latest.Codec, &api.Pod{
+						ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault},
+						Spec: api.PodSpec{
+							NodeName:   "node1",
+							Containers: []api.Container{{Name: "ctr"}},
+						},
+					}),
+				},
+			},
+		}
+
+		obj, err := storage.Log.Get(ctx, "foo", &tc.opts)
+		if tc.err {
+			if !errors.IsInvalid(err) {
+				t.Errorf("%d: expected invalid error, got %v", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		location := obj.(*genericrest.LocationStreamer).Location
+		if location.Host != "node1:10250" || location.Path != "/containerLogs/default/foo/ctr" {
+			t.Errorf("%d: unexpected location: %v", i, location)
+		}
+		if query := location.Query(); !reflect.DeepEqual(query, tc.query) {
+			t.Errorf("%d: expected query %v, got %v", i, tc.query, query)
+		}
+	}
+}
+
 func TestDeletePod(t *testing.T) {
 	fakeEtcdClient, etcdStorage := newEtcdStorage(t)
 	fakeEtcdClient.ChangeIndex = 1
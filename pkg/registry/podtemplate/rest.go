@@ -80,6 +80,6 @@ func MatchPodTemplate(label labels.Selector, field fields.Selector) generic.Matc
 		if !ok {
 			return false, fmt.Errorf("not a pod template")
 		}
-		return label.Matches(labels.Set(podObj.Labels)), nil
+		return label.Matches(labels.Set(podObj.Labels)) && field.Matches(api.NameSelectableFields.Set(podObj)), nil
 	})
 }
@@ -260,7 +260,7 @@ func TestListResourceQuotaListSelection(t *testing.T) {
 		{
 			expectedIDs: util.NewStringSet("foo", "qux", "zot"),
 		}, {
-			field:       "name=zot",
+			field:       "metadata.name=zot",
 			expectedIDs: util.NewStringSet("zot"),
 		}, {
 			label:       "label=qux",
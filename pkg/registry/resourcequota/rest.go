@@ -100,15 +100,7 @@ func MatchResourceQuota(label labels.Selector, field fields.Selector) generic.Ma
 		if !ok {
 			return false, fmt.Errorf("not a resourcequota")
 		}
-		fields := ResourceQuotaToSelectableFields(resourcequotaObj)
+		fields := api.NameSelectableFields.Set(resourcequotaObj)
 		return label.Matches(labels.Set(resourcequotaObj.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// ResourceQuotaToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func ResourceQuotaToSelectableFields(resourcequota *api.ResourceQuota) labels.Set {
-	return labels.Set{
-		"name": resourcequota.Name,
-	}
-}
@@ -76,14 +76,7 @@ func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
 		if !ok {
 			return false, fmt.Errorf("not a secret")
 		}
-		fields := SelectableFields(sa)
+		fields := api.SecretSelectableFields.Set(sa)
 		return label.Matches(labels.Set(sa.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// SelectableFields returns a label set that can be used for filter selection
-func SelectableFields(obj *api.Secret) labels.Set {
-	return labels.Set{
-		"type": string(obj.Type),
-	}
-}
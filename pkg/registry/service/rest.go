@@ -174,7 +174,7 @@ func (rs *REST) List(ctx api.Context, label labels.Selector, field fields.Select
 	}
 	var filtered []api.Service
 	for _, service := range list.Items {
-		if label.Matches(labels.Set(service.Labels)) {
+		if label.Matches(labels.Set(service.Labels)) && field.Matches(api.NameSelectableFields.Set(&service)) {
 			filtered = append(filtered, service)
 		}
 	}
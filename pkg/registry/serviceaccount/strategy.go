@@ -79,14 +79,7 @@ func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
 		if !ok {
 			return false, fmt.Errorf("not a serviceaccount")
 		}
-		fields := SelectableFields(sa)
+		fields := api.NameSelectableFields.Set(sa)
 		return label.Matches(labels.Set(sa.Labels)) && field.Matches(fields), nil
 	})
 }
-
-// SelectableFields returns a label set that represents the object
-func SelectableFields(obj *api.ServiceAccount) labels.Set {
-	return labels.Set{
-		"metadata.name": obj.Name,
-	}
-}
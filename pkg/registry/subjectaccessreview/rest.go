@@ -0,0 +1,98 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subjectaccessreview
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// REST implements a RESTStorage for SubjectAccessReviews. Reviews are
+// evaluated against the authorizer of the apiserver and are never persisted.
+type REST struct {
+	authorizer authorizer.Authorizer
+}
+
+// NewREST returns a RESTStorage object that reviews access with the given
+// authorizer. Reviews fail without an authorizer, rather than allow every
+// request.
+func NewREST(authorizer authorizer.Authorizer) *REST {
+	return &REST{authorizer: authorizer}
+}
+
+// New returns a new SubjectAccessReview.
+func (r *REST) New() runtime.Object {
+	return &expapi.SubjectAccessReview{}
+}
+
+var _ = rest.Creater(&REST{})
+
+// Create evaluates the access request of the review and returns the review
+// with its status filled in.
+func (r *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, error) {
+	review, ok := obj.(*expapi.SubjectAccessReview)
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("not a SubjectAccessReview: %#v", obj))
+	}
+	if len(review.Spec.User) == 0 && len(review.Spec.Groups) == 0 {
+		return nil, errors.NewBadRequest("at least one of user or groups must be specified")
+	}
+	if len(review.Spec.Verb) == 0 {
+		return nil, errors.NewBadRequest("verb must be specified")
+	}
+
+	if r.authorizer == nil {
+		return nil, errors.NewServiceUnavailable("no authorizer is configured to review access")
+	}
+	review.Status = expapi.SubjectAccessReviewStatus{Allowed: true}
+	if err := r.authorizer.Authorize(attributesFrom(review.Spec)); err != nil {
+		review.Status = expapi.SubjectAccessReviewStatus{Allowed: false, Reason: err.Error()}
+	}
+	return review, nil
+}
+
+// attributesFrom returns the authorizer attributes described by the spec of a
+// SubjectAccessReview.
+func attributesFrom(spec expapi.SubjectAccessReviewSpec) authorizer.Attributes {
+	return authorizer.AttributesRecord{
+		User: &user.DefaultInfo{
+			Name:   spec.User,
+			Groups: spec.Groups,
+		},
+		ReadOnly:    isReadOnlyVerb(spec.Verb),
+		Namespace:   spec.Namespace,
+		Resource:    spec.Resource,
+		Verb:        spec.Verb,
+		Subresource: spec.Subresource,
+		Name:        spec.Name,
+	}
+}
+
+func isReadOnlyVerb(verb string) bool {
+	switch verb {
+	case "get", "list", "watch":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subjectaccessreview
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+func TestCreate(t *testing.T) {
+	var got authorizer.Attributes
+	storage := NewREST(authorizer.AuthorizerFunc(func(a authorizer.Attributes) error {
+		got = a
+		if a.GetUserName() != "alice" {
+			return errors.New("only alice may do that")
+		}
+		return nil
+	}))
+
+	tests := []struct {
+		spec     expapi.SubjectAccessReviewSpec
+		expected expapi.SubjectAccessReviewStatus
+	}{
+		{
+			spec:     expapi.SubjectAccessReviewSpec{User: "alice", Verb: "get", Resource: "nodes", Subresource: "stats", Name: "node1"},
+			expected: expapi.SubjectAccessReviewStatus{Allowed: true},
+		},
+		{
+			spec:     expapi.SubjectAccessReviewSpec{User: "bob", Groups: []string{"users"}, Verb: "create", Resource: "nodes", Subresource: "proxy", Name: "node1"},
+			expected: expapi.SubjectAccessReviewStatus{Allowed: false, Reason: "only alice may do that"},
+		},
+	}
+	for _, test := range tests {
+		obj, err := storage.Create(api.NewContext(), &expapi.SubjectAccessReview{Spec: test.spec})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.spec.User, err)
+			continue
+		}
+		if status := obj.(*expapi.SubjectAccessReview).Status; !reflect.DeepEqual(status, test.expected) {
+			t.Errorf("%s: expected %#v, got %#v", test.spec.User, test.expected, status)
+		}
+		if got.GetUserName() != test.spec.User ||
+			!reflect.DeepEqual(got.GetGroups(), test.spec.Groups) ||
+			got.GetVerb() != test.spec.Verb ||
+			got.GetResource() != test.spec.Resource ||
+			got.GetSubresource() != test.spec.Subresource ||
+			got.GetName() != test.spec.Name ||
+			got.IsReadOnly() != (test.spec.Verb == "get") {
+			t.Errorf("%s: unexpected attributes %#v", test.spec.User, got)
+		}
+	}
+}
+
+func TestCreateValidation(t *testing.T) {
+	storage := NewREST(nil)
+	for _, spec := range []expapi.SubjectAccessReviewSpec{
+		{Verb: "get"},
+		{User: "alice"},
+	} {
+		if _, err := storage.Create(api.NewContext(), &expapi.SubjectAccessReview{Spec: spec}); err == nil {
+			t.Errorf("expected an error for %#v", spec)
+		}
+	}
+}
+
+func TestCreateWithoutAuthorizer(t *testing.T) {
+	spec := expapi.SubjectAccessReviewSpec{User: "alice", Verb: "get", Resource: "pods"}
+	_, err := NewREST(nil).Create(api.NewContext(), &expapi.SubjectAccessReview{Spec: spec})
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok || statusErr.Status().Reason != api.StatusReasonServiceUnavailable {
+		t.Errorf("expected a service unavailable error, got %v", err)
+	}
+}
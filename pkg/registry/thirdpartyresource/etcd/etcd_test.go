@@ -0,0 +1,161 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/rest/resttest"
+	"k8s.io/kubernetes/pkg/expapi"
+	explatest "k8s.io/kubernetes/pkg/expapi/latest"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
+	"k8s.io/kubernetes/pkg/tools"
+	"k8s.io/kubernetes/pkg/tools/etcdtest"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newStorage(t *testing.T) (*REST, *tools.FakeEtcdClient, storage.Interface) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	etcdStorage := etcdstorage.NewEtcdStorage(fakeEtcdClient, explatest.Codec, etcdtest.PathPrefix())
+	return NewREST(etcdStorage), fakeEtcdClient, etcdStorage
+}
+
+func validNewThirdPartyResource(name string) *expapi.ThirdPartyResource {
+	return &expapi.ThirdPartyResource{
+		ObjectMeta: api.ObjectMeta{
+			Name: name,
+		},
+		Versions: []expapi.APIVersion{
+			{Name: "v1"},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := api.NewContext()
+	storage, _, _ := newStorage(t)
+	obj, err := storage.Create(ctx, validNewThirdPartyResource("cron-tab.example.com"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rsrc := obj.(*expapi.ThirdPartyResource)
+	if !api.HasObjectMetaSystemFieldValues(&rsrc.ObjectMeta) {
+		t.Errorf("storage did not populate object meta field values")
+	}
+
+	invalid := []*expapi.ThirdPartyResource{
+		validNewThirdPartyResource("cron-tab"),
+		{ObjectMeta: api.ObjectMeta{Name: "cron-tab.example.com"}},
+	}
+	for _, rsrc := range invalid {
+		if _, err := storage.Create(ctx, rsrc); err == nil {
+			t.Errorf("expected error for %#v", rsrc)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	storage, fakeEtcdClient, _ := newStorage(t)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError).ClusterScope()
+	key, err := storage.KeyFunc(test.TestContext(), "cron-tab.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key = etcdtest.AddPrefix(key)
+
+	fakeEtcdClient.ExpectNotFoundGet(key)
+	fakeEtcdClient.ChangeIndex = 2
+	rsrc := validNewThirdPartyResource("cron-tab.example.com")
+	existing := validNewThirdPartyResource("exists.example.com")
+	obj, err := storage.Create(test.TestContext(), existing)
+	if err != nil {
+		t.Fatalf("unable to create object: %v", err)
+	}
+	older := obj.(*expapi.ThirdPartyResource)
+	older.ResourceVersion = "1"
+
+	test.TestUpdate(
+		rsrc,
+		existing,
+		older,
+	)
+}
+
+func TestDelete(t *testing.T) {
+	ctx := api.NewContext()
+	storage, fakeEtcdClient, _ := newStorage(t)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError).ClusterScope()
+
+	rsrc := validNewThirdPartyResource("cron-tab.example.com")
+	rsrc.ResourceVersion = "1"
+	key, _ := storage.KeyFunc(ctx, rsrc.Name)
+	key = etcdtest.AddPrefix(key)
+	createFn := func() runtime.Object {
+		fakeEtcdClient.Data[key] = tools.EtcdResponseWithError{
+			R: &etcd.Response{
+				Node: &etcd.Node{
+					Value:         runtime.EncodeOrDie(explatest.Codec, rsrc),
+					ModifiedIndex: 1,
+				},
+			},
+		}
+		return rsrc
+	}
+	gracefulSetFn := func() bool {
+		if fakeEtcdClient.Data[key].R.Node == nil {
+			return false
+		}
+		return fakeEtcdClient.Data[key].R.Node.TTL == 30
+	}
+	test.TestDeleteNoGraceful(createFn, gracefulSetFn)
+}
+
+func TestList(t *testing.T) {
+	ctx := api.NewContext()
+	storage, fakeClient, _ := newStorage(t)
+	key := etcdtest.AddPrefix(storage.KeyRootFunc(ctx))
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{
+						Value: runtime.EncodeOrDie(explatest.Codec, validNewThirdPartyResource("foo.example.com")),
+					},
+					{
+						Value: runtime.EncodeOrDie(explatest.Codec, validNewThirdPartyResource("bar.example.com")),
+					},
+				},
+			},
+		},
+	}
+
+	obj, err := storage.List(ctx, labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := obj.(*expapi.ThirdPartyResourceList)
+	if len(list.Items) != 2 || list.Items[0].Name != "foo.example.com" || list.Items[1].Name != "bar.example.com" {
+		t.Errorf("unexpected list: %#v", list)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package thirdpartyresourcedata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/expapi"
+	explatest "k8s.io/kubernetes/pkg/expapi/latest"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// ResourceName returns the name under which objects of the given third party
+// kind are served (e.g. "crontabs" for CronTab).
+func ResourceName(kind string) string {
+	plural, _ := meta.KindToResource(kind, false)
+	return plural
+}
+
+// thirdPartyResourceDataMapper maps the kind of a ThirdPartyResource onto the
+// ThirdPartyResourceData type used to store it, and delegates all other kinds.
+type thirdPartyResourceDataMapper struct {
+	mapper  meta.RESTMapper
+	kind    string
+	version string
+	group   string
+}
+
+// NewMapper returns a RESTMapper that serves the given third party kind, in the
+// given version and API group, from ThirdPartyResourceData storage.
+func NewMapper(mapper meta.RESTMapper, kind, version, group string) meta.RESTMapper {
+	return &thirdPartyResourceDataMapper{
+		mapper:  mapper,
+		kind:    kind,
+		version: version,
+		group:   group,
+	}
+}
+
+func (t *thirdPartyResourceDataMapper) isThirdPartyResource(resource string) bool {
+	resource = strings.ToLower(resource)
+	return resource == ResourceName(t.kind) || resource == strings.ToLower(t.kind)
+}
+
+func (t *thirdPartyResourceDataMapper) RESTMapping(kind string, versions ...string) (*meta.RESTMapping, error) {
+	if kind != "ThirdPartyResourceData" && kind != t.kind {
+		return t.mapper.RESTMapping(kind, versions...)
+	}
+	return &meta.RESTMapping{
+		Resource:         ResourceName(t.kind),
+		APIVersion:       t.version,
+		Kind:             t.kind,
+		Scope:            meta.RESTScopeNamespace,
+		Codec:            NewCodec(explatest.Codec, t.kind, t.group+"/"+t.version),
+		ObjectConvertor:  api.Scheme,
+		MetadataAccessor: meta.NewAccessor(),
+	}, nil
+}
+
+func (t *thirdPartyResourceDataMapper) AliasesForResource(resource string) ([]string, bool) {
+	return t.mapper.AliasesForResource(resource)
+}
+
+func (t *thirdPartyResourceDataMapper) ResourceSingularizer(resource string) (singular string, err error) {
+	if t.isThirdPartyResource(resource) {
+		return strings.ToLower(t.kind), nil
+	}
+	return t.mapper.ResourceSingularizer(resource)
+}
+
+func (t *thirdPartyResourceDataMapper) VersionAndKindForResource(resource string) (defaultVersion, kind string, err error) {
+	if t.isThirdPartyResource(resource) {
+		return t.version, t.kind, nil
+	}
+	return t.mapper.VersionAndKindForResource(resource)
+}
+
+// thirdPartyResourceDataCodec converts between the JSON representation of an
+// object of a third party kind and ThirdPartyResourceData. Any other object,
+// such as a Status or DeleteOptions, is handled by the delegate codec.
+type thirdPartyResourceDataCodec struct {
+	delegate   runtime.Codec
+	kind       string
+	apiVersion string
+}
+
+// NewCodec returns a codec for objects of the given third party kind. The
+// apiVersion is the one objects of the kind carry on the wire (<group>/<version>).
+func NewCodec(delegate runtime.Codec, kind, apiVersion string) runtime.Codec {
+	return &thirdPartyResourceDataCodec{
+		delegate:   delegate,
+		kind:       kind,
+		apiVersion: apiVersion,
+	}
+}
+
+// thirdPartyResourceDataList is the wire representation of a ThirdPartyResourceDataList.
+type thirdPartyResourceDataList struct {
+	api.TypeMeta `json:",inline"`
+	api.ListMeta `json:"metadata,omitempty"`
+
+	Items []json.RawMessage `json:"items"`
+}
+
+func (t *thirdPartyResourceDataCodec) populate(obj *expapi.ThirdPartyResourceData, data []byte) error {
+	var mapObj map[string]interface{}
+	if err := json.Unmarshal(data, &mapObj); err != nil {
+		return err
+	}
+	if mapObj == nil {
+		return fmt.Errorf("expected a JSON object for kind %s", t.kind)
+	}
+	if kind, ok := mapObj["kind"]; ok && kind != t.kind {
+		return fmt.Errorf("unexpected kind: %v, expected %s", kind, t.kind)
+	}
+	if apiVersion, ok := mapObj["apiVersion"]; ok && apiVersion != t.apiVersion {
+		return fmt.Errorf("unexpected apiVersion: %v, expected %s", apiVersion, t.apiVersion)
+	}
+	mapObj["kind"] = t.kind
+	mapObj["apiVersion"] = t.apiVersion
+
+	if metadata, ok := mapObj["metadata"]; ok {
+		metadataData, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(metadataData, &obj.ObjectMeta); err != nil {
+			return err
+		}
+		// the authoritative copy of the metadata is ObjectMeta
+		delete(mapObj, "metadata")
+	}
+
+	data, err := json.Marshal(mapObj)
+	if err != nil {
+		return err
+	}
+	obj.Data = data
+	return nil
+}
+
+func (t *thirdPartyResourceDataCodec) Decode(data []byte) (runtime.Object, error) {
+	result := &expapi.ThirdPartyResourceData{}
+	if err := t.populate(result, data); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t *thirdPartyResourceDataCodec) DecodeInto(data []byte, obj runtime.Object) error {
+	thirdParty, ok := obj.(*expapi.ThirdPartyResourceData)
+	if !ok {
+		return t.delegate.DecodeInto(data, obj)
+	}
+	return t.populate(thirdParty, data)
+}
+
+func (t *thirdPartyResourceDataCodec) DecodeIntoWithSpecifiedVersionKind(data []byte, obj runtime.Object, kind, version string) error {
+	thirdParty, ok := obj.(*expapi.ThirdPartyResourceData)
+	if !ok {
+		return t.delegate.DecodeIntoWithSpecifiedVersionKind(data, obj, kind, version)
+	}
+	return t.populate(thirdParty, data)
+}
+
+func (t *thirdPartyResourceDataCodec) encode(obj *expapi.ThirdPartyResourceData) ([]byte, error) {
+	mapObj := map[string]interface{}{}
+	if len(obj.Data) > 0 {
+		if err := json.Unmarshal(obj.Data, &mapObj); err != nil {
+			return nil, err
+		}
+	}
+	mapObj["kind"] = t.kind
+	mapObj["apiVersion"] = t.apiVersion
+	mapObj["metadata"] = &obj.ObjectMeta
+	return json.Marshal(mapObj)
+}
+
+func (t *thirdPartyResourceDataCodec) Encode(obj runtime.Object) ([]byte, error) {
+	switch obj := obj.(type) {
+	case *expapi.ThirdPartyResourceData:
+		return t.encode(obj)
+	case *expapi.ThirdPartyResourceDataList:
+		list := &thirdPartyResourceDataList{
+			TypeMeta: api.TypeMeta{Kind: t.kind + "List", APIVersion: t.apiVersion},
+			ListMeta: obj.ListMeta,
+			Items:    make([]json.RawMessage, len(obj.Items)),
+		}
+		for i := range obj.Items {
+			data, err := t.encode(&obj.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			list.Items[i] = data
+		}
+		return json.Marshal(list)
+	default:
+		return t.delegate.Encode(obj)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package thirdpartyresourcedata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+	explatest "k8s.io/kubernetes/pkg/expapi/latest"
+)
+
+type Foo struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	SomeField  string `json:"someField"`
+	OtherField int    `json:"otherField"`
+}
+
+type FooList struct {
+	api.TypeMeta `json:",inline"`
+	api.ListMeta `json:"metadata,omitempty"`
+
+	Items []Foo `json:"items"`
+}
+
+func TestCodec(t *testing.T) {
+	tests := []struct {
+		obj       *Foo
+		expectErr bool
+		name      string
+	}{
+		{
+			obj:  &Foo{ObjectMeta: api.ObjectMeta{Name: "bar"}, TypeMeta: api.TypeMeta{Kind: "Foo", APIVersion: "company.com/v1"}},
+			name: "basic",
+		},
+		{
+			obj:  &Foo{ObjectMeta: api.ObjectMeta{Name: "bar", ResourceVersion: "baz", Labels: map[string]string{"foo": "bar"}}, TypeMeta: api.TypeMeta{Kind: "Foo"}, SomeField: "value", OtherField: 10},
+			name: "resource version and fields",
+		},
+		{
+			obj:       &Foo{ObjectMeta: api.ObjectMeta{Name: "bar"}, TypeMeta: api.TypeMeta{Kind: "Bar"}},
+			expectErr: true,
+			name:      "wrong kind",
+		},
+		{
+			obj:       &Foo{ObjectMeta: api.ObjectMeta{Name: "bar"}, TypeMeta: api.TypeMeta{Kind: "Foo", APIVersion: "other.com/v1"}},
+			expectErr: true,
+			name:      "wrong apiVersion",
+		},
+	}
+	for _, test := range tests {
+		codec := NewCodec(explatest.Codec, "Foo", "company.com/v1")
+		data, err := json.Marshal(test.obj)
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+		obj, err := codec.Decode(data)
+		if err != nil && !test.expectErr {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("[%s] unexpected non-error", test.name)
+			}
+			continue
+		}
+		rsrcObj, ok := obj.(*expapi.ThirdPartyResourceData)
+		if !ok {
+			t.Errorf("[%s] unexpected object: %v", test.name, obj)
+			continue
+		}
+		if !reflect.DeepEqual(rsrcObj.ObjectMeta, test.obj.ObjectMeta) {
+			t.Errorf("[%s]\nexpected\n%v\nsaw\n%v\n", test.name, test.obj.ObjectMeta, rsrcObj.ObjectMeta)
+		}
+
+		data, err = codec.Encode(rsrcObj)
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+		var output Foo
+		if err := json.Unmarshal(data, &output); err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.name, err)
+			continue
+		}
+		expected := *test.obj
+		expected.APIVersion = "company.com/v1"
+		if !reflect.DeepEqual(expected, output) {
+			t.Errorf("[%s]\nexpected\n%v\nsaw\n%v\n", test.name, expected, output)
+		}
+	}
+}
+
+func TestCodecEncodeList(t *testing.T) {
+	codec := NewCodec(explatest.Codec, "Foo", "company.com/v1")
+	list := &expapi.ThirdPartyResourceDataList{
+		ListMeta: api.ListMeta{ResourceVersion: "10"},
+		Items: []expapi.ThirdPartyResourceData{
+			{ObjectMeta: api.ObjectMeta{Name: "a"}, Data: []byte(`{"someField":"one"}`)},
+			{ObjectMeta: api.ObjectMeta{Name: "b"}, Data: []byte(`{"otherField":2}`)},
+		},
+	}
+	data, err := codec.Encode(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var output FooList
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := FooList{
+		TypeMeta: api.TypeMeta{Kind: "FooList", APIVersion: "company.com/v1"},
+		ListMeta: api.ListMeta{ResourceVersion: "10"},
+		Items: []Foo{
+			{TypeMeta: api.TypeMeta{Kind: "Foo", APIVersion: "company.com/v1"}, ObjectMeta: api.ObjectMeta{Name: "a"}, SomeField: "one"},
+			{TypeMeta: api.TypeMeta{Kind: "Foo", APIVersion: "company.com/v1"}, ObjectMeta: api.ObjectMeta{Name: "b"}, OtherField: 2},
+		},
+	}
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("expected\n%v\nsaw\n%v\n", expected, output)
+	}
+}
+
+func TestCodecDelegates(t *testing.T) {
+	codec := NewCodec(explatest.Codec, "Foo", "company.com/v1")
+	data, err := codec.Encode(&api.Status{Status: api.StatusFailure, Message: "failed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := explatest.Codec.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status, ok := obj.(*api.Status); !ok || status.Message != "failed" {
+		t.Errorf("unexpected object: %#v", obj)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
+	"k8s.io/kubernetes/pkg/registry/thirdpartyresourcedata"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+)
+
+// REST implements a RESTStorage for the objects of a third party kind against etcd
+type REST struct {
+	etcdgeneric.Etcd
+}
+
+// NewREST returns a RESTStorage object that will work against objects of the
+// given third party kind in the given API group.
+func NewREST(s storage.Interface, group, kind string) *REST {
+	prefix := "/ThirdPartyResourceData/" + group + "/" + thirdpartyresourcedata.ResourceName(kind)
+	store := etcdgeneric.Etcd{
+		NewFunc:     func() runtime.Object { return &expapi.ThirdPartyResourceData{} },
+		NewListFunc: func() runtime.Object { return &expapi.ThirdPartyResourceDataList{} },
+		KeyRootFunc: func(ctx api.Context) string {
+			return etcdgeneric.NamespaceKeyRootFunc(ctx, prefix)
+		},
+		KeyFunc: func(ctx api.Context, name string) (string, error) {
+			return etcdgeneric.NamespaceKeyFunc(ctx, prefix, name)
+		},
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*expapi.ThirdPartyResourceData).Name, nil
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return thirdpartyresourcedata.Matcher(label, field)
+		},
+		EndpointName: thirdpartyresourcedata.ResourceName(kind),
+
+		CreateStrategy:      thirdpartyresourcedata.Strategy,
+		UpdateStrategy:      thirdpartyresourcedata.Strategy,
+		ReturnDeletedObject: true,
+
+		Storage: s,
+	}
+
+	return &REST{store}
+}
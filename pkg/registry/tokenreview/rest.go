@@ -0,0 +1,81 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenreview
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/auth/authenticator"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// REST implements a RESTStorage for TokenReviews. Reviews are evaluated
+// against the authenticator of the apiserver and are never persisted.
+type REST struct {
+	authenticator authenticator.Request
+}
+
+// NewREST returns a RESTStorage object that reviews tokens with the given
+// authenticator. A nil authenticator rejects every token.
+func NewREST(authenticator authenticator.Request) *REST {
+	return &REST{authenticator: authenticator}
+}
+
+// New returns a new TokenReview.
+func (r *REST) New() runtime.Object {
+	return &expapi.TokenReview{}
+}
+
+var _ = rest.Creater(&REST{})
+
+// Create authenticates the token of the review and returns the review with
+// its status filled in.
+func (r *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, error) {
+	review, ok := obj.(*expapi.TokenReview)
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("not a TokenReview: %#v", obj))
+	}
+	if len(review.Spec.Token) == 0 {
+		return nil, errors.NewBadRequest("token must be specified")
+	}
+	review.Status = expapi.TokenReviewStatus{}
+	if r.authenticator == nil {
+		return review, nil
+	}
+
+	// The apiserver authenticators only understand requests, so the token is
+	// presented to them the way a client would have sent it.
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set("Authorization", "Bearer "+review.Spec.Token)
+	// An error means the token could not be verified, which the review
+	// reports the same way as an unknown token.
+	user, ok, err := r.authenticator.AuthenticateRequest(req)
+	if err == nil && ok && user != nil {
+		review.Status.Authenticated = true
+		review.Status.User = expapi.UserInfo{
+			Username: user.GetName(),
+			UID:      user.GetUID(),
+			Groups:   user.GetGroups(),
+		}
+	}
+	return review, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenreview
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/authenticator/bearertoken"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/plugin/pkg/auth/authenticator/token/tokentest"
+)
+
+func TestCreate(t *testing.T) {
+	tokens := tokentest.New()
+	tokens.Tokens["good"] = &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"admins"}}
+	storage := NewREST(bearertoken.New(tokens))
+
+	tests := []struct {
+		token    string
+		expected expapi.TokenReviewStatus
+	}{
+		{
+			token: "good",
+			expected: expapi.TokenReviewStatus{
+				Authenticated: true,
+				User:          expapi.UserInfo{Username: "alice", UID: "1", Groups: []string{"admins"}},
+			},
+		},
+		{
+			token:    "bad",
+			expected: expapi.TokenReviewStatus{},
+		},
+	}
+	for _, test := range tests {
+		obj, err := storage.Create(api.NewContext(), &expapi.TokenReview{Spec: expapi.TokenReviewSpec{Token: test.token}})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.token, err)
+			continue
+		}
+		if status := obj.(*expapi.TokenReview).Status; !reflect.DeepEqual(status, test.expected) {
+			t.Errorf("%s: expected %#v, got %#v", test.token, test.expected, status)
+		}
+	}
+}
+
+func TestCreateRequiresToken(t *testing.T) {
+	storage := NewREST(bearertoken.New(tokentest.New()))
+	if _, err := storage.Create(api.NewContext(), &expapi.TokenReview{}); err == nil {
+		t.Errorf("expected an error for a review without a token")
+	}
+}
+
+func TestCreateWithoutAuthenticator(t *testing.T) {
+	obj, err := NewREST(nil).Create(api.NewContext(), &expapi.TokenReview{Spec: expapi.TokenReviewSpec{Token: "any"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj.(*expapi.TokenReview).Status.Authenticated {
+		t.Errorf("expected the token not to be authenticated")
+	}
+}
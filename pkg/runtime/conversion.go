@@ -22,8 +22,10 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/kubernetes/pkg/conversion"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 // JSONKeyMapper uses the struct tags on a conversion to determine the key value for
@@ -44,6 +46,8 @@ var DefaultStringConversions = []interface{}{
 	convertStringSliceToInt,
 	convertStringSliceToBool,
 	convertStringSliceToInt64,
+	convertStringSliceToInt64Pointer,
+	convertStringSliceToTimePointer,
 }
 
 func convertStringSliceToString(input *[]string, out *string, s conversion.Scope) error {
@@ -96,3 +100,30 @@ func convertStringSliceToInt64(input *[]string, out *int64, s conversion.Scope)
 	*out = i
 	return nil
 }
+
+func convertStringSliceToInt64Pointer(input *[]string, out **int64, s conversion.Scope) error {
+	if len(*input) == 0 {
+		*out = nil
+		return nil
+	}
+	var i int64
+	if err := convertStringSliceToInt64(input, &i, s); err != nil {
+		return err
+	}
+	*out = &i
+	return nil
+}
+
+// convertStringSliceToTimePointer parses an RFC3339 timestamp parameter.
+func convertStringSliceToTimePointer(input *[]string, out **util.Time, s conversion.Scope) error {
+	if len(*input) == 0 {
+		*out = nil
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, (*input)[0])
+	if err != nil {
+		return err
+	}
+	*out = &util.Time{Time: t}
+	return nil
+}
@@ -19,8 +19,10 @@ package runtime_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 type InternalComplex struct {
@@ -30,6 +32,8 @@ type InternalComplex struct {
 	Integer64 int64
 	Int64     int64
 	Bool      bool
+	Pointer   *int64
+	Time      *util.Time
 }
 
 type ExternalComplex struct {
@@ -38,7 +42,9 @@ type ExternalComplex struct {
 	Integer   int    `json:"int"`
 	Integer64 int64  `json:",omitempty"`
 	Int64     int64
-	Bool      bool `json:"bool"`
+	Bool      bool       `json:"bool"`
+	Pointer   *int64     `json:"pointer"`
+	Time      *util.Time `json:"time"`
 }
 
 func (*InternalComplex) IsAnAPIObject() {}
@@ -50,6 +56,7 @@ func TestStringMapConversion(t *testing.T) {
 	scheme.AddKnownTypeWithName("", "Complex", &InternalComplex{})
 	scheme.AddKnownTypeWithName("external", "Complex", &ExternalComplex{})
 
+	ten := int64(10)
 	testCases := map[string]struct {
 		input    map[string][]string
 		errFn    func(error) bool
@@ -114,6 +121,32 @@ func TestStringMapConversion(t *testing.T) {
 			},
 			expected: &ExternalComplex{Bool: false},
 		},
+		"parses int64 pointer": {
+			input: map[string][]string{
+				"pointer": {"10"},
+			},
+			expected: &ExternalComplex{Pointer: &ten},
+		},
+		"returns error on bad int64 pointer": {
+			input: map[string][]string{
+				"pointer": {"a"},
+			},
+			errFn:    func(err error) bool { return err != nil },
+			expected: &ExternalComplex{},
+		},
+		"parses time pointer": {
+			input: map[string][]string{
+				"time": {"2015-08-01T10:00:00Z"},
+			},
+			expected: &ExternalComplex{Time: &util.Time{Time: time.Date(2015, 8, 1, 10, 0, 0, 0, time.UTC)}},
+		},
+		"returns error on bad time": {
+			input: map[string][]string{
+				"time": {"yesterday"},
+			},
+			errFn:    func(err error) bool { return err != nil },
+			expected: &ExternalComplex{},
+		},
 	}
 
 	for k, tc := range testCases {
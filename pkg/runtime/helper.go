@@ -60,6 +60,9 @@ func GetItemsPtr(list Object) (interface{}, error) {
 // Returns an error if obj is not a List type (does not have an Items member).
 // TODO: move me to pkg/api/meta
 func ExtractList(obj Object) ([]Object, error) {
+	if unstructured, ok := obj.(*Unstructured); ok {
+		return extractUnstructuredList(unstructured)
+	}
 	itemsPtr, err := GetItemsPtr(obj)
 	if err != nil {
 		return nil, err
@@ -85,6 +88,27 @@ func ExtractList(obj Object) ([]Object, error) {
 	return list, nil
 }
 
+// extractUnstructuredList returns the "items" of an Unstructured list as
+// Unstructured objects.
+func extractUnstructuredList(obj *Unstructured) ([]Object, error) {
+	items, ok := obj.Object["items"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no items field in %#v", obj)
+	}
+	list := make([]Object, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("item[%v]: Expected object, got %#v", i, item)
+		}
+		unstructured := &Unstructured{Object: m}
+		unstructured.Kind, _ = m["kind"].(string)
+		unstructured.APIVersion, _ = m["apiVersion"].(string)
+		list[i] = unstructured
+	}
+	return list, nil
+}
+
 // objectSliceType is the type of a slice of Objects
 var objectSliceType = reflect.TypeOf([]Object{})
 
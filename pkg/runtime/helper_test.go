@@ -133,6 +133,38 @@ func TestExtractListOfValuePtrs(t *testing.T) {
 	}
 }
 
+func TestExtractListUnstructured(t *testing.T) {
+	pl := &runtime.Unstructured{
+		TypeMeta: runtime.TypeMeta{Kind: "FooList", APIVersion: "company.com/v1"},
+		Object: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"kind": "Foo", "apiVersion": "company.com/v1", "metadata": map[string]interface{}{"name": "1"}},
+				map[string]interface{}{"kind": "Foo", "apiVersion": "company.com/v1", "metadata": map[string]interface{}{"name": "2"}},
+			},
+		},
+	}
+	list, err := runtime.ExtractList(pl)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e, a := 2, len(list); e != a {
+		t.Fatalf("Expected %v, got %v", e, a)
+	}
+	for i := range list {
+		obj, ok := list[i].(*runtime.Unstructured)
+		if !ok {
+			t.Fatalf("Expected list[%d] to be *runtime.Unstructured, it is %#v", i, list[i])
+		}
+		if obj.Kind != "Foo" || obj.APIVersion != "company.com/v1" {
+			t.Errorf("Unexpected type meta on list[%d]: %#v", i, obj.TypeMeta)
+		}
+	}
+
+	if _, err := runtime.ExtractList(&runtime.Unstructured{Object: map[string]interface{}{}}); err == nil {
+		t.Errorf("Expected error for an object without items")
+	}
+}
+
 func TestDecodeList(t *testing.T) {
 	pl := &api.List{
 		Items: []runtime.Object{
@@ -91,3 +91,9 @@ func (unstructuredJSONScheme) DataVersionAndKind(data []byte) (version, kind str
 	}
 	return obj.APIVersion, obj.Kind, nil
 }
+
+// MarshalJSON writes out the JSON compatible map backing the object, so that
+// an Unstructured object serializes exactly as it was read.
+func (obj *Unstructured) MarshalJSON() ([]byte, error) {
+	return json.Marshal(obj.Object)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package runtime_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -46,3 +47,18 @@ func TestDecodeUnstructured(t *testing.T) {
 		t.Errorf("object should not have been converted: %#v", pl.Items[2])
 	}
 }
+
+func TestEncodeUnstructured(t *testing.T) {
+	rawJson := `{"apiVersion":"company.com/v1","kind":"Foo","metadata":{"name":"test"},"someField":"value"}`
+	obj, err := runtime.UnstructuredJSONScheme.Decode([]byte(rawJson))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != rawJson {
+		t.Errorf("expected %s, got %s", rawJson, string(data))
+	}
+}
@@ -0,0 +1,533 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/golang/glog"
+)
+
+// cacheReadyTimeout is how long a watch waits for a Cacher to be populated.
+const cacheReadyTimeout = 3 * time.Second
+
+// CacherConfig contains the configuration of a Cacher.
+type CacherConfig struct {
+	// CacheCapacity is the number of the most recent events that are kept to
+	// serve watches starting at a past resource version.
+	CacheCapacity int
+
+	// Storage is the underlying storage the cached objects are read from.
+	Storage Interface
+
+	// KeyPrefix is the key the cached objects are stored under.
+	KeyPrefix string
+
+	// KeyFunc returns the key of an object.
+	KeyFunc func(obj runtime.Object) (string, error)
+
+	// NewListFunc returns an empty list of the cached objects.
+	NewListFunc func() runtime.Object
+
+	// Indexes are the secondary indexes kept of the cached objects, by name.
+	Indexes map[string]IndexFunc
+}
+
+// Cacher serves watches of the objects under a key from an in memory cache of
+// them, which is kept up to date by a single watch of the underlying storage.
+// All other operations are passed through to the underlying storage.
+//
+// Watches starting at a resource version older than the cached events are also
+// passed through. Watches are terminated when the cache has to be rebuilt, or
+// when a watcher falls too far behind, and clients should restart them.
+type Cacher struct {
+	Interface
+
+	capacity    int
+	keyPrefix   string
+	keyFunc     func(runtime.Object) (string, error)
+	newListFunc func() runtime.Object
+	indexFuncs  map[string]IndexFunc
+
+	// readyTimeout is how long a watch waits for the cache to be populated
+	// before it is passed through to the underlying storage.
+	readyTimeout time.Duration
+
+	lock sync.RWMutex
+	// ready is closed once the cache has been populated.
+	ready chan struct{}
+	// populated is true once the cache has been populated.
+	populated bool
+	// objects holds the cached objects by key.
+	objects map[string]runtime.Object
+	// indexes holds the keys of the cached objects by index name and value.
+	indexes map[string]map[string]util.StringSet
+	// events holds the most recent events, of which every event newer than
+	// oldestVersion is kept.
+	events        []*cacheEvent
+	oldestVersion uint64
+	// watchers holds the watchers not using an index, by id.
+	watchers map[int]*cacheWatcher
+	// indexedWatchers holds the watchers using an index, by index name, index
+	// value and id.
+	indexedWatchers map[string]map[string]map[int]*cacheWatcher
+	nextWatcherID   int
+
+	stopCh chan struct{}
+}
+
+// NewCacher returns a Cacher for the given configuration, and starts
+// populating it.
+func NewCacher(config CacherConfig) *Cacher {
+	c := &Cacher{
+		Interface:       config.Storage,
+		capacity:        config.CacheCapacity,
+		keyPrefix:       config.KeyPrefix,
+		keyFunc:         config.KeyFunc,
+		newListFunc:     config.NewListFunc,
+		indexFuncs:      config.Indexes,
+		watchers:        map[int]*cacheWatcher{},
+		indexedWatchers: map[string]map[string]map[int]*cacheWatcher{},
+		readyTimeout:    cacheReadyTimeout,
+		ready:           make(chan struct{}),
+		stopCh:          make(chan struct{}),
+	}
+	for name := range c.indexFuncs {
+		c.indexedWatchers[name] = map[string]map[int]*cacheWatcher{}
+	}
+	go util.Until(c.listAndWatch, time.Second, c.stopCh)
+	return c
+}
+
+// Stop stops keeping the cache up to date.
+func (c *Cacher) Stop() {
+	close(c.stopCh)
+}
+
+// Indexes implements IndexedWatcher.
+func (c *Cacher) Indexes() []string {
+	names := []string{}
+	for name := range c.indexFuncs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// WatchList implements Interface.
+func (c *Cacher) WatchList(key string, resourceVersion uint64, filter FilterFunc) (watch.Interface, error) {
+	return c.watch(key, resourceVersion, "", "", filter)
+}
+
+// WatchListIndexed implements IndexedWatcher.
+func (c *Cacher) WatchListIndexed(key string, resourceVersion uint64, index, value string, filter FilterFunc) (watch.Interface, error) {
+	if _, ok := c.indexFuncs[index]; !ok {
+		return nil, fmt.Errorf("no index named %q is kept", index)
+	}
+	return c.watch(key, resourceVersion, index, value, filter)
+}
+
+// watch starts a watch of the items under key, using the named index unless it
+// is empty. If the cache is not populated within readyTimeout, for example
+// because the underlying storage is unavailable, the watch is passed through to
+// the underlying storage rather than blocking until it is.
+func (c *Cacher) watch(key string, resourceVersion uint64, index, value string, filter FilterFunc) (watch.Interface, error) {
+	select {
+	case <-c.ready:
+	case <-time.After(c.readyTimeout):
+		glog.V(2).Infof("Cache of %s is not ready, passing the watch of %s through", c.keyPrefix, key)
+		return c.Interface.WatchList(key, resourceVersion, filter)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// resourceVersion is the first version that is sent, and every event newer
+	// than oldestVersion is known
+	if resourceVersion != 0 && resourceVersion <= c.oldestVersion {
+		return c.Interface.WatchList(key, resourceVersion, filter)
+	}
+
+	var initEvents []*cacheEvent
+	if resourceVersion == 0 {
+		// like etcd, start with the current state of the items
+		keys := c.indexes[index][value]
+		if len(index) == 0 {
+			keys = util.NewStringSet()
+			for key := range c.objects {
+				keys.Insert(key)
+			}
+		}
+		for _, key := range keys.List() {
+			initEvents = append(initEvents, &cacheEvent{Type: watch.Added, key: key, Object: c.objects[key]})
+		}
+	} else {
+		for _, event := range c.events {
+			if event.resourceVersion >= resourceVersion && (len(index) == 0 || event.hasIndexValue(index, value)) {
+				initEvents = append(initEvents, event)
+			}
+		}
+	}
+
+	w := &cacheWatcher{
+		input:           make(chan *cacheEvent, 100),
+		result:          make(chan watch.Event),
+		done:            make(chan struct{}),
+		prefix:          strings.TrimSuffix(key, "/") + "/",
+		resourceVersion: resourceVersion,
+		filter:          filter,
+	}
+	id := c.nextWatcherID
+	c.nextWatcherID++
+	if len(index) == 0 {
+		c.watchers[id] = w
+	} else {
+		watchers, ok := c.indexedWatchers[index][value]
+		if !ok {
+			watchers = map[int]*cacheWatcher{}
+			c.indexedWatchers[index][value] = watchers
+		}
+		watchers[id] = w
+	}
+	w.forget = func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.removeWatcher(id, index, value)
+	}
+	go w.process(initEvents)
+	return w, nil
+}
+
+// removeWatcher stops sending events to a watcher. Must be called with the lock held.
+func (c *Cacher) removeWatcher(id int, index, value string) {
+	var w *cacheWatcher
+	if len(index) == 0 {
+		w = c.watchers[id]
+		delete(c.watchers, id)
+	} else {
+		w = c.indexedWatchers[index][value][id]
+		delete(c.indexedWatchers[index][value], id)
+		if len(c.indexedWatchers[index][value]) == 0 {
+			delete(c.indexedWatchers[index], value)
+		}
+	}
+	if w != nil {
+		close(w.input)
+	}
+}
+
+// listAndWatch populates the cache from the underlying storage and keeps it up
+// to date until the watch of the underlying storage ends.
+func (c *Cacher) listAndWatch() {
+	list := c.newListFunc()
+	if err := c.Interface.List(c.keyPrefix, list); err != nil {
+		glog.Errorf("Unable to list %s: %v", c.keyPrefix, err)
+		return
+	}
+	listMeta, err := api.ListMetaFor(list)
+	if err != nil {
+		glog.Errorf("Unable to read the resource version of %s: %v", c.keyPrefix, err)
+		return
+	}
+	resourceVersion, err := strconv.ParseUint(listMeta.ResourceVersion, 10, 64)
+	if err != nil {
+		glog.Errorf("Unable to parse the resource version of %s: %v", c.keyPrefix, err)
+		return
+	}
+	items, err := runtime.ExtractList(list)
+	if err != nil {
+		glog.Errorf("Unable to extract the items of %s: %v", c.keyPrefix, err)
+		return
+	}
+	if err := c.replace(items, resourceVersion); err != nil {
+		glog.Errorf("Unable to cache %s: %v", c.keyPrefix, err)
+		return
+	}
+
+	w, err := c.Interface.WatchList(c.keyPrefix, resourceVersion+1, Everything)
+	if err != nil {
+		glog.Errorf("Unable to watch %s: %v", c.keyPrefix, err)
+		return
+	}
+	defer w.Stop()
+	for {
+		select {
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			if event.Type == watch.Error {
+				glog.V(2).Infof("Watch of %s ended: %v", c.keyPrefix, event.Object)
+				return
+			}
+			if err := c.processEvent(event); err != nil {
+				glog.Errorf("Unable to cache the %s event of %s: %v", event.Type, c.keyPrefix, err)
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// replace replaces the cached objects, and terminates all watchers since they
+// may have missed events.
+func (c *Cacher) replace(items []runtime.Object, resourceVersion uint64) error {
+	objects := map[string]runtime.Object{}
+	indexes := map[string]map[string]util.StringSet{}
+	for name := range c.indexFuncs {
+		indexes[name] = map[string]util.StringSet{}
+	}
+	for _, obj := range items {
+		key, err := c.keyFunc(obj)
+		if err != nil {
+			return err
+		}
+		objects[key] = obj
+		for name, indexFunc := range c.indexFuncs {
+			value := indexFunc(obj)
+			if _, ok := indexes[name][value]; !ok {
+				indexes[name][value] = util.NewStringSet()
+			}
+			indexes[name][value].Insert(key)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.objects = objects
+	c.indexes = indexes
+	c.events = nil
+	c.oldestVersion = resourceVersion
+	for id := range c.watchers {
+		c.removeWatcher(id, "", "")
+	}
+	for index, values := range c.indexedWatchers {
+		for value, watchers := range values {
+			for id := range watchers {
+				c.removeWatcher(id, index, value)
+			}
+		}
+	}
+	if !c.populated {
+		c.populated = true
+		close(c.ready)
+	}
+	return nil
+}
+
+// processEvent applies an event of the underlying storage to the cache, and
+// sends it to the watchers it may concern.
+func (c *Cacher) processEvent(event watch.Event) error {
+	resourceVersion, err := c.Interface.Versioner().ObjectResourceVersion(event.Object)
+	if err != nil {
+		return err
+	}
+	key, err := c.keyFunc(event.Object)
+	if err != nil {
+		return err
+	}
+	cached := &cacheEvent{
+		Type:            event.Type,
+		Object:          event.Object,
+		key:             key,
+		resourceVersion: resourceVersion,
+		indexValues:     map[string]string{},
+		prevIndexValues: map[string]string{},
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached.PrevObject = c.objects[key]
+	for name, indexFunc := range c.indexFuncs {
+		if cached.PrevObject != nil {
+			value := indexFunc(cached.PrevObject)
+			cached.prevIndexValues[name] = value
+			c.indexes[name][value].Delete(key)
+			if len(c.indexes[name][value]) == 0 {
+				delete(c.indexes[name], value)
+			}
+		}
+		value := indexFunc(event.Object)
+		cached.indexValues[name] = value
+		if event.Type != watch.Deleted {
+			if _, ok := c.indexes[name][value]; !ok {
+				c.indexes[name][value] = util.NewStringSet()
+			}
+			c.indexes[name][value].Insert(key)
+		}
+	}
+	if event.Type == watch.Deleted {
+		delete(c.objects, key)
+	} else {
+		c.objects[key] = event.Object
+	}
+
+	c.events = append(c.events, cached)
+	if len(c.events) > c.capacity {
+		c.oldestVersion = c.events[0].resourceVersion
+		c.events = c.events[1:]
+	}
+
+	for id, w := range c.watchers {
+		c.send(w, id, "", "", cached)
+	}
+	for name, values := range c.indexedWatchers {
+		for value, watchers := range values {
+			if !cached.hasIndexValue(name, value) {
+				continue
+			}
+			for id, w := range watchers {
+				c.send(w, id, name, value, cached)
+			}
+		}
+	}
+	return nil
+}
+
+// send sends an event to a watcher, and terminates the watcher if it has
+// fallen too far behind. Must be called with the lock held.
+func (c *Cacher) send(w *cacheWatcher, id int, index, value string, event *cacheEvent) {
+	select {
+	case w.input <- event:
+	default:
+		glog.V(2).Infof("Terminating a watch of %s that fell behind", c.keyPrefix)
+		c.removeWatcher(id, index, value)
+	}
+}
+
+// cacheEvent is an event of the underlying storage, with the object it replaced.
+type cacheEvent struct {
+	Type       watch.EventType
+	Object     runtime.Object
+	PrevObject runtime.Object
+
+	key             string
+	resourceVersion uint64
+	// indexValues and prevIndexValues hold the index values of Object and
+	// PrevObject, by index name.
+	indexValues     map[string]string
+	prevIndexValues map[string]string
+}
+
+// hasIndexValue returns true if the object of the event had the given index
+// value before or after the event.
+func (e *cacheEvent) hasIndexValue(index, value string) bool {
+	if e.indexValues[index] == value {
+		return true
+	}
+	prevValue, ok := e.prevIndexValues[index]
+	return ok && prevValue == value
+}
+
+// cacheWatcher implements watch.Interface for a watch served by a Cacher.
+type cacheWatcher struct {
+	input  chan *cacheEvent
+	result chan watch.Event
+	done   chan struct{}
+	stop   sync.Once
+	forget func()
+
+	prefix          string
+	resourceVersion uint64
+	filter          FilterFunc
+}
+
+// ResultChan implements watch.Interface.
+func (w *cacheWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *cacheWatcher) Stop() {
+	w.stop.Do(func() {
+		close(w.done)
+		w.forget()
+	})
+}
+
+func (w *cacheWatcher) process(initEvents []*cacheEvent) {
+	defer close(w.result)
+	for _, event := range initEvents {
+		if !w.sendEvent(event) {
+			return
+		}
+	}
+	for event := range w.input {
+		if event.resourceVersion < w.resourceVersion {
+			continue
+		}
+		if !w.sendEvent(event) {
+			return
+		}
+	}
+}
+
+// sendEvent sends the event to the client if it concerns the items that are
+// watched, and returns false if the watch has been stopped. Changes may make
+// an object start or stop passing the filter, which are sent as adds and
+// deletes, as the etcd watcher does.
+func (w *cacheWatcher) sendEvent(event *cacheEvent) bool {
+	if !strings.HasPrefix(event.key, w.prefix) {
+		return true
+	}
+	// the filter may decorate the objects, which are shared
+	var curObj, prevObj runtime.Object
+	curObjPasses, prevObjPasses := false, false
+	if obj, err := api.Scheme.Copy(event.Object); err == nil {
+		curObj = obj
+		curObjPasses = w.filter(curObj)
+	} else {
+		glog.Errorf("Unable to copy %#v: %v", event.Object, err)
+	}
+	if event.Type != watch.Deleted && event.PrevObject != nil {
+		if obj, err := api.Scheme.Copy(event.PrevObject); err == nil {
+			prevObj = obj
+			prevObjPasses = w.filter(prevObj)
+		} else {
+			glog.Errorf("Unable to copy %#v: %v", event.PrevObject, err)
+		}
+	}
+
+	var result watch.Event
+	switch {
+	case event.Type == watch.Deleted && curObjPasses:
+		result = watch.Event{Type: watch.Deleted, Object: curObj}
+	case event.Type == watch.Deleted:
+		return true
+	case curObjPasses && prevObjPasses:
+		result = watch.Event{Type: watch.Modified, Object: curObj}
+	case curObjPasses && !prevObjPasses:
+		result = watch.Event{Type: watch.Added, Object: curObj}
+	case !curObjPasses && prevObjPasses:
+		result = watch.Event{Type: watch.Deleted, Object: prevObj}
+	default:
+		return true
+	}
+	select {
+	case w.result <- result:
+		return true
+	case <-w.done:
+		return false
+	}
+}
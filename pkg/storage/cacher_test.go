@@ -0,0 +1,251 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+type testVersioner struct{}
+
+func (testVersioner) UpdateObject(obj runtime.Object, expiration *time.Time, resourceVersion uint64) error {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	objectMeta.ResourceVersion = strconv.FormatUint(resourceVersion, 10)
+	return nil
+}
+
+func (testVersioner) UpdateList(obj runtime.Object, resourceVersion uint64) error {
+	listMeta, err := api.ListMetaFor(obj)
+	if err != nil {
+		return err
+	}
+	listMeta.ResourceVersion = strconv.FormatUint(resourceVersion, 10)
+	return nil
+}
+
+func (testVersioner) ObjectResourceVersion(obj runtime.Object) (uint64, error) {
+	objectMeta, err := api.ObjectMetaFor(obj)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(objectMeta.ResourceVersion, 10, 64)
+}
+
+// fakeStorage serves a fixed list of pods, and records the watches started.
+type fakeStorage struct {
+	Interface
+
+	lock     sync.Mutex
+	list     *api.PodList
+	listErr  error
+	watches  []*watch.FakeWatcher
+	versions []uint64
+}
+
+func (f *fakeStorage) Versioner() Versioner {
+	return testVersioner{}
+}
+
+func (f *fakeStorage) List(key string, listObj runtime.Object) error {
+	if f.listErr != nil {
+		return f.listErr
+	}
+	*listObj.(*api.PodList) = *f.list
+	return nil
+}
+
+func (f *fakeStorage) WatchList(key string, resourceVersion uint64, filter FilterFunc) (watch.Interface, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	w := watch.NewFake()
+	f.watches = append(f.watches, w)
+	f.versions = append(f.versions, resourceVersion)
+	return w, nil
+}
+
+func (f *fakeStorage) watchCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.watches)
+}
+
+// waitForWatches waits until the given number of watches have been started.
+func (f *fakeStorage) waitForWatches(t *testing.T, count int) {
+	for i := 0; i < 100 && f.watchCount() < count; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if f.watchCount() != count {
+		t.Fatalf("expected %d watches of the storage, got %v", count, f.versions)
+	}
+}
+
+func makeTestPod(name, nodeName, resourceVersion string) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: "test", ResourceVersion: resourceVersion},
+		Spec:       api.PodSpec{NodeName: nodeName},
+	}
+}
+
+func newTestCacher(storage *fakeStorage) *Cacher {
+	return NewCacher(CacherConfig{
+		CacheCapacity: 10,
+		Storage:       storage,
+		KeyPrefix:     "/pods",
+		KeyFunc: func(obj runtime.Object) (string, error) {
+			pod := obj.(*api.Pod)
+			return "/pods/" + pod.Namespace + "/" + pod.Name, nil
+		},
+		NewListFunc: func() runtime.Object { return &api.PodList{} },
+		Indexes: map[string]IndexFunc{
+			"spec.nodeName": func(obj runtime.Object) string { return obj.(*api.Pod).Spec.NodeName },
+		},
+	})
+}
+
+func expectEvent(t *testing.T, w watch.Interface, eventType watch.EventType, name string) {
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatalf("unexpected end of watch")
+		}
+		if event.Type != eventType || event.Object.(*api.Pod).Name != name {
+			t.Errorf("expected %s of %s, got %s of %#v", eventType, name, event.Type, event.Object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s of %s", eventType, name)
+	}
+}
+
+func expectNoEvent(t *testing.T, w watch.Interface) {
+	select {
+	case event := <-w.ResultChan():
+		t.Errorf("unexpected event %#v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestCacherWatchListIndexed(t *testing.T) {
+	storage := &fakeStorage{list: &api.PodList{
+		ListMeta: api.ListMeta{ResourceVersion: "10"},
+		Items:    []api.Pod{*makeTestPod("foo", "a", "8"), *makeTestPod("bar", "b", "9")},
+	}}
+	cacher := newTestCacher(storage)
+	defer cacher.Stop()
+
+	w, err := cacher.WatchListIndexed("/pods/test", 0, "spec.nodeName", "a", Everything)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+	expectEvent(t, w, watch.Added, "foo")
+	expectNoEvent(t, w)
+
+	storage.waitForWatches(t, 1)
+	if storage.versions[0] != 11 {
+		t.Fatalf("expected the storage to be watched from 11, got %v", storage.versions)
+	}
+	// moving bar onto node a adds it, and moving foo off deletes it
+	storage.watches[0].Modify(makeTestPod("bar", "a", "11"))
+	expectEvent(t, w, watch.Modified, "bar")
+	storage.watches[0].Modify(makeTestPod("foo", "b", "12"))
+	expectEvent(t, w, watch.Modified, "foo")
+	storage.watches[0].Add(makeTestPod("baz", "c", "13"))
+	expectNoEvent(t, w)
+
+	// events newer than the list are replayed from the cache
+	replay, err := cacher.WatchListIndexed("/pods", 12, "spec.nodeName", "b", Everything)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer replay.Stop()
+	expectEvent(t, replay, watch.Modified, "foo")
+	expectNoEvent(t, replay)
+
+	if _, err := cacher.WatchListIndexed("/pods", 0, "metadata.name", "foo", Everything); err == nil {
+		t.Errorf("expected an error watching an index that is not kept")
+	}
+}
+
+func TestCacherWatchListFilter(t *testing.T) {
+	storage := &fakeStorage{list: &api.PodList{
+		ListMeta: api.ListMeta{ResourceVersion: "10"},
+		Items:    []api.Pod{*makeTestPod("foo", "a", "8")},
+	}}
+	cacher := newTestCacher(storage)
+	defer cacher.Stop()
+
+	onNodeA := func(obj runtime.Object) bool { return obj.(*api.Pod).Spec.NodeName == "a" }
+	w, err := cacher.WatchList("/pods", 0, onNodeA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+	expectEvent(t, w, watch.Added, "foo")
+	storage.waitForWatches(t, 1)
+
+	storage.watches[0].Add(makeTestPod("bar", "b", "11"))
+	storage.watches[0].Modify(makeTestPod("bar", "a", "12"))
+	expectEvent(t, w, watch.Added, "bar")
+	storage.watches[0].Modify(makeTestPod("foo", "b", "13"))
+	expectEvent(t, w, watch.Deleted, "foo")
+	storage.watches[0].Delete(makeTestPod("bar", "a", "14"))
+	expectEvent(t, w, watch.Deleted, "bar")
+}
+
+func TestCacherWatchListTooOld(t *testing.T) {
+	storage := &fakeStorage{list: &api.PodList{ListMeta: api.ListMeta{ResourceVersion: "10"}}}
+	cacher := newTestCacher(storage)
+	defer cacher.Stop()
+
+	w, err := cacher.WatchList("/pods", 5, Everything)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+	storage.waitForWatches(t, 2)
+	if storage.versions[0] != 5 && storage.versions[1] != 5 {
+		t.Errorf("expected the watch to be passed through, got %v", storage.versions)
+	}
+}
+
+func TestCacherWatchListNotReady(t *testing.T) {
+	storage := &fakeStorage{listErr: errors.New("unavailable")}
+	cacher := newTestCacher(storage)
+	cacher.readyTimeout = 10 * time.Millisecond
+	defer cacher.Stop()
+
+	w, err := cacher.WatchListIndexed("/pods", 7, "spec.nodeName", "node1", Everything)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+	storage.waitForWatches(t, 1)
+	if storage.versions[0] != 7 {
+		t.Errorf("expected the watch to be passed through, got %v", storage.versions)
+	}
+}
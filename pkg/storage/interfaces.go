@@ -58,6 +58,21 @@ type ResponseMeta struct {
 // iff the object should remain in the set.
 type FilterFunc func(obj runtime.Object) bool
 
+// IndexFunc returns the value of an index for an object.
+type IndexFunc func(obj runtime.Object) string
+
+// IndexedWatcher is implemented by storage that keeps secondary indexes of the
+// objects it watches, and serves watches of the objects with a given index
+// value without considering any other object.
+type IndexedWatcher interface {
+	// Indexes returns the names of the indexes that are kept.
+	Indexes() []string
+
+	// WatchListIndexed is like WatchList, but only sends down events for the
+	// items whose value of the named index is value, before or after the event.
+	WatchListIndexed(key string, resourceVersion uint64, index, value string, filter FilterFunc) (watch.Interface, error)
+}
+
 // Everything is a FilterFunc which accepts all objects.
 func Everything(runtime.Object) bool {
 	return true
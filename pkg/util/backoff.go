@@ -0,0 +1,123 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+type backoffEntry struct {
+	backoff    time.Duration
+	lastUpdate time.Time
+}
+
+// Backoff tracks an exponentially growing delay per item, e.g. per container,
+// between retries of an operation that keeps failing.
+type Backoff struct {
+	sync.Mutex
+	Clock           Clock
+	defaultDuration time.Duration
+	maxDuration     time.Duration
+	perItemBackoff  map[string]*backoffEntry
+}
+
+// NewBackOff returns a backoff whose delays start at initial and double on
+// every failure, up to max.
+func NewBackOff(initial, max time.Duration) *Backoff {
+	return &Backoff{
+		Clock:           RealClock{},
+		defaultDuration: initial,
+		maxDuration:     max,
+		perItemBackoff:  map[string]*backoffEntry{},
+	}
+}
+
+// Get returns the current delay of the item, zero if it is not backing off.
+func (p *Backoff) Get(id string) time.Duration {
+	p.Lock()
+	defer p.Unlock()
+	if entry, ok := p.perItemBackoff[id]; ok {
+		return entry.backoff
+	}
+	return 0
+}
+
+// Next records a failure of the item that happened at eventTime, doubling its
+// delay. The delay is reset if the item has not failed for long enough since
+// the last failure, i.e. it was stable in between.
+func (p *Backoff) Next(id string, eventTime time.Time) {
+	p.Lock()
+	defer p.Unlock()
+	entry, ok := p.perItemBackoff[id]
+	if !ok || hasExpired(eventTime, entry.lastUpdate, p.maxDuration) {
+		entry = &backoffEntry{backoff: p.defaultDuration}
+		p.perItemBackoff[id] = entry
+	} else {
+		entry.backoff *= 2
+		if entry.backoff > p.maxDuration {
+			entry.backoff = p.maxDuration
+		}
+	}
+	entry.lastUpdate = p.Clock.Now()
+}
+
+// IsInBackOffSince returns whether the delay of the item has not passed yet
+// since eventTime, e.g. the time the item last failed.
+func (p *Backoff) IsInBackOffSince(id string, eventTime time.Time) bool {
+	p.Lock()
+	defer p.Unlock()
+	entry, ok := p.perItemBackoff[id]
+	if !ok {
+		return false
+	}
+	if hasExpired(eventTime, entry.lastUpdate, p.maxDuration) {
+		return false
+	}
+	return p.Clock.Now().Sub(eventTime) < entry.backoff
+}
+
+// IsInBackOffSinceUpdate returns whether the delay of the item has not passed
+// yet since its last failure was recorded.
+func (p *Backoff) IsInBackOffSinceUpdate(id string) bool {
+	p.Lock()
+	defer p.Unlock()
+	entry, ok := p.perItemBackoff[id]
+	if !ok {
+		return false
+	}
+	return p.Clock.Now().Sub(entry.lastUpdate) < entry.backoff
+}
+
+// GC removes the entries of the items that have not failed for long enough
+// for their delay to be reset.
+func (p *Backoff) GC() {
+	p.Lock()
+	defer p.Unlock()
+	now := p.Clock.Now()
+	for id, entry := range p.perItemBackoff {
+		if hasExpired(now, entry.lastUpdate, p.maxDuration) {
+			delete(p.perItemBackoff, id)
+		}
+	}
+}
+
+// An item is considered stable, and its delay reset, once it has not failed
+// for twice the maximum delay.
+func hasExpired(eventTime, lastUpdate time.Time, maxDuration time.Duration) bool {
+	return eventTime.Sub(lastUpdate) > maxDuration*2
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlowBackoff(t *testing.T) {
+	id := "_idSlow"
+	tc := &FakeClock{Time: time.Now()}
+	step := time.Second
+	maxDuration := 50 * step
+
+	b := NewBackOff(step, maxDuration)
+	b.Clock = tc
+	cases := []time.Duration{0, 1, 2, 4, 8, 16, 32, 50, 50, 50}
+	for ix, c := range cases {
+		tc.Time = tc.Time.Add(step)
+		w := b.Get(id)
+		if w != c*step {
+			t.Errorf("input: '%d': expected %s, got %s", ix, c*step, w)
+		}
+		b.Next(id, tc.Now())
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	id := "_idReset"
+	tc := &FakeClock{Time: time.Now()}
+	step := time.Second
+	maxDuration := step * 5
+	b := NewBackOff(step, maxDuration)
+	b.Clock = tc
+	startTime := tc.Now()
+
+	// get to backoff = maxDuration
+	for i := 0; i <= int(maxDuration/step); i++ {
+		tc.Time = tc.Time.Add(step)
+		b.Next(id, tc.Now())
+	}
+
+	// backoff should be capped at maxDuration
+	if !b.IsInBackOffSince(id, tc.Now()) {
+		t.Errorf("expected to be in Backoff got %s", b.Get(id))
+	}
+
+	lastUpdate := tc.Now()
+	tc.Time = startTime.Add(2*maxDuration + step) // time += 11s, 11 > 2*maxDuration
+	if b.IsInBackOffSince(id, lastUpdate) {
+		t.Errorf("expected to not be in Backoff after reset (start=%s, now=%s, lastUpdate=%s), got %s", startTime, tc.Now(), lastUpdate, b.Get(id))
+	}
+
+	// A failure after a stable period starts over with the initial delay.
+	tc.Time = lastUpdate.Add(2*maxDuration + step)
+	b.Next(id, tc.Now())
+	if b.Get(id) != step {
+		t.Errorf("expected the backoff to be reset to %s, got %s", step, b.Get(id))
+	}
+}
+
+func TestBackoffSinceUpdate(t *testing.T) {
+	id := "_idSinceUpdate"
+	tc := &FakeClock{Time: time.Now()}
+	step := time.Second
+	b := NewBackOff(step, 10*step)
+	b.Clock = tc
+
+	if b.IsInBackOffSinceUpdate(id) {
+		t.Errorf("expected an unknown item not to be in backoff")
+	}
+	b.Next(id, tc.Now())
+	b.Next(id, tc.Now())
+	tc.Time = tc.Time.Add(step)
+	if !b.IsInBackOffSinceUpdate(id) {
+		t.Errorf("expected to be in backoff for %s after %s", b.Get(id), step)
+	}
+	tc.Time = tc.Time.Add(step)
+	if b.IsInBackOffSinceUpdate(id) {
+		t.Errorf("expected to be out of backoff for %s after %s", b.Get(id), 2*step)
+	}
+}
+
+func TestBackoffGC(t *testing.T) {
+	id := "_idGC"
+	tc := &FakeClock{Time: time.Now()}
+	step := time.Second
+	maxDuration := 5 * step
+
+	b := NewBackOff(step, maxDuration)
+	b.Clock = tc
+
+	for i := 0; i <= int(maxDuration/step); i++ {
+		tc.Time = tc.Time.Add(step)
+		b.Next(id, tc.Now())
+	}
+	lastUpdate := tc.Now()
+	tc.Time = tc.Time.Add(maxDuration + step)
+	b.GC()
+	if _, found := b.perItemBackoff[id]; !found {
+		t.Errorf("expected GC to skip entry, elapsed time=%s maxDuration=%s", tc.Now().Sub(lastUpdate), maxDuration)
+	}
+
+	tc.Time = tc.Time.Add(maxDuration + step)
+	b.GC()
+	if r, ok := b.perItemBackoff[id]; ok {
+		t.Errorf("expected GC of entry after %s got entry %v", tc.Now().Sub(lastUpdate), r)
+	}
+}
@@ -119,3 +119,127 @@ func ObjectGoPrintSideBySide(a, b interface{}) string {
 	w.Flush()
 	return buf.String()
 }
+
+// UnifiedDiff returns the differences between the lines of a and b in the
+// unified format of diff -u, with context unchanged lines around every change.
+// It returns an empty string if a and b are equal.
+func UnifiedDiff(aName, bName, a, b string, context int) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	// aPos[i] and bPos[i] are the numbers of lines of a and b before lines[i].
+	aPos, bPos := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, line := range lines {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if line.op != '+' {
+			aPos[i+1]++
+		}
+		if line.op != '-' {
+			bPos[i+1]++
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	for start := 0; start < len(lines); {
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+		// Changes closer than twice the context go into the same hunk.
+		end := start
+		for {
+			for end < len(lines) && lines[end].op != ' ' {
+				end++
+			}
+			next := end
+			for next < len(lines) && lines[next].op == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+			end = next
+		}
+		from, to := start-context, end+context
+		if from < 0 {
+			from = 0
+		}
+		if to > len(lines) {
+			to = len(lines)
+		}
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(aPos[from], aPos[to]-aPos[from]), hunkRange(bPos[from], bPos[to]-bPos[from]))
+		for _, line := range lines[from:to] {
+			fmt.Fprintf(buf, "%c%s\n", line.op, line.text)
+		}
+		start = to
+	}
+	if buf.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("--- %s\n+++ %s\n%s", aName, bName, buf.String())
+}
+
+type diffLine struct {
+	// op is ' ' for a line of both a and b, '-' for a line of a only and '+'
+	// for a line of b only.
+	op   byte
+	text string
+}
+
+// diffLines returns the lines of a and b, in order, marked as common to both
+// or only in one of them, keeping the common lines a longest common subsequence.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// hunkRange formats a range of count lines after the first start lines the way
+// diff -u does.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -59,6 +59,9 @@ func (j *JSONPath) Execute(wr io.Writer, data interface{}) error {
 
 	j.cur = []reflect.Value{reflect.ValueOf(data)}
 	nodes := j.parser.Root.Nodes
+	// range loops execute the remaining nodes recursively, restore them
+	// afterwards so the template can be executed again
+	defer func() { j.parser.Root.Nodes = nodes }()
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
 		results, err := j.walk(j.cur, node)
@@ -96,6 +99,30 @@ func (j *JSONPath) Execute(wr io.Writer, data interface{}) error {
 	return nil
 }
 
+// FindResults evaluates the template against data and returns the values
+// matched by each of its top level nodes, without writing them out. Range
+// blocks are not supported.
+func (j *JSONPath) FindResults(data interface{}) ([][]reflect.Value, error) {
+	if j.parser == nil {
+		return nil, fmt.Errorf("%s is an incomplete jsonpath template", j.name)
+	}
+
+	j.cur = []reflect.Value{reflect.ValueOf(data)}
+	fullResult := [][]reflect.Value{}
+	for _, node := range j.parser.Root.Nodes {
+		results, err := j.walk(j.cur, node)
+		if err != nil {
+			return nil, err
+		}
+		if j.beginRange > 0 {
+			j.beginRange = 0
+			return nil, fmt.Errorf("range is not supported when finding results of %s", j.name)
+		}
+		fullResult = append(fullResult, results)
+	}
+	return fullResult, nil
+}
+
 // PrintResults write the results into writer
 func (j *JSONPath) PrintResults(wr io.Writer, results []reflect.Value) error {
 	for i, r := range results {
@@ -224,6 +251,10 @@ func (j *JSONPath) evalArray(input []reflect.Value, node *ArrayNode) ([]reflect.
 			params[1].Value += value.Len()
 		}
 
+		if params[0].Value < 0 || params[1].Value > value.Len() || params[0].Value > params[1].Value {
+			return input, fmt.Errorf("array index out of bounds: index %d, length %d", params[0].Value, value.Len())
+		}
+
 		if !params[2].Known {
 			value = value.Slice(params[0].Value, params[1].Value)
 		} else {
@@ -131,6 +131,7 @@ func TestStructInput(t *testing.T) {
 		{"invalid array", "{.Labels[0]}", storeData, "<map[string]int Value> is not array or slice"},
 		{"invalid filter operator", "{.Book[?(@.Price<>10)]}", storeData, "unrecognized filter operator <>"},
 		{"redundent end", "{range .Labels.*}{@}{end}{end}", storeData, "not in range, nothing to end"},
+		{"array out of bounds", "{.Book[5]}", storeData, "array index out of bounds: index 5, length 3"},
 	}
 	testFailJSONPath(failStoreTests, t)
 }
@@ -212,3 +213,59 @@ func TestKubenates(t *testing.T) {
 	}
 	testJSONPath(nodesTests, t)
 }
+
+func TestFindResults(t *testing.T) {
+	data := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo"},
+		"items":    []interface{}{1, 2},
+	}
+	j := New("find")
+	if err := j.Parse("{.metadata.name}-{.items[*]}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := j.FindResults(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if len(results[0]) != 1 || results[0][0].Interface() != "foo" {
+		t.Errorf("unexpected first result: %v", results[0])
+	}
+	if len(results[1]) != 1 || results[1][0].Interface() != "-" {
+		t.Errorf("unexpected second result: %v", results[1])
+	}
+	if len(results[2]) != 2 || results[2][0].Interface() != 1 || results[2][1].Interface() != 2 {
+		t.Errorf("unexpected third result: %v", results[2])
+	}
+
+	if err := j.Parse("{range .items[*]}{@}{end}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := j.FindResults(data); err == nil {
+		t.Errorf("expected range to be rejected")
+	}
+}
+
+func TestExecuteRangeTwice(t *testing.T) {
+	j := New("twice")
+	if err := j.Parse("{range .items[*]}{.name},{end}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		buf := new(bytes.Buffer)
+		if err := j.Execute(buf, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != "a,b," {
+			t.Errorf("execution %d: unexpected output %q", i, buf.String())
+		}
+	}
+}
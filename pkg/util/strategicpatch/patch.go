@@ -252,6 +252,252 @@ func findMapInSliceBasedOnKeyValue(m []interface{}, key string, value interface{
 	return nil, 0, false
 }
 
+// CreateTwoWayMergePatch creates a strategic merge patch that turns the
+// original configuration of an object into the modified configuration.
+func CreateTwoWayMergePatch(original, modified []byte, dataStruct interface{}) ([]byte, error) {
+	var o, m map[string]interface{}
+	if err := json.Unmarshal(original, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &m); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(dataStruct)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("strategic merge patch needs a struct, %s received instead", t.Kind().String())
+	}
+
+	patch, err := diffMaps(o, m, t, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patch)
+}
+
+// CreateThreeWayMergePatch creates a strategic merge patch that, applied to the
+// current configuration of an object, produces the modified configuration
+// while preserving the changes made to the current configuration by others.
+// The patch sets the fields of modified which differ from current, and deletes
+// the fields of original that are absent from modified. The fields of current
+// which are absent from both original and modified are left untouched.
+func CreateThreeWayMergePatch(original, modified, current []byte, dataStruct interface{}) ([]byte, error) {
+	o, m, c := map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{}
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &o); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(modified, &m); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(current, &c); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(dataStruct)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("strategic merge patch needs a struct, %s received instead", t.Kind().String())
+	}
+
+	// the changes and additions from current to modified
+	delta, err := diffMaps(c, m, t, false, true)
+	if err != nil {
+		return nil, err
+	}
+	// the deletions from original to modified
+	deletions, err := diffMaps(o, m, t, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := mergePatches(deletions, delta, t)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patch)
+}
+
+// diffMaps returns a patch which turns original into modified, ignoring the
+// changes and additions or the deletions as asked.
+func diffMaps(original, modified map[string]interface{}, t reflect.Type, ignoreChangesAndAdditions, ignoreDeletions bool) (map[string]interface{}, error) {
+	patch := map[string]interface{}{}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for k, originalV := range original {
+		modifiedV, ok := modified[k]
+		if !ok {
+			if !ignoreDeletions {
+				patch[k] = nil
+			}
+			continue
+		}
+		if reflect.DeepEqual(originalV, modifiedV) {
+			continue
+		}
+		if reflect.TypeOf(originalV) != reflect.TypeOf(modifiedV) {
+			if !ignoreChangesAndAdditions {
+				patch[k] = modifiedV
+			}
+			continue
+		}
+
+		fieldType, fieldPatchStrategy, fieldPatchMergeKey, err := forkedjson.LookupPatchMetadata(t, k)
+		if err != nil {
+			return nil, err
+		}
+		switch typedOriginal := originalV.(type) {
+		case map[string]interface{}:
+			if fieldPatchStrategy != "replace" {
+				patchV, err := diffMaps(typedOriginal, modifiedV.(map[string]interface{}), fieldType, ignoreChangesAndAdditions, ignoreDeletions)
+				if err != nil {
+					return nil, err
+				}
+				if len(patchV) > 0 {
+					patch[k] = patchV
+				}
+				continue
+			}
+		case []interface{}:
+			if fieldPatchStrategy == "merge" && len(fieldPatchMergeKey) > 0 {
+				patchV, err := diffListsOfMaps(typedOriginal, modifiedV.([]interface{}), fieldType.Elem(), fieldPatchMergeKey, ignoreChangesAndAdditions, ignoreDeletions)
+				if err != nil {
+					return nil, err
+				}
+				if len(patchV) > 0 {
+					patch[k] = patchV
+				}
+				continue
+			}
+		}
+		if !ignoreChangesAndAdditions {
+			patch[k] = modifiedV
+		}
+	}
+	if !ignoreChangesAndAdditions {
+		for k, modifiedV := range modified {
+			if _, ok := original[k]; !ok {
+				patch[k] = modifiedV
+			}
+		}
+	}
+	return patch, nil
+}
+
+// diffListsOfMaps returns the elements of a patch of a list merged by mergeKey
+// which turns original into modified. Elements absent from modified are
+// deleted with a "$patch: delete" directive.
+func diffListsOfMaps(original, modified []interface{}, elemType reflect.Type, mergeKey string, ignoreChangesAndAdditions, ignoreDeletions bool) ([]interface{}, error) {
+	patch := []interface{}{}
+	for _, v := range append(append([]interface{}{}, original...), modified...) {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("cannot diff lists of %s merged by key %s", reflect.TypeOf(v), mergeKey)
+		}
+	}
+	for _, v := range modified {
+		modifiedMap := v.(map[string]interface{})
+		mergeValue, ok := modifiedMap[mergeKey]
+		if !ok {
+			return nil, fmt.Errorf("all list elements need the merge key %s", mergeKey)
+		}
+		originalMap, _, found := findMapInSliceBasedOnKeyValue(original, mergeKey, mergeValue)
+		if !found {
+			if !ignoreChangesAndAdditions {
+				patch = append(patch, modifiedMap)
+			}
+			continue
+		}
+		patchMap, err := diffMaps(originalMap, modifiedMap, elemType, ignoreChangesAndAdditions, ignoreDeletions)
+		if err != nil {
+			return nil, err
+		}
+		if len(patchMap) > 0 {
+			patchMap[mergeKey] = mergeValue
+			patch = append(patch, patchMap)
+		}
+	}
+	if !ignoreDeletions {
+		for _, v := range original {
+			mergeValue, ok := v.(map[string]interface{})[mergeKey]
+			if !ok {
+				continue
+			}
+			if _, _, found := findMapInSliceBasedOnKeyValue(modified, mergeKey, mergeValue); !found {
+				patch = append(patch, map[string]interface{}{specialKey: "delete", mergeKey: mergeValue})
+			}
+		}
+	}
+	return patch, nil
+}
+
+// mergePatches merges two patches of the same type, which do not set the same
+// fields other than the maps and lists they both patch.
+func mergePatches(patch, other map[string]interface{}, t reflect.Type) (map[string]interface{}, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for k, otherV := range other {
+		patchV, ok := patch[k]
+		if !ok {
+			patch[k] = otherV
+			continue
+		}
+		fieldType, _, fieldPatchMergeKey, err := forkedjson.LookupPatchMetadata(t, k)
+		if err != nil {
+			return nil, err
+		}
+		switch typedOther := otherV.(type) {
+		case map[string]interface{}:
+			if typedPatch, ok := patchV.(map[string]interface{}); ok {
+				if patch[k], err = mergePatches(typedPatch, typedOther, fieldType); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		case []interface{}:
+			if typedPatch, ok := patchV.([]interface{}); ok {
+				if patch[k], err = mergePatchLists(typedPatch, typedOther, fieldType.Elem(), fieldPatchMergeKey); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		patch[k] = otherV
+	}
+	return patch, nil
+}
+
+// mergePatchLists merges the elements of two patches of the same list merged
+// by mergeKey. The elements which patch the same element of the list are
+// merged, unless either is a directive.
+func mergePatchLists(patch, other []interface{}, elemType reflect.Type, mergeKey string) ([]interface{}, error) {
+	for _, otherV := range other {
+		typedOther, ok := otherV.(map[string]interface{})
+		if !ok || len(mergeKey) == 0 || typedOther[specialKey] != nil {
+			patch = append(patch, otherV)
+			continue
+		}
+		typedPatch, i, found := findMapInSliceBasedOnKeyValue(patch, mergeKey, typedOther[mergeKey])
+		if !found || typedPatch[specialKey] != nil {
+			patch = append(patch, otherV)
+			continue
+		}
+		merged, err := mergePatches(typedPatch, typedOther, elemType)
+		if err != nil {
+			return nil, err
+		}
+		patch[i] = merged
+	}
+	return patch, nil
+}
+
 // This function takes a JSON map and sorts all the lists that should be merged
 // by key. This is needed by tests because in JSON, list order is significant,
 // but in Strategic Merge Patch, merge lists do not have significant order.
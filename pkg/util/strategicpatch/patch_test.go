@@ -29,6 +29,7 @@ import (
 type TestCases struct {
 	StrategicMergePatchCases []StrategicMergePatchCase
 	SortMergeListTestCases   []SortMergeListCase
+	ThreeWayMergePatchCases  []ThreeWayMergePatchCase
 }
 
 type StrategicMergePatchCase struct {
@@ -44,6 +45,14 @@ type SortMergeListCase struct {
 	Sorted      map[string]interface{}
 }
 
+type ThreeWayMergePatchCase struct {
+	Description string
+	Original    map[string]interface{}
+	Modified    map[string]interface{}
+	Current     map[string]interface{}
+	Result      map[string]interface{}
+}
+
 type MergeItem struct {
 	Name              string
 	Value             string
@@ -478,6 +487,200 @@ func TestSortMergeLists(t *testing.T) {
 	}
 }
 
+var threeWayTestCaseData = []byte(`
+threeWayMergePatchCases:
+  - description: create with no original
+    modified:
+      name: 1
+      value: 1
+    current:
+      name: 1
+      nonMergingIntList: [1]
+    result:
+      name: 1
+      value: 1
+      nonMergingIntList: [1]
+  - description: delete a field removed from the configuration
+    original:
+      name: 1
+      value: 1
+    modified:
+      name: 1
+    current:
+      name: 1
+      value: 1
+      nonMergingIntList: [1]
+    result:
+      name: 1
+      nonMergingIntList: [1]
+  - description: change a field changed by others
+    original:
+      name: 1
+      value: 1
+    modified:
+      name: 1
+      value: 2
+    current:
+      name: 1
+      value: 3
+    result:
+      name: 1
+      value: 2
+  - description: keep a field changed by others but not in the configuration
+    original:
+      name: 1
+    modified:
+      name: 1
+    current:
+      name: 1
+      value: 3
+    result:
+      name: 1
+      value: 3
+  - description: delete a map entry and keep the entries set by others
+    original:
+      simpleMap:
+        a: "1"
+        b: "2"
+    modified:
+      simpleMap:
+        a: "1"
+    current:
+      simpleMap:
+        a: "1"
+        b: "2"
+        c: "3"
+    result:
+      simpleMap:
+        a: "1"
+        c: "3"
+  - description: merge lists by key
+    original:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 2
+          value: b
+    modified:
+      mergingList:
+        - name: 1
+        - name: 3
+          value: c
+    current:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 2
+          value: b
+        - name: 4
+          value: d
+    result:
+      mergingList:
+        - name: 1
+        - name: 3
+          value: c
+        - name: 4
+          value: d
+  - description: replace lists which are not merged
+    original:
+      nonMergingList:
+        - name: 1
+        - name: 2
+    modified:
+      nonMergingList:
+        - name: 1
+    current:
+      nonMergingList:
+        - name: 1
+        - name: 2
+        - name: 3
+    result:
+      nonMergingList:
+        - name: 1
+`)
+
+func TestThreeWayMergePatch(t *testing.T) {
+	tc := TestCases{}
+	err := yaml.Unmarshal(threeWayTestCaseData, &tc)
+	if err != nil {
+		t.Errorf("can't unmarshal test cases: %v", err)
+		return
+	}
+
+	var e MergeItem
+	for _, c := range tc.ThreeWayMergePatchCases {
+		var original []byte
+		if c.Original != nil {
+			original = toJSON(c.Original)
+		}
+		patch, err := CreateThreeWayMergePatch(original, toJSON(c.Modified), toJSON(c.Current), e)
+		if err != nil {
+			t.Errorf("%s: error creating patch: %v", c.Description, err)
+			continue
+		}
+		result, err := StrategicMergePatchData(toJSON(c.Current), patch, e)
+		if err != nil {
+			t.Errorf("%s: error applying patch %s: %v", c.Description, patch, err)
+			continue
+		}
+
+		result, err = sortMergeListsByName(result, e)
+		if err != nil {
+			t.Errorf("error sorting result object: %v", err)
+		}
+		cResult, err := sortMergeListsByName(toJSON(c.Result), e)
+		if err != nil {
+			t.Errorf("error sorting result object: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, cResult) {
+			t.Errorf("three-way patch failed: %s\npatch:\n%s\nexpected result:\n%s\ngot result:\n%s",
+				c.Description, jsonToYAML(patch), jsonToYAML(cResult), jsonToYAML(result))
+		}
+	}
+}
+
+func TestTwoWayMergePatch(t *testing.T) {
+	tc := TestCases{}
+	err := yaml.Unmarshal(threeWayTestCaseData, &tc)
+	if err != nil {
+		t.Errorf("can't unmarshal test cases: %v", err)
+		return
+	}
+
+	// a two-way patch applied to the original produces the modified object
+	var e MergeItem
+	for _, c := range tc.ThreeWayMergePatchCases {
+		if c.Original == nil {
+			continue
+		}
+		patch, err := CreateTwoWayMergePatch(toJSON(c.Original), toJSON(c.Modified), e)
+		if err != nil {
+			t.Errorf("%s: error creating patch: %v", c.Description, err)
+			continue
+		}
+		result, err := StrategicMergePatchData(toJSON(c.Original), patch, e)
+		if err != nil {
+			t.Errorf("%s: error applying patch %s: %v", c.Description, patch, err)
+			continue
+		}
+
+		result, err = sortMergeListsByName(result, e)
+		if err != nil {
+			t.Errorf("error sorting result object: %v", err)
+		}
+		cModified, err := sortMergeListsByName(toJSON(c.Modified), e)
+		if err != nil {
+			t.Errorf("error sorting result object: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, cModified) {
+			t.Errorf("two-way patch failed: %s\npatch:\n%s\nexpected result:\n%s\ngot result:\n%s",
+				c.Description, jsonToYAML(patch), jsonToYAML(cModified), jsonToYAML(result))
+		}
+	}
+}
+
 func toYAML(v interface{}) string {
 	y, err := yaml.Marshal(v)
 	if err != nil {
@@ -0,0 +1,304 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downwardapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fieldpath"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/mount"
+	"k8s.io/kubernetes/pkg/volume"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+)
+
+// ProbeVolumePlugins is the entry point for plugin detection in a package.
+func ProbeVolumePlugins() []volume.VolumePlugin {
+	return []volume.VolumePlugin{&downwardAPIPlugin{}}
+}
+
+const (
+	downwardAPIPluginName = "kubernetes.io/downward-api"
+
+	// dataDirName is the name of the symlink, in the volume, to the hidden
+	// directory holding the current data of the volume.
+	dataDirName = "..downwardapi"
+	// newDataDirName is the name of the symlink that is renamed over
+	// dataDirName to swap in a directory holding new data.
+	newDataDirName = "..downwardapi_tmp"
+)
+
+// downwardAPIPlugin implements the VolumePlugin interface.
+type downwardAPIPlugin struct {
+	host volume.VolumeHost
+}
+
+var _ volume.VolumePlugin = &downwardAPIPlugin{}
+
+func (plugin *downwardAPIPlugin) Init(host volume.VolumeHost) {
+	plugin.host = host
+}
+
+func (plugin *downwardAPIPlugin) Name() string {
+	return downwardAPIPluginName
+}
+
+func (plugin *downwardAPIPlugin) CanSupport(spec *volume.Spec) bool {
+	return spec.VolumeSource.DownwardAPI != nil
+}
+
+func (plugin *downwardAPIPlugin) NewBuilder(spec *volume.Spec, pod *api.Pod, opts volume.VolumeOptions, mounter mount.Interface) (volume.Builder, error) {
+	return &downwardAPIVolumeBuilder{
+		downwardAPIVolume: &downwardAPIVolume{spec.Name, pod.UID, plugin, mounter},
+		items:             spec.VolumeSource.DownwardAPI.Items,
+		pod:               *pod,
+		opts:              &opts}, nil
+}
+
+func (plugin *downwardAPIPlugin) NewCleaner(volName string, podUID types.UID, mounter mount.Interface) (volume.Cleaner, error) {
+	return &downwardAPIVolumeCleaner{&downwardAPIVolume{volName, podUID, plugin, mounter}}, nil
+}
+
+type downwardAPIVolume struct {
+	volName string
+	podUID  types.UID
+	plugin  *downwardAPIPlugin
+	mounter mount.Interface
+}
+
+var _ volume.Volume = &downwardAPIVolume{}
+
+func (dv *downwardAPIVolume) GetPath() string {
+	return dv.plugin.host.GetPodVolumeDir(dv.podUID, util.EscapeQualifiedNameForDisk(downwardAPIPluginName), dv.volName)
+}
+
+func (dv *downwardAPIVolume) IsReadOnly() bool {
+	return false
+}
+
+// downwardAPIVolumeBuilder handles extracting information about the pod
+// and placing it into the volume on the host.
+type downwardAPIVolumeBuilder struct {
+	*downwardAPIVolume
+
+	items []api.DownwardAPIVolumeFile
+	pod   api.Pod
+	opts  *volume.VolumeOptions
+}
+
+var _ volume.Builder = &downwardAPIVolumeBuilder{}
+
+func (b *downwardAPIVolumeBuilder) SetUp() error {
+	return b.SetUpAt(b.GetPath())
+}
+
+// This is the spec for the volume that this plugin wraps.
+var wrappedVolumeSpec = &volume.Spec{
+	Name:         "not-used",
+	VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{Medium: api.StorageMediumMemory}},
+}
+
+func (b *downwardAPIVolumeBuilder) getMetaDir() string {
+	return path.Join(b.plugin.host.GetPodPluginDir(b.podUID, util.EscapeQualifiedNameForDisk(downwardAPIPluginName)), b.volName)
+}
+
+// SetUpAt sets up the volume at dir, and writes the information about the
+// pod into it. The kubelet sets up the volumes of a pod on every sync, so
+// the files are updated when the labels or annotations of the pod change.
+func (b *downwardAPIVolumeBuilder) SetUpAt(dir string) error {
+	isMnt, err := b.mounter.IsMountPoint(dir)
+	// Getting an os.IsNotExist err from is a contingency; the directory
+	// may not exist yet, in which case, setup should run.
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Unless the plugin readiness file is present for this volume and the
+	// setup dir is a mountpoint, the wrapped volume must be set up first.
+	if !volumeutil.IsReady(b.getMetaDir()) || !isMnt {
+		glog.V(3).Infof("Setting up volume %v for pod %v at %v", b.volName, b.pod.UID, dir)
+
+		// Wrap EmptyDir, let it do the setup.
+		wrapped, err := b.plugin.host.NewWrapperBuilder(wrappedVolumeSpec, &b.pod, *b.opts, b.mounter)
+		if err != nil {
+			return err
+		}
+		if err := wrapped.SetUpAt(dir); err != nil {
+			return err
+		}
+	}
+
+	data, err := b.collectData()
+	if err != nil {
+		return err
+	}
+	if err := writeData(dir, data); err != nil {
+		glog.Errorf("Error writing downward API data for volume %v of pod %v to %v: %v", b.volName, b.pod.UID, dir, err)
+		return err
+	}
+
+	volumeutil.SetReady(b.getMetaDir())
+
+	return nil
+}
+
+// collectData returns the value of each item of the volume, keyed by the
+// cleaned path of the item.
+func (b *downwardAPIVolumeBuilder) collectData() (map[string]string, error) {
+	data := make(map[string]string)
+	for _, item := range b.items {
+		var value string
+		var err error
+		switch {
+		case item.FieldRef != nil:
+			var internalFieldPath string
+			internalFieldPath, _, err = api.Scheme.ConvertFieldLabel(item.FieldRef.APIVersion, "Pod", item.FieldRef.FieldPath, "")
+			if err == nil {
+				value, err = fieldpath.ExtractFieldPathAsString(&b.pod, internalFieldPath)
+			}
+		case item.ResourceFieldRef != nil:
+			value, err = fieldpath.ExtractResourceValueByContainerName(&b.pod, item.ResourceFieldRef.ContainerName, item.ResourceFieldRef.Resource)
+		}
+		if err != nil {
+			glog.Errorf("Unable to extract %v for volume %v of pod %v: %v", item.Path, b.volName, b.pod.UID, err)
+			return nil, err
+		}
+		data[path.Clean(item.Path)] = value
+	}
+	return data, nil
+}
+
+// writeData makes the files of the volume at dir present data, which is
+// keyed by the relative paths of the files. The top-level entries of the
+// paths are symlinks through the dataDirName symlink into a hidden,
+// timestamped directory holding the data. New data is written to a new
+// directory, which is swapped in by renaming a symlink to it over
+// dataDirName, so readers always see either the old or the new data. Nothing
+// is written if the data has not changed.
+func writeData(dir string, data map[string]string) error {
+	dataDirPath := path.Join(dir, dataDirName)
+	oldTsDir, err := os.Readlink(dataDirPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if oldTsDir != "" && !dataChanged(path.Join(dir, oldTsDir), data) {
+		return nil
+	}
+
+	tsDir, err := ioutil.TempDir(dir, time.Now().Format("..2006_01_02_15_04_05."))
+	if err != nil {
+		return err
+	}
+	if err := writeDataDir(tsDir, data); err != nil {
+		os.RemoveAll(tsDir)
+		return err
+	}
+
+	// Remove a symlink left behind by an interrupted swap before making it.
+	newDataDirPath := path.Join(dir, newDataDirName)
+	if err := os.Remove(newDataDirPath); err != nil && !os.IsNotExist(err) {
+		os.RemoveAll(tsDir)
+		return err
+	}
+	if err := os.Symlink(path.Base(tsDir), newDataDirPath); err != nil {
+		os.RemoveAll(tsDir)
+		return err
+	}
+	if err := os.Rename(newDataDirPath, dataDirPath); err != nil {
+		os.Remove(newDataDirPath)
+		os.RemoveAll(tsDir)
+		return err
+	}
+
+	for relPath := range data {
+		top := strings.SplitN(relPath, "/", 2)[0]
+		linkPath := path.Join(dir, top)
+		if _, err := os.Lstat(linkPath); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Symlink(path.Join(dataDirName, top), linkPath); err != nil {
+			return err
+		}
+	}
+
+	if oldTsDir != "" {
+		if err := os.RemoveAll(path.Join(dir, oldTsDir)); err != nil {
+			glog.Errorf("Unable to remove old downward API data directory %v: %v", path.Join(dir, oldTsDir), err)
+		}
+	}
+	return nil
+}
+
+// writeDataDir writes data into the new directory dir.
+func writeDataDir(dir string, data map[string]string) error {
+	// ioutil.TempDir makes directories only accessible to their owner.
+	if err := os.Chmod(dir, 0755); err != nil {
+		return err
+	}
+	for relPath, value := range data {
+		filePath := path.Join(dir, relPath)
+		if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filePath, []byte(value), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataChanged returns true if the files in dataDir do not hold data.
+func dataChanged(dataDir string, data map[string]string) bool {
+	for relPath, value := range data {
+		current, err := ioutil.ReadFile(path.Join(dataDir, relPath))
+		if err != nil || string(current) != value {
+			return true
+		}
+	}
+	return false
+}
+
+// downwardAPIVolumeCleaner handles cleaning up downward API volumes.
+type downwardAPIVolumeCleaner struct {
+	*downwardAPIVolume
+}
+
+var _ volume.Cleaner = &downwardAPIVolumeCleaner{}
+
+func (c *downwardAPIVolumeCleaner) TearDown() error {
+	return c.TearDownAt(c.GetPath())
+}
+
+func (c *downwardAPIVolumeCleaner) TearDownAt(dir string) error {
+	glog.V(3).Infof("Tearing down volume %v for pod %v at %v", c.volName, c.podUID, dir)
+
+	// Wrap EmptyDir, let it do the teardown.
+	wrapped, err := c.plugin.host.NewWrapperCleaner(wrappedVolumeSpec, c.podUID, c.mounter)
+	if err != nil {
+		return err
+	}
+	return wrapped.TearDownAt(dir)
+}
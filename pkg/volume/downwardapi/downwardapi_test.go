@@ -0,0 +1,227 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downwardapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	_ "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util/mount"
+	"k8s.io/kubernetes/pkg/volume"
+	"k8s.io/kubernetes/pkg/volume/empty_dir"
+)
+
+func newTestHost(t *testing.T) (string, volume.VolumeHost) {
+	tempDir, err := ioutil.TempDir("/tmp", "downwardapi_volume_test.")
+	if err != nil {
+		t.Fatalf("can't make a temp rootdir: %v", err)
+	}
+
+	return tempDir, volume.NewFakeVolumeHost(tempDir, nil, empty_dir.ProbeVolumePlugins())
+}
+
+func newTestPlugin(t *testing.T) (string, volume.VolumePlugin) {
+	pluginMgr := volume.VolumePluginMgr{}
+	rootDir, host := newTestHost(t)
+	pluginMgr.InitPlugins(ProbeVolumePlugins(), host)
+
+	plugin, err := pluginMgr.FindPluginByName(downwardAPIPluginName)
+	if err != nil {
+		t.Fatalf("Can't find the plugin by name")
+	}
+	return rootDir, plugin
+}
+
+func TestCanSupport(t *testing.T) {
+	rootDir, plugin := newTestPlugin(t)
+	defer os.RemoveAll(rootDir)
+
+	if plugin.Name() != downwardAPIPluginName {
+		t.Errorf("Wrong name: %s", plugin.Name())
+	}
+	if !plugin.CanSupport(&volume.Spec{Name: "foo", VolumeSource: api.VolumeSource{DownwardAPI: &api.DownwardAPIVolumeSource{}}}) {
+		t.Errorf("Expected true")
+	}
+	if plugin.CanSupport(&volume.Spec{Name: "foo", VolumeSource: api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}}) {
+		t.Errorf("Expected false")
+	}
+}
+
+func volumeSpec(volumeName string, items ...api.DownwardAPIVolumeFile) *volume.Spec {
+	return volume.NewSpecFromVolume(&api.Volume{
+		Name:         volumeName,
+		VolumeSource: api.VolumeSource{DownwardAPI: &api.DownwardAPIVolumeSource{Items: items}},
+	})
+}
+
+func fieldItem(path, fieldPath string) api.DownwardAPIVolumeFile {
+	return api.DownwardAPIVolumeFile{Path: path, FieldRef: &api.ObjectFieldSelector{APIVersion: "v1", FieldPath: fieldPath}}
+}
+
+func setUp(t *testing.T, plugin volume.VolumePlugin, spec *volume.Spec, pod *api.Pod, mounter mount.Interface) string {
+	builder, err := plugin.NewBuilder(spec, pod, volume.VolumeOptions{}, mounter)
+	if err != nil {
+		t.Fatalf("Failed to make a new Builder: %v", err)
+	}
+	if builder == nil {
+		t.Fatalf("Got a nil Builder")
+	}
+	if err := builder.SetUp(); err != nil {
+		t.Fatalf("Failed to setup volume: %v", err)
+	}
+	return builder.GetPath()
+}
+
+func verifyFiles(t *testing.T, volumePath string, expected map[string]string) {
+	for file, value := range expected {
+		data, err := ioutil.ReadFile(path.Join(volumePath, file))
+		if err != nil {
+			t.Errorf("Couldn't read %v: %v", file, err)
+			continue
+		}
+		if string(data) != value {
+			t.Errorf("Unexpected value of %v; expected %q, got %q", file, value, string(data))
+		}
+	}
+}
+
+func dataDirs(t *testing.T, volumePath string) []string {
+	entries, err := ioutil.ReadDir(volumePath)
+	if err != nil {
+		t.Fatalf("Couldn't read %v: %v", volumePath, err)
+	}
+	dirs := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "..") {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs
+}
+
+func TestLabelsAndAnnotations(t *testing.T) {
+	var (
+		testPodUID     = types.UID("test_pod_uid")
+		testVolumeName = "test_volume_name"
+
+		spec = volumeSpec(testVolumeName,
+			fieldItem("labels", "metadata.labels"),
+			fieldItem("meta/annotations", "metadata.annotations"))
+		mounter = &mount.FakeMounter{}
+	)
+	rootDir, plugin := newTestPlugin(t)
+	defer os.RemoveAll(rootDir)
+
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:         testPodUID,
+			Labels:      map[string]string{"key1": "value1", "key2": "value2"},
+			Annotations: map[string]string{"a": "1"},
+		},
+	}
+	volumePath := setUp(t, plugin, spec, pod, mounter)
+	if !strings.HasSuffix(volumePath, "pods/test_pod_uid/volumes/kubernetes.io~downward-api/test_volume_name") {
+		t.Errorf("Got unexpected path: %s", volumePath)
+	}
+	verifyFiles(t, volumePath, map[string]string{
+		"labels":           "key1=\"value1\"\nkey2=\"value2\"",
+		"meta/annotations": "a=\"1\"",
+	})
+	initialDirs := dataDirs(t, volumePath)
+	if len(initialDirs) != 1 {
+		t.Fatalf("Expected one data directory, got %v", initialDirs)
+	}
+
+	// Setting up the volume again for an unchanged pod must not write.
+	setUp(t, plugin, spec, pod, mounter)
+	if dirs := dataDirs(t, volumePath); len(dirs) != 1 || dirs[0] != initialDirs[0] {
+		t.Errorf("Expected data directory %v to be kept, got %v", initialDirs[0], dirs)
+	}
+
+	// A change of the labels is swapped in, and the old data is removed.
+	pod.Labels = map[string]string{"key1": "value3"}
+	setUp(t, plugin, spec, pod, mounter)
+	verifyFiles(t, volumePath, map[string]string{
+		"labels":           "key1=\"value3\"",
+		"meta/annotations": "a=\"1\"",
+	})
+	if dirs := dataDirs(t, volumePath); len(dirs) != 1 || dirs[0] == initialDirs[0] {
+		t.Errorf("Expected data directory %v to be replaced, got %v", initialDirs[0], dirs)
+	}
+	if _, err := os.Lstat(path.Join(volumePath, newDataDirName)); !os.IsNotExist(err) {
+		t.Errorf("Expected %v to be renamed, got %v", newDataDirName, err)
+	}
+}
+
+func TestNameNamespaceAndResources(t *testing.T) {
+	var (
+		testPodUID     = types.UID("test_pod_uid2")
+		testVolumeName = "test_volume_name"
+
+		spec = volumeSpec(testVolumeName,
+			fieldItem("name", "metadata.name"),
+			fieldItem("namespace", "metadata.namespace"),
+			api.DownwardAPIVolumeFile{Path: "cpu_limit", ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "ctr", Resource: "limits.cpu"}},
+			api.DownwardAPIVolumeFile{Path: "memory_request", ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "ctr", Resource: "requests.memory"}})
+		mounter = &mount.FakeMounter{}
+	)
+	rootDir, plugin := newTestPlugin(t)
+	defer os.RemoveAll(rootDir)
+
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{UID: testPodUID, Name: "test-name", Namespace: "test-namespace"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{
+				Name: "ctr",
+				Resources: api.ResourceRequirements{
+					Limits:   api.ResourceList{api.ResourceCPU: resource.MustParse("500m")},
+					Requests: api.ResourceList{api.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			}},
+		},
+	}
+	volumePath := setUp(t, plugin, spec, pod, mounter)
+	verifyFiles(t, volumePath, map[string]string{
+		"name":           "test-name",
+		"namespace":      "test-namespace",
+		"cpu_limit":      "500m",
+		"memory_request": "64Mi",
+	})
+}
+
+func TestUnknownContainer(t *testing.T) {
+	rootDir, plugin := newTestPlugin(t)
+	defer os.RemoveAll(rootDir)
+
+	spec := volumeSpec("test_volume_name",
+		api.DownwardAPIVolumeFile{Path: "cpu_limit", ResourceFieldRef: &api.ResourceFieldSelector{ContainerName: "missing", Resource: "limits.cpu"}})
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{UID: types.UID("test_pod_uid3")}}
+	builder, err := plugin.NewBuilder(spec, pod, volume.VolumeOptions{}, &mount.FakeMounter{})
+	if err != nil {
+		t.Fatalf("Failed to make a new Builder: %v", err)
+	}
+	if err := builder.SetUp(); err == nil {
+		t.Errorf("Expected an error setting up a volume for an unknown container")
+	}
+}
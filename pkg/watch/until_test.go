@@ -0,0 +1,98 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+func TestUntil(t *testing.T) {
+	fw := NewFake()
+	go func() {
+		fw.Add(testType("foo"))
+		fw.Modify(testType("bar"))
+	}()
+	conditions := []ConditionFunc{
+		func(event Event) (bool, error) { return event.Type == Added, nil },
+		func(event Event) (bool, error) { return event.Type == Modified, nil },
+	}
+
+	lastEvent, err := Until(time.Minute, fw, conditions...)
+	if err != nil {
+		t.Fatalf("expected nil error, got %#v", err)
+	}
+	if lastEvent == nil || lastEvent.Type != Modified || lastEvent.Object != testType("bar") {
+		t.Fatalf("expected the modify event, got %#v", lastEvent)
+	}
+}
+
+func TestUntilSatisfiedByLastEvent(t *testing.T) {
+	fw := NewFake()
+	go fw.Modify(testType("bar"))
+	conditions := []ConditionFunc{
+		func(event Event) (bool, error) { return event.Type == Modified, nil },
+		func(event Event) (bool, error) { return event.Object == testType("bar"), nil },
+	}
+
+	lastEvent, err := Until(time.Minute, fw, conditions...)
+	if err != nil {
+		t.Fatalf("expected nil error, got %#v", err)
+	}
+	if lastEvent == nil || lastEvent.Type != Modified {
+		t.Fatalf("expected the modify event, got %#v", lastEvent)
+	}
+}
+
+func TestUntilErrorCondition(t *testing.T) {
+	fw := NewFake()
+	go fw.Add(testType("foo"))
+	expected := "something bad"
+	conditions := []ConditionFunc{
+		func(event Event) (bool, error) { return false, errors.New(expected) },
+	}
+
+	if _, err := Until(time.Minute, fw, conditions...); err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestUntilClosed(t *testing.T) {
+	fw := NewFake()
+	go fw.Stop()
+	conditions := []ConditionFunc{
+		func(event Event) (bool, error) { return true, nil },
+	}
+
+	if _, err := Until(time.Minute, fw, conditions...); err != ErrWatchClosed {
+		t.Fatalf("expected ErrWatchClosed, got %v", err)
+	}
+}
+
+func TestUntilTimeout(t *testing.T) {
+	fw := NewFake()
+	conditions := []ConditionFunc{
+		func(event Event) (bool, error) { return true, nil },
+	}
+
+	if _, err := Until(10*time.Millisecond, fw, conditions...); err != wait.ErrWaitTimeout {
+		t.Fatalf("expected ErrWaitTimeout, got %v", err)
+	}
+}
@@ -61,7 +61,7 @@ func (d *denyExecOnPrivileged) Admit(a admission.Attributes) (err error) {
 
 // isPrivileged will return true a pod has any privileged containers
 func isPrivileged(pod *api.Pod) bool {
-	for _, c := range pod.Spec.Containers {
+	for _, c := range append(append([]api.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
 		if c.SecurityContext == nil {
 			continue
 		}
@@ -57,7 +57,7 @@ func (p *plugin) Admit(a admission.Attributes) (err error) {
 	if !ok {
 		return apierrors.NewBadRequest("Resource was marked with kind Pod but was unable to be converted")
 	}
-	for _, v := range pod.Spec.Containers {
+	for _, v := range append(append([]api.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
 		if v.SecurityContext != nil {
 			if v.SecurityContext.SELinuxOptions != nil {
 				return apierrors.NewForbidden(a.GetResource(), pod.Name, fmt.Errorf("SecurityContext.SELinuxOptions is forbidden"))
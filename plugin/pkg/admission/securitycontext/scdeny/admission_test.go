@@ -62,6 +62,16 @@ func TestAdmission(t *testing.T) {
 			t.Errorf("Expected error returned from admission handler for case %s", k)
 		}
 	}
+
+	pod.Spec.Containers[0].SecurityContext = nil
+	pod.Spec.InitContainers = []api.Container{{}}
+	for k, v := range errorCases {
+		pod.Spec.InitContainers[0].SecurityContext = v
+		err := handler.Admit(admission.NewAttributesRecord(&pod, "Pod", "foo", "name", string(api.ResourcePods), "", "ignored", nil))
+		if err == nil {
+			t.Errorf("Expected error returned from admission handler for init container case %s", k)
+		}
+	}
 }
 
 func TestHandles(t *testing.T) {
@@ -368,18 +368,20 @@ func (s *serviceAccount) mountServiceAccountToken(serviceAccount *api.ServiceAcc
 
 	// Ensure every container mounts the APISecret volume
 	needsTokenVolume := false
-	for i, container := range pod.Spec.Containers {
-		existingContainerMount := false
-		for _, volumeMount := range container.VolumeMounts {
-			// Existing mounts at the default mount path prevent mounting of the API token
-			if volumeMount.MountPath == DefaultAPITokenMountPath {
-				existingContainerMount = true
-				break
+	for _, containers := range [][]api.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i, container := range containers {
+			existingContainerMount := false
+			for _, volumeMount := range container.VolumeMounts {
+				// Existing mounts at the default mount path prevent mounting of the API token
+				if volumeMount.MountPath == DefaultAPITokenMountPath {
+					existingContainerMount = true
+					break
+				}
+			}
+			if !existingContainerMount {
+				containers[i].VolumeMounts = append(containers[i].VolumeMounts, volumeMount)
+				needsTokenVolume = true
 			}
-		}
-		if !existingContainerMount {
-			pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, volumeMount)
-			needsTokenVolume = true
 		}
 	}
 
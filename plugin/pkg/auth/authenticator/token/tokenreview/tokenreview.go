@@ -0,0 +1,53 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokenreview implements a token authenticator that delegates the
+// authentication of bearer tokens to the apiserver with TokenReviews.
+package tokenreview
+
+import (
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+// TokenAuthenticator authenticates tokens by asking the apiserver about them.
+type TokenAuthenticator struct {
+	client client.TokenReviewsInterface
+}
+
+// New returns a TokenAuthenticator that reviews tokens with the given client.
+func New(client client.TokenReviewsInterface) *TokenAuthenticator {
+	return &TokenAuthenticator{client}
+}
+
+// AuthenticateToken implements authenticator.Token.
+func (a *TokenAuthenticator) AuthenticateToken(value string) (user.Info, bool, error) {
+	review, err := a.client.TokenReviews().Create(&expapi.TokenReview{
+		Spec: expapi.TokenReviewSpec{Token: value},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if !review.Status.Authenticated {
+		return nil, false, nil
+	}
+	return &user.DefaultInfo{
+		Name:   review.Status.User.Username,
+		UID:    review.Status.User.UID,
+		Groups: review.Status.User.Groups,
+	}, true, nil
+}
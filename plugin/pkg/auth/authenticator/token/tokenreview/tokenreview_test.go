@@ -0,0 +1,73 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenreview
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/testclient"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	fake := &testclient.FakeExperimental{Fake: &testclient.Fake{
+		ReactFn: func(action testclient.Action) (runtime.Object, error) {
+			review := action.(testclient.CreateAction).GetObject().(*expapi.TokenReview)
+			switch review.Spec.Token {
+			case "good":
+				review.Status = expapi.TokenReviewStatus{
+					Authenticated: true,
+					User:          expapi.UserInfo{Username: "alice", UID: "1", Groups: []string{"admins"}},
+				}
+			case "broken":
+				return nil, errors.New("apiserver unavailable")
+			}
+			return review, nil
+		},
+	}}
+	auth := New(fake)
+
+	tests := []struct {
+		token    string
+		expected user.Info
+		ok       bool
+		err      bool
+	}{
+		{token: "good", expected: &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"admins"}}, ok: true},
+		{token: "bad"},
+		{token: "broken", err: true},
+	}
+	for _, test := range tests {
+		u, ok, err := auth.AuthenticateToken(test.token)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error: %v", test.token, err)
+		}
+		if ok != test.ok {
+			t.Errorf("%s: expected ok=%v, got %v", test.token, test.ok, ok)
+		}
+		if !reflect.DeepEqual(u, test.expected) {
+			t.Errorf("%s: expected user %#v, got %#v", test.token, test.expected, u)
+		}
+	}
+	if actions := fake.Actions(); len(actions) != 3 || actions[0].GetResource() != "tokenreviews" {
+		t.Errorf("unexpected actions: %#v", actions)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subjectaccessreview implements an authorizer that delegates
+// authorization decisions to the apiserver with SubjectAccessReviews.
+package subjectaccessreview
+
+import (
+	"errors"
+
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+// Authorizer authorizes requests by asking the apiserver about them.
+type Authorizer struct {
+	client client.SubjectAccessReviewsInterface
+}
+
+// New returns an Authorizer that reviews requests with the given client.
+func New(client client.SubjectAccessReviewsInterface) *Authorizer {
+	return &Authorizer{client}
+}
+
+// Authorize implements authorizer.Authorizer.
+func (a *Authorizer) Authorize(attrs authorizer.Attributes) error {
+	review, err := a.client.SubjectAccessReviews().Create(&expapi.SubjectAccessReview{
+		Spec: expapi.SubjectAccessReviewSpec{
+			User:        attrs.GetUserName(),
+			Groups:      attrs.GetGroups(),
+			Verb:        attrs.GetVerb(),
+			Namespace:   attrs.GetNamespace(),
+			Resource:    attrs.GetResource(),
+			Subresource: attrs.GetSubresource(),
+			Name:        attrs.GetName(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		if len(review.Status.Reason) > 0 {
+			return errors.New(review.Status.Reason)
+		}
+		return errors.New("forbidden by the apiserver")
+	}
+	return nil
+}
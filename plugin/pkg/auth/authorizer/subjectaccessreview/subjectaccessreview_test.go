@@ -0,0 +1,72 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subjectaccessreview
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/auth/authorizer"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/testclient"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestAuthorize(t *testing.T) {
+	var got expapi.SubjectAccessReviewSpec
+	fake := &testclient.FakeExperimental{Fake: &testclient.Fake{
+		ReactFn: func(action testclient.Action) (runtime.Object, error) {
+			review := action.(testclient.CreateAction).GetObject().(*expapi.SubjectAccessReview)
+			got = review.Spec
+			if review.Spec.User == "alice" {
+				review.Status = expapi.SubjectAccessReviewStatus{Allowed: true}
+			} else {
+				review.Status = expapi.SubjectAccessReviewStatus{Reason: "no policy matched"}
+			}
+			return review, nil
+		},
+	}}
+	a := New(fake)
+
+	attrs := authorizer.AttributesRecord{
+		User:        &user.DefaultInfo{Name: "alice", Groups: []string{"admins"}},
+		Verb:        "get",
+		Resource:    "nodes",
+		Subresource: "stats",
+		Name:        "node1",
+	}
+	if err := a.Authorize(attrs); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := expapi.SubjectAccessReviewSpec{
+		User:        "alice",
+		Groups:      []string{"admins"},
+		Verb:        "get",
+		Resource:    "nodes",
+		Subresource: "stats",
+		Name:        "node1",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %#v, got %#v", expected, got)
+	}
+
+	attrs.User = &user.DefaultInfo{Name: "bob"}
+	if err := a.Authorize(attrs); err == nil || err.Error() != "no policy matched" {
+		t.Errorf("expected the request to be denied, got %v", err)
+	}
+}
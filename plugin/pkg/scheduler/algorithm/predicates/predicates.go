@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
 
@@ -112,6 +113,17 @@ func getResourceRequest(pod *api.Pod) resourceRequest {
 		result.memory += limits.Memory().Value()
 		result.milliCPU += limits.Cpu().MilliValue()
 	}
+	// Init containers run one at a time before the other containers, so the
+	// pod needs as much as the largest of them.
+	for ix := range pod.Spec.InitContainers {
+		limits := pod.Spec.InitContainers[ix].Resources.Limits
+		if memory := limits.Memory().Value(); memory > result.memory {
+			result.memory = memory
+		}
+		if milliCPU := limits.Cpu().MilliValue(); milliCPU > result.milliCPU {
+			result.milliCPU = milliCPU
+		}
+	}
 	return result
 }
 
@@ -137,6 +149,15 @@ func CheckPodsExceedingCapacity(pods []*api.Pod, capacity api.ResourceList) (fit
 	return
 }
 
+// Returns the resources of the node available for scheduling. Nodes whose
+// kubelet does not report allocatable resources offer their whole capacity.
+func getNodeAllocatable(node *api.Node) api.ResourceList {
+	if len(node.Status.Allocatable) > 0 {
+		return node.Status.Allocatable
+	}
+	return node.Status.Capacity
+}
+
 // PodFitsResources calculates fit based on requested, rather than used resources
 func (r *ResourceFit) PodFitsResources(pod *api.Pod, existingPods []*api.Pod, node string) (bool, error) {
 	podRequest := getResourceRequest(pod)
@@ -144,18 +165,19 @@ func (r *ResourceFit) PodFitsResources(pod *api.Pod, existingPods []*api.Pod, no
 	if err != nil {
 		return false, err
 	}
+	allocatable := getNodeAllocatable(info)
 	if podRequest.milliCPU == 0 && podRequest.memory == 0 {
-		return int64(len(existingPods)) < info.Status.Capacity.Pods().Value(), nil
+		return int64(len(existingPods)) < allocatable.Pods().Value(), nil
 	}
 	pods := []*api.Pod{}
 	copy(pods, existingPods)
 	pods = append(existingPods, pod)
-	_, exceeding := CheckPodsExceedingCapacity(pods, info.Status.Capacity)
-	if len(exceeding) > 0 || int64(len(pods)) > info.Status.Capacity.Pods().Value() {
-		glog.V(4).Infof("Cannot schedule Pod %v, because Node %v is full, running %v out of %v Pods.", pod, node, len(pods)-1, info.Status.Capacity.Pods().Value())
+	_, exceeding := CheckPodsExceedingCapacity(pods, allocatable)
+	if len(exceeding) > 0 || int64(len(pods)) > allocatable.Pods().Value() {
+		glog.V(4).Infof("Cannot schedule Pod %v, because Node %v is full, running %v out of %v Pods.", pod, node, len(pods)-1, allocatable.Pods().Value())
 		return false, nil
 	}
-	glog.V(4).Infof("Schedule Pod %v on Node %v is allowed, Node is running only %v out of %v Pods.", pod, node, len(pods)-1, info.Status.Capacity.Pods().Value())
+	glog.V(4).Infof("Schedule Pod %v on Node %v is allowed, Node is running only %v out of %v Pods.", pod, node, len(pods)-1, allocatable.Pods().Value())
 	return true, nil
 }
 
@@ -200,6 +222,55 @@ func PodFitsHost(pod *api.Pod, existingPods []*api.Pod, node string) (bool, erro
 	return pod.Spec.NodeName == node, nil
 }
 
+type NodeConditionChecker struct {
+	info NodeInfo
+}
+
+func NewNodeMemoryPressurePredicate(info NodeInfo) algorithm.FitPredicate {
+	checker := &NodeConditionChecker{
+		info: info,
+	}
+	return checker.CheckNodeMemoryPressure
+}
+
+func NewNodeDiskPressurePredicate(info NodeInfo) algorithm.FitPredicate {
+	checker := &NodeConditionChecker{
+		info: info,
+	}
+	return checker.CheckNodeDiskPressure
+}
+
+// CheckNodeMemoryPressure returns false for best-effort pods on a minion that reports memory pressure,
+// as the kubelet would evict them first.
+func (c *NodeConditionChecker) CheckNodeMemoryPressure(pod *api.Pod, existingPods []*api.Pod, node string) (bool, error) {
+	if qos.GetPodQOS(pod) != api.PodQOSBestEffort {
+		return true, nil
+	}
+	minion, err := c.info.GetNodeInfo(node)
+	if err != nil {
+		return false, err
+	}
+	return !nodeConditionIsTrue(minion, api.NodeMemoryPressure), nil
+}
+
+// CheckNodeDiskPressure returns false for all pods on a minion that reports disk pressure.
+func (c *NodeConditionChecker) CheckNodeDiskPressure(pod *api.Pod, existingPods []*api.Pod, node string) (bool, error) {
+	minion, err := c.info.GetNodeInfo(node)
+	if err != nil {
+		return false, err
+	}
+	return !nodeConditionIsTrue(minion, api.NodeDiskPressure), nil
+}
+
+func nodeConditionIsTrue(node *api.Node, conditionType api.NodeConditionType) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == api.ConditionTrue
+		}
+	}
+	return false
+}
+
 type NodeLabelChecker struct {
 	info     NodeInfo
 	labels   []string
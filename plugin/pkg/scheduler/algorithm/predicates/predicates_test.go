@@ -73,6 +73,11 @@ func newResourcePod(usage ...resourceRequest) *api.Pod {
 	}
 }
 
+func newResourceInitPod(pod *api.Pod, usage ...resourceRequest) *api.Pod {
+	pod.Spec.InitContainers = newResourcePod(usage...).Spec.Containers
+	return pod
+}
+
 func TestPodFitsResources(t *testing.T) {
 
 	enoughPodsTests := []struct {
@@ -121,6 +126,22 @@ func TestPodFitsResources(t *testing.T) {
 			fits: true,
 			test: "equal edge case",
 		},
+		{
+			pod: newResourceInitPod(newResourcePod(resourceRequest{milliCPU: 1, memory: 1}), resourceRequest{milliCPU: 6, memory: 1}),
+			existingPods: []*api.Pod{
+				newResourcePod(resourceRequest{milliCPU: 5, memory: 5}),
+			},
+			fits: false,
+			test: "init container does not fit",
+		},
+		{
+			pod: newResourceInitPod(newResourcePod(resourceRequest{milliCPU: 1, memory: 1}, resourceRequest{milliCPU: 1, memory: 1}), resourceRequest{milliCPU: 5, memory: 1}, resourceRequest{milliCPU: 1, memory: 5}),
+			existingPods: []*api.Pod{
+				newResourcePod(resourceRequest{milliCPU: 5, memory: 15}),
+			},
+			fits: true,
+			test: "init containers are not added to each other",
+		},
 	}
 
 	for _, test := range enoughPodsTests {
@@ -179,6 +200,53 @@ func TestPodFitsResources(t *testing.T) {
 			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
 		}
 	}
+
+	allocatableTests := []struct {
+		pod          *api.Pod
+		existingPods []*api.Pod
+		fits         bool
+		test         string
+	}{
+		{
+			pod: newResourcePod(resourceRequest{milliCPU: 1, memory: 1}),
+			existingPods: []*api.Pod{
+				newResourcePod(resourceRequest{milliCPU: 5, memory: 5}),
+			},
+			fits: true,
+			test: "both resources fit the allocatable resources",
+		},
+		{
+			pod: newResourcePod(resourceRequest{milliCPU: 1, memory: 6}),
+			existingPods: []*api.Pod{
+				newResourcePod(resourceRequest{milliCPU: 5, memory: 5}),
+			},
+			fits: false,
+			test: "memory fits the capacity but not the allocatable resources",
+		},
+		{
+			pod: newResourcePod(resourceRequest{milliCPU: 4, memory: 1}),
+			existingPods: []*api.Pod{
+				newResourcePod(resourceRequest{milliCPU: 5, memory: 5}),
+			},
+			fits: false,
+			test: "cpu fits the capacity but not the allocatable resources",
+		},
+	}
+	for _, test := range allocatableTests {
+		node := api.Node{Status: api.NodeStatus{
+			Capacity:    makeResources(10, 20, 32).Capacity,
+			Allocatable: makeResources(8, 10, 32).Capacity,
+		}}
+
+		fit := ResourceFit{FakeNodeInfo(node)}
+		fits, err := fit.PodFitsResources(test.pod, test.existingPods, "machine")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
+		}
+	}
 }
 
 func TestPodFitsHost(t *testing.T) {
@@ -577,6 +645,75 @@ func TestNodeLabelPresence(t *testing.T) {
 	}
 }
 
+func TestNodePressure(t *testing.T) {
+	bestEffortPod := &api.Pod{Spec: api.PodSpec{Containers: []api.Container{{}}}}
+	burstablePod := &api.Pod{Spec: api.PodSpec{Containers: []api.Container{{
+		Resources: api.ResourceRequirements{Requests: api.ResourceList{api.ResourceMemory: resource.MustParse("100Mi")}},
+	}}}}
+	pressure := func(conditionType api.NodeConditionType, status api.ConditionStatus) api.Node {
+		return api.Node{Status: api.NodeStatus{Conditions: []api.NodeCondition{{Type: conditionType, Status: status}}}}
+	}
+	tests := []struct {
+		pod        *api.Pod
+		node       api.Node
+		memoryFits bool
+		diskFits   bool
+		test       string
+	}{
+		{
+			pod:        bestEffortPod,
+			node:       api.Node{},
+			memoryFits: true,
+			diskFits:   true,
+			test:       "no conditions",
+		},
+		{
+			pod:        bestEffortPod,
+			node:       pressure(api.NodeMemoryPressure, api.ConditionFalse),
+			memoryFits: true,
+			diskFits:   true,
+			test:       "no memory pressure",
+		},
+		{
+			pod:        bestEffortPod,
+			node:       pressure(api.NodeMemoryPressure, api.ConditionTrue),
+			memoryFits: false,
+			diskFits:   true,
+			test:       "best-effort pod under memory pressure",
+		},
+		{
+			pod:        burstablePod,
+			node:       pressure(api.NodeMemoryPressure, api.ConditionTrue),
+			memoryFits: true,
+			diskFits:   true,
+			test:       "burstable pod under memory pressure",
+		},
+		{
+			pod:        burstablePod,
+			node:       pressure(api.NodeDiskPressure, api.ConditionTrue),
+			memoryFits: true,
+			diskFits:   false,
+			test:       "burstable pod under disk pressure",
+		},
+	}
+	for _, test := range tests {
+		memoryFits, err := NewNodeMemoryPressurePredicate(FakeNodeInfo(test.node))(test.pod, nil, "machine")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if memoryFits != test.memoryFits {
+			t.Errorf("%s: expected memory pressure fit %v, got %v", test.test, test.memoryFits, memoryFits)
+		}
+		diskFits, err := NewNodeDiskPressurePredicate(FakeNodeInfo(test.node))(test.pod, nil, "machine")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if diskFits != test.diskFits {
+			t.Errorf("%s: expected disk pressure fit %v, got %v", test.test, test.diskFits, diskFits)
+		}
+	}
+}
+
 func TestServiceAffinity(t *testing.T) {
 	selector := map[string]string{"foo": "bar"}
 	labels1 := map[string]string{
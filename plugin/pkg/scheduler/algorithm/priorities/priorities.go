@@ -70,6 +70,28 @@ func getNonzeroLimits(limits *api.ResourceList) (int64, int64) {
 	return out_millicpu, out_memory
 }
 
+// getNonzeroPodLimits returns the resources used by a pod, using the same
+// defaults as getNonzeroLimits. Init containers run one at a time before the
+// other containers, so only the largest of them counts.
+func getNonzeroPodLimits(pod *api.Pod) (int64, int64) {
+	var totalMilliCPU, totalMemory int64
+	for _, container := range pod.Spec.Containers {
+		cpu, memory := getNonzeroLimits(&container.Resources.Limits)
+		totalMilliCPU += cpu
+		totalMemory += memory
+	}
+	for _, container := range pod.Spec.InitContainers {
+		cpu, memory := getNonzeroLimits(&container.Resources.Limits)
+		if cpu > totalMilliCPU {
+			totalMilliCPU = cpu
+		}
+		if memory > totalMemory {
+			totalMemory = memory
+		}
+	}
+	return totalMilliCPU, totalMemory
+}
+
 // Calculate the resource occupancy on a node.  'node' has information about the resources on the node.
 // 'pods' is a list of pods currently scheduled on the node.
 func calculateResourceOccupancy(pod *api.Pod, node api.Node, pods []*api.Pod) algorithm.HostPriority {
@@ -79,19 +101,15 @@ func calculateResourceOccupancy(pod *api.Pod, node api.Node, pods []*api.Pod) al
 	capacityMemory := node.Status.Capacity.Memory().Value()
 
 	for _, existingPod := range pods {
-		for _, container := range existingPod.Spec.Containers {
-			cpu, memory := getNonzeroLimits(&container.Resources.Limits)
-			totalMilliCPU += cpu
-			totalMemory += memory
-		}
-	}
-	// Add the resources requested by the current pod being scheduled.
-	// This also helps differentiate between differently sized, but empty, minions.
-	for _, container := range pod.Spec.Containers {
-		cpu, memory := getNonzeroLimits(&container.Resources.Limits)
+		cpu, memory := getNonzeroPodLimits(existingPod)
 		totalMilliCPU += cpu
 		totalMemory += memory
 	}
+	// Add the resources requested by the current pod being scheduled.
+	// This also helps differentiate between differently sized, but empty, minions.
+	cpu, memory := getNonzeroPodLimits(pod)
+	totalMilliCPU += cpu
+	totalMemory += memory
 
 	cpuScore := calculateScore(totalMilliCPU, capacityMilliCPU, node.Name)
 	memoryScore := calculateScore(totalMemory, capacityMemory, node.Name)
@@ -195,19 +213,15 @@ func calculateBalancedResourceAllocation(pod *api.Pod, node api.Node, pods []*ap
 	totalMemory := int64(0)
 	score := int(0)
 	for _, existingPod := range pods {
-		for _, container := range existingPod.Spec.Containers {
-			cpu, memory := getNonzeroLimits(&container.Resources.Limits)
-			totalMilliCPU += cpu
-			totalMemory += memory
-		}
-	}
-	// Add the resources requested by the current pod being scheduled.
-	// This also helps differentiate between differently sized, but empty, minions.
-	for _, container := range pod.Spec.Containers {
-		cpu, memory := getNonzeroLimits(&container.Resources.Limits)
+		cpu, memory := getNonzeroPodLimits(existingPod)
 		totalMilliCPU += cpu
 		totalMemory += memory
 	}
+	// Add the resources requested by the current pod being scheduled.
+	// This also helps differentiate between differently sized, but empty, minions.
+	cpu, memory := getNonzeroPodLimits(pod)
+	totalMilliCPU += cpu
+	totalMemory += memory
 
 	capacityMilliCPU := node.Status.Capacity.Cpu().MilliValue()
 	capacityMemory := node.Status.Capacity.Memory().Value()
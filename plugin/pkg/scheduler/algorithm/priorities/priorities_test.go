@@ -151,6 +151,54 @@ func TestZeroLimit(t *testing.T) {
 	}
 }
 
+func TestGetNonzeroPodLimits(t *testing.T) {
+	limits := func(cpu, memory string) api.ResourceRequirements {
+		return api.ResourceRequirements{
+			Limits: api.ResourceList{
+				"cpu":    resource.MustParse(cpu),
+				"memory": resource.MustParse(memory),
+			},
+		}
+	}
+	tests := []struct {
+		spec           api.PodSpec
+		expectedCPU    int64
+		expectedMemory int64
+		test           string
+	}{
+		{
+			spec:           api.PodSpec{Containers: []api.Container{{}}},
+			expectedCPU:    defaultMilliCpuLimit,
+			expectedMemory: defaultMemoryLimit,
+			test:           "defaults",
+		},
+		{
+			spec: api.PodSpec{
+				InitContainers: []api.Container{{Resources: limits("500m", "1000")}, {Resources: limits("100m", "3000")}},
+				Containers:     []api.Container{{Resources: limits("200m", "1000")}, {Resources: limits("200m", "1000")}},
+			},
+			expectedCPU:    500,
+			expectedMemory: 3000,
+			test:           "largest init container",
+		},
+		{
+			spec: api.PodSpec{
+				InitContainers: []api.Container{{Resources: limits("300m", "1000")}},
+				Containers:     []api.Container{{Resources: limits("200m", "1000")}, {Resources: limits("200m", "1000")}},
+			},
+			expectedCPU:    400,
+			expectedMemory: 2000,
+			test:           "sum of containers",
+		},
+	}
+	for _, test := range tests {
+		cpu, memory := getNonzeroPodLimits(&api.Pod{Spec: test.spec})
+		if cpu != test.expectedCPU || memory != test.expectedMemory {
+			t.Errorf("%s: expected (%d, %d), got (%d, %d)", test.test, test.expectedCPU, test.expectedMemory, cpu, memory)
+		}
+	}
+}
+
 func TestLeastRequested(t *testing.T) {
 	labels1 := map[string]string{
 		"foo": "bar",
@@ -54,6 +54,21 @@ func defaultPredicates() util.StringSet {
 				return predicates.NewSelectorMatchPredicate(args.NodeInfo)
 			},
 		),
+		// Fit is determined by the memory pressure reported by the node: best-effort pods
+		// would be evicted first.
+		factory.RegisterFitPredicateFactory(
+			"CheckNodeMemoryPressure",
+			func(args factory.PluginFactoryArgs) algorithm.FitPredicate {
+				return predicates.NewNodeMemoryPressurePredicate(args.NodeInfo)
+			},
+		),
+		// Fit is determined by the disk pressure reported by the node.
+		factory.RegisterFitPredicateFactory(
+			"CheckNodeDiskPressure",
+			func(args factory.PluginFactoryArgs) algorithm.FitPredicate {
+				return predicates.NewNodeDiskPressurePredicate(args.NodeInfo)
+			},
+		),
 		// Fit is determined by the presence of the Host parameter and a string match
 		factory.RegisterFitPredicate("HostName", predicates.PodFitsHost),
 	)
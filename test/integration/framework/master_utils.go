@@ -148,7 +148,7 @@ func startMasterOrDie(masterConfig *master.Config) (*master.Master, *httptest.Se
 			EnableProfiling:    true,
 			EnableUISupport:    false,
 			APIPrefix:          "/api",
-			ExpAPIPrefix:       "/experimental",
+			APIGroupPrefix:     "/apis",
 			Authorizer:         apiserver.NewAlwaysAllowAuthorizer(),
 			AdmissionControl:   admit.NewAlwaysAdmit(),
 		}
@@ -286,7 +286,7 @@ func RunAMaster(t *testing.T) (*master.Master, *httptest.Server) {
 		EnableProfiling:    true,
 		EnableUISupport:    false,
 		APIPrefix:          "/api",
-		ExpAPIPrefix:       "/experimental",
+		APIGroupPrefix:     "/apis",
 		EnableExp:          true,
 		Authorizer:         apiserver.NewAlwaysAllowAuthorizer(),
 		AdmissionControl:   admit.NewAlwaysAdmit(),